@@ -0,0 +1,50 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Package certcache implements autocert.Cache on top of a kvstore.Store,
+// so that several replicas of the server can share the certificates
+// and account keys obtained from the ACME server.
+package certcache
+
+import (
+	"context"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/johanbrandhorst/grpcweb-example/kvstore"
+)
+
+// New returns an autocert.Cache storing its entries in s.
+// All keys are prefixed with prefix, allowing a single
+// store to be used for other purposes as well.
+func New(s kvstore.Store, prefix string) autocert.Cache {
+	return &cache{
+		store:  s,
+		prefix: prefix,
+	}
+}
+
+type cache struct {
+	store  kvstore.Store
+	prefix string
+}
+
+func (c *cache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.store.Get(ctx, c.prefix+key)
+	if err == kvstore.ErrNotFound {
+		return nil, autocert.ErrCacheMiss
+	}
+	return data, err
+}
+
+func (c *cache) Put(ctx context.Context, key string, data []byte) error {
+	return c.store.Set(ctx, c.prefix+key, data)
+}
+
+func (c *cache) Delete(ctx context.Context, key string) error {
+	err := c.store.Delete(ctx, c.prefix+key)
+	if err == kvstore.ErrNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package certcache_test
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/johanbrandhorst/grpcweb-example/certcache"
+	"github.com/johanbrandhorst/grpcweb-example/kvstore"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	store := kvstore.NewMemoryStore()
+	cache := certcache.New(store, "acme/")
+
+	if _, err := cache.Get(ctx, "example.com"); err != autocert.ErrCacheMiss {
+		t.Fatalf("got error %v getting a missing key, want autocert.ErrCacheMiss", err)
+	}
+	if err := cache.Put(ctx, "example.com", []byte("certificate")); err != nil {
+		t.Fatal(err)
+	}
+	got, err := cache.Get(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "certificate" {
+		t.Errorf("got %q, want %q", got, "certificate")
+	}
+
+	// Keys are stored with the prefix
+	if got, err := store.Get(ctx, "acme/example.com"); err != nil || string(got) != "certificate" {
+		t.Errorf("got %q, %v from the store, want the certificate", got, err)
+	}
+	if _, err := store.Get(ctx, "example.com"); err != kvstore.ErrNotFound {
+		t.Errorf("got error %v getting the key without prefix, want kvstore.ErrNotFound", err)
+	}
+
+	if err := cache.Delete(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Get(ctx, "example.com"); err != autocert.ErrCacheMiss {
+		t.Errorf("got error %v getting a deleted key, want autocert.ErrCacheMiss", err)
+	}
+	if err := cache.Delete(ctx, "example.com"); err != nil {
+		t.Errorf("got error %v deleting a missing key", err)
+	}
+}
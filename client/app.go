@@ -8,9 +8,12 @@ import (
 )
 
 //go:generate gopherjs build app.go -m -o html/client.js
-//go:generate find ./html/ -name *.gz -prune -o -type f -exec go-zopfli {} +
+//go:generate bash -c "cd compiled/ && go run hash_generate.go"
+//go:generate bash -c "mkdir -p build/source/book/ && find book/ -maxdepth 1 -name '*.go' ! -name 'gen_*' -exec cp -t build/source/book/ {} +"
+//go:generate find ./build/ -name *.gz -prune -o -type f -exec go-zopfli {} +
+//go:generate bash -c "cd compiled/ && go run brotli_generate.go"
 //go:generate bash -c "cd compiled/ && go run assets_generate.go"
-//go:generate bash -c "rm html/*.js* && rm html/*.gz"
+//go:generate bash -c "rm html/*.js* && rm -r build/"
 
 func main() {
 	domTarget := dom.GetWindow().Document().GetElementByID("app")
@@ -201,7 +201,7 @@ func (t toggleconnect) OnClick(se *r.SyntheticMouseEvent) {
 		var err error
 		timeout := 5 * time.Minute
 		ctx, _ := context.WithTimeout(context.Background(), timeout)
-		newSt.client, err = t.g.Props().Client.BookChat(ctx)
+		newSt.client, err = t.g.Props().Client.BookChat(userContext(ctx))
 		if err != nil {
 			newSt.err = err.Error()
 			return
@@ -0,0 +1,93 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"github.com/johanbrandhorst/protobuf/grpcweb/status"
+	r "myitcv.io/react"
+
+	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+)
+
+//go:generate reactGen
+
+// BookDetailDef defines the BookDetail component
+type BookDetailDef struct {
+	r.ComponentDef
+}
+
+// BookDetailProps defines the properties of this component
+type BookDetailProps struct {
+	Client library.BookServiceClient
+	Isbn   int64
+}
+
+// BookDetailState holds the state for the BookDetail component
+type BookDetailState struct {
+	book *library.Book
+	err  string
+}
+
+// BookDetail returns a new BookDetailElem, which shows
+// the book with the ISBN provided.
+func BookDetail(p BookDetailProps) *BookDetailElem {
+	return buildBookDetailElem(p)
+}
+
+// ComponentDidMount is a React lifecycle method for the BookDetail component.
+// It fetches the book to show.
+func (b BookDetailDef) ComponentDidMount() {
+	b.fetch(b.Props())
+}
+
+// ComponentWillReceiveProps is a React lifecycle method for the BookDetail
+// component. It fetches the book to show if the ISBN has changed.
+func (b BookDetailDef) ComponentWillReceiveProps(p BookDetailProps) {
+	if p.Isbn != b.Props().Isbn {
+		b.fetch(p)
+	}
+}
+
+func (b BookDetailDef) fetch(p BookDetailProps) {
+	// Wrapped in goroutine because GetBook is blocking
+	go func() {
+		newSt := BookDetailState{}
+		defer func() {
+			b.SetState(newSt)
+		}()
+
+		// 1 second timeout
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		bk, err := p.Client.GetBook(ctx, &library.GetBookRequest{
+			Isbn: p.Isbn,
+		})
+		if err != nil {
+			sts := status.FromError(err)
+			newSt.err = sts.Message
+			return
+		}
+
+		newSt.book = bk
+	}()
+}
+
+// Render renders the BookDetail component
+func (b BookDetailDef) Render() r.Element {
+	st := b.State()
+	switch {
+	case st.book != nil:
+		return r.Div(nil,
+			renderBook(st.book),
+			Reviews(ReviewsProps{Client: b.Props().Client, Isbn: st.book.GetIsbn()}),
+		)
+	case st.err != "":
+		return r.Div(nil,
+			r.Hr(nil),
+			r.S("Error: "+st.err),
+		)
+	}
+	return r.Div(nil, r.S("Loading..."))
+}
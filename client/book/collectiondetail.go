@@ -0,0 +1,107 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"github.com/johanbrandhorst/protobuf/grpcweb/status"
+	r "myitcv.io/react"
+
+	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+)
+
+//go:generate reactGen
+
+// CollectionDetailDef defines the CollectionDetail component
+type CollectionDetailDef struct {
+	r.ComponentDef
+}
+
+// CollectionDetailProps defines the properties of this component
+type CollectionDetailProps struct {
+	Client library.BookServiceClient
+	ID     string
+}
+
+// CollectionDetailState holds the state for the CollectionDetail component
+type CollectionDetailState struct {
+	collection *library.Collection
+	err        string
+}
+
+// CollectionDetail returns a new CollectionDetailElem, which shows
+// the collection with the Id provided.
+func CollectionDetail(p CollectionDetailProps) *CollectionDetailElem {
+	return buildCollectionDetailElem(p)
+}
+
+// ComponentDidMount is a React lifecycle method for the CollectionDetail
+// component. It fetches the collection to show.
+func (c CollectionDetailDef) ComponentDidMount() {
+	c.fetch(c.Props())
+}
+
+// ComponentWillReceiveProps is a React lifecycle method for the
+// CollectionDetail component. It fetches the collection to show
+// if the Id has changed.
+func (c CollectionDetailDef) ComponentWillReceiveProps(p CollectionDetailProps) {
+	if p.ID != c.Props().ID {
+		c.fetch(p)
+	}
+}
+
+func (c CollectionDetailDef) fetch(p CollectionDetailProps) {
+	// Wrapped in goroutine because GetCollection is blocking
+	go func() {
+		newSt := CollectionDetailState{}
+		defer func() {
+			c.SetState(newSt)
+		}()
+
+		// 1 second timeout
+		ctx, cancel := context.WithTimeout(userContext(context.Background()), time.Second)
+		defer cancel()
+
+		collection, err := p.Client.GetCollection(ctx, &library.GetCollectionRequest{
+			Id: p.ID,
+		})
+		if err != nil {
+			sts := status.FromError(err)
+			newSt.err = sts.Message
+			return
+		}
+
+		newSt.collection = collection
+	}()
+}
+
+// Render renders the CollectionDetail component
+func (c CollectionDetailDef) Render() r.Element {
+	st := c.State()
+	switch {
+	case st.collection != nil:
+		name := st.collection.GetName()
+		if name == "" {
+			name = "Unnamed collection"
+		}
+		content := []r.Element{
+			r.H4(nil, r.S(name)),
+		}
+		if owner := st.collection.GetOwner(); owner != "" {
+			content = append(content, r.P(nil, r.S("Made by "+owner)))
+		}
+		if len(st.collection.GetBooks()) == 0 {
+			content = append(content, r.P(nil, r.S("This collection has no books.")))
+		}
+		for _, bk := range st.collection.GetBooks() {
+			content = append(content, renderBook(bk))
+		}
+		return r.Div(nil, content...)
+	case st.err != "":
+		return r.Div(nil,
+			r.Hr(nil),
+			r.S("Error: "+st.err),
+		)
+	}
+	return r.Div(nil, r.S("Loading..."))
+}
@@ -0,0 +1,74 @@
+// Code generated by reactGen. DO NOT EDIT.
+
+package book
+
+import "myitcv.io/react"
+
+type BookDetailElem struct {
+	react.Element
+}
+
+func buildBookDetail(cd react.ComponentDef) react.Component {
+	return BookDetailDef{ComponentDef: cd}
+}
+
+func buildBookDetailElem(props BookDetailProps, children ...react.Element) *BookDetailElem {
+	return &BookDetailElem{
+		Element: react.CreateElement(buildBookDetail, props, children...),
+	}
+}
+
+func (b BookDetailDef) RendersElement() react.Element {
+	return b.Render()
+}
+
+// SetState is an auto-generated proxy proxy to update the state for the
+// BookDetail component.  SetState does not immediately mutate b.State()
+// but creates a pending state transition.
+func (b BookDetailDef) SetState(state BookDetailState) {
+	b.ComponentDef.SetState(state)
+}
+
+// State is an auto-generated proxy to return the current state in use for the
+// render of the BookDetail component
+func (b BookDetailDef) State() BookDetailState {
+	return b.ComponentDef.State().(BookDetailState)
+}
+
+// IsState is an auto-generated definition so that BookDetailState implements
+// the myitcv.io/react.State interface.
+func (b BookDetailState) IsState() {}
+
+var _ react.State = BookDetailState{}
+
+// GetInitialStateIntf is an auto-generated proxy to GetInitialState
+func (b BookDetailDef) GetInitialStateIntf() react.State {
+	return BookDetailState{}
+}
+
+func (b BookDetailState) EqualsIntf(val react.State) bool {
+	return b == val.(BookDetailState)
+}
+
+// IsProps is an auto-generated definition so that BookDetailProps implements
+// the myitcv.io/react.Props interface.
+func (b BookDetailProps) IsProps() {}
+
+// Props is an auto-generated proxy to the current props of BookDetail
+func (b BookDetailDef) Props() BookDetailProps {
+	uprops := b.ComponentDef.Props()
+	return uprops.(BookDetailProps)
+}
+
+// ComponentWillReceivePropsIntf is an auto-generated proxy to
+// ComponentWillReceiveProps
+func (b BookDetailDef) ComponentWillReceivePropsIntf(val interface{}) {
+	ourProps := val.(BookDetailProps)
+	b.ComponentWillReceiveProps(ourProps)
+}
+
+func (b BookDetailProps) EqualsIntf(val react.Props) bool {
+	return b == val.(BookDetailProps)
+}
+
+var _ react.Props = BookDetailProps{}
@@ -0,0 +1,74 @@
+// Code generated by reactGen. DO NOT EDIT.
+
+package book
+
+import "myitcv.io/react"
+
+type CollectionDetailElem struct {
+	react.Element
+}
+
+func buildCollectionDetail(cd react.ComponentDef) react.Component {
+	return CollectionDetailDef{ComponentDef: cd}
+}
+
+func buildCollectionDetailElem(props CollectionDetailProps, children ...react.Element) *CollectionDetailElem {
+	return &CollectionDetailElem{
+		Element: react.CreateElement(buildCollectionDetail, props, children...),
+	}
+}
+
+func (c CollectionDetailDef) RendersElement() react.Element {
+	return c.Render()
+}
+
+// SetState is an auto-generated proxy proxy to update the state for the
+// CollectionDetail component.  SetState does not immediately mutate c.State()
+// but creates a pending state transition.
+func (c CollectionDetailDef) SetState(state CollectionDetailState) {
+	c.ComponentDef.SetState(state)
+}
+
+// State is an auto-generated proxy to return the current state in use for the
+// render of the CollectionDetail component
+func (c CollectionDetailDef) State() CollectionDetailState {
+	return c.ComponentDef.State().(CollectionDetailState)
+}
+
+// IsState is an auto-generated definition so that CollectionDetailState implements
+// the myitcv.io/react.State interface.
+func (c CollectionDetailState) IsState() {}
+
+var _ react.State = CollectionDetailState{}
+
+// GetInitialStateIntf is an auto-generated proxy to GetInitialState
+func (c CollectionDetailDef) GetInitialStateIntf() react.State {
+	return CollectionDetailState{}
+}
+
+func (c CollectionDetailState) EqualsIntf(val react.State) bool {
+	return c == val.(CollectionDetailState)
+}
+
+// IsProps is an auto-generated definition so that CollectionDetailProps implements
+// the myitcv.io/react.Props interface.
+func (c CollectionDetailProps) IsProps() {}
+
+// Props is an auto-generated proxy to the current props of CollectionDetail
+func (c CollectionDetailDef) Props() CollectionDetailProps {
+	uprops := c.ComponentDef.Props()
+	return uprops.(CollectionDetailProps)
+}
+
+// ComponentWillReceivePropsIntf is an auto-generated proxy to
+// ComponentWillReceiveProps
+func (c CollectionDetailDef) ComponentWillReceivePropsIntf(val interface{}) {
+	ourProps := val.(CollectionDetailProps)
+	c.ComponentWillReceiveProps(ourProps)
+}
+
+func (c CollectionDetailProps) EqualsIntf(val react.Props) bool {
+	return c == val.(CollectionDetailProps)
+}
+
+var _ react.Props = CollectionDetailProps{}
@@ -0,0 +1,67 @@
+// Code generated by reactGen. DO NOT EDIT.
+
+package book
+
+import "myitcv.io/react"
+
+type HoldsElem struct {
+	react.Element
+}
+
+func buildHolds(cd react.ComponentDef) react.Component {
+	return HoldsDef{ComponentDef: cd}
+}
+
+func buildHoldsElem(props HoldsProps, children ...react.Element) *HoldsElem {
+	return &HoldsElem{
+		Element: react.CreateElement(buildHolds, props, children...),
+	}
+}
+
+func (h HoldsDef) RendersElement() react.Element {
+	return h.Render()
+}
+
+// SetState is an auto-generated proxy proxy to update the state for the
+// Holds component.  SetState does not immediately mutate h.State()
+// but creates a pending state transition.
+func (h HoldsDef) SetState(state HoldsState) {
+	h.ComponentDef.SetState(state)
+}
+
+// State is an auto-generated proxy to return the current state in use for the
+// render of the Holds component
+func (h HoldsDef) State() HoldsState {
+	return h.ComponentDef.State().(HoldsState)
+}
+
+// IsState is an auto-generated definition so that HoldsState implements
+// the myitcv.io/react.State interface.
+func (h HoldsState) IsState() {}
+
+var _ react.State = HoldsState{}
+
+// GetInitialStateIntf is an auto-generated proxy to GetInitialState
+func (h HoldsDef) GetInitialStateIntf() react.State {
+	return HoldsState{}
+}
+
+func (h HoldsState) EqualsIntf(val react.State) bool {
+	return h == val.(HoldsState)
+}
+
+// IsProps is an auto-generated definition so that HoldsProps implements
+// the myitcv.io/react.Props interface.
+func (h HoldsProps) IsProps() {}
+
+// Props is an auto-generated proxy to the current props of Holds
+func (h HoldsDef) Props() HoldsProps {
+	uprops := h.ComponentDef.Props()
+	return uprops.(HoldsProps)
+}
+
+func (h HoldsProps) EqualsIntf(val react.Props) bool {
+	return h == val.(HoldsProps)
+}
+
+var _ react.Props = HoldsProps{}
@@ -0,0 +1,74 @@
+// Code generated by reactGen. DO NOT EDIT.
+
+package book
+
+import "myitcv.io/react"
+
+type ReviewsElem struct {
+	react.Element
+}
+
+func buildReviews(cd react.ComponentDef) react.Component {
+	return ReviewsDef{ComponentDef: cd}
+}
+
+func buildReviewsElem(props ReviewsProps, children ...react.Element) *ReviewsElem {
+	return &ReviewsElem{
+		Element: react.CreateElement(buildReviews, props, children...),
+	}
+}
+
+func (r ReviewsDef) RendersElement() react.Element {
+	return r.Render()
+}
+
+// SetState is an auto-generated proxy proxy to update the state for the
+// Reviews component.  SetState does not immediately mutate r.State()
+// but creates a pending state transition.
+func (r ReviewsDef) SetState(state ReviewsState) {
+	r.ComponentDef.SetState(state)
+}
+
+// State is an auto-generated proxy to return the current state in use for the
+// render of the Reviews component
+func (r ReviewsDef) State() ReviewsState {
+	return r.ComponentDef.State().(ReviewsState)
+}
+
+// IsState is an auto-generated definition so that ReviewsState implements
+// the myitcv.io/react.State interface.
+func (r ReviewsState) IsState() {}
+
+var _ react.State = ReviewsState{}
+
+// GetInitialStateIntf is an auto-generated proxy to GetInitialState
+func (r ReviewsDef) GetInitialStateIntf() react.State {
+	return ReviewsState{}
+}
+
+func (r ReviewsState) EqualsIntf(val react.State) bool {
+	return r == val.(ReviewsState)
+}
+
+// IsProps is an auto-generated definition so that ReviewsProps implements
+// the myitcv.io/react.Props interface.
+func (r ReviewsProps) IsProps() {}
+
+// Props is an auto-generated proxy to the current props of Reviews
+func (r ReviewsDef) Props() ReviewsProps {
+	uprops := r.ComponentDef.Props()
+	return uprops.(ReviewsProps)
+}
+
+// ComponentWillReceivePropsIntf is an auto-generated proxy to
+// ComponentWillReceiveProps
+func (r ReviewsDef) ComponentWillReceivePropsIntf(val interface{}) {
+	ourProps := val.(ReviewsProps)
+	r.ComponentWillReceiveProps(ourProps)
+}
+
+func (r ReviewsProps) EqualsIntf(val react.Props) bool {
+	return r == val.(ReviewsProps)
+}
+
+var _ react.Props = ReviewsProps{}
@@ -0,0 +1,161 @@
+// Code generated by immutableGen. DO NOT EDIT.
+
+package book
+
+//immutableVet:skipFile
+
+import (
+	"myitcv.io/immutable"
+
+	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+)
+
+// _Imm_holds is generated to an immutable
+// type *holds which we use in the state
+//
+// holds is an immutable type and has the following template:
+//
+// 	[]*library.Hold
+//
+type holds struct {
+	theSlice []*library.Hold
+	mutable  bool
+	__tmpl   _Imm_holds
+}
+
+var _ immutable.Immutable = new(holds)
+var _ = new(holds).__tmpl
+
+func newHolds(s ...*library.Hold) *holds {
+	c := make([]*library.Hold, len(s))
+	copy(c, s)
+
+	return &holds{
+		theSlice: c,
+	}
+}
+
+func newHoldsLen(l int) *holds {
+	c := make([]*library.Hold, l)
+
+	return &holds{
+		theSlice: c,
+	}
+}
+
+func (m *holds) Mutable() bool {
+	return m.mutable
+}
+
+func (m *holds) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theSlice)
+}
+
+func (m *holds) Get(i int) *library.Hold {
+	return m.theSlice[i]
+}
+
+func (m *holds) AsMutable() *holds {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *holds) dup() *holds {
+	resSlice := make([]*library.Hold, len(m.theSlice))
+
+	for i := range m.theSlice {
+		resSlice[i] = m.theSlice[i]
+	}
+
+	res := &holds{
+		theSlice: resSlice,
+	}
+
+	return res
+}
+
+func (m *holds) AsImmutable(v *holds) *holds {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *holds) Range() []*library.Hold {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice
+}
+
+func (m *holds) WithMutable(f func(mi *holds)) *holds {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *holds) WithImmutable(f func(mi *holds)) *holds {
+	prev := m.mutable
+	m.mutable = false
+	f(m)
+	m.mutable = prev
+
+	return m
+}
+
+func (m *holds) Set(i int, v *library.Hold) *holds {
+	if m.mutable {
+		m.theSlice[i] = v
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice[i] = v
+
+	return res
+}
+
+func (m *holds) Append(v ...*library.Hold) *holds {
+	if m.mutable {
+		m.theSlice = append(m.theSlice, v...)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = append(res.theSlice, v...)
+
+	return res
+}
+func (s *holds) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
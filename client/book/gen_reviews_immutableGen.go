@@ -0,0 +1,161 @@
+// Code generated by immutableGen. DO NOT EDIT.
+
+package book
+
+//immutableVet:skipFile
+
+import (
+	"myitcv.io/immutable"
+
+	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+)
+
+// _Imm_reviews is generated to an immutable
+// type *reviews which we use in the state
+//
+// reviews is an immutable type and has the following template:
+//
+// 	[]*library.Review
+//
+type reviews struct {
+	theSlice []*library.Review
+	mutable  bool
+	__tmpl   _Imm_reviews
+}
+
+var _ immutable.Immutable = new(reviews)
+var _ = new(reviews).__tmpl
+
+func newReviews(s ...*library.Review) *reviews {
+	c := make([]*library.Review, len(s))
+	copy(c, s)
+
+	return &reviews{
+		theSlice: c,
+	}
+}
+
+func newReviewsLen(l int) *reviews {
+	c := make([]*library.Review, l)
+
+	return &reviews{
+		theSlice: c,
+	}
+}
+
+func (m *reviews) Mutable() bool {
+	return m.mutable
+}
+
+func (m *reviews) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theSlice)
+}
+
+func (m *reviews) Get(i int) *library.Review {
+	return m.theSlice[i]
+}
+
+func (m *reviews) AsMutable() *reviews {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *reviews) dup() *reviews {
+	resSlice := make([]*library.Review, len(m.theSlice))
+
+	for i := range m.theSlice {
+		resSlice[i] = m.theSlice[i]
+	}
+
+	res := &reviews{
+		theSlice: resSlice,
+	}
+
+	return res
+}
+
+func (m *reviews) AsImmutable(v *reviews) *reviews {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *reviews) Range() []*library.Review {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice
+}
+
+func (m *reviews) WithMutable(f func(mi *reviews)) *reviews {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *reviews) WithImmutable(f func(mi *reviews)) *reviews {
+	prev := m.mutable
+	m.mutable = false
+	f(m)
+	m.mutable = prev
+
+	return m
+}
+
+func (m *reviews) Set(i int, v *library.Review) *reviews {
+	if m.mutable {
+		m.theSlice[i] = v
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice[i] = v
+
+	return res
+}
+
+func (m *reviews) Append(v ...*library.Review) *reviews {
+	if m.mutable {
+		m.theSlice = append(m.theSlice, v...)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = append(res.theSlice, v...)
+
+	return res
+}
+func (s *reviews) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
@@ -1,14 +1,86 @@
 package book
 
 import (
+	"context"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"google.golang.org/grpc/metadata"
 	r "myitcv.io/react"
 
 	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+	"github.com/johanbrandhorst/grpcweb-example/client/router"
 )
 
+// Path returns the path of the detail page of the book with the ISBN provided.
+func Path(isbn int64) string {
+	return "/books/" + strconv.FormatInt(isbn, 10)
+}
+
+// roleNames are the names of the roles of contributors
+// other than authors, in the order they are shown in.
+var roleNames = []struct {
+	role library.ContributorRole
+	name string
+}{
+	{library.ContributorRole_EDITOR, "Editor"},
+	{library.ContributorRole_TRANSLATOR, "Translator"},
+	{library.ContributorRole_ILLUSTRATOR, "Illustrator"},
+	{library.ContributorRole_NARRATOR, "Narrator"},
+	{library.ContributorRole_FOREWORD, "Foreword"},
+}
+
+// contributors returns the names of the contributors of bk with the role
+// provided. Books without authors among their contributors have the
+// author as their only author.
+func contributors(bk *library.Book, role library.ContributorRole) []string {
+	var names []string
+	for _, c := range bk.GetContributors() {
+		if c.GetRole() == role {
+			names = append(names, c.GetName())
+		}
+	}
+	if len(names) == 0 && role == library.ContributorRole_AUTHOR && bk.GetAuthor() != "" {
+		names = append(names, bk.GetAuthor())
+	}
+	return names
+}
+
+// formatDuration formats the running time of an audiobook.
+func formatDuration(seconds int32) string {
+	d := time.Duration(seconds) * time.Second
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func renderField(label, value string) r.Element {
+	return r.Div(nil,
+		r.S(label+": "),
+		r.Code(nil,
+			r.S(value),
+		),
+	)
+}
+
+// CollectionPath returns the path of the page of the collection with the Id provided.
+func CollectionPath(id string) string {
+	return "/collections/" + id
+}
+
+// user and session are the name and session of the user logged
+// in with the Login component, which the collections, loans, holds
+// and reviews of this client are made by.
+var user, session string
+
+// userContext returns ctx with the request metadata of session.
+func userContext(ctx context.Context) context.Context {
+	if session == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "session", session)
+}
+
 func renderBook(bk *library.Book) r.Element {
 	var publisher string
 	switch bk.GetPublishingMethod().(type) {
@@ -17,46 +89,90 @@ func renderBook(bk *library.Book) r.Element {
 	case *library.Book_SelfPublished:
 		publisher = "Self-published"
 	}
-	return r.Div(nil,
+
+	content := []r.Element{
 		r.Hr(nil),
-		r.Div(nil,
-			r.S("Title: "),
-			r.Code(nil,
-				r.S(bk.GetTitle()),
-			),
-		),
-		r.Div(nil,
-			r.S("Author: "),
-			r.Code(nil,
-				r.S(bk.GetAuthor()),
-			),
-		),
-		r.Div(nil,
-			r.S("Publisher: "),
-			r.Code(nil,
-				r.S(publisher),
-			),
-		),
-		r.Div(nil,
-			r.S("Publication date: "),
-			r.Code(nil,
-				r.S(time.Unix(
-					bk.GetPublicationDate().GetSeconds(),
-					int64(bk.GetPublicationDate().GetNanos()),
-				).Format("Monday, 02 Jan 2006")),
-			),
-		),
-		r.Div(nil,
-			r.S("Book type: "),
-			r.Code(nil,
-				r.S(bk.GetBookType().String()),
-			),
-		),
+	}
+	if bk.GetCoverImageUrl() != "" {
+		content = append(content,
+			r.Img(&r.ImgProps{
+				Src:   bk.GetCoverImageUrl(),
+				Alt:   "Cover of " + bk.GetTitle(),
+				Style: &r.CSS{Float: "right", MaxHeight: "180px"},
+			}),
+		)
+	}
+	content = append(content,
+		renderField("Title", bk.GetTitle()),
+		renderField("Author", strings.Join(contributors(bk, library.ContributorRole_AUTHOR), ", ")),
+	)
+	for _, rn := range roleNames {
+		if names := contributors(bk, rn.role); len(names) > 0 {
+			content = append(content, renderField(rn.name, strings.Join(names, ", ")))
+		}
+	}
+	if bk.GetEdition() != "" {
+		content = append(content, renderField("Edition", bk.GetEdition()))
+	}
+	if series := bk.GetSeries(); series != nil {
+		name := series.GetName()
+		if series.GetPosition() > 0 {
+			name += " #" + strconv.Itoa(int(series.GetPosition()))
+		}
+		content = append(content, renderField("Series", name))
+	}
+	content = append(content,
+		renderField("Publisher", publisher),
+		renderField("Publication date", time.Unix(
+			bk.GetPublicationDate().GetSeconds(),
+			int64(bk.GetPublicationDate().GetNanos()),
+		).Format("Monday, 02 Jan 2006")),
+		renderField("Book type", bk.GetBookType().String()),
+	)
+	switch bk.GetExtent().(type) {
+	case *library.Book_PageCount:
+		content = append(content, renderField("Pages", strconv.Itoa(int(bk.GetPageCount()))))
+	case *library.Book_DurationSeconds:
+		content = append(content, renderField("Duration", formatDuration(bk.GetDurationSeconds())))
+	}
+	if bk.GetLanguage() != "" {
+		content = append(content, renderField("Language", bk.GetLanguage()))
+	}
+	if len(bk.GetSubjects()) > 0 {
+		content = append(content, renderField("Subjects", strings.Join(bk.GetSubjects(), ", ")))
+	}
+	if bk.GetRatingCount() > 0 {
+		content = append(content, renderField("Rating", fmt.Sprintf("%.1f of 5 from %d reviews", bk.GetAverageRating(), bk.GetRatingCount())))
+	}
+	if a := bk.GetAvailability(); a != nil {
+		content = append(content, renderField("Available", renderAvailability(a)))
+	}
+	content = append(content,
 		r.Div(nil,
 			r.S("ISBN: "),
-			r.Code(nil,
-				r.S(strconv.Itoa(int(bk.GetIsbn()))),
+			router.Link(Path(bk.GetIsbn()), nil,
+				r.Code(nil,
+					r.S(strconv.Itoa(int(bk.GetIsbn()))),
+				),
 			),
 		),
 	)
+	if bk.GetDescription() != "" {
+		content = append(content, r.P(nil, r.S(bk.GetDescription())))
+	}
+	return r.Div(nil, content...)
+}
+
+// renderAvailability describes the copies of a book available,
+// out of all its copies, in total and at each branch.
+func renderAvailability(a *library.Availability) string {
+	s := fmt.Sprintf("%d of %d copies", a.GetAvailable(), a.GetCopies())
+	var branches []string
+	for _, ba := range a.GetBranches() {
+		branches = append(branches, fmt.Sprintf("%s: %d of %d", ba.GetBranchName(), ba.GetAvailable(), ba.GetCopies()))
+	}
+	if len(branches) > 0 {
+		s += " (" + strings.Join(branches, ", ") + ")"
+	}
+	return s
 }
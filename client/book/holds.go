@@ -0,0 +1,320 @@
+package book
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/johanbrandhorst/protobuf/grpcweb/status"
+	"honnef.co/go/js/dom"
+	r "myitcv.io/react"
+
+	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+	"github.com/johanbrandhorst/grpcweb-example/client/router"
+)
+
+//go:generate reactGen
+//go:generate immutableGen
+
+// HoldsDef defines the Holds component
+type HoldsDef struct {
+	r.ComponentDef
+}
+
+// HoldsProps defines the properties of this component
+type HoldsProps struct {
+	Client library.BookServiceClient
+}
+
+// _Imm_holds is generated to an immutable
+// type *holds which we use in the state
+type _Imm_holds []*library.Hold
+
+// HoldsState holds the state for the Holds component
+type HoldsState struct {
+	isbnInput string
+	holds     *holds
+	err       string
+	// watch is the watch of the holds of the
+	// user, once they have been requested.
+	watch *holdsWatch
+}
+
+// holdsWatch stops watching the holds when cancelled.
+type holdsWatch struct {
+	cancel context.CancelFunc
+}
+
+// GetInitialState ensures HoldsState is initialized with a valid
+// holds value.
+func (h *HoldsDef) GetInitialState() HoldsState {
+	return HoldsState{
+		holds: newHolds(),
+	}
+}
+
+// Holds returns the Holds component.
+func Holds(p HoldsProps) *HoldsElem {
+	return buildHoldsElem(p)
+}
+
+// ComponentWillUnmount is a React lifecycle method for the Holds
+// component. It stops watching the holds.
+func (h HoldsDef) ComponentWillUnmount() {
+	if w := h.State().watch; w != nil {
+		w.cancel()
+	}
+}
+
+// Render renders the Holds component.
+func (h HoldsDef) Render() r.Element {
+	st := h.State()
+	content := []r.Element{
+		r.P(nil, r.S("Log in, then get in line for books with no copy available, "+
+			"and be told when a copy is ready to pick up.")),
+		r.Form(&r.FormProps{ClassName: "form-inline"},
+			r.Div(
+				&r.DivProps{ClassName: "form-group"},
+				r.Button(&r.ButtonProps{
+					Type:      "submit",
+					ClassName: "btn btn-default",
+					OnClick:   triggerWatchHolds{h},
+				}, r.S("Show my holds")),
+			),
+		),
+		r.Form(&r.FormProps{ClassName: "form-inline"},
+			r.Div(
+				&r.DivProps{ClassName: "form-group"},
+				r.Label(&r.LabelProps{ClassName: "sr-only", For: "holdIsbn"}, r.S("ISBN")),
+				r.Input(&r.InputProps{
+					Type:        "number",
+					ClassName:   "form-control",
+					ID:          "holdIsbn",
+					Value:       st.isbnInput,
+					OnChange:    holdsInputChange{h},
+					Placeholder: "Book ISBN",
+				}),
+				r.Button(&r.ButtonProps{
+					Type:      "submit",
+					ClassName: "btn btn-default",
+					OnClick:   triggerPlaceHold{h},
+				}, r.S("Place hold")),
+			),
+		),
+	}
+
+	for _, hold := range st.holds.Range() {
+		content = append(content, h.renderHold(hold))
+	}
+
+	if st.err != "" {
+		content = append(content,
+			r.Div(nil,
+				r.Hr(nil),
+				r.S("Error: "+st.err),
+			),
+		)
+	}
+
+	return r.Div(nil, content...)
+}
+
+func (h HoldsDef) renderHold(hold *library.Hold) r.Element {
+	content := []r.Element{
+		r.Hr(nil),
+		r.Div(nil,
+			r.S("ISBN: "),
+			router.Link(Path(hold.GetIsbn()), nil,
+				r.Code(nil,
+					r.S(strconv.FormatInt(hold.GetIsbn(), 10)),
+				),
+			),
+		),
+	}
+	switch hold.GetState() {
+	case library.Hold_WAITING:
+		content = append(content, renderField("Place in line", strconv.Itoa(int(hold.GetPosition()))))
+	case library.Hold_READY:
+		content = append(content,
+			r.Div(&r.DivProps{ClassName: "text-success"},
+				r.S("Copy "+hold.GetCopyId()+" is ready to pick up until "+readyUntil(hold)),
+			),
+		)
+	default:
+		content = append(content, renderField("State", hold.GetState().String()))
+	}
+	if hold.GetState() == library.Hold_WAITING || hold.GetState() == library.Hold_READY {
+		content = append(content,
+			r.Button(&r.ButtonProps{
+				Type:      "button",
+				ClassName: "btn btn-default btn-xs",
+				OnClick:   triggerCancelHold{h, hold.GetId()},
+			}, r.S("Cancel hold")),
+		)
+	}
+	return r.Div(nil, content...)
+}
+
+// readyUntil returns when the copy of the ready hold is released.
+func readyUntil(hold *library.Hold) string {
+	return time.Unix(
+		hold.GetReadyUntil().GetSeconds(),
+		int64(hold.GetReadyUntil().GetNanos()),
+	).Format("Monday, 02 Jan 15:04")
+}
+
+type holdsInputChange struct{ h HoldsDef }
+type triggerWatchHolds struct{ h HoldsDef }
+type triggerPlaceHold struct{ h HoldsDef }
+type triggerCancelHold struct {
+	h  HoldsDef
+	id string
+}
+
+func (c holdsInputChange) OnChange(se *r.SyntheticEvent) {
+	target := se.Target().(*dom.HTMLInputElement)
+
+	newSt := c.h.State()
+	newSt.isbnInput = target.Value
+
+	c.h.SetState(newSt)
+}
+
+func (t triggerWatchHolds) OnClick(se *r.SyntheticMouseEvent) {
+	if session == "" {
+		newSt := t.h.State()
+		newSt.err = "Log in to see your holds"
+		t.h.SetState(newSt)
+		se.PreventDefault()
+		return
+	}
+	requestNotifications()
+	// Wrapped in goroutine because Recv is blocking
+	go t.h.watchHolds()
+
+	se.PreventDefault()
+}
+
+func (t triggerPlaceHold) OnClick(se *r.SyntheticMouseEvent) {
+	// Wrapped in goroutine because PlaceHold is blocking
+	go func() {
+		newSt := t.h.State()
+		defer func() {
+			t.h.SetState(newSt)
+		}()
+		newSt.err = ""
+
+		isbn, err := strconv.ParseInt(newSt.isbnInput, 10, 64)
+		if err != nil {
+			newSt.err = "ISBN must not be empty"
+			return
+		}
+		if newSt.watch == nil {
+			newSt.err = "Show your holds first"
+			return
+		}
+
+		// 1 second timeout
+		ctx, cancel := context.WithTimeout(userContext(context.Background()), time.Second)
+		defer cancel()
+
+		// The hold is shown when the watch receives it
+		_, err = t.h.Props().Client.PlaceHold(ctx, &library.PlaceHoldRequest{
+			Isbn: isbn,
+		})
+		if err != nil {
+			sts := status.FromError(err)
+			newSt.err = sts.Message
+			return
+		}
+		newSt.isbnInput = ""
+	}()
+
+	se.PreventDefault()
+}
+
+func (t triggerCancelHold) OnClick(se *r.SyntheticMouseEvent) {
+	// Wrapped in goroutine because CancelHold is blocking
+	go func() {
+		// 1 second timeout
+		ctx, cancel := context.WithTimeout(userContext(context.Background()), time.Second)
+		defer cancel()
+
+		_, err := t.h.Props().Client.CancelHold(ctx, &library.CancelHoldRequest{
+			HoldId: t.id,
+		})
+		if err != nil {
+			newSt := t.h.State()
+			newSt.err = status.FromError(err).Message
+			t.h.SetState(newSt)
+		}
+	}()
+
+	se.PreventDefault()
+}
+
+// watchHolds shows the holds of the user as they change,
+// until the holds of another user are shown.
+func (h HoldsDef) watchHolds() {
+	ctx, cancel := context.WithCancel(userContext(context.Background()))
+	newSt := h.State()
+	if newSt.watch != nil {
+		newSt.watch.cancel()
+	}
+	newSt.watch = &holdsWatch{cancel: cancel}
+	newSt.holds = newHolds()
+	newSt.err = ""
+	h.SetState(newSt)
+
+	srv, err := h.Props().Client.WatchMyHolds(ctx, &library.WatchMyHoldsRequest{})
+	for err == nil {
+		var hold *library.Hold
+		hold, err = srv.Recv()
+		if err != nil {
+			break
+		}
+		newSt := h.State()
+		newSt.holds = applyHold(newSt.holds, hold)
+		h.SetState(newSt)
+		if hold.GetState() == library.Hold_READY {
+			notify("A book is ready to pick up",
+				"Copy "+hold.GetCopyId()+" is kept for you until "+readyUntil(hold))
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	newSt = h.State()
+	newSt.err = "Updates to holds interrupted: " + status.FromError(err).Message
+	h.SetState(newSt)
+}
+
+// applyHold returns hs with hold replacing the hold with its Id.
+func applyHold(hs *holds, hold *library.Hold) *holds {
+	for i, old := range hs.Range() {
+		if old.GetId() == hold.GetId() {
+			return hs.Set(i, hold)
+		}
+	}
+	return hs.Append(hold)
+}
+
+// requestNotifications asks for permission to show
+// notifications, if the browser supports them.
+func requestNotifications() {
+	n := js.Global.Get("Notification")
+	if n == js.Undefined || n.Get("permission").String() != "default" {
+		return
+	}
+	n.Call("requestPermission")
+}
+
+// notify shows a notification, if permitted.
+func notify(title, body string) {
+	n := js.Global.Get("Notification")
+	if n == js.Undefined || n.Get("permission").String() != "granted" {
+		return
+	}
+	n.New(title, js.M{"body": body})
+}
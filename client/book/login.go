@@ -0,0 +1,149 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"github.com/johanbrandhorst/protobuf/grpcweb/status"
+	"honnef.co/go/js/dom"
+	r "myitcv.io/react"
+
+	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+)
+
+//go:generate reactGen
+
+// LoginDef defines the Login component
+type LoginDef struct {
+	r.ComponentDef
+}
+
+// LoginProps defines the properties of this component
+type LoginProps struct {
+	Client library.UserServiceClient
+}
+
+// LoginState holds the state for the Login component
+type LoginState struct {
+	nameInput     string
+	passwordInput string
+	// loggedIn is the name of the user
+	// logged in, or "" if logged out.
+	loggedIn string
+	err      string
+}
+
+// Login returns the Login component, which logs in
+// the user the calls of this client are made by.
+func Login(p LoginProps) *LoginElem {
+	return buildLoginElem(p)
+}
+
+// Render renders the Login component.
+func (l LoginDef) Render() r.Element {
+	st := l.State()
+	if st.loggedIn != "" {
+		return r.Form(&r.FormProps{ClassName: "navbar-form navbar-right"},
+			r.Span(&r.SpanProps{ClassName: "navbar-text"}, r.S("Logged in as "+st.loggedIn)),
+			r.Button(&r.ButtonProps{
+				Type:      "submit",
+				ClassName: "btn btn-default",
+				OnClick:   triggerLogout{l},
+			}, r.S("Log out")),
+		)
+	}
+
+	content := []r.Element{
+		r.Div(
+			&r.DivProps{ClassName: "form-group"},
+			r.Label(&r.LabelProps{ClassName: "sr-only", For: "loginName"}, r.S("User name")),
+			r.Input(&r.InputProps{
+				Type:        "text",
+				ClassName:   "form-control",
+				ID:          "loginName",
+				Value:       st.nameInput,
+				OnChange:    loginInputChange{l, "name"},
+				Placeholder: "User name",
+			}),
+			r.Label(&r.LabelProps{ClassName: "sr-only", For: "loginPassword"}, r.S("Password")),
+			r.Input(&r.InputProps{
+				Type:        "password",
+				ClassName:   "form-control",
+				ID:          "loginPassword",
+				Value:       st.passwordInput,
+				OnChange:    loginInputChange{l, "password"},
+				Placeholder: "Password",
+			}),
+		),
+		r.Button(&r.ButtonProps{
+			Type:      "submit",
+			ClassName: "btn btn-default",
+			OnClick:   triggerLogin{l},
+		}, r.S("Log in")),
+	}
+	if st.err != "" {
+		content = append(content,
+			r.Span(&r.SpanProps{ClassName: "navbar-text text-danger"}, r.S(st.err)),
+		)
+	}
+	return r.Form(&r.FormProps{ClassName: "navbar-form navbar-right"}, content...)
+}
+
+type loginInputChange struct {
+	l     LoginDef
+	field string
+}
+type triggerLogin struct{ l LoginDef }
+type triggerLogout struct{ l LoginDef }
+
+func (c loginInputChange) OnChange(se *r.SyntheticEvent) {
+	target := se.Target().(*dom.HTMLInputElement)
+
+	newSt := c.l.State()
+	switch c.field {
+	case "name":
+		newSt.nameInput = target.Value
+	case "password":
+		newSt.passwordInput = target.Value
+	}
+
+	c.l.SetState(newSt)
+}
+
+func (t triggerLogin) OnClick(se *r.SyntheticMouseEvent) {
+	// Wrapped in goroutine because Login is blocking
+	go func() {
+		newSt := t.l.State()
+		defer func() {
+			t.l.SetState(newSt)
+		}()
+		newSt.err = ""
+
+		// 1 second timeout
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		resp, err := t.l.Props().Client.Login(ctx, &library.LoginRequest{
+			Name:     newSt.nameInput,
+			Password: newSt.passwordInput,
+		})
+		newSt.passwordInput = ""
+		if err != nil {
+			newSt.err = status.FromError(err).Message
+			return
+		}
+		user, session = resp.GetUser().GetName(), resp.GetSession()
+		newSt.loggedIn = user
+	}()
+
+	se.PreventDefault()
+}
+
+func (t triggerLogout) OnClick(se *r.SyntheticMouseEvent) {
+	user, session = "", ""
+	newSt := t.l.State()
+	newSt.loggedIn = ""
+	t.l.SetState(newSt)
+
+	se.PreventDefault()
+}
@@ -5,10 +5,12 @@ import (
 	"strconv"
 
 	"github.com/johanbrandhorst/protobuf/grpcweb/status"
+	"google.golang.org/grpc/metadata"
 	"honnef.co/go/js/dom"
 	r "myitcv.io/react"
 
 	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+	"github.com/johanbrandhorst/grpcweb-example/client/router"
 )
 
 //go:generate reactGen
@@ -25,6 +27,8 @@ type MakeCollectionProps struct {
 
 // MakeCollectionState holds the state for the MakeCollection component
 type MakeCollectionState struct {
+	nameInput  string
+	visibility string
 	isbnInput  string
 	numAdded   int
 	collection *library.Collection
@@ -40,8 +44,41 @@ func MakeCollection(p MakeCollectionProps) *MakeCollectionElem {
 // Render renders the MakeCollection component
 func (g MakeCollectionDef) Render() r.Element {
 	st := g.State()
+	visibility := st.visibility
+	if visibility == "" {
+		visibility = library.Visibility_UNLISTED.String()
+	}
+	var visibilities []*r.OptionElem
+	for _, v := range []library.Visibility{
+		library.Visibility_PRIVATE,
+		library.Visibility_UNLISTED,
+		library.Visibility_PUBLIC,
+	} {
+		visibilities = append(visibilities,
+			r.Option(&r.OptionProps{Value: v.String()}, r.S(v.String())),
+		)
+	}
 	content := []r.Element{
 		r.P(nil, r.S("Assemble a collection of Books")),
+		r.Form(&r.FormProps{ClassName: "form-inline"},
+			r.Div(
+				&r.DivProps{ClassName: "form-group"},
+				r.Label(&r.LabelProps{ClassName: "sr-only", For: "collectionName"}, r.S("Collection name")),
+				r.Input(&r.InputProps{
+					Type:        "text",
+					ClassName:   "form-control",
+					ID:          "collectionName",
+					Value:       st.nameInput,
+					OnChange:    collectionInputChange{g, "name"},
+					Placeholder: "Collection name",
+				}),
+				r.Select(&r.SelectProps{
+					ClassName: "form-control",
+					Value:     visibility,
+					OnChange:  collectionInputChange{g, "visibility"},
+				}, visibilities...),
+			),
+		),
 		r.Form(&r.FormProps{ClassName: "form-inline"},
 			r.Div(
 				&r.DivProps{ClassName: "form-group"},
@@ -79,19 +116,28 @@ func (g MakeCollectionDef) Render() r.Element {
 	))
 
 	if st.collection != nil {
-		for _, bk := range st.collection.GetBooks() {
-			content = append(content,
-				r.Div(nil,
-					r.Hr(nil),
-					r.Div(nil,
-						r.S("ISBN: "),
-						r.Code(nil,
-							r.S(strconv.Itoa(int(bk.GetIsbn()))),
-						),
+		path := CollectionPath(st.collection.GetId())
+		content = append(content,
+			r.Div(nil,
+				r.Hr(nil),
+				r.S("Share this collection: "),
+				router.Link(path, nil,
+					r.Code(nil,
+						r.S(dom.GetWindow().Location().Origin+path),
 					),
 				),
+			),
+		)
+		for _, e := range st.collection.GetErrors() {
+			content = append(content,
+				r.Div(&r.DivProps{ClassName: "text-warning"},
+					r.S("ISBN "+strconv.FormatInt(e.GetIsbn(), 10)+" was not added: "+e.GetMessage()),
+				),
 			)
 		}
+		for _, bk := range st.collection.GetBooks() {
+			content = append(content, renderBook(bk))
+		}
 	}
 
 	if st.err != "" {
@@ -107,6 +153,10 @@ func (g MakeCollectionDef) Render() r.Element {
 }
 
 type isbnInputChange2 struct{ g MakeCollectionDef }
+type collectionInputChange struct {
+	g     MakeCollectionDef
+	field string
+}
 type triggerAdd struct{ g MakeCollectionDef }
 type triggerCollect struct{ g MakeCollectionDef }
 
@@ -119,6 +169,26 @@ func (i isbnInputChange2) OnChange(se *r.SyntheticEvent) {
 	i.g.SetState(newSt)
 }
 
+func (c collectionInputChange) OnChange(se *r.SyntheticEvent) {
+	var value string
+	switch target := se.Target().(type) {
+	case *dom.HTMLInputElement:
+		value = target.Value
+	case *dom.HTMLSelectElement:
+		value = target.Value
+	}
+
+	newSt := c.g.State()
+	switch c.field {
+	case "name":
+		newSt.nameInput = value
+	case "visibility":
+		newSt.visibility = value
+	}
+
+	c.g.SetState(newSt)
+}
+
 func (t triggerAdd) OnClick(se *r.SyntheticMouseEvent) {
 	// Wrapped in goroutine because MakeCollection is blocking
 	go func() {
@@ -136,7 +206,17 @@ func (t triggerAdd) OnClick(se *r.SyntheticMouseEvent) {
 		}
 
 		if newSt.client == nil {
-			newSt.client, err = t.g.Props().Client.MakeCollection(context.Background())
+			// The collection is made by the user logged
+			// in, who can then see it if it is private.
+			visibility := newSt.visibility
+			if visibility == "" {
+				visibility = library.Visibility_UNLISTED.String()
+			}
+			ctx := metadata.AppendToOutgoingContext(userContext(context.Background()),
+				"collection-name", newSt.nameInput,
+				"collection-visibility", visibility,
+			)
+			newSt.client, err = t.g.Props().Client.MakeCollection(ctx)
 			if err != nil {
 				sts := status.FromError(err)
 				newSt.err = sts.Error()
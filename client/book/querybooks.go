@@ -3,8 +3,10 @@ package book
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/johanbrandhorst/protobuf/grpcweb/status"
+	"google.golang.org/grpc/codes"
 	"honnef.co/go/js/dom"
 	r "myitcv.io/react"
 
@@ -33,6 +35,14 @@ type QueryBooksState struct {
 	authorInput string
 	books       *books
 	err         string
+	// watch is the watch keeping books up to date,
+	// once the books have been queried.
+	watch *bookWatch
+}
+
+// bookWatch stops the current query and watch when cancelled.
+type bookWatch struct {
+	cancel context.CancelFunc
 }
 
 // GetInitialState ensures QueryBooksState is initialized with a valid
@@ -48,6 +58,14 @@ func QueryBooks(p QueryBooksProps) *QueryBooksElem {
 	return buildQueryBooksElem(p)
 }
 
+// ComponentWillUnmount is a React lifecycle method for the QueryBooks
+// component. It stops watching the books.
+func (q QueryBooksDef) ComponentWillUnmount() {
+	if w := q.State().watch; w != nil {
+		w.cancel()
+	}
+}
+
 // Render renders the QueryBooks component.
 func (q QueryBooksDef) Render() r.Element {
 	st := q.State()
@@ -76,6 +94,14 @@ func (q QueryBooksDef) Render() r.Element {
 		),
 	}
 
+	if st.watch != nil && st.err == "" {
+		content = append(content,
+			r.P(&r.PProps{ClassName: "text-muted"},
+				r.S("Results are updated live as the library changes."),
+			),
+		)
+	}
+
 	if st.books.Len() != 0 {
 		for _, bk := range st.books.Range() {
 			content = append(content, renderBook(bk))
@@ -108,48 +134,126 @@ func (a authorInputChange) OnChange(se *r.SyntheticEvent) {
 
 func (t triggerQuery) OnClick(se *r.SyntheticMouseEvent) {
 	// Wrapped in goroutine because Recv is blocking
-	go func() {
-		newSt := t.q.State()
-		defer func() {
-			t.q.SetState(newSt)
-		}()
-		newSt.err = ""
-
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
-		srv, err := t.q.Props().Client.QueryBooks(ctx, &library.QueryBooksRequest{
-			AuthorPrefix: newSt.authorInput,
-		})
+	go t.q.query(t.q.State().authorInput)
+
+	se.PreventDefault()
+}
+
+// query replaces the books with the books by authors starting
+// with author, and keeps them up to date until the next query.
+func (q QueryBooksDef) query(author string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	newSt := q.State()
+	if newSt.watch != nil {
+		newSt.watch.cancel()
+	}
+	newSt.watch = &bookWatch{cancel: cancel}
+	newSt.err = ""
+	q.SetState(newSt)
+
+	srv, err := q.Props().Client.QueryBooks(ctx, &library.QueryBooksRequest{
+		AuthorPrefix: author,
+	})
+	if err != nil {
+		sts := status.FromError(err)
+		newSt.err = sts.Message
+		q.SetState(newSt)
+		return
+	}
+	newSt.books = newBooks()
+
+	for {
+		// Blocks until book received
+		bk, err := srv.Recv()
+		if err == io.EOF {
+			// Success!
+			break
+		}
 		if err != nil {
-			sts := status.FromError(err)
-			newSt.err = sts.Message
+			if ctx.Err() == nil {
+				sts := status.FromError(err)
+				newSt.err = sts.Message
+				q.SetState(newSt)
+			}
 			return
 		}
-		newSt.books = newBooks()
 
-		for {
-			// Blocks until book received
-			bk, err := srv.Recv()
+		newSt.books = newSt.books.Append(bk)
+		// Set state to immediately show book to user
+		q.SetState(newSt)
+	}
+	if newSt.books.Len() == 0 {
+		newSt.err = "No books found for that author"
+	}
+	q.SetState(newSt)
+
+	q.watch(ctx, author)
+}
+
+// watch applies the changes to the books by authors starting with
+// author to the books in the state, until ctx is cancelled.
+func (q QueryBooksDef) watch(ctx context.Context, author string) {
+	// The books may have changed since they were queried, so
+	// the existing books are sent before any further changes.
+	req := &library.WatchBooksRequest{
+		AuthorPrefix:    author,
+		IncludeExisting: true,
+	}
+	for {
+		srv, err := q.Props().Client.WatchBooks(ctx, req)
+		for err == nil {
+			var ev *library.BookEvent
+			ev, err = srv.Recv()
 			if err != nil {
-				if err == io.EOF {
-					// Success!
-					if newSt.books.Len() == 0 {
-						newSt.err = "No books found for that author"
-					}
-
-					return
-				}
-				sts := status.FromError(err)
-				newSt.err = sts.Message
-				return
+				break
 			}
+			req.AfterSequence = ev.GetSequence()
+			req.IncludeExisting = false
 
-			newSt.books = newSt.books.Append(bk)
-			// Set state to immediately show book to user
-			t.q.SetState(newSt)
+			newSt := q.State()
+			newSt.books = applyEvent(newSt.books, ev)
+			newSt.err = ""
+			q.SetState(newSt)
+		}
+		if ctx.Err() != nil {
+			return
 		}
-	}()
 
-	se.PreventDefault()
+		sts := status.FromError(err)
+		if sts.Code == codes.OutOfRange {
+			// The changes missed are no longer
+			// available, so start all over.
+			go q.query(author)
+			return
+		}
+		newSt := q.State()
+		newSt.err = "Live updates interrupted: " + sts.Message
+		q.SetState(newSt)
+
+		// Resume after the last change received
+		select {
+		case <-time.After(5 * time.Second):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// applyEvent returns bks with the change described by ev applied.
+func applyEvent(bks *books, ev *library.BookEvent) *books {
+	bk := ev.GetBook()
+	for i, old := range bks.Range() {
+		if old.GetIsbn() != bk.GetIsbn() {
+			continue
+		}
+		if ev.GetType() == library.BookEvent_DELETED {
+			rest := append([]*library.Book{}, bks.Range()[:i]...)
+			return newBooks(append(rest, bks.Range()[i+1:]...)...)
+		}
+		return bks.Set(i, bk)
+	}
+	if ev.GetType() == library.BookEvent_DELETED {
+		return bks
+	}
+	return bks.Append(bk)
 }
@@ -0,0 +1,422 @@
+package book
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johanbrandhorst/protobuf/grpcweb/status"
+	"honnef.co/go/js/dom"
+	r "myitcv.io/react"
+
+	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+)
+
+//go:generate reactGen
+//go:generate immutableGen
+
+// ReviewsDef defines the Reviews component
+type ReviewsDef struct {
+	r.ComponentDef
+}
+
+// ReviewsProps defines the properties of this component
+type ReviewsProps struct {
+	Client library.BookServiceClient
+	Isbn   int64
+}
+
+// _Imm_reviews is generated to an immutable
+// type *reviews which we use in the state
+type _Imm_reviews []*library.Review
+
+// ReviewsState holds the state for the Reviews component
+type ReviewsState struct {
+	ratingInput string
+	textInput   string
+	// editing is the Id of the review of the user
+	// being edited, or empty if writing a review.
+	editing string
+	order   library.ReviewOrder
+	reviews *reviews
+	// nextPageToken lists the next page of
+	// reviews, if there are more to show.
+	nextPageToken string
+	err           string
+}
+
+// GetInitialState ensures ReviewsState is initialized with a valid
+// reviews value.
+func (v *ReviewsDef) GetInitialState() ReviewsState {
+	return ReviewsState{
+		ratingInput: "5",
+		reviews:     newReviews(),
+	}
+}
+
+// Reviews returns the Reviews component, which shows
+// and edits the reviews of the book with the ISBN provided.
+func Reviews(p ReviewsProps) *ReviewsElem {
+	return buildReviewsElem(p)
+}
+
+// ComponentDidMount is a React lifecycle method for the Reviews component.
+// It fetches the first page of reviews.
+func (v ReviewsDef) ComponentDidMount() {
+	go v.reload(v.Props())
+}
+
+// ComponentWillReceiveProps is a React lifecycle method for the Reviews
+// component. It fetches the reviews if the ISBN has changed.
+func (v ReviewsDef) ComponentWillReceiveProps(p ReviewsProps) {
+	if p.Isbn != v.Props().Isbn {
+		go v.reload(p)
+	}
+}
+
+// Render renders the Reviews component.
+func (v ReviewsDef) Render() r.Element {
+	st := v.State()
+	var ratings []*r.OptionElem
+	for i := 5; i >= 1; i-- {
+		ratings = append(ratings, r.Option(&r.OptionProps{Value: strconv.Itoa(i)}, r.S(stars(int32(i)))))
+	}
+	submit := "Submit review"
+	if st.editing != "" {
+		submit = "Save review"
+	}
+	content := []r.Element{
+		r.Hr(nil),
+		r.H3(nil, r.S("Reviews")),
+		r.Form(nil,
+			r.Div(
+				&r.DivProps{ClassName: "form-group"},
+				r.Label(&r.LabelProps{ClassName: "sr-only", For: "reviewRating"}, r.S("Rating")),
+				r.Select(&r.SelectProps{
+					ClassName: "form-control",
+					ID:        "reviewRating",
+					Value:     st.ratingInput,
+					OnChange:  reviewsInputChange{v, "rating"},
+				}, ratings...),
+			),
+			r.Div(
+				&r.DivProps{ClassName: "form-group"},
+				r.Label(&r.LabelProps{ClassName: "sr-only", For: "reviewText"}, r.S("Review")),
+				r.TextArea(&r.TextAreaProps{
+					ClassName:   "form-control",
+					ID:          "reviewText",
+					Value:       st.textInput,
+					OnChange:    reviewsInputChange{v, "text"},
+					Placeholder: "What did you think of the book?",
+				}),
+			),
+			r.Button(&r.ButtonProps{
+				Type:      "submit",
+				ClassName: "btn btn-default",
+				OnClick:   triggerSubmitReview{v},
+			}, r.S(submit)),
+		),
+		r.Div(nil,
+			r.S("Sort by: "),
+			v.renderOrder(library.ReviewOrder_NEWEST, "Newest"),
+			r.S(" "),
+			v.renderOrder(library.ReviewOrder_MOST_HELPFUL, "Most helpful"),
+		),
+	}
+
+	for _, rv := range st.reviews.Range() {
+		content = append(content, v.renderReview(rv))
+	}
+	if st.reviews.Len() == 0 {
+		content = append(content, r.P(nil, r.S("No reviews yet.")))
+	}
+	if st.nextPageToken != "" {
+		content = append(content,
+			r.Button(&r.ButtonProps{
+				Type:      "button",
+				ClassName: "btn btn-default",
+				OnClick:   triggerMoreReviews{v},
+			}, r.S("More reviews")),
+		)
+	}
+
+	if st.err != "" {
+		content = append(content,
+			r.Div(nil,
+				r.Hr(nil),
+				r.S("Error: "+st.err),
+			),
+		)
+	}
+
+	return r.Div(nil, content...)
+}
+
+func (v ReviewsDef) renderOrder(o library.ReviewOrder, label string) r.Element {
+	className := "btn btn-default btn-xs"
+	if v.State().order == o {
+		className += " active"
+	}
+	return r.Button(&r.ButtonProps{
+		Type:      "button",
+		ClassName: className,
+		OnClick:   triggerReviewOrder{v, o},
+	}, r.S(label))
+}
+
+func (v ReviewsDef) renderReview(rv *library.Review) r.Element {
+	content := []r.Element{
+		r.Hr(nil),
+		r.Div(nil,
+			r.B(nil, r.S(stars(rv.GetRating()))),
+			r.S(" by "+rv.GetUser()+" on "+reviewDate(rv)),
+		),
+	}
+	if rv.GetText() != "" {
+		content = append(content, r.P(nil, r.S(rv.GetText())))
+	}
+	helpful := strconv.Itoa(int(rv.GetHelpfulVotes())) + " found this helpful"
+	if rv.GetUser() == user && user != "" {
+		content = append(content,
+			r.Div(nil,
+				r.S(helpful+" "),
+				r.Button(&r.ButtonProps{
+					Type:      "button",
+					ClassName: "btn btn-default btn-xs",
+					OnClick:   triggerEditReview{v, rv},
+				}, r.S("Edit")),
+				r.S(" "),
+				r.Button(&r.ButtonProps{
+					Type:      "button",
+					ClassName: "btn btn-default btn-xs",
+					OnClick:   triggerDeleteReview{v, rv.GetId()},
+				}, r.S("Delete")),
+			),
+		)
+	} else {
+		content = append(content,
+			r.Div(nil,
+				r.S(helpful+" "),
+				r.Button(&r.ButtonProps{
+					Type:      "button",
+					ClassName: "btn btn-default btn-xs",
+					OnClick:   triggerMarkHelpful{v, rv.GetId()},
+				}, r.S("Helpful")),
+			),
+		)
+	}
+	return r.Div(nil, content...)
+}
+
+// stars returns the rating as stars, such as "★★★☆☆".
+func stars(rating int32) string {
+	return strings.Repeat("★", int(rating)) + strings.Repeat("☆", 5-int(rating))
+}
+
+// reviewDate returns when the review was last edited.
+func reviewDate(rv *library.Review) string {
+	return time.Unix(
+		rv.GetUpdated().GetSeconds(),
+		int64(rv.GetUpdated().GetNanos()),
+	).Format("02 Jan 2006")
+}
+
+type reviewsInputChange struct {
+	v     ReviewsDef
+	field string
+}
+type triggerSubmitReview struct{ v ReviewsDef }
+type triggerEditReview struct {
+	v  ReviewsDef
+	rv *library.Review
+}
+type triggerDeleteReview struct {
+	v  ReviewsDef
+	id string
+}
+type triggerMarkHelpful struct {
+	v  ReviewsDef
+	id string
+}
+type triggerReviewOrder struct {
+	v     ReviewsDef
+	order library.ReviewOrder
+}
+type triggerMoreReviews struct{ v ReviewsDef }
+
+func (c reviewsInputChange) OnChange(se *r.SyntheticEvent) {
+	var value string
+	switch target := se.Target().(type) {
+	case *dom.HTMLInputElement:
+		value = target.Value
+	case *dom.HTMLSelectElement:
+		value = target.Value
+	case *dom.HTMLTextAreaElement:
+		value = target.Value
+	}
+
+	newSt := c.v.State()
+	switch c.field {
+	case "rating":
+		newSt.ratingInput = value
+	case "text":
+		newSt.textInput = value
+	}
+
+	c.v.SetState(newSt)
+}
+
+func (t triggerSubmitReview) OnClick(se *r.SyntheticMouseEvent) {
+	// Wrapped in goroutine because SubmitReview is blocking
+	go func() {
+		st := t.v.State()
+		rating, _ := strconv.Atoi(st.ratingInput)
+
+		// 1 second timeout
+		ctx, cancel := context.WithTimeout(userContext(context.Background()), time.Second)
+		defer cancel()
+
+		var err error
+		if st.editing != "" {
+			_, err = t.v.Props().Client.UpdateReview(ctx, &library.UpdateReviewRequest{
+				ReviewId: st.editing,
+				Rating:   int32(rating),
+				Text:     st.textInput,
+			})
+		} else {
+			_, err = t.v.Props().Client.SubmitReview(ctx, &library.SubmitReviewRequest{
+				Isbn:   t.v.Props().Isbn,
+				Rating: int32(rating),
+				Text:   st.textInput,
+			})
+		}
+		if err != nil {
+			t.v.setErr(err)
+			return
+		}
+		newSt := t.v.State()
+		newSt.editing = ""
+		newSt.textInput = ""
+		newSt.ratingInput = "5"
+		t.v.SetState(newSt)
+		t.v.reload(t.v.Props())
+	}()
+
+	se.PreventDefault()
+}
+
+func (t triggerEditReview) OnClick(se *r.SyntheticMouseEvent) {
+	newSt := t.v.State()
+	newSt.editing = t.rv.GetId()
+	newSt.ratingInput = strconv.Itoa(int(t.rv.GetRating()))
+	newSt.textInput = t.rv.GetText()
+	t.v.SetState(newSt)
+
+	se.PreventDefault()
+}
+
+func (t triggerDeleteReview) OnClick(se *r.SyntheticMouseEvent) {
+	// Wrapped in goroutine because DeleteReview is blocking
+	go func() {
+		// 1 second timeout
+		ctx, cancel := context.WithTimeout(userContext(context.Background()), time.Second)
+		defer cancel()
+
+		_, err := t.v.Props().Client.DeleteReview(ctx, &library.DeleteReviewRequest{
+			ReviewId: t.id,
+		})
+		if err != nil {
+			t.v.setErr(err)
+			return
+		}
+		if t.v.State().editing == t.id {
+			newSt := t.v.State()
+			newSt.editing = ""
+			t.v.SetState(newSt)
+		}
+		t.v.reload(t.v.Props())
+	}()
+
+	se.PreventDefault()
+}
+
+func (t triggerMarkHelpful) OnClick(se *r.SyntheticMouseEvent) {
+	// Wrapped in goroutine because MarkReviewHelpful is blocking
+	go func() {
+		// 1 second timeout
+		ctx, cancel := context.WithTimeout(userContext(context.Background()), time.Second)
+		defer cancel()
+
+		rv, err := t.v.Props().Client.MarkReviewHelpful(ctx, &library.MarkReviewHelpfulRequest{
+			ReviewId: t.id,
+		})
+		if err != nil {
+			t.v.setErr(err)
+			return
+		}
+		newSt := t.v.State()
+		for i, old := range newSt.reviews.Range() {
+			if old.GetId() == rv.GetId() {
+				newSt.reviews = newSt.reviews.Set(i, rv)
+			}
+		}
+		t.v.SetState(newSt)
+	}()
+
+	se.PreventDefault()
+}
+
+func (t triggerReviewOrder) OnClick(se *r.SyntheticMouseEvent) {
+	newSt := t.v.State()
+	newSt.order = t.order
+	t.v.SetState(newSt)
+	// Wrapped in goroutine because ListReviews is blocking
+	go t.v.reload(t.v.Props())
+
+	se.PreventDefault()
+}
+
+func (t triggerMoreReviews) OnClick(se *r.SyntheticMouseEvent) {
+	// Wrapped in goroutine because ListReviews is blocking
+	go t.v.load(t.v.Props(), t.v.State().nextPageToken)
+
+	se.PreventDefault()
+}
+
+// reload shows the first page of the reviews.
+func (v ReviewsDef) reload(p ReviewsProps) {
+	newSt := v.State()
+	newSt.reviews = newReviews()
+	newSt.nextPageToken = ""
+	v.SetState(newSt)
+	v.load(p, "")
+}
+
+// load adds the page of reviews listed by token to those shown.
+func (v ReviewsDef) load(p ReviewsProps, token string) {
+	// 1 second timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := p.Client.ListReviews(ctx, &library.ListReviewsRequest{
+		Isbn:      p.Isbn,
+		Order:     v.State().order,
+		PageToken: token,
+	})
+	if err != nil {
+		v.setErr(err)
+		return
+	}
+	newSt := v.State()
+	newSt.reviews = newSt.reviews.Append(resp.GetReviews()...)
+	newSt.nextPageToken = resp.GetNextPageToken()
+	newSt.err = ""
+	v.SetState(newSt)
+}
+
+func (v ReviewsDef) setErr(err error) {
+	newSt := v.State()
+	newSt.err = status.FromError(err).Message
+	v.SetState(newSt)
+}
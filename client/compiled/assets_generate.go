@@ -10,7 +10,7 @@ import (
 )
 
 func main() {
-	var fs http.FileSystem = http.Dir("../html/")
+	var fs http.FileSystem = http.Dir("../build/")
 
 	err := vfsgen.Generate(fs, vfsgen.Options{
 		PackageName:  "compiled",
@@ -0,0 +1,51 @@
+//+build generate
+
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// main writes a brotli compressed copy of every file in ../build/,
+// alongside the zopfli compressed ones.
+func main() {
+	err := filepath.Walk("../build/", func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(name, ".gz") || strings.HasSuffix(name, ".br") {
+			return nil
+		}
+		content, err := ioutil.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		out, err := os.Create(name + ".br")
+		if err != nil {
+			return err
+		}
+		w := brotli.NewWriterOptions(out, brotli.WriterOptions{
+			Quality: brotli.BestCompression,
+			LGWin:   24,
+		})
+		_, err = w.Write(content)
+		if err == nil {
+			err = w.Close()
+		}
+		// out is closed once, even if compressing failed
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		return err
+	})
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -0,0 +1,116 @@
+//+build generate
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// unhashed lists the files that must keep their names,
+// as they are requested by name by browsers.
+var unhashed = map[string]bool{
+	"index.html":  true,
+	"favicon.ico": true,
+}
+
+var (
+	sourceMapURL = regexp.MustCompile(`(//# sourceMappingURL=)(\S+)`)
+	htmlRefs     = regexp.MustCompile(`((?:src|href)=")(/?)([^"/]+)(")`)
+)
+
+// hashedName inserts a prefix of the hex encoded SHA256 sum of
+// content into name, before the first extension.
+func hashedName(name string, content []byte) string {
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:6])
+	if i := strings.Index(name, "."); i > 0 {
+		return name[:i] + "." + hash + name[i:]
+	}
+	return name + "." + hash
+}
+
+// main copies the contents of ../html/ to ../build/, adding content hashes
+// to the names of all assets except those listed in unhashed,
+// and rewriting any references to the renamed files.
+func main() {
+	files, err := ioutil.ReadDir("../html/")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	err = os.MkdirAll("../build/", 0755)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	contents := map[string][]byte{}
+	var names []string
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join("../html/", f.Name()))
+		if err != nil {
+			log.Fatalln(err)
+		}
+		contents[f.Name()] = content
+		names = append(names, f.Name())
+	}
+
+	// Source maps are hashed first, as they are referenced
+	// from the scripts, and the HTML files are rewritten last,
+	// as they reference everything else.
+	rank := func(name string) int {
+		switch {
+		case strings.HasSuffix(name, ".map"):
+			return 0
+		case unhashed[name]:
+			return 2
+		}
+		return 1
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return rank(names[i]) < rank(names[j])
+	})
+
+	renames := map[string]string{}
+	for _, name := range names {
+		content := contents[name]
+		switch filepath.Ext(name) {
+		case ".js", ".css":
+			content = sourceMapURL.ReplaceAllFunc(content, func(ref []byte) []byte {
+				m := sourceMapURL.FindSubmatch(ref)
+				if newName, ok := renames[string(m[2])]; ok {
+					return []byte(string(m[1]) + newName)
+				}
+				return ref
+			})
+		case ".html":
+			content = htmlRefs.ReplaceAllFunc(content, func(ref []byte) []byte {
+				m := htmlRefs.FindSubmatch(ref)
+				if newName, ok := renames[string(m[3])]; ok {
+					return []byte(string(m[1]) + string(m[2]) + newName + string(m[4]))
+				}
+				return ref
+			})
+		}
+
+		newName := name
+		if !unhashed[name] {
+			newName = hashedName(name, content)
+			renames[name] = newName
+		}
+
+		err = ioutil.WriteFile(filepath.Join("../build/", newName), content, 0644)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
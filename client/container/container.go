@@ -1,6 +1,7 @@
 package container
 
 import (
+	"strconv"
 	"strings"
 
 	"honnef.co/go/js/dom"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/johanbrandhorst/grpcweb-example/client/book"
 	"github.com/johanbrandhorst/grpcweb-example/client/proto/library"
+	"github.com/johanbrandhorst/grpcweb-example/client/router"
 )
 
 //go:generate reactGen
@@ -22,9 +24,15 @@ type ContainerDef struct {
 // ContainerState contains the state for the Container
 type ContainerState struct {
 	client   library.BookServiceClient
+	users    library.UserServiceClient
 	examples *exampleSource
+	path     string
 }
 
+// stopListening stops the Container from listening
+// for changes to the location.
+var stopListening func()
+
 // Container creates the Container
 func Container() *ContainerElem {
 	return buildContainerElem()
@@ -35,23 +43,29 @@ func (p ContainerDef) GetInitialState() ContainerState {
 	return ContainerState{
 		client:   nil,
 		examples: newExampleSource(),
+		path:     router.Path(),
 	}
 }
 
 // ComponentWillMount is a React lifecycle method for the ContainerDef component.
-// It populates the sources from the source code on github.
+// It populates the sources from the source code served alongside the client.
 func (p ContainerDef) ComponentWillMount() {
 	newSt := p.State()
 	if !fetchStarted {
 		for i, e := range sources.Range() {
 			go func(i exampleKey, e *source) {
-				req := xhr.NewRequest("GET", "https://raw.githubusercontent.com/johanbrandhorst/grpcweb-example/master/client/"+e.file())
+				req := xhr.NewRequest("GET", "/source/"+e.file())
 				err := req.Send(nil)
-				if err != nil {
-					panic(err)
+				switch {
+				case err != nil:
+					e = e.setErr("failed to fetch source: " + err.Error())
+				case req.Status != 200:
+					e = e.setErr("failed to fetch source: " + req.StatusText)
+				default:
+					e = e.setSrc(req.ResponseText)
 				}
 
-				sources = sources.Set(i, e.setSrc(req.ResponseText))
+				sources = sources.Set(i, e)
 
 				newSt.examples = sources
 				p.SetState(newSt)
@@ -61,21 +75,55 @@ func (p ContainerDef) ComponentWillMount() {
 		fetchStarted = true
 	}
 
-	newSt.client = library.NewBookServiceClient(
-		strings.TrimSuffix(dom.GetWindow().Document().BaseURI(), "/"),
-	)
+	host := strings.TrimSuffix(dom.GetWindow().Document().BaseURI(), "/")
+	newSt.client = library.NewBookServiceClient(host)
+	newSt.users = library.NewUserServiceClient(host)
 
 	p.SetState(newSt)
 }
 
+// ComponentDidMount is a React lifecycle method for the ContainerDef component.
+// It starts listening for changes to the location, rendering
+// the page matching the new path.
+func (p ContainerDef) ComponentDidMount() {
+	stopListening = router.Listen(func(path string) {
+		newSt := p.State()
+		newSt.path = path
+		p.SetState(newSt)
+		dom.GetWindow().ScrollTo(0, 0)
+	})
+}
+
+// ComponentWillUnmount is a React lifecycle method for the ContainerDef
+// component. It stops listening for changes to the location.
+func (p ContainerDef) ComponentWillUnmount() {
+	if stopListening != nil {
+		stopListening()
+		stopListening = nil
+	}
+}
+
 func (p ContainerDef) renderExample(key exampleKey, title, msg, elem r.Element) r.Element {
-	var goSrc string
+	var goSrc, srcErr string
 	src, _ := p.State().examples.Get(key)
 	if src != nil {
 		goSrc = src.src()
+		srcErr = src.err()
 	}
 
-	code := r.NewDangerousInnerHTML(highlightjs.Highlight("go", goSrc, true).Value)
+	var code r.Element
+	if srcErr != "" {
+		code = r.Div(&r.DivProps{ClassName: "alert alert-danger"},
+			r.S(srcErr),
+		)
+	} else {
+		code = r.Pre(&r.PreProps{
+			Style: &r.CSS{
+				MaxHeight: "400px",
+			},
+			DangerouslySetInnerHTML: r.NewDangerousInnerHTML(highlightjs.Highlight("go", goSrc, true).Value),
+		})
+	}
 
 	return r.Div(nil,
 		r.H3(nil, title),
@@ -84,12 +132,7 @@ func (p ContainerDef) renderExample(key exampleKey, title, msg, elem r.Element)
 			r.Div(&r.DivProps{ClassName: "col-md-8"},
 				r.Div(&r.DivProps{ClassName: "panel panel-default"},
 					r.Div(&r.DivProps{ClassName: "panel-body"},
-						r.Pre(&r.PreProps{
-							Style: &r.CSS{
-								MaxHeight: "400px",
-							},
-							DangerouslySetInnerHTML: code,
-						}),
+						code,
 					),
 				),
 			),
@@ -102,17 +145,84 @@ func (p ContainerDef) renderExample(key exampleKey, title, msg, elem r.Element)
 
 // Render renders the Container
 func (p ContainerDef) Render() r.Element {
+	path := p.State().path
+
+	var navItems []r.RendersLi
+	for _, key := range exampleKeys {
+		var className string
+		if path == examplePaths[key] {
+			className = "active"
+		}
+		navItems = append(navItems,
+			r.Li(&r.LiProps{ClassName: className},
+				router.Link(examplePaths[key], nil, r.S(exampleNames[key])),
+			),
+		)
+	}
+
 	navbar := r.Nav(&r.NavProps{ClassName: "navbar navbar-inverse navbar-fixed-top"},
 		r.Div(&r.DivProps{ClassName: "container"},
 			r.Div(&r.DivProps{ClassName: "navbar-header"},
-				r.A(&r.AProps{ClassName: "navbar-brand"},
+				router.Link("/", &r.AProps{ClassName: "navbar-brand"},
 					r.S("GopherJS gRPC-Web Showcase"),
 				),
 			),
+			r.Ul(&r.UlProps{ClassName: "nav navbar-nav"},
+				navItems...,
+			),
+			book.Login(book.LoginProps{Client: p.State().users}),
+		),
+	)
+
+	var content []r.Element
+	switch {
+	case path == "/":
+		content = p.renderIntroduction()
+		for _, key := range exampleKeys {
+			content = append(content, p.renderExampleByKey(key))
+		}
+	case strings.HasPrefix(path, "/books/"):
+		isbn, err := strconv.ParseInt(strings.TrimPrefix(path, "/books/"), 10, 64)
+		if err != nil {
+			content = renderNotFound()
+			break
+		}
+		content = []r.Element{
+			r.H3(nil, r.S("Book details")),
+			plainPanel(
+				book.BookDetail(book.BookDetailProps{Client: p.State().client, Isbn: isbn}),
+			),
+		}
+	case strings.HasPrefix(path, "/collections/"):
+		content = []r.Element{
+			r.H3(nil, r.S("Collection")),
+			plainPanel(
+				book.CollectionDetail(book.CollectionDetailProps{
+					Client: p.State().client,
+					ID:     strings.TrimPrefix(path, "/collections/"),
+				}),
+			),
+		}
+	default:
+		content = renderNotFound()
+		for _, key := range exampleKeys {
+			if path == examplePaths[key] {
+				content = []r.Element{p.renderExampleByKey(key)}
+				break
+			}
+		}
+	}
+
+	return r.Div(nil,
+		navbar,
+		r.Div(&r.DivProps{ClassName: "container"},
+			content...,
 		),
 	)
+}
 
-	content := []r.Element{
+func (p ContainerDef) renderIntroduction() []r.Element {
+	return []r.Element{
 		r.H3(nil, r.S("GopherJS gRPC-Web Client Examples")),
 		r.P(nil,
 			r.S("This page shows a couple of examples of using the "),
@@ -169,14 +279,15 @@ func (p ContainerDef) Render() r.Element {
 			r.S("."),
 		),
 		r.P(nil,
-			r.S("Note the examples below show the Go source code from "),
-			r.Code(nil, r.S("master")),
-			r.S("."),
+			r.S("Note the examples below show the Go source code this page was built from."),
 		),
 	}
+}
 
-	content = append(content,
-		p.renderExample(
+func (p ContainerDef) renderExampleByKey(key exampleKey) r.Element {
+	switch key {
+	case exampleGetBook:
+		return p.renderExample(
 			exampleGetBook,
 			r.Span(nil,
 				r.S("Getting a book from the library by ISBN"),
@@ -186,8 +297,9 @@ func (p ContainerDef) Render() r.Element {
 					"Renders the returned book (or error)."),
 			),
 			book.GetBook(book.GetBookProps{Client: p.State().client}),
-		),
-		p.renderExample(
+		)
+	case exampleQueryBooks:
+		return p.renderExample(
 			exampleQueryBooks,
 			r.Span(nil,
 				r.S("Querying for books in the library by author"),
@@ -202,18 +314,34 @@ func (p ContainerDef) Render() r.Element {
 				r.S("from the server, allowing large responses to be delivered in chunks."),
 			),
 			book.QueryBooks(book.QueryBooksProps{Client: p.State().client}),
-		),
-		p.renderExample(
+		)
+	case exampleMakeCollection:
+		return p.renderExample(
 			exampleMakeCollection,
 			r.Span(nil,
 				r.S("Streaming a collection of books from the client"),
 			),
 			r.P(nil,
-				r.S("Sends a stream of ISBNs to the gRPC backend to be collected into a list."),
+				r.S("Sends a stream of ISBNs to the gRPC backend to be collected into a list, "+
+					"which is stored and can be shared with a link."),
 			),
 			book.MakeCollection(book.MakeCollectionProps{Client: p.State().client}),
-		),
-		p.renderExample(
+		)
+	case exampleHolds:
+		return p.renderExample(
+			exampleHolds,
+			r.Span(nil,
+				r.S("Watching holds with server streaming"),
+			),
+			r.P(nil,
+				r.S("Places holds on books, and keeps a stream open to the gRPC backend which "+
+					"sends the holds of the user as they change, so the page can tell the user "+
+					"when a copy is ready to pick up."),
+			),
+			book.Holds(book.HoldsProps{Client: p.State().client}),
+		)
+	case exampleBookChat:
+		return p.renderExample(
 			exampleBookChat,
 			r.Span(nil,
 				r.S("Bi-directional streaming chat"),
@@ -223,15 +351,20 @@ func (p ContainerDef) Render() r.Element {
 				r.S("Try connecting in another browser window and see the chat in action."),
 			),
 			book.BookChat(book.BookChatProps{Client: p.State().client}),
-		),
-	)
+		)
+	}
+	return nil
+}
 
-	return r.Div(nil,
-		navbar,
-		r.Div(&r.DivProps{ClassName: "container"},
-			content...,
+func renderNotFound() []r.Element {
+	return []r.Element{
+		r.H3(nil, r.S("Page not found")),
+		r.P(nil,
+			r.S("There is nothing here, try "),
+			router.Link("/", nil, r.S("the start page")),
+			r.S("."),
 		),
-	)
+	}
 }
 
 func plainPanel(children ...r.Element) r.Element {
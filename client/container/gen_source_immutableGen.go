@@ -192,11 +192,13 @@ func (s *exampleSource) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 // 	struct {
 // 		file	string
 // 		src	string
+// 		err	string
 // 	}
 //
 type source struct {
 	_file string
 	_src  string
+	_err  string
 
 	mutable bool
 	__tmpl  _Imm_source
@@ -298,3 +300,18 @@ func (s *source) setSrc(n string) *source {
 	res._src = n
 	return &res
 }
+func (s *source) err() string {
+	return s._err
+}
+
+// setErr is the setter for Err()
+func (s *source) setErr(n string) *source {
+	if s.mutable {
+		s._err = n
+		return s
+	}
+
+	res := *s
+	res._err = n
+	return &res
+}
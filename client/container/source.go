@@ -8,14 +8,43 @@ const (
 	exampleGetBook exampleKey = iota
 	exampleQueryBooks
 	exampleMakeCollection
+	exampleHolds
 	exampleBookChat
 )
 
+// exampleKeys lists the examples in the order they are shown.
+var exampleKeys = []exampleKey{
+	exampleGetBook,
+	exampleQueryBooks,
+	exampleMakeCollection,
+	exampleHolds,
+	exampleBookChat,
+}
+
+// examplePaths maps each example to the path of its own page.
+var examplePaths = map[exampleKey]string{
+	exampleGetBook:        "/get-book",
+	exampleQueryBooks:     "/query-books",
+	exampleMakeCollection: "/make-collection",
+	exampleHolds:          "/holds",
+	exampleBookChat:       "/book-chat",
+}
+
+// exampleNames maps each example to its name in the navigation bar.
+var exampleNames = map[exampleKey]string{
+	exampleGetBook:        "Get book",
+	exampleQueryBooks:     "Query books",
+	exampleMakeCollection: "Make collection",
+	exampleHolds:          "Holds",
+	exampleBookChat:       "Book chat",
+}
+
 // _Imm_source generates a *source immutable type
 // for storing information about a source file
 type _Imm_source struct {
 	file string
 	src  string
+	err  string
 }
 
 // _Imm_exampleSource generates an immutable map type
@@ -28,6 +57,7 @@ var sources = newExampleSource(func(es *exampleSource) {
 	es.Set(exampleGetBook, new(source).setFile("book/getbook.go"))
 	es.Set(exampleQueryBooks, new(source).setFile("book/querybooks.go"))
 	es.Set(exampleMakeCollection, new(source).setFile("book/makecollection.go"))
+	es.Set(exampleHolds, new(source).setFile("book/holds.go"))
 	es.Set(exampleBookChat, new(source).setFile("book/bookchat.go"))
 })
 
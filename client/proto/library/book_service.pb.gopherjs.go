@@ -12,12 +12,79 @@
 
 	It has these top-level messages:
 		Publisher
+		Author
+		Contributor
+		Series
 		Book
+		Branch
+		Availability
+		BranchAvailability
 		GetBookRequest
 		QueryBooksRequest
+		GetAuthorRequest
+		ListAuthorsRequest
+		ListAuthorsResponse
+		GetPublisherRequest
 		Collection
+		CollectionError
+		GetCollectionRequest
+		ListCollectionsRequest
+		ListCollectionsResponse
+		AddToCollectionRequest
+		RemoveFromCollectionRequest
+		DeleteCollectionRequest
+		Copy
+		Loan
+		ListCopiesRequest
+		ListCopiesResponse
+		AddCopyRequest
+		CheckOutRequest
+		ReturnRequest
+		RenewRequest
+		ListLoansRequest
+		ListLoansResponse
+		Hold
+		PlaceHoldRequest
+		CancelHoldRequest
+		ListHoldsRequest
+		ListHoldsResponse
+		WatchMyHoldsRequest
+		Job
+		ListBranchesRequest
+		ListBranchesResponse
+		ListJobsRequest
+		ListJobsResponse
+		Review
+		SubmitReviewRequest
+		UpdateReviewRequest
+		DeleteReviewRequest
+		MarkReviewHelpfulRequest
+		ListReviewsRequest
+		RecommendBooksRequest
+		Recommendation
+		RecommendBooksResponse
+		ReadingEntry
+		UpdateReadingRequest
+		RemoveReadingRequest
+		ListReadingRequest
+		ListReadingResponse
+		ListReviewsResponse
 		BookMessage
 		BookResponse
+		ImportOptions
+		ImportBooksRequest
+		ImportError
+		ImportBooksResponse
+		ExportBooksRequest
+		ExportBooksResponse
+		WatchBooksRequest
+		BookEvent
+		User
+		RegisterRequest
+		LoginRequest
+		LoginResponse
+		GetUserRequest
+		UpdateProfileRequest
 */
 package library
 
@@ -62,10 +129,264 @@ func (x BookType) String() string {
 	return BookType_name[int(x)]
 }
 
+// ContributorRole describes what a contributor
+// has contributed to a book.
+type ContributorRole int
+
+const (
+	// Author is a writer of the book.
+	ContributorRole_AUTHOR ContributorRole = 0
+	// Editor is an editor of the book.
+	ContributorRole_EDITOR ContributorRole = 1
+	// Translator translated the book.
+	ContributorRole_TRANSLATOR ContributorRole = 2
+	// Illustrator illustrated the book.
+	ContributorRole_ILLUSTRATOR ContributorRole = 3
+	// Narrator reads an audiobook.
+	ContributorRole_NARRATOR ContributorRole = 4
+	// Foreword is the writer of the foreword.
+	ContributorRole_FOREWORD ContributorRole = 5
+)
+
+var ContributorRole_name = map[int]string{
+	0: "AUTHOR",
+	1: "EDITOR",
+	2: "TRANSLATOR",
+	3: "ILLUSTRATOR",
+	4: "NARRATOR",
+	5: "FOREWORD",
+}
+var ContributorRole_value = map[string]int{
+	"AUTHOR":      0,
+	"EDITOR":      1,
+	"TRANSLATOR":  2,
+	"ILLUSTRATOR": 3,
+	"NARRATOR":    4,
+	"FOREWORD":    5,
+}
+
+func (x ContributorRole) String() string {
+	return ContributorRole_name[int(x)]
+}
+
+// Visibility describes who can see a Collection.
+type Visibility int
+
+const (
+	// Private collections can only be seen by their owner.
+	Visibility_PRIVATE Visibility = 0
+	// Unlisted collections can be seen by anyone with their Id,
+	// but are only listed to their owner.
+	Visibility_UNLISTED Visibility = 1
+	// Public collections can be seen and are listed to anyone.
+	Visibility_PUBLIC Visibility = 2
+)
+
+var Visibility_name = map[int]string{
+	0: "PRIVATE",
+	1: "UNLISTED",
+	2: "PUBLIC",
+}
+var Visibility_value = map[string]int{
+	"PRIVATE":  0,
+	"UNLISTED": 1,
+	"PUBLIC":   2,
+}
+
+func (x Visibility) String() string {
+	return Visibility_name[int(x)]
+}
+
+// ReviewOrder is the order in which reviews are listed.
+type ReviewOrder int
+
+const (
+	// NEWEST lists the reviews created last first.
+	ReviewOrder_NEWEST ReviewOrder = 0
+	// MOST_HELPFUL lists the reviews with the most
+	// helpful votes first, and then the newest first.
+	ReviewOrder_MOST_HELPFUL ReviewOrder = 1
+)
+
+var ReviewOrder_name = map[int]string{
+	0: "NEWEST",
+	1: "MOST_HELPFUL",
+}
+var ReviewOrder_value = map[string]int{
+	"NEWEST":       0,
+	"MOST_HELPFUL": 1,
+}
+
+func (x ReviewOrder) String() string {
+	return ReviewOrder_name[int(x)]
+}
+
+// ReadingShelf is the reading list a Book is on.
+type ReadingShelf int
+
+const (
+	// WantToRead books are books the user
+	// has not started reading yet.
+	ReadingShelf_WANT_TO_READ ReadingShelf = 0
+	// Reading books are being read.
+	ReadingShelf_READING ReadingShelf = 1
+	// Finished books have been read.
+	ReadingShelf_FINISHED ReadingShelf = 2
+)
+
+var ReadingShelf_name = map[int]string{
+	0: "WANT_TO_READ",
+	1: "READING",
+	2: "FINISHED",
+}
+var ReadingShelf_value = map[string]int{
+	"WANT_TO_READ": 0,
+	"READING":      1,
+	"FINISHED":     2,
+}
+
+func (x ReadingShelf) String() string {
+	return ReadingShelf_name[int(x)]
+}
+
+// Reason is why a book was not added.
+type CollectionError_Reason int
+
+const (
+	// ReasonUnspecified is never sent, so that
+	// errors without a reason are told apart.
+	CollectionError_REASON_UNSPECIFIED CollectionError_Reason = 0
+	// NotFound is a book with an ISBN
+	// that is not in the library.
+	CollectionError_NOT_FOUND CollectionError_Reason = 1
+	// Duplicate is a book that was
+	// already sent earlier in the stream.
+	CollectionError_DUPLICATE CollectionError_Reason = 2
+)
+
+var CollectionError_Reason_name = map[int]string{
+	0: "REASON_UNSPECIFIED",
+	1: "NOT_FOUND",
+	2: "DUPLICATE",
+}
+var CollectionError_Reason_value = map[string]int{
+	"REASON_UNSPECIFIED": 0,
+	"NOT_FOUND":          1,
+	"DUPLICATE":          2,
+}
+
+func (x CollectionError_Reason) String() string {
+	return CollectionError_Reason_name[int(x)]
+}
+
+// State is the state of a hold.
+type Hold_State int
+
+const (
+	// WAITING holds are waiting for a copy.
+	Hold_WAITING Hold_State = 0
+	// READY holds have a copy kept for the
+	// user to pick up until ReadyUntil.
+	Hold_READY Hold_State = 1
+	// FULFILLED holds ended with the user
+	// checking out the copy.
+	Hold_FULFILLED Hold_State = 2
+	// CANCELLED holds were cancelled by the user.
+	Hold_CANCELLED Hold_State = 3
+	// EXPIRED holds ended with the copy
+	// not picked up in time.
+	Hold_EXPIRED Hold_State = 4
+)
+
+var Hold_State_name = map[int]string{
+	0: "WAITING",
+	1: "READY",
+	2: "FULFILLED",
+	3: "CANCELLED",
+	4: "EXPIRED",
+}
+var Hold_State_value = map[string]int{
+	"WAITING":   0,
+	"READY":     1,
+	"FULFILLED": 2,
+	"CANCELLED": 3,
+	"EXPIRED":   4,
+}
+
+func (x Hold_State) String() string {
+	return Hold_State_name[int(x)]
+}
+
+// Reason is why a book is recommended.
+type Recommendation_Reason int
+
+const (
+	// SIMILAR_READERS is a book which readers of the
+	// book collected, borrowed or rated highly.
+	Recommendation_SIMILAR_READERS Recommendation_Reason = 0
+	// SAME_AUTHOR is a book by an author of the book.
+	Recommendation_SAME_AUTHOR Recommendation_Reason = 1
+	// SAME_PUBLISHER is a book of the publisher of the book.
+	Recommendation_SAME_PUBLISHER Recommendation_Reason = 2
+)
+
+var Recommendation_Reason_name = map[int]string{
+	0: "SIMILAR_READERS",
+	1: "SAME_AUTHOR",
+	2: "SAME_PUBLISHER",
+}
+var Recommendation_Reason_value = map[string]int{
+	"SIMILAR_READERS": 0,
+	"SAME_AUTHOR":     1,
+	"SAME_PUBLISHER":  2,
+}
+
+func (x Recommendation_Reason) String() string {
+	return Recommendation_Reason_name[int(x)]
+}
+
+// Type is the type of a BookEvent.
+type BookEvent_Type int
+
+const (
+	// Existing is a book that was in the library
+	// when the watch started.
+	BookEvent_EXISTING BookEvent_Type = 0
+	// Created is a book that was added to the library,
+	// or that now matches the author prefix watched.
+	BookEvent_CREATED BookEvent_Type = 1
+	// Updated is a book that was replaced.
+	BookEvent_UPDATED BookEvent_Type = 2
+	// Deleted is a book that no longer matches
+	// the author prefix watched.
+	BookEvent_DELETED BookEvent_Type = 3
+)
+
+var BookEvent_Type_name = map[int]string{
+	0: "EXISTING",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "DELETED",
+}
+var BookEvent_Type_value = map[string]int{
+	"EXISTING": 0,
+	"CREATED":  1,
+	"UPDATED":  2,
+	"DELETED":  3,
+}
+
+func (x BookEvent_Type) String() string {
+	return BookEvent_Type_name[int(x)]
+}
+
 // Publisher describes a Book Publisher.
 type Publisher struct {
 	// Name is the name of the Publisher.
 	Name string
+	// Id identifies the Publisher. Books referencing a publisher by
+	// Id get the name of the publisher, and books naming a publisher
+	// without an Id get the Id of the publisher with that name.
+	Id string
 }
 
 // GetName gets the Name of the Publisher.
@@ -76,6 +397,14 @@ func (m *Publisher) GetName() (x string) {
 	return m.Name
 }
 
+// GetId gets the Id of the Publisher.
+func (m *Publisher) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
 // MarshalToWriter marshals Publisher to the provided writer.
 func (m *Publisher) MarshalToWriter(writer jspb.Writer) {
 	if m == nil {
@@ -86,6 +415,10 @@ func (m *Publisher) MarshalToWriter(writer jspb.Writer) {
 		writer.WriteString(1, m.Name)
 	}
 
+	if len(m.Id) > 0 {
+		writer.WriteString(2, m.Id)
+	}
+
 	return
 }
 
@@ -106,6 +439,8 @@ func (m *Publisher) UnmarshalFromReader(reader jspb.Reader) *Publisher {
 		switch reader.GetFieldNumber() {
 		case 1:
 			m.Name = reader.ReadString()
+		case 2:
+			m.Id = reader.ReadString()
 		default:
 			reader.SkipField()
 		}
@@ -127,192 +462,166 @@ func (m *Publisher) Unmarshal(rawBytes []byte) (*Publisher, error) {
 	return m, nil
 }
 
-// Book represents a book in the library.
-type Book struct {
-	// Isbn is the ISBN number of the book.
-	Isbn int64
-	// Title is the title of the book.
-	Title string
-	// Author is the author of the book.
-	Author string
-	// BookType is the type of the book.
-	BookType BookType
-	// PublishingMethod is the publishing method
-	// used for this Book.
-	//
-	// Types that are valid to be assigned to PublishingMethod:
-	//	*Book_SelfPublished
-	//	*Book_Publisher
-	PublishingMethod isBook_PublishingMethod
-	// PublicationDate is the time of publication of the book.
-	PublicationDate *google_protobuf.Timestamp
-}
-
-// isBook_PublishingMethod is used to distinguish types assignable to PublishingMethod
-type isBook_PublishingMethod interface{ isBook_PublishingMethod() }
-
-// Book_SelfPublished is assignable to PublishingMethod
-type Book_SelfPublished struct {
-	// SelfPublished means this book was
-	// self published.
-	SelfPublished bool
-}
-
-// Book_Publisher is assignable to PublishingMethod
-type Book_Publisher struct {
-	// Publisher means this book was published
-	// through a Publisher.
-	Publisher *Publisher
+// Author describes a person who contributes to books.
+type Author struct {
+	// Id identifies the Author.
+	Id string
+	// Name is the name of the Author.
+	Name string
 }
 
-func (*Book_SelfPublished) isBook_PublishingMethod() {}
-func (*Book_Publisher) isBook_PublishingMethod()     {}
-
-// GetPublishingMethod gets the PublishingMethod of the Book.
-func (m *Book) GetPublishingMethod() (x isBook_PublishingMethod) {
+// GetId gets the Id of the Author.
+func (m *Author) GetId() (x string) {
 	if m == nil {
 		return x
 	}
-	return m.PublishingMethod
+	return m.Id
 }
 
-// GetIsbn gets the Isbn of the Book.
-func (m *Book) GetIsbn() (x int64) {
+// GetName gets the Name of the Author.
+func (m *Author) GetName() (x string) {
 	if m == nil {
 		return x
 	}
-	return m.Isbn
+	return m.Name
 }
 
-// GetTitle gets the Title of the Book.
-func (m *Book) GetTitle() (x string) {
+// MarshalToWriter marshals Author to the provided writer.
+func (m *Author) MarshalToWriter(writer jspb.Writer) {
 	if m == nil {
-		return x
+		return
 	}
-	return m.Title
-}
 
-// GetAuthor gets the Author of the Book.
-func (m *Book) GetAuthor() (x string) {
-	if m == nil {
-		return x
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
 	}
-	return m.Author
-}
 
-// GetBookType gets the BookType of the Book.
-func (m *Book) GetBookType() (x BookType) {
-	if m == nil {
-		return x
+	if len(m.Name) > 0 {
+		writer.WriteString(2, m.Name)
 	}
-	return m.BookType
+
+	return
 }
 
-// GetSelfPublished gets the SelfPublished of the Book.
-func (m *Book) GetSelfPublished() (x bool) {
-	if v, ok := m.GetPublishingMethod().(*Book_SelfPublished); ok {
-		return v.SelfPublished
+// Marshal marshals Author to a slice of bytes.
+func (m *Author) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Author from the provided reader.
+func (m *Author) UnmarshalFromReader(reader jspb.Reader) *Author {
+	for reader.Next() {
+		if m == nil {
+			m = &Author{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		case 2:
+			m.Name = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
 	}
-	return x
+
+	return m
 }
 
-// GetPublisher gets the Publisher of the Book.
-func (m *Book) GetPublisher() (x *Publisher) {
-	if v, ok := m.GetPublishingMethod().(*Book_Publisher); ok {
-		return v.Publisher
+// Unmarshal unmarshals a Author from a slice of bytes.
+func (m *Author) Unmarshal(rawBytes []byte) (*Author, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
 	}
-	return x
+
+	return m, nil
 }
 
-// GetPublicationDate gets the PublicationDate of the Book.
-func (m *Book) GetPublicationDate() (x *google_protobuf.Timestamp) {
+// Contributor is a person who has contributed to a book.
+type Contributor struct {
+	// Name is the name of the contributor.
+	Name string
+	// Role is what the contributor has contributed.
+	Role ContributorRole
+	// AuthorId is the Id of the Author who is the contributor. Contributors
+	// referencing an author by AuthorId get the name of the author, and
+	// contributors without an AuthorId get the Id of the author with
+	// their name, who is added to the library if there is none.
+	AuthorId string
+}
+
+// GetName gets the Name of the Contributor.
+func (m *Contributor) GetName() (x string) {
 	if m == nil {
 		return x
 	}
-	return m.PublicationDate
+	return m.Name
 }
 
-// MarshalToWriter marshals Book to the provided writer.
-func (m *Book) MarshalToWriter(writer jspb.Writer) {
+// GetRole gets the Role of the Contributor.
+func (m *Contributor) GetRole() (x ContributorRole) {
 	if m == nil {
-		return
-	}
-
-	switch t := m.PublishingMethod.(type) {
-	case *Book_SelfPublished:
-		if t.SelfPublished {
-			writer.WriteBool(5, t.SelfPublished)
-		}
-	case *Book_Publisher:
-		if t.Publisher != nil {
-			writer.WriteMessage(6, func() {
-				t.Publisher.MarshalToWriter(writer)
-			})
-		}
+		return x
 	}
+	return m.Role
+}
 
-	if m.Isbn != 0 {
-		writer.WriteInt64(1, m.Isbn)
+// GetAuthorId gets the AuthorId of the Contributor.
+func (m *Contributor) GetAuthorId() (x string) {
+	if m == nil {
+		return x
 	}
+	return m.AuthorId
+}
 
-	if len(m.Title) > 0 {
-		writer.WriteString(2, m.Title)
+// MarshalToWriter marshals Contributor to the provided writer.
+func (m *Contributor) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
 	}
 
-	if len(m.Author) > 0 {
-		writer.WriteString(3, m.Author)
+	if len(m.Name) > 0 {
+		writer.WriteString(1, m.Name)
 	}
 
-	if int(m.BookType) != 0 {
-		writer.WriteEnum(4, int(m.BookType))
+	if int(m.Role) != 0 {
+		writer.WriteEnum(2, int(m.Role))
 	}
 
-	if m.PublicationDate != nil {
-		writer.WriteMessage(7, func() {
-			m.PublicationDate.MarshalToWriter(writer)
-		})
+	if len(m.AuthorId) > 0 {
+		writer.WriteString(3, m.AuthorId)
 	}
 
 	return
 }
 
-// Marshal marshals Book to a slice of bytes.
-func (m *Book) Marshal() []byte {
+// Marshal marshals Contributor to a slice of bytes.
+func (m *Contributor) Marshal() []byte {
 	writer := jspb.NewWriter()
 	m.MarshalToWriter(writer)
 	return writer.GetResult()
 }
 
-// UnmarshalFromReader unmarshals a Book from the provided reader.
-func (m *Book) UnmarshalFromReader(reader jspb.Reader) *Book {
+// UnmarshalFromReader unmarshals a Contributor from the provided reader.
+func (m *Contributor) UnmarshalFromReader(reader jspb.Reader) *Contributor {
 	for reader.Next() {
 		if m == nil {
-			m = &Book{}
+			m = &Contributor{}
 		}
 
 		switch reader.GetFieldNumber() {
 		case 1:
-			m.Isbn = reader.ReadInt64()
+			m.Name = reader.ReadString()
 		case 2:
-			m.Title = reader.ReadString()
+			m.Role = ContributorRole(reader.ReadEnum())
 		case 3:
-			m.Author = reader.ReadString()
-		case 4:
-			m.BookType = BookType(reader.ReadEnum())
-		case 5:
-			m.PublishingMethod = &Book_SelfPublished{
-				SelfPublished: reader.ReadBool(),
-			}
-		case 6:
-			reader.ReadMessage(func() {
-				m.PublishingMethod = &Book_Publisher{
-					Publisher: new(Publisher).UnmarshalFromReader(reader),
-				}
-			})
-		case 7:
-			reader.ReadMessage(func() {
-				m.PublicationDate = m.PublicationDate.UnmarshalFromReader(reader)
-			})
+			m.AuthorId = reader.ReadString()
 		default:
 			reader.SkipField()
 		}
@@ -321,8 +630,8 @@ func (m *Book) UnmarshalFromReader(reader jspb.Reader) *Book {
 	return m
 }
 
-// Unmarshal unmarshals a Book from a slice of bytes.
-func (m *Book) Unmarshal(rawBytes []byte) (*Book, error) {
+// Unmarshal unmarshals a Contributor from a slice of bytes.
+func (m *Contributor) Unmarshal(rawBytes []byte) (*Contributor, error) {
 	reader := jspb.NewReader(rawBytes)
 
 	m = m.UnmarshalFromReader(reader)
@@ -334,51 +643,67 @@ func (m *Book) Unmarshal(rawBytes []byte) (*Book, error) {
 	return m, nil
 }
 
-// GetBookRequest is the input to the GetBook method.
-type GetBookRequest struct {
-	// Isbn is the ISBN with which
-	// to match against the ISBN of a book in the library.
-	Isbn int64
+// Series describes a series of books.
+type Series struct {
+	// Name is the name of the series.
+	Name string
+	// Position is the position of the book in the series,
+	// starting at 1. It is 0 if the position is not known.
+	Position int32
 }
 
-// GetIsbn gets the Isbn of the GetBookRequest.
-func (m *GetBookRequest) GetIsbn() (x int64) {
+// GetName gets the Name of the Series.
+func (m *Series) GetName() (x string) {
 	if m == nil {
 		return x
 	}
-	return m.Isbn
+	return m.Name
 }
 
-// MarshalToWriter marshals GetBookRequest to the provided writer.
-func (m *GetBookRequest) MarshalToWriter(writer jspb.Writer) {
+// GetPosition gets the Position of the Series.
+func (m *Series) GetPosition() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Position
+}
+
+// MarshalToWriter marshals Series to the provided writer.
+func (m *Series) MarshalToWriter(writer jspb.Writer) {
 	if m == nil {
 		return
 	}
 
-	if m.Isbn != 0 {
-		writer.WriteInt64(1, m.Isbn)
+	if len(m.Name) > 0 {
+		writer.WriteString(1, m.Name)
+	}
+
+	if m.Position != 0 {
+		writer.WriteInt32(2, m.Position)
 	}
 
 	return
 }
 
-// Marshal marshals GetBookRequest to a slice of bytes.
-func (m *GetBookRequest) Marshal() []byte {
+// Marshal marshals Series to a slice of bytes.
+func (m *Series) Marshal() []byte {
 	writer := jspb.NewWriter()
 	m.MarshalToWriter(writer)
 	return writer.GetResult()
 }
 
-// UnmarshalFromReader unmarshals a GetBookRequest from the provided reader.
-func (m *GetBookRequest) UnmarshalFromReader(reader jspb.Reader) *GetBookRequest {
+// UnmarshalFromReader unmarshals a Series from the provided reader.
+func (m *Series) UnmarshalFromReader(reader jspb.Reader) *Series {
 	for reader.Next() {
 		if m == nil {
-			m = &GetBookRequest{}
+			m = &Series{}
 		}
 
 		switch reader.GetFieldNumber() {
 		case 1:
-			m.Isbn = reader.ReadInt64()
+			m.Name = reader.ReadString()
+		case 2:
+			m.Position = reader.ReadInt32()
 		default:
 			reader.SkipField()
 		}
@@ -387,8 +712,8 @@ func (m *GetBookRequest) UnmarshalFromReader(reader jspb.Reader) *GetBookRequest
 	return m
 }
 
-// Unmarshal unmarshals a GetBookRequest from a slice of bytes.
-func (m *GetBookRequest) Unmarshal(rawBytes []byte) (*GetBookRequest, error) {
+// Unmarshal unmarshals a Series from a slice of bytes.
+func (m *Series) Unmarshal(rawBytes []byte) (*Series, error) {
 	reader := jspb.NewReader(rawBytes)
 
 	m = m.UnmarshalFromReader(reader)
@@ -400,370 +725,7539 @@ func (m *GetBookRequest) Unmarshal(rawBytes []byte) (*GetBookRequest, error) {
 	return m, nil
 }
 
-// QueryBooksRequest is the input to the QueryBooks method.
-type QueryBooksRequest struct {
-	// AuthorPrefix is the prefix with which
-	// to match against the author of a book in the library.
-	AuthorPrefix string
-}
-
-// GetAuthorPrefix gets the AuthorPrefix of the QueryBooksRequest.
-func (m *QueryBooksRequest) GetAuthorPrefix() (x string) {
-	if m == nil {
-		return x
-	}
-	return m.AuthorPrefix
+// Book represents a book in the library.
+type Book struct {
+	// Isbn is the ISBN number of the book.
+	Isbn int64
+	// Title is the title of the book.
+	Title string
+	// Author is the author of the book. Books with several
+	// authors list them all, separated by commas. It is
+	// set from the contributors if it is not set.
+	Author string
+	// BookType is the type of the book.
+	BookType BookType
+	// PublishingMethod is the publishing method
+	// used for this Book.
+	//
+	// Types that are valid to be assigned to PublishingMethod:
+	//	*Book_SelfPublished
+	//	*Book_Publisher
+	PublishingMethod isBook_PublishingMethod
+	// PublicationDate is the time of publication of the book.
+	PublicationDate *google_protobuf.Timestamp
+	// Contributors are the people who have contributed to the book.
+	// Books without contributors with the AUTHOR
+	// role have the author as their only author.
+	Contributors []*Contributor
+	// Edition describes the edition of the book, for example "2nd".
+	Edition string
+	// Series is the series the book is part of, if any.
+	Series *Series
+	// Language is the BCP 47 code of the
+	// language of the book, for example "en-GB".
+	Language string
+	// Extent is the length of the book.
+	//
+	// Types that are valid to be assigned to Extent:
+	//	*Book_PageCount
+	//	*Book_DurationSeconds
+	Extent isBook_Extent
+	// Description is a summary of the book.
+	Description string
+	// Subjects are the subjects of the book,
+	// for example "Fiction" or "Dystopias".
+	Subjects []string
+	// CoverImageUrl is the URL of an image of the cover of the book.
+	CoverImageUrl string
+	// Availability summarizes the copies of the book. It is only
+	// set on the books returned by GetBook and QueryBooks, and is
+	// ignored on the books imported.
+	Availability *Availability
+	// RatingCount is the number of reviews of the book.
+	// Like Availability, it is only set on the books
+	// returned by GetBook and QueryBooks.
+	RatingCount int32
+	// AverageRating is the average rating of the
+	// reviews of the book, or 0 if there are none.
+	AverageRating float64
 }
 
-// MarshalToWriter marshals QueryBooksRequest to the provided writer.
-func (m *QueryBooksRequest) MarshalToWriter(writer jspb.Writer) {
-	if m == nil {
-		return
-	}
+// isBook_PublishingMethod is used to distinguish types assignable to PublishingMethod
+type isBook_PublishingMethod interface{ isBook_PublishingMethod() }
 
-	if len(m.AuthorPrefix) > 0 {
-		writer.WriteString(1, m.AuthorPrefix)
-	}
+// isBook_Extent is used to distinguish types assignable to Extent
+type isBook_Extent interface{ isBook_Extent() }
 
-	return
+// Book_SelfPublished is assignable to PublishingMethod
+type Book_SelfPublished struct {
+	// SelfPublished means this book was
+	// self published.
+	SelfPublished bool
 }
 
-// Marshal marshals QueryBooksRequest to a slice of bytes.
-func (m *QueryBooksRequest) Marshal() []byte {
-	writer := jspb.NewWriter()
-	m.MarshalToWriter(writer)
-	return writer.GetResult()
+// Book_Publisher is assignable to PublishingMethod
+type Book_Publisher struct {
+	// Publisher means this book was published
+	// through a Publisher.
+	Publisher *Publisher
 }
 
-// UnmarshalFromReader unmarshals a QueryBooksRequest from the provided reader.
-func (m *QueryBooksRequest) UnmarshalFromReader(reader jspb.Reader) *QueryBooksRequest {
-	for reader.Next() {
-		if m == nil {
-			m = &QueryBooksRequest{}
-		}
-
-		switch reader.GetFieldNumber() {
-		case 1:
-			m.AuthorPrefix = reader.ReadString()
-		default:
-			reader.SkipField()
-		}
-	}
-
-	return m
+// Book_PageCount is assignable to Extent
+type Book_PageCount struct {
+	// PageCount is the number of pages of a printed book.
+	PageCount int32
 }
 
-// Unmarshal unmarshals a QueryBooksRequest from a slice of bytes.
-func (m *QueryBooksRequest) Unmarshal(rawBytes []byte) (*QueryBooksRequest, error) {
-	reader := jspb.NewReader(rawBytes)
+// Book_DurationSeconds is assignable to Extent
+type Book_DurationSeconds struct {
+	// DurationSeconds is the running time of an audiobook.
+	DurationSeconds int32
+}
 
-	m = m.UnmarshalFromReader(reader)
+func (*Book_SelfPublished) isBook_PublishingMethod() {}
+func (*Book_Publisher) isBook_PublishingMethod()     {}
+func (*Book_PageCount) isBook_Extent()               {}
+func (*Book_DurationSeconds) isBook_Extent()         {}
 
-	if err := reader.Err(); err != nil {
-		return nil, err
+// GetPublishingMethod gets the PublishingMethod of the Book.
+func (m *Book) GetPublishingMethod() (x isBook_PublishingMethod) {
+	if m == nil {
+		return x
 	}
-
-	return m, nil
+	return m.PublishingMethod
 }
 
-// Collection is a collection of books
-type Collection struct {
-	// Books is a list of books
-	Books []*Book
+// GetExtent gets the Extent of the Book.
+func (m *Book) GetExtent() (x isBook_Extent) {
+	if m == nil {
+		return x
+	}
+	return m.Extent
 }
 
-// GetBooks gets the Books of the Collection.
-func (m *Collection) GetBooks() (x []*Book) {
+// GetIsbn gets the Isbn of the Book.
+func (m *Book) GetIsbn() (x int64) {
 	if m == nil {
 		return x
 	}
-	return m.Books
+	return m.Isbn
 }
 
-// MarshalToWriter marshals Collection to the provided writer.
-func (m *Collection) MarshalToWriter(writer jspb.Writer) {
+// GetTitle gets the Title of the Book.
+func (m *Book) GetTitle() (x string) {
 	if m == nil {
-		return
+		return x
 	}
+	return m.Title
+}
 
-	for _, msg := range m.Books {
-		writer.WriteMessage(1, func() {
-			msg.MarshalToWriter(writer)
-		})
+// GetAuthor gets the Author of the Book.
+func (m *Book) GetAuthor() (x string) {
+	if m == nil {
+		return x
 	}
-
-	return
+	return m.Author
 }
 
-// Marshal marshals Collection to a slice of bytes.
-func (m *Collection) Marshal() []byte {
-	writer := jspb.NewWriter()
-	m.MarshalToWriter(writer)
-	return writer.GetResult()
+// GetBookType gets the BookType of the Book.
+func (m *Book) GetBookType() (x BookType) {
+	if m == nil {
+		return x
+	}
+	return m.BookType
 }
 
-// UnmarshalFromReader unmarshals a Collection from the provided reader.
-func (m *Collection) UnmarshalFromReader(reader jspb.Reader) *Collection {
-	for reader.Next() {
-		if m == nil {
-			m = &Collection{}
-		}
-
-		switch reader.GetFieldNumber() {
-		case 1:
-			reader.ReadMessage(func() {
-				m.Books = append(m.Books, new(Book).UnmarshalFromReader(reader))
-			})
-		default:
-			reader.SkipField()
-		}
+// GetSelfPublished gets the SelfPublished of the Book.
+func (m *Book) GetSelfPublished() (x bool) {
+	if v, ok := m.GetPublishingMethod().(*Book_SelfPublished); ok {
+		return v.SelfPublished
 	}
-
-	return m
+	return x
 }
 
-// Unmarshal unmarshals a Collection from a slice of bytes.
-func (m *Collection) Unmarshal(rawBytes []byte) (*Collection, error) {
-	reader := jspb.NewReader(rawBytes)
-
-	m = m.UnmarshalFromReader(reader)
-
-	if err := reader.Err(); err != nil {
-		return nil, err
+// GetPublisher gets the Publisher of the Book.
+func (m *Book) GetPublisher() (x *Publisher) {
+	if v, ok := m.GetPublishingMethod().(*Book_Publisher); ok {
+		return v.Publisher
 	}
-
-	return m, nil
+	return x
 }
 
-// BookMessage is used to discuss books
-type BookMessage struct {
-	// Types that are valid to be assigned to Content:
-	//	*BookMessage_Name
-	//	*BookMessage_Message
-	Content isBookMessage_Content
+// GetPublicationDate gets the PublicationDate of the Book.
+func (m *Book) GetPublicationDate() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.PublicationDate
 }
 
-// isBookMessage_Content is used to distinguish types assignable to Content
-type isBookMessage_Content interface{ isBookMessage_Content() }
-
-// BookMessage_Name is assignable to Content
-type BookMessage_Name struct {
-	// Name is the name of the person who is sending this message.
-	// It should be sent as the first message on the stream.
-	Name string
+// GetContributors gets the Contributors of the Book.
+func (m *Book) GetContributors() (x []*Contributor) {
+	if m == nil {
+		return x
+	}
+	return m.Contributors
 }
 
-// BookMessage_Message is assignable to Content
-type BookMessage_Message struct {
-	// Message is any message the user wishes to send.
-	Message string
+// GetEdition gets the Edition of the Book.
+func (m *Book) GetEdition() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Edition
 }
 
-func (*BookMessage_Name) isBookMessage_Content()    {}
-func (*BookMessage_Message) isBookMessage_Content() {}
+// GetSeries gets the Series of the Book.
+func (m *Book) GetSeries() (x *Series) {
+	if m == nil {
+		return x
+	}
+	return m.Series
+}
 
-// GetContent gets the Content of the BookMessage.
-func (m *BookMessage) GetContent() (x isBookMessage_Content) {
+// GetLanguage gets the Language of the Book.
+func (m *Book) GetLanguage() (x string) {
 	if m == nil {
 		return x
 	}
-	return m.Content
+	return m.Language
 }
 
-// GetName gets the Name of the BookMessage.
-func (m *BookMessage) GetName() (x string) {
-	if v, ok := m.GetContent().(*BookMessage_Name); ok {
-		return v.Name
+// GetPageCount gets the PageCount of the Book.
+func (m *Book) GetPageCount() (x int32) {
+	if v, ok := m.GetExtent().(*Book_PageCount); ok {
+		return v.PageCount
 	}
 	return x
 }
 
-// GetMessage gets the Message of the BookMessage.
-func (m *BookMessage) GetMessage() (x string) {
-	if v, ok := m.GetContent().(*BookMessage_Message); ok {
-		return v.Message
+// GetDurationSeconds gets the DurationSeconds of the Book.
+func (m *Book) GetDurationSeconds() (x int32) {
+	if v, ok := m.GetExtent().(*Book_DurationSeconds); ok {
+		return v.DurationSeconds
 	}
 	return x
 }
 
-// MarshalToWriter marshals BookMessage to the provided writer.
-func (m *BookMessage) MarshalToWriter(writer jspb.Writer) {
+// GetDescription gets the Description of the Book.
+func (m *Book) GetDescription() (x string) {
 	if m == nil {
-		return
+		return x
 	}
+	return m.Description
+}
 
-	switch t := m.Content.(type) {
-	case *BookMessage_Name:
-		if len(t.Name) > 0 {
-			writer.WriteString(1, t.Name)
+// GetSubjects gets the Subjects of the Book.
+func (m *Book) GetSubjects() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.Subjects
+}
+
+// GetCoverImageUrl gets the CoverImageUrl of the Book.
+func (m *Book) GetCoverImageUrl() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.CoverImageUrl
+}
+
+// GetAvailability gets the Availability of the Book.
+func (m *Book) GetAvailability() (x *Availability) {
+	if m == nil {
+		return x
+	}
+	return m.Availability
+}
+
+// GetRatingCount gets the RatingCount of the Book.
+func (m *Book) GetRatingCount() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.RatingCount
+}
+
+// GetAverageRating gets the AverageRating of the Book.
+func (m *Book) GetAverageRating() (x float64) {
+	if m == nil {
+		return x
+	}
+	return m.AverageRating
+}
+
+// MarshalToWriter marshals Book to the provided writer.
+func (m *Book) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	switch t := m.PublishingMethod.(type) {
+	case *Book_SelfPublished:
+		if t.SelfPublished {
+			writer.WriteBool(5, t.SelfPublished)
+		}
+	case *Book_Publisher:
+		if t.Publisher != nil {
+			writer.WriteMessage(6, func() {
+				t.Publisher.MarshalToWriter(writer)
+			})
+		}
+	}
+
+	switch t := m.Extent.(type) {
+	case *Book_PageCount:
+		if t.PageCount != 0 {
+			writer.WriteInt32(12, t.PageCount)
+		}
+	case *Book_DurationSeconds:
+		if t.DurationSeconds != 0 {
+			writer.WriteInt32(13, t.DurationSeconds)
+		}
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	if len(m.Title) > 0 {
+		writer.WriteString(2, m.Title)
+	}
+
+	if len(m.Author) > 0 {
+		writer.WriteString(3, m.Author)
+	}
+
+	if int(m.BookType) != 0 {
+		writer.WriteEnum(4, int(m.BookType))
+	}
+
+	if m.PublicationDate != nil {
+		writer.WriteMessage(7, func() {
+			m.PublicationDate.MarshalToWriter(writer)
+		})
+	}
+
+	for _, msg := range m.Contributors {
+		writer.WriteMessage(8, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	if len(m.Edition) > 0 {
+		writer.WriteString(9, m.Edition)
+	}
+
+	if m.Series != nil {
+		writer.WriteMessage(10, func() {
+			m.Series.MarshalToWriter(writer)
+		})
+	}
+
+	if len(m.Language) > 0 {
+		writer.WriteString(11, m.Language)
+	}
+
+	if len(m.Description) > 0 {
+		writer.WriteString(14, m.Description)
+	}
+
+	for _, val := range m.Subjects {
+		writer.WriteString(15, val)
+	}
+
+	if len(m.CoverImageUrl) > 0 {
+		writer.WriteString(16, m.CoverImageUrl)
+	}
+
+	if m.Availability != nil {
+		writer.WriteMessage(17, func() {
+			m.Availability.MarshalToWriter(writer)
+		})
+	}
+
+	if m.RatingCount != 0 {
+		writer.WriteInt32(18, m.RatingCount)
+	}
+
+	if m.AverageRating != 0 {
+		writer.WriteFloat64(19, m.AverageRating)
+	}
+
+	return
+}
+
+// Marshal marshals Book to a slice of bytes.
+func (m *Book) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Book from the provided reader.
+func (m *Book) UnmarshalFromReader(reader jspb.Reader) *Book {
+	for reader.Next() {
+		if m == nil {
+			m = &Book{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		case 2:
+			m.Title = reader.ReadString()
+		case 3:
+			m.Author = reader.ReadString()
+		case 4:
+			m.BookType = BookType(reader.ReadEnum())
+		case 5:
+			m.PublishingMethod = &Book_SelfPublished{
+				SelfPublished: reader.ReadBool(),
+			}
+		case 6:
+			reader.ReadMessage(func() {
+				m.PublishingMethod = &Book_Publisher{
+					Publisher: new(Publisher).UnmarshalFromReader(reader),
+				}
+			})
+		case 7:
+			reader.ReadMessage(func() {
+				m.PublicationDate = m.PublicationDate.UnmarshalFromReader(reader)
+			})
+		case 8:
+			reader.ReadMessage(func() {
+				m.Contributors = append(m.Contributors, new(Contributor).UnmarshalFromReader(reader))
+			})
+		case 9:
+			m.Edition = reader.ReadString()
+		case 10:
+			reader.ReadMessage(func() {
+				m.Series = m.Series.UnmarshalFromReader(reader)
+			})
+		case 11:
+			m.Language = reader.ReadString()
+		case 12:
+			m.Extent = &Book_PageCount{
+				PageCount: reader.ReadInt32(),
+			}
+		case 13:
+			m.Extent = &Book_DurationSeconds{
+				DurationSeconds: reader.ReadInt32(),
+			}
+		case 14:
+			m.Description = reader.ReadString()
+		case 15:
+			m.Subjects = append(m.Subjects, reader.ReadString())
+		case 16:
+			m.CoverImageUrl = reader.ReadString()
+		case 17:
+			reader.ReadMessage(func() {
+				m.Availability = m.Availability.UnmarshalFromReader(reader)
+			})
+		case 18:
+			m.RatingCount = reader.ReadInt32()
+		case 19:
+			m.AverageRating = reader.ReadFloat64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Book from a slice of bytes.
+func (m *Book) Unmarshal(rawBytes []byte) (*Book, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Branch is a branch of the library, which holds copies of books.
+type Branch struct {
+	// Id identifies the branch.
+	Id string
+	// Name is the name of the branch.
+	Name string
+	// Address is the street address of the branch.
+	Address string
+}
+
+// GetId gets the Id of the Branch.
+func (m *Branch) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// GetName gets the Name of the Branch.
+func (m *Branch) GetName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Name
+}
+
+// GetAddress gets the Address of the Branch.
+func (m *Branch) GetAddress() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Address
+}
+
+// MarshalToWriter marshals Branch to the provided writer.
+func (m *Branch) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	if len(m.Name) > 0 {
+		writer.WriteString(2, m.Name)
+	}
+
+	if len(m.Address) > 0 {
+		writer.WriteString(3, m.Address)
+	}
+
+	return
+}
+
+// Marshal marshals Branch to a slice of bytes.
+func (m *Branch) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Branch from the provided reader.
+func (m *Branch) UnmarshalFromReader(reader jspb.Reader) *Branch {
+	for reader.Next() {
+		if m == nil {
+			m = &Branch{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		case 2:
+			m.Name = reader.ReadString()
+		case 3:
+			m.Address = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Branch from a slice of bytes.
+func (m *Branch) Unmarshal(rawBytes []byte) (*Branch, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Availability summarizes the copies of a Book.
+type Availability struct {
+	// Copies is the number of copies.
+	Copies int32
+	// Available is the number of copies which can be checked out.
+	Available int32
+	// Branches summarizes the copies at each branch
+	// with copies of the book, ordered by branch Id.
+	Branches []*BranchAvailability
+}
+
+// GetCopies gets the Copies of the Availability.
+func (m *Availability) GetCopies() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Copies
+}
+
+// GetAvailable gets the Available of the Availability.
+func (m *Availability) GetAvailable() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Available
+}
+
+// GetBranches gets the Branches of the Availability.
+func (m *Availability) GetBranches() (x []*BranchAvailability) {
+	if m == nil {
+		return x
+	}
+	return m.Branches
+}
+
+// MarshalToWriter marshals Availability to the provided writer.
+func (m *Availability) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Copies != 0 {
+		writer.WriteInt32(1, m.Copies)
+	}
+
+	if m.Available != 0 {
+		writer.WriteInt32(2, m.Available)
+	}
+
+	for _, msg := range m.Branches {
+		writer.WriteMessage(3, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals Availability to a slice of bytes.
+func (m *Availability) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Availability from the provided reader.
+func (m *Availability) UnmarshalFromReader(reader jspb.Reader) *Availability {
+	for reader.Next() {
+		if m == nil {
+			m = &Availability{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Copies = reader.ReadInt32()
+		case 2:
+			m.Available = reader.ReadInt32()
+		case 3:
+			reader.ReadMessage(func() {
+				m.Branches = append(m.Branches, new(BranchAvailability).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Availability from a slice of bytes.
+func (m *Availability) Unmarshal(rawBytes []byte) (*Availability, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// BranchAvailability summarizes the copies of a Book at a Branch.
+type BranchAvailability struct {
+	// BranchId is the Id of the branch.
+	BranchId string
+	// BranchName is the name of the branch.
+	BranchName string
+	// Copies is the number of copies at the branch.
+	Copies int32
+	// Available is the number of copies which can be checked out.
+	Available int32
+	// OnLoan is the number of copies on loan.
+	OnLoan int32
+	// OnHold is the number of copies kept for holds.
+	OnHold int32
+}
+
+// GetBranchId gets the BranchId of the BranchAvailability.
+func (m *BranchAvailability) GetBranchId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.BranchId
+}
+
+// GetBranchName gets the BranchName of the BranchAvailability.
+func (m *BranchAvailability) GetBranchName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.BranchName
+}
+
+// GetCopies gets the Copies of the BranchAvailability.
+func (m *BranchAvailability) GetCopies() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Copies
+}
+
+// GetAvailable gets the Available of the BranchAvailability.
+func (m *BranchAvailability) GetAvailable() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Available
+}
+
+// GetOnLoan gets the OnLoan of the BranchAvailability.
+func (m *BranchAvailability) GetOnLoan() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.OnLoan
+}
+
+// GetOnHold gets the OnHold of the BranchAvailability.
+func (m *BranchAvailability) GetOnHold() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.OnHold
+}
+
+// MarshalToWriter marshals BranchAvailability to the provided writer.
+func (m *BranchAvailability) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.BranchId) > 0 {
+		writer.WriteString(1, m.BranchId)
+	}
+
+	if len(m.BranchName) > 0 {
+		writer.WriteString(2, m.BranchName)
+	}
+
+	if m.Copies != 0 {
+		writer.WriteInt32(3, m.Copies)
+	}
+
+	if m.Available != 0 {
+		writer.WriteInt32(4, m.Available)
+	}
+
+	if m.OnLoan != 0 {
+		writer.WriteInt32(5, m.OnLoan)
+	}
+
+	if m.OnHold != 0 {
+		writer.WriteInt32(6, m.OnHold)
+	}
+
+	return
+}
+
+// Marshal marshals BranchAvailability to a slice of bytes.
+func (m *BranchAvailability) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a BranchAvailability from the provided reader.
+func (m *BranchAvailability) UnmarshalFromReader(reader jspb.Reader) *BranchAvailability {
+	for reader.Next() {
+		if m == nil {
+			m = &BranchAvailability{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.BranchId = reader.ReadString()
+		case 2:
+			m.BranchName = reader.ReadString()
+		case 3:
+			m.Copies = reader.ReadInt32()
+		case 4:
+			m.Available = reader.ReadInt32()
+		case 5:
+			m.OnLoan = reader.ReadInt32()
+		case 6:
+			m.OnHold = reader.ReadInt32()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a BranchAvailability from a slice of bytes.
+func (m *BranchAvailability) Unmarshal(rawBytes []byte) (*BranchAvailability, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetBookRequest is the input to the GetBook method.
+type GetBookRequest struct {
+	// Isbn is the ISBN with which
+	// to match against the ISBN of a book in the library.
+	Isbn int64
+}
+
+// GetIsbn gets the Isbn of the GetBookRequest.
+func (m *GetBookRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// MarshalToWriter marshals GetBookRequest to the provided writer.
+func (m *GetBookRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	return
+}
+
+// Marshal marshals GetBookRequest to a slice of bytes.
+func (m *GetBookRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a GetBookRequest from the provided reader.
+func (m *GetBookRequest) UnmarshalFromReader(reader jspb.Reader) *GetBookRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &GetBookRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a GetBookRequest from a slice of bytes.
+func (m *GetBookRequest) Unmarshal(rawBytes []byte) (*GetBookRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// QueryBooksRequest is the input to the QueryBooks method.
+type QueryBooksRequest struct {
+	// AuthorPrefix is the prefix with which
+	// to match against the author of a book in the library.
+	// Books with several authors match if any author matches.
+	AuthorPrefix string
+	// AuthorId, if set, only matches books
+	// with an author with this Id.
+	AuthorId string
+	// AvailableAtBranch, if set, only matches books with
+	// a copy available at the branch with this Id.
+	AvailableAtBranch string
+}
+
+// GetAuthorPrefix gets the AuthorPrefix of the QueryBooksRequest.
+func (m *QueryBooksRequest) GetAuthorPrefix() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.AuthorPrefix
+}
+
+// GetAuthorId gets the AuthorId of the QueryBooksRequest.
+func (m *QueryBooksRequest) GetAuthorId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.AuthorId
+}
+
+// GetAvailableAtBranch gets the AvailableAtBranch of the QueryBooksRequest.
+func (m *QueryBooksRequest) GetAvailableAtBranch() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.AvailableAtBranch
+}
+
+// MarshalToWriter marshals QueryBooksRequest to the provided writer.
+func (m *QueryBooksRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.AuthorPrefix) > 0 {
+		writer.WriteString(1, m.AuthorPrefix)
+	}
+
+	if len(m.AuthorId) > 0 {
+		writer.WriteString(2, m.AuthorId)
+	}
+
+	if len(m.AvailableAtBranch) > 0 {
+		writer.WriteString(3, m.AvailableAtBranch)
+	}
+
+	return
+}
+
+// Marshal marshals QueryBooksRequest to a slice of bytes.
+func (m *QueryBooksRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a QueryBooksRequest from the provided reader.
+func (m *QueryBooksRequest) UnmarshalFromReader(reader jspb.Reader) *QueryBooksRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &QueryBooksRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.AuthorPrefix = reader.ReadString()
+		case 2:
+			m.AuthorId = reader.ReadString()
+		case 3:
+			m.AvailableAtBranch = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a QueryBooksRequest from a slice of bytes.
+func (m *QueryBooksRequest) Unmarshal(rawBytes []byte) (*QueryBooksRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetAuthorRequest is the input to the GetAuthor method.
+type GetAuthorRequest struct {
+	// Id is the Id of the author.
+	Id string
+}
+
+// GetId gets the Id of the GetAuthorRequest.
+func (m *GetAuthorRequest) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// MarshalToWriter marshals GetAuthorRequest to the provided writer.
+func (m *GetAuthorRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	return
+}
+
+// Marshal marshals GetAuthorRequest to a slice of bytes.
+func (m *GetAuthorRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a GetAuthorRequest from the provided reader.
+func (m *GetAuthorRequest) UnmarshalFromReader(reader jspb.Reader) *GetAuthorRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &GetAuthorRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a GetAuthorRequest from a slice of bytes.
+func (m *GetAuthorRequest) Unmarshal(rawBytes []byte) (*GetAuthorRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListAuthorsRequest is the input to the ListAuthors method.
+type ListAuthorsRequest struct {
+	// NamePrefix is the prefix with which
+	// to match against the name of an author.
+	NamePrefix string
+}
+
+// GetNamePrefix gets the NamePrefix of the ListAuthorsRequest.
+func (m *ListAuthorsRequest) GetNamePrefix() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.NamePrefix
+}
+
+// MarshalToWriter marshals ListAuthorsRequest to the provided writer.
+func (m *ListAuthorsRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.NamePrefix) > 0 {
+		writer.WriteString(1, m.NamePrefix)
+	}
+
+	return
+}
+
+// Marshal marshals ListAuthorsRequest to a slice of bytes.
+func (m *ListAuthorsRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListAuthorsRequest from the provided reader.
+func (m *ListAuthorsRequest) UnmarshalFromReader(reader jspb.Reader) *ListAuthorsRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListAuthorsRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.NamePrefix = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListAuthorsRequest from a slice of bytes.
+func (m *ListAuthorsRequest) Unmarshal(rawBytes []byte) (*ListAuthorsRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListAuthorsResponse is the output of the ListAuthors method.
+type ListAuthorsResponse struct {
+	// Authors are the authors matching the
+	// name prefix, ordered by name.
+	Authors []*Author
+}
+
+// GetAuthors gets the Authors of the ListAuthorsResponse.
+func (m *ListAuthorsResponse) GetAuthors() (x []*Author) {
+	if m == nil {
+		return x
+	}
+	return m.Authors
+}
+
+// MarshalToWriter marshals ListAuthorsResponse to the provided writer.
+func (m *ListAuthorsResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Authors {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ListAuthorsResponse to a slice of bytes.
+func (m *ListAuthorsResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListAuthorsResponse from the provided reader.
+func (m *ListAuthorsResponse) UnmarshalFromReader(reader jspb.Reader) *ListAuthorsResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ListAuthorsResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Authors = append(m.Authors, new(Author).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListAuthorsResponse from a slice of bytes.
+func (m *ListAuthorsResponse) Unmarshal(rawBytes []byte) (*ListAuthorsResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetPublisherRequest is the input to the GetPublisher method.
+type GetPublisherRequest struct {
+	// Id is the Id of the publisher.
+	Id string
+}
+
+// GetId gets the Id of the GetPublisherRequest.
+func (m *GetPublisherRequest) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// MarshalToWriter marshals GetPublisherRequest to the provided writer.
+func (m *GetPublisherRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	return
+}
+
+// Marshal marshals GetPublisherRequest to a slice of bytes.
+func (m *GetPublisherRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a GetPublisherRequest from the provided reader.
+func (m *GetPublisherRequest) UnmarshalFromReader(reader jspb.Reader) *GetPublisherRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &GetPublisherRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a GetPublisherRequest from a slice of bytes.
+func (m *GetPublisherRequest) Unmarshal(rawBytes []byte) (*GetPublisherRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Collection is a collection of books
+type Collection struct {
+	// Books is a list of books
+	Books []*Book
+	// Id identifies the collection, and is part of the link
+	// with which the collection can be shared.
+	Id string
+	// Name is the name of the collection.
+	Name string
+	// Owner is the user who made the collection. Collections
+	// made without a session have no owner, and cannot be changed.
+	Owner string
+	// Visibility is who can see the collection.
+	Visibility Visibility
+	// Errors describe the books sent to MakeCollection
+	// that were not added to the collection. They are
+	// only set in the response of MakeCollection.
+	Errors []*CollectionError
+}
+
+// GetBooks gets the Books of the Collection.
+func (m *Collection) GetBooks() (x []*Book) {
+	if m == nil {
+		return x
+	}
+	return m.Books
+}
+
+// GetId gets the Id of the Collection.
+func (m *Collection) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// GetName gets the Name of the Collection.
+func (m *Collection) GetName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Name
+}
+
+// GetOwner gets the Owner of the Collection.
+func (m *Collection) GetOwner() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Owner
+}
+
+// GetVisibility gets the Visibility of the Collection.
+func (m *Collection) GetVisibility() (x Visibility) {
+	if m == nil {
+		return x
+	}
+	return m.Visibility
+}
+
+// GetErrors gets the Errors of the Collection.
+func (m *Collection) GetErrors() (x []*CollectionError) {
+	if m == nil {
+		return x
+	}
+	return m.Errors
+}
+
+// MarshalToWriter marshals Collection to the provided writer.
+func (m *Collection) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Books {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(2, m.Id)
+	}
+
+	if len(m.Name) > 0 {
+		writer.WriteString(3, m.Name)
+	}
+
+	if len(m.Owner) > 0 {
+		writer.WriteString(4, m.Owner)
+	}
+
+	if int(m.Visibility) != 0 {
+		writer.WriteEnum(5, int(m.Visibility))
+	}
+
+	for _, msg := range m.Errors {
+		writer.WriteMessage(6, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals Collection to a slice of bytes.
+func (m *Collection) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Collection from the provided reader.
+func (m *Collection) UnmarshalFromReader(reader jspb.Reader) *Collection {
+	for reader.Next() {
+		if m == nil {
+			m = &Collection{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Books = append(m.Books, new(Book).UnmarshalFromReader(reader))
+			})
+		case 2:
+			m.Id = reader.ReadString()
+		case 3:
+			m.Name = reader.ReadString()
+		case 4:
+			m.Owner = reader.ReadString()
+		case 5:
+			m.Visibility = Visibility(reader.ReadEnum())
+		case 6:
+			reader.ReadMessage(func() {
+				m.Errors = append(m.Errors, new(CollectionError).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Collection from a slice of bytes.
+func (m *Collection) Unmarshal(rawBytes []byte) (*Collection, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// CollectionError describes a book sent to
+// MakeCollection that was not added to the collection.
+type CollectionError struct {
+	// Index is the position of the book in the
+	// stream of books sent, starting at 0.
+	Index int32
+	// Isbn is the ISBN of the book.
+	Isbn int64
+	// Reason is why the book was not added.
+	Reason CollectionError_Reason
+	// Message describes the error.
+	Message string
+}
+
+// GetIndex gets the Index of the CollectionError.
+func (m *CollectionError) GetIndex() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Index
+}
+
+// GetIsbn gets the Isbn of the CollectionError.
+func (m *CollectionError) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetReason gets the Reason of the CollectionError.
+func (m *CollectionError) GetReason() (x CollectionError_Reason) {
+	if m == nil {
+		return x
+	}
+	return m.Reason
+}
+
+// GetMessage gets the Message of the CollectionError.
+func (m *CollectionError) GetMessage() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Message
+}
+
+// MarshalToWriter marshals CollectionError to the provided writer.
+func (m *CollectionError) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Index != 0 {
+		writer.WriteInt32(1, m.Index)
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(2, m.Isbn)
+	}
+
+	if int(m.Reason) != 0 {
+		writer.WriteEnum(3, int(m.Reason))
+	}
+
+	if len(m.Message) > 0 {
+		writer.WriteString(4, m.Message)
+	}
+
+	return
+}
+
+// Marshal marshals CollectionError to a slice of bytes.
+func (m *CollectionError) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a CollectionError from the provided reader.
+func (m *CollectionError) UnmarshalFromReader(reader jspb.Reader) *CollectionError {
+	for reader.Next() {
+		if m == nil {
+			m = &CollectionError{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Index = reader.ReadInt32()
+		case 2:
+			m.Isbn = reader.ReadInt64()
+		case 3:
+			m.Reason = CollectionError_Reason(reader.ReadEnum())
+		case 4:
+			m.Message = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a CollectionError from a slice of bytes.
+func (m *CollectionError) Unmarshal(rawBytes []byte) (*CollectionError, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetCollectionRequest is the input to the GetCollection method.
+type GetCollectionRequest struct {
+	// Id is the Id of the collection.
+	Id string
+}
+
+// GetId gets the Id of the GetCollectionRequest.
+func (m *GetCollectionRequest) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// MarshalToWriter marshals GetCollectionRequest to the provided writer.
+func (m *GetCollectionRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	return
+}
+
+// Marshal marshals GetCollectionRequest to a slice of bytes.
+func (m *GetCollectionRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a GetCollectionRequest from the provided reader.
+func (m *GetCollectionRequest) UnmarshalFromReader(reader jspb.Reader) *GetCollectionRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &GetCollectionRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a GetCollectionRequest from a slice of bytes.
+func (m *GetCollectionRequest) Unmarshal(rawBytes []byte) (*GetCollectionRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListCollectionsRequest is the input to the ListCollections method.
+type ListCollectionsRequest struct {
+	// Owner, if set, only lists the collections of this user.
+	Owner string
+}
+
+// GetOwner gets the Owner of the ListCollectionsRequest.
+func (m *ListCollectionsRequest) GetOwner() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Owner
+}
+
+// MarshalToWriter marshals ListCollectionsRequest to the provided writer.
+func (m *ListCollectionsRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Owner) > 0 {
+		writer.WriteString(1, m.Owner)
+	}
+
+	return
+}
+
+// Marshal marshals ListCollectionsRequest to a slice of bytes.
+func (m *ListCollectionsRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListCollectionsRequest from the provided reader.
+func (m *ListCollectionsRequest) UnmarshalFromReader(reader jspb.Reader) *ListCollectionsRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListCollectionsRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Owner = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListCollectionsRequest from a slice of bytes.
+func (m *ListCollectionsRequest) Unmarshal(rawBytes []byte) (*ListCollectionsRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListCollectionsResponse is the output of the ListCollections method.
+type ListCollectionsResponse struct {
+	// Collections are the collections listed,
+	// ordered by name.
+	Collections []*Collection
+}
+
+// GetCollections gets the Collections of the ListCollectionsResponse.
+func (m *ListCollectionsResponse) GetCollections() (x []*Collection) {
+	if m == nil {
+		return x
+	}
+	return m.Collections
+}
+
+// MarshalToWriter marshals ListCollectionsResponse to the provided writer.
+func (m *ListCollectionsResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Collections {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ListCollectionsResponse to a slice of bytes.
+func (m *ListCollectionsResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListCollectionsResponse from the provided reader.
+func (m *ListCollectionsResponse) UnmarshalFromReader(reader jspb.Reader) *ListCollectionsResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ListCollectionsResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Collections = append(m.Collections, new(Collection).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListCollectionsResponse from a slice of bytes.
+func (m *ListCollectionsResponse) Unmarshal(rawBytes []byte) (*ListCollectionsResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// AddToCollectionRequest is the input to the AddToCollection method.
+type AddToCollectionRequest struct {
+	// Id is the Id of the collection.
+	Id string
+	// Isbns are the ISBNs of the books to add.
+	Isbns []int64
+}
+
+// GetId gets the Id of the AddToCollectionRequest.
+func (m *AddToCollectionRequest) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// GetIsbns gets the Isbns of the AddToCollectionRequest.
+func (m *AddToCollectionRequest) GetIsbns() (x []int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbns
+}
+
+// MarshalToWriter marshals AddToCollectionRequest to the provided writer.
+func (m *AddToCollectionRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	if len(m.Isbns) > 0 {
+		writer.WriteInt64Slice(2, m.Isbns)
+	}
+
+	return
+}
+
+// Marshal marshals AddToCollectionRequest to a slice of bytes.
+func (m *AddToCollectionRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a AddToCollectionRequest from the provided reader.
+func (m *AddToCollectionRequest) UnmarshalFromReader(reader jspb.Reader) *AddToCollectionRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &AddToCollectionRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		case 2:
+			m.Isbns = reader.ReadInt64Slice()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a AddToCollectionRequest from a slice of bytes.
+func (m *AddToCollectionRequest) Unmarshal(rawBytes []byte) (*AddToCollectionRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RemoveFromCollectionRequest is the input
+// to the RemoveFromCollection method.
+type RemoveFromCollectionRequest struct {
+	// Id is the Id of the collection.
+	Id string
+	// Isbns are the ISBNs of the books to remove.
+	Isbns []int64
+}
+
+// GetId gets the Id of the RemoveFromCollectionRequest.
+func (m *RemoveFromCollectionRequest) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// GetIsbns gets the Isbns of the RemoveFromCollectionRequest.
+func (m *RemoveFromCollectionRequest) GetIsbns() (x []int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbns
+}
+
+// MarshalToWriter marshals RemoveFromCollectionRequest to the provided writer.
+func (m *RemoveFromCollectionRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	if len(m.Isbns) > 0 {
+		writer.WriteInt64Slice(2, m.Isbns)
+	}
+
+	return
+}
+
+// Marshal marshals RemoveFromCollectionRequest to a slice of bytes.
+func (m *RemoveFromCollectionRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a RemoveFromCollectionRequest from the provided reader.
+func (m *RemoveFromCollectionRequest) UnmarshalFromReader(reader jspb.Reader) *RemoveFromCollectionRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &RemoveFromCollectionRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		case 2:
+			m.Isbns = reader.ReadInt64Slice()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a RemoveFromCollectionRequest from a slice of bytes.
+func (m *RemoveFromCollectionRequest) Unmarshal(rawBytes []byte) (*RemoveFromCollectionRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// DeleteCollectionRequest is the input to the DeleteCollection method.
+type DeleteCollectionRequest struct {
+	// Id is the Id of the collection.
+	Id string
+}
+
+// GetId gets the Id of the DeleteCollectionRequest.
+func (m *DeleteCollectionRequest) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// MarshalToWriter marshals DeleteCollectionRequest to the provided writer.
+func (m *DeleteCollectionRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	return
+}
+
+// Marshal marshals DeleteCollectionRequest to a slice of bytes.
+func (m *DeleteCollectionRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a DeleteCollectionRequest from the provided reader.
+func (m *DeleteCollectionRequest) UnmarshalFromReader(reader jspb.Reader) *DeleteCollectionRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &DeleteCollectionRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a DeleteCollectionRequest from a slice of bytes.
+func (m *DeleteCollectionRequest) Unmarshal(rawBytes []byte) (*DeleteCollectionRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Copy is a physical copy of a book, which can be borrowed.
+type Copy struct {
+	// Id identifies the copy.
+	Id string
+	// Isbn is the ISBN of the book the copy is of.
+	Isbn int64
+	// OnLoan is whether the copy is borrowed.
+	OnLoan bool
+	// Due is when the copy should be returned, if on loan.
+	Due *google_protobuf.Timestamp
+	// OnHold is whether the copy is kept for
+	// the user of a hold to pick up.
+	OnHold bool
+	// BranchId is the Id of the branch the copy is at.
+	BranchId string
+}
+
+// GetId gets the Id of the Copy.
+func (m *Copy) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// GetIsbn gets the Isbn of the Copy.
+func (m *Copy) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetOnLoan gets the OnLoan of the Copy.
+func (m *Copy) GetOnLoan() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.OnLoan
+}
+
+// GetDue gets the Due of the Copy.
+func (m *Copy) GetDue() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Due
+}
+
+// GetOnHold gets the OnHold of the Copy.
+func (m *Copy) GetOnHold() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.OnHold
+}
+
+// GetBranchId gets the BranchId of the Copy.
+func (m *Copy) GetBranchId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.BranchId
+}
+
+// MarshalToWriter marshals Copy to the provided writer.
+func (m *Copy) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(2, m.Isbn)
+	}
+
+	if m.OnLoan {
+		writer.WriteBool(3, m.OnLoan)
+	}
+
+	if m.Due != nil {
+		writer.WriteMessage(4, func() {
+			m.Due.MarshalToWriter(writer)
+		})
+	}
+
+	if m.OnHold {
+		writer.WriteBool(5, m.OnHold)
+	}
+
+	if len(m.BranchId) > 0 {
+		writer.WriteString(6, m.BranchId)
+	}
+
+	return
+}
+
+// Marshal marshals Copy to a slice of bytes.
+func (m *Copy) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Copy from the provided reader.
+func (m *Copy) UnmarshalFromReader(reader jspb.Reader) *Copy {
+	for reader.Next() {
+		if m == nil {
+			m = &Copy{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		case 2:
+			m.Isbn = reader.ReadInt64()
+		case 3:
+			m.OnLoan = reader.ReadBool()
+		case 4:
+			reader.ReadMessage(func() {
+				m.Due = m.Due.UnmarshalFromReader(reader)
+			})
+		case 5:
+			m.OnHold = reader.ReadBool()
+		case 6:
+			m.BranchId = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Copy from a slice of bytes.
+func (m *Copy) Unmarshal(rawBytes []byte) (*Copy, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Loan describes the borrowing of a Copy.
+type Loan struct {
+	// Id identifies the loan.
+	Id string
+	// CopyId is the Id of the copy borrowed.
+	CopyId string
+	// Isbn is the ISBN of the book the copy is of.
+	Isbn int64
+	// Borrower is the user who borrowed the copy.
+	Borrower string
+	// CheckedOut is when the copy was borrowed.
+	CheckedOut *google_protobuf.Timestamp
+	// Due is when the copy should be returned.
+	Due *google_protobuf.Timestamp
+	// Returned is when the copy was returned,
+	// or not set if it has not been returned.
+	Returned *google_protobuf.Timestamp
+	// Renewals is the number of times the loan has been renewed.
+	Renewals int32
+	// FineCents is the fine for returning the copy late, in
+	// cents. It is updated periodically until the copy is returned.
+	FineCents int64
+}
+
+// GetId gets the Id of the Loan.
+func (m *Loan) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// GetCopyId gets the CopyId of the Loan.
+func (m *Loan) GetCopyId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.CopyId
+}
+
+// GetIsbn gets the Isbn of the Loan.
+func (m *Loan) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetBorrower gets the Borrower of the Loan.
+func (m *Loan) GetBorrower() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Borrower
+}
+
+// GetCheckedOut gets the CheckedOut of the Loan.
+func (m *Loan) GetCheckedOut() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.CheckedOut
+}
+
+// GetDue gets the Due of the Loan.
+func (m *Loan) GetDue() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Due
+}
+
+// GetReturned gets the Returned of the Loan.
+func (m *Loan) GetReturned() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Returned
+}
+
+// GetRenewals gets the Renewals of the Loan.
+func (m *Loan) GetRenewals() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Renewals
+}
+
+// GetFineCents gets the FineCents of the Loan.
+func (m *Loan) GetFineCents() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.FineCents
+}
+
+// MarshalToWriter marshals Loan to the provided writer.
+func (m *Loan) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	if len(m.CopyId) > 0 {
+		writer.WriteString(2, m.CopyId)
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(3, m.Isbn)
+	}
+
+	if len(m.Borrower) > 0 {
+		writer.WriteString(4, m.Borrower)
+	}
+
+	if m.CheckedOut != nil {
+		writer.WriteMessage(5, func() {
+			m.CheckedOut.MarshalToWriter(writer)
+		})
+	}
+
+	if m.Due != nil {
+		writer.WriteMessage(6, func() {
+			m.Due.MarshalToWriter(writer)
+		})
+	}
+
+	if m.Returned != nil {
+		writer.WriteMessage(7, func() {
+			m.Returned.MarshalToWriter(writer)
+		})
+	}
+
+	if m.Renewals != 0 {
+		writer.WriteInt32(8, m.Renewals)
+	}
+
+	if m.FineCents != 0 {
+		writer.WriteInt64(9, m.FineCents)
+	}
+
+	return
+}
+
+// Marshal marshals Loan to a slice of bytes.
+func (m *Loan) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Loan from the provided reader.
+func (m *Loan) UnmarshalFromReader(reader jspb.Reader) *Loan {
+	for reader.Next() {
+		if m == nil {
+			m = &Loan{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		case 2:
+			m.CopyId = reader.ReadString()
+		case 3:
+			m.Isbn = reader.ReadInt64()
+		case 4:
+			m.Borrower = reader.ReadString()
+		case 5:
+			reader.ReadMessage(func() {
+				m.CheckedOut = m.CheckedOut.UnmarshalFromReader(reader)
+			})
+		case 6:
+			reader.ReadMessage(func() {
+				m.Due = m.Due.UnmarshalFromReader(reader)
+			})
+		case 7:
+			reader.ReadMessage(func() {
+				m.Returned = m.Returned.UnmarshalFromReader(reader)
+			})
+		case 8:
+			m.Renewals = reader.ReadInt32()
+		case 9:
+			m.FineCents = reader.ReadInt64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Loan from a slice of bytes.
+func (m *Loan) Unmarshal(rawBytes []byte) (*Loan, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListCopiesRequest is the input to the ListCopies method.
+type ListCopiesRequest struct {
+	// Isbn is the ISBN of the book to list the copies of.
+	Isbn int64
+}
+
+// GetIsbn gets the Isbn of the ListCopiesRequest.
+func (m *ListCopiesRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// MarshalToWriter marshals ListCopiesRequest to the provided writer.
+func (m *ListCopiesRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	return
+}
+
+// Marshal marshals ListCopiesRequest to a slice of bytes.
+func (m *ListCopiesRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListCopiesRequest from the provided reader.
+func (m *ListCopiesRequest) UnmarshalFromReader(reader jspb.Reader) *ListCopiesRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListCopiesRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListCopiesRequest from a slice of bytes.
+func (m *ListCopiesRequest) Unmarshal(rawBytes []byte) (*ListCopiesRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListCopiesResponse is the output of the ListCopies method.
+type ListCopiesResponse struct {
+	// Copies are the copies of the book.
+	Copies []*Copy
+}
+
+// GetCopies gets the Copies of the ListCopiesResponse.
+func (m *ListCopiesResponse) GetCopies() (x []*Copy) {
+	if m == nil {
+		return x
+	}
+	return m.Copies
+}
+
+// MarshalToWriter marshals ListCopiesResponse to the provided writer.
+func (m *ListCopiesResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Copies {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ListCopiesResponse to a slice of bytes.
+func (m *ListCopiesResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListCopiesResponse from the provided reader.
+func (m *ListCopiesResponse) UnmarshalFromReader(reader jspb.Reader) *ListCopiesResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ListCopiesResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Copies = append(m.Copies, new(Copy).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListCopiesResponse from a slice of bytes.
+func (m *ListCopiesResponse) Unmarshal(rawBytes []byte) (*ListCopiesResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// AddCopyRequest is the input to the AddCopy method.
+type AddCopyRequest struct {
+	// Isbn is the ISBN of the book to add a copy of.
+	Isbn int64
+	// BranchId is the Id of the branch the copy is at.
+	BranchId string
+}
+
+// GetIsbn gets the Isbn of the AddCopyRequest.
+func (m *AddCopyRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetBranchId gets the BranchId of the AddCopyRequest.
+func (m *AddCopyRequest) GetBranchId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.BranchId
+}
+
+// MarshalToWriter marshals AddCopyRequest to the provided writer.
+func (m *AddCopyRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	if len(m.BranchId) > 0 {
+		writer.WriteString(2, m.BranchId)
+	}
+
+	return
+}
+
+// Marshal marshals AddCopyRequest to a slice of bytes.
+func (m *AddCopyRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a AddCopyRequest from the provided reader.
+func (m *AddCopyRequest) UnmarshalFromReader(reader jspb.Reader) *AddCopyRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &AddCopyRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		case 2:
+			m.BranchId = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a AddCopyRequest from a slice of bytes.
+func (m *AddCopyRequest) Unmarshal(rawBytes []byte) (*AddCopyRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// CheckOutRequest is the input to the CheckOut method.
+type CheckOutRequest struct {
+	// CopyId is the Id of the copy to borrow.
+	CopyId string
+	// Isbn is the ISBN of the book to borrow any
+	// available copy of, if CopyId is not set.
+	Isbn int64
+	// BranchId, if set, only borrows a copy at the
+	// branch with this Id, if CopyId is not set.
+	BranchId string
+}
+
+// GetCopyId gets the CopyId of the CheckOutRequest.
+func (m *CheckOutRequest) GetCopyId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.CopyId
+}
+
+// GetIsbn gets the Isbn of the CheckOutRequest.
+func (m *CheckOutRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetBranchId gets the BranchId of the CheckOutRequest.
+func (m *CheckOutRequest) GetBranchId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.BranchId
+}
+
+// MarshalToWriter marshals CheckOutRequest to the provided writer.
+func (m *CheckOutRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.CopyId) > 0 {
+		writer.WriteString(1, m.CopyId)
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(2, m.Isbn)
+	}
+
+	if len(m.BranchId) > 0 {
+		writer.WriteString(3, m.BranchId)
+	}
+
+	return
+}
+
+// Marshal marshals CheckOutRequest to a slice of bytes.
+func (m *CheckOutRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a CheckOutRequest from the provided reader.
+func (m *CheckOutRequest) UnmarshalFromReader(reader jspb.Reader) *CheckOutRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &CheckOutRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.CopyId = reader.ReadString()
+		case 2:
+			m.Isbn = reader.ReadInt64()
+		case 3:
+			m.BranchId = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a CheckOutRequest from a slice of bytes.
+func (m *CheckOutRequest) Unmarshal(rawBytes []byte) (*CheckOutRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ReturnRequest is the input to the Return method.
+type ReturnRequest struct {
+	// CopyId is the Id of the copy returned.
+	CopyId string
+}
+
+// GetCopyId gets the CopyId of the ReturnRequest.
+func (m *ReturnRequest) GetCopyId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.CopyId
+}
+
+// MarshalToWriter marshals ReturnRequest to the provided writer.
+func (m *ReturnRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.CopyId) > 0 {
+		writer.WriteString(1, m.CopyId)
+	}
+
+	return
+}
+
+// Marshal marshals ReturnRequest to a slice of bytes.
+func (m *ReturnRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ReturnRequest from the provided reader.
+func (m *ReturnRequest) UnmarshalFromReader(reader jspb.Reader) *ReturnRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ReturnRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.CopyId = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ReturnRequest from a slice of bytes.
+func (m *ReturnRequest) Unmarshal(rawBytes []byte) (*ReturnRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RenewRequest is the input to the Renew method.
+type RenewRequest struct {
+	// LoanId is the Id of the loan to renew.
+	LoanId string
+}
+
+// GetLoanId gets the LoanId of the RenewRequest.
+func (m *RenewRequest) GetLoanId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.LoanId
+}
+
+// MarshalToWriter marshals RenewRequest to the provided writer.
+func (m *RenewRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.LoanId) > 0 {
+		writer.WriteString(1, m.LoanId)
+	}
+
+	return
+}
+
+// Marshal marshals RenewRequest to a slice of bytes.
+func (m *RenewRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a RenewRequest from the provided reader.
+func (m *RenewRequest) UnmarshalFromReader(reader jspb.Reader) *RenewRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &RenewRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.LoanId = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a RenewRequest from a slice of bytes.
+func (m *RenewRequest) Unmarshal(rawBytes []byte) (*RenewRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListLoansRequest is the input to the ListLoans method.
+type ListLoansRequest struct {
+	// IncludeReturned also lists the loans
+	// of copies that have been returned.
+	IncludeReturned bool
+}
+
+// GetIncludeReturned gets the IncludeReturned of the ListLoansRequest.
+func (m *ListLoansRequest) GetIncludeReturned() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.IncludeReturned
+}
+
+// MarshalToWriter marshals ListLoansRequest to the provided writer.
+func (m *ListLoansRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.IncludeReturned {
+		writer.WriteBool(1, m.IncludeReturned)
+	}
+
+	return
+}
+
+// Marshal marshals ListLoansRequest to a slice of bytes.
+func (m *ListLoansRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListLoansRequest from the provided reader.
+func (m *ListLoansRequest) UnmarshalFromReader(reader jspb.Reader) *ListLoansRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListLoansRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.IncludeReturned = reader.ReadBool()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListLoansRequest from a slice of bytes.
+func (m *ListLoansRequest) Unmarshal(rawBytes []byte) (*ListLoansRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListLoansResponse is the output of the ListLoans method.
+type ListLoansResponse struct {
+	// Loans are the loans of the user,
+	// in the order they were made.
+	Loans []*Loan
+}
+
+// GetLoans gets the Loans of the ListLoansResponse.
+func (m *ListLoansResponse) GetLoans() (x []*Loan) {
+	if m == nil {
+		return x
+	}
+	return m.Loans
+}
+
+// MarshalToWriter marshals ListLoansResponse to the provided writer.
+func (m *ListLoansResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Loans {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ListLoansResponse to a slice of bytes.
+func (m *ListLoansResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListLoansResponse from the provided reader.
+func (m *ListLoansResponse) UnmarshalFromReader(reader jspb.Reader) *ListLoansResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ListLoansResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Loans = append(m.Loans, new(Loan).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListLoansResponse from a slice of bytes.
+func (m *ListLoansResponse) Unmarshal(rawBytes []byte) (*ListLoansResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Hold is the place of a user in the queue for a Book.
+type Hold struct {
+	// Id identifies the hold.
+	Id string
+	// Isbn is the ISBN of the book held.
+	Isbn int64
+	// User is the user who placed the hold.
+	User  string
+	State Hold_State
+	// Placed is when the hold was placed.
+	Placed *google_protobuf.Timestamp
+	// Position is the place of a waiting hold in the
+	// queue for the book, from 1, when it was sent.
+	Position int32
+	// CopyId is the Id of the copy kept for a ready hold.
+	CopyId string
+	// ReadyUntil is when the copy kept
+	// for a ready hold is released.
+	ReadyUntil *google_protobuf.Timestamp
+}
+
+// GetId gets the Id of the Hold.
+func (m *Hold) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// GetIsbn gets the Isbn of the Hold.
+func (m *Hold) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetUser gets the User of the Hold.
+func (m *Hold) GetUser() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.User
+}
+
+// GetState gets the State of the Hold.
+func (m *Hold) GetState() (x Hold_State) {
+	if m == nil {
+		return x
+	}
+	return m.State
+}
+
+// GetPlaced gets the Placed of the Hold.
+func (m *Hold) GetPlaced() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Placed
+}
+
+// GetPosition gets the Position of the Hold.
+func (m *Hold) GetPosition() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Position
+}
+
+// GetCopyId gets the CopyId of the Hold.
+func (m *Hold) GetCopyId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.CopyId
+}
+
+// GetReadyUntil gets the ReadyUntil of the Hold.
+func (m *Hold) GetReadyUntil() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.ReadyUntil
+}
+
+// MarshalToWriter marshals Hold to the provided writer.
+func (m *Hold) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(2, m.Isbn)
+	}
+
+	if len(m.User) > 0 {
+		writer.WriteString(3, m.User)
+	}
+
+	if int(m.State) != 0 {
+		writer.WriteEnum(4, int(m.State))
+	}
+
+	if m.Placed != nil {
+		writer.WriteMessage(5, func() {
+			m.Placed.MarshalToWriter(writer)
+		})
+	}
+
+	if m.Position != 0 {
+		writer.WriteInt32(6, m.Position)
+	}
+
+	if len(m.CopyId) > 0 {
+		writer.WriteString(7, m.CopyId)
+	}
+
+	if m.ReadyUntil != nil {
+		writer.WriteMessage(8, func() {
+			m.ReadyUntil.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals Hold to a slice of bytes.
+func (m *Hold) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Hold from the provided reader.
+func (m *Hold) UnmarshalFromReader(reader jspb.Reader) *Hold {
+	for reader.Next() {
+		if m == nil {
+			m = &Hold{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		case 2:
+			m.Isbn = reader.ReadInt64()
+		case 3:
+			m.User = reader.ReadString()
+		case 4:
+			m.State = Hold_State(reader.ReadEnum())
+		case 5:
+			reader.ReadMessage(func() {
+				m.Placed = m.Placed.UnmarshalFromReader(reader)
+			})
+		case 6:
+			m.Position = reader.ReadInt32()
+		case 7:
+			m.CopyId = reader.ReadString()
+		case 8:
+			reader.ReadMessage(func() {
+				m.ReadyUntil = m.ReadyUntil.UnmarshalFromReader(reader)
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Hold from a slice of bytes.
+func (m *Hold) Unmarshal(rawBytes []byte) (*Hold, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// PlaceHoldRequest is the input to the PlaceHold method.
+type PlaceHoldRequest struct {
+	// Isbn is the ISBN of the book to hold.
+	Isbn int64
+}
+
+// GetIsbn gets the Isbn of the PlaceHoldRequest.
+func (m *PlaceHoldRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// MarshalToWriter marshals PlaceHoldRequest to the provided writer.
+func (m *PlaceHoldRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	return
+}
+
+// Marshal marshals PlaceHoldRequest to a slice of bytes.
+func (m *PlaceHoldRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a PlaceHoldRequest from the provided reader.
+func (m *PlaceHoldRequest) UnmarshalFromReader(reader jspb.Reader) *PlaceHoldRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &PlaceHoldRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a PlaceHoldRequest from a slice of bytes.
+func (m *PlaceHoldRequest) Unmarshal(rawBytes []byte) (*PlaceHoldRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// CancelHoldRequest is the input to the CancelHold method.
+type CancelHoldRequest struct {
+	// HoldId is the Id of the hold to cancel.
+	HoldId string
+}
+
+// GetHoldId gets the HoldId of the CancelHoldRequest.
+func (m *CancelHoldRequest) GetHoldId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.HoldId
+}
+
+// MarshalToWriter marshals CancelHoldRequest to the provided writer.
+func (m *CancelHoldRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.HoldId) > 0 {
+		writer.WriteString(1, m.HoldId)
+	}
+
+	return
+}
+
+// Marshal marshals CancelHoldRequest to a slice of bytes.
+func (m *CancelHoldRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a CancelHoldRequest from the provided reader.
+func (m *CancelHoldRequest) UnmarshalFromReader(reader jspb.Reader) *CancelHoldRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &CancelHoldRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.HoldId = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a CancelHoldRequest from a slice of bytes.
+func (m *CancelHoldRequest) Unmarshal(rawBytes []byte) (*CancelHoldRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListHoldsRequest is the input to the ListHolds method.
+type ListHoldsRequest struct {
+	// IncludeEnded also lists the holds which
+	// were fulfilled, cancelled or expired.
+	IncludeEnded bool
+}
+
+// GetIncludeEnded gets the IncludeEnded of the ListHoldsRequest.
+func (m *ListHoldsRequest) GetIncludeEnded() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.IncludeEnded
+}
+
+// MarshalToWriter marshals ListHoldsRequest to the provided writer.
+func (m *ListHoldsRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.IncludeEnded {
+		writer.WriteBool(1, m.IncludeEnded)
+	}
+
+	return
+}
+
+// Marshal marshals ListHoldsRequest to a slice of bytes.
+func (m *ListHoldsRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListHoldsRequest from the provided reader.
+func (m *ListHoldsRequest) UnmarshalFromReader(reader jspb.Reader) *ListHoldsRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListHoldsRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.IncludeEnded = reader.ReadBool()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListHoldsRequest from a slice of bytes.
+func (m *ListHoldsRequest) Unmarshal(rawBytes []byte) (*ListHoldsRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListHoldsResponse is the output of the ListHolds method.
+type ListHoldsResponse struct {
+	// Holds are the holds of the user,
+	// in the order they were placed.
+	Holds []*Hold
+}
+
+// GetHolds gets the Holds of the ListHoldsResponse.
+func (m *ListHoldsResponse) GetHolds() (x []*Hold) {
+	if m == nil {
+		return x
+	}
+	return m.Holds
+}
+
+// MarshalToWriter marshals ListHoldsResponse to the provided writer.
+func (m *ListHoldsResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Holds {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ListHoldsResponse to a slice of bytes.
+func (m *ListHoldsResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListHoldsResponse from the provided reader.
+func (m *ListHoldsResponse) UnmarshalFromReader(reader jspb.Reader) *ListHoldsResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ListHoldsResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Holds = append(m.Holds, new(Hold).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListHoldsResponse from a slice of bytes.
+func (m *ListHoldsResponse) Unmarshal(rawBytes []byte) (*ListHoldsResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// WatchMyHoldsRequest is the input to the WatchMyHolds method.
+type WatchMyHoldsRequest struct {
+}
+
+// MarshalToWriter marshals WatchMyHoldsRequest to the provided writer.
+func (m *WatchMyHoldsRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	return
+}
+
+// Marshal marshals WatchMyHoldsRequest to a slice of bytes.
+func (m *WatchMyHoldsRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a WatchMyHoldsRequest from the provided reader.
+func (m *WatchMyHoldsRequest) UnmarshalFromReader(reader jspb.Reader) *WatchMyHoldsRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &WatchMyHoldsRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a WatchMyHoldsRequest from a slice of bytes.
+func (m *WatchMyHoldsRequest) Unmarshal(rawBytes []byte) (*WatchMyHoldsRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Job is the status of a background job of the server.
+type Job struct {
+	// Name identifies the job.
+	Name string
+	// IntervalSeconds is the time between runs of the job.
+	IntervalSeconds int64
+	// Runs is the number of times the job has run.
+	Runs int64
+	// LastRun is when the job last started running.
+	LastRun *google_protobuf.Timestamp
+	// LastResult describes the outcome of the last run.
+	LastResult string
+	// LastError is the error of the last run, if it failed.
+	LastError string
+	// NextRun is when the job runs next.
+	NextRun *google_protobuf.Timestamp
+}
+
+// GetName gets the Name of the Job.
+func (m *Job) GetName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Name
+}
+
+// GetIntervalSeconds gets the IntervalSeconds of the Job.
+func (m *Job) GetIntervalSeconds() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.IntervalSeconds
+}
+
+// GetRuns gets the Runs of the Job.
+func (m *Job) GetRuns() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Runs
+}
+
+// GetLastRun gets the LastRun of the Job.
+func (m *Job) GetLastRun() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.LastRun
+}
+
+// GetLastResult gets the LastResult of the Job.
+func (m *Job) GetLastResult() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.LastResult
+}
+
+// GetLastError gets the LastError of the Job.
+func (m *Job) GetLastError() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.LastError
+}
+
+// GetNextRun gets the NextRun of the Job.
+func (m *Job) GetNextRun() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.NextRun
+}
+
+// MarshalToWriter marshals Job to the provided writer.
+func (m *Job) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Name) > 0 {
+		writer.WriteString(1, m.Name)
+	}
+
+	if m.IntervalSeconds != 0 {
+		writer.WriteInt64(2, m.IntervalSeconds)
+	}
+
+	if m.Runs != 0 {
+		writer.WriteInt64(3, m.Runs)
+	}
+
+	if m.LastRun != nil {
+		writer.WriteMessage(4, func() {
+			m.LastRun.MarshalToWriter(writer)
+		})
+	}
+
+	if len(m.LastResult) > 0 {
+		writer.WriteString(5, m.LastResult)
+	}
+
+	if len(m.LastError) > 0 {
+		writer.WriteString(6, m.LastError)
+	}
+
+	if m.NextRun != nil {
+		writer.WriteMessage(7, func() {
+			m.NextRun.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals Job to a slice of bytes.
+func (m *Job) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Job from the provided reader.
+func (m *Job) UnmarshalFromReader(reader jspb.Reader) *Job {
+	for reader.Next() {
+		if m == nil {
+			m = &Job{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Name = reader.ReadString()
+		case 2:
+			m.IntervalSeconds = reader.ReadInt64()
+		case 3:
+			m.Runs = reader.ReadInt64()
+		case 4:
+			reader.ReadMessage(func() {
+				m.LastRun = m.LastRun.UnmarshalFromReader(reader)
+			})
+		case 5:
+			m.LastResult = reader.ReadString()
+		case 6:
+			m.LastError = reader.ReadString()
+		case 7:
+			reader.ReadMessage(func() {
+				m.NextRun = m.NextRun.UnmarshalFromReader(reader)
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Job from a slice of bytes.
+func (m *Job) Unmarshal(rawBytes []byte) (*Job, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListBranchesRequest is the input to the ListBranches method.
+type ListBranchesRequest struct {
+}
+
+// MarshalToWriter marshals ListBranchesRequest to the provided writer.
+func (m *ListBranchesRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	return
+}
+
+// Marshal marshals ListBranchesRequest to a slice of bytes.
+func (m *ListBranchesRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListBranchesRequest from the provided reader.
+func (m *ListBranchesRequest) UnmarshalFromReader(reader jspb.Reader) *ListBranchesRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListBranchesRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListBranchesRequest from a slice of bytes.
+func (m *ListBranchesRequest) Unmarshal(rawBytes []byte) (*ListBranchesRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListBranchesResponse is the output of the ListBranches method.
+type ListBranchesResponse struct {
+	// Branches are the branches, ordered by Id.
+	Branches []*Branch
+}
+
+// GetBranches gets the Branches of the ListBranchesResponse.
+func (m *ListBranchesResponse) GetBranches() (x []*Branch) {
+	if m == nil {
+		return x
+	}
+	return m.Branches
+}
+
+// MarshalToWriter marshals ListBranchesResponse to the provided writer.
+func (m *ListBranchesResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Branches {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ListBranchesResponse to a slice of bytes.
+func (m *ListBranchesResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListBranchesResponse from the provided reader.
+func (m *ListBranchesResponse) UnmarshalFromReader(reader jspb.Reader) *ListBranchesResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ListBranchesResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Branches = append(m.Branches, new(Branch).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListBranchesResponse from a slice of bytes.
+func (m *ListBranchesResponse) Unmarshal(rawBytes []byte) (*ListBranchesResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListJobsRequest is the input to the ListJobs method.
+type ListJobsRequest struct {
+}
+
+// MarshalToWriter marshals ListJobsRequest to the provided writer.
+func (m *ListJobsRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	return
+}
+
+// Marshal marshals ListJobsRequest to a slice of bytes.
+func (m *ListJobsRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListJobsRequest from the provided reader.
+func (m *ListJobsRequest) UnmarshalFromReader(reader jspb.Reader) *ListJobsRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListJobsRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListJobsRequest from a slice of bytes.
+func (m *ListJobsRequest) Unmarshal(rawBytes []byte) (*ListJobsRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListJobsResponse is the output of the ListJobs method.
+type ListJobsResponse struct {
+	// Running is whether the jobs are being run.
+	Running bool
+	// Jobs are the background jobs, ordered by name.
+	Jobs []*Job
+}
+
+// GetRunning gets the Running of the ListJobsResponse.
+func (m *ListJobsResponse) GetRunning() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.Running
+}
+
+// GetJobs gets the Jobs of the ListJobsResponse.
+func (m *ListJobsResponse) GetJobs() (x []*Job) {
+	if m == nil {
+		return x
+	}
+	return m.Jobs
+}
+
+// MarshalToWriter marshals ListJobsResponse to the provided writer.
+func (m *ListJobsResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Running {
+		writer.WriteBool(1, m.Running)
+	}
+
+	for _, msg := range m.Jobs {
+		writer.WriteMessage(2, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ListJobsResponse to a slice of bytes.
+func (m *ListJobsResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListJobsResponse from the provided reader.
+func (m *ListJobsResponse) UnmarshalFromReader(reader jspb.Reader) *ListJobsResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ListJobsResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Running = reader.ReadBool()
+		case 2:
+			reader.ReadMessage(func() {
+				m.Jobs = append(m.Jobs, new(Job).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListJobsResponse from a slice of bytes.
+func (m *ListJobsResponse) Unmarshal(rawBytes []byte) (*ListJobsResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Review is the rating and opinion of a user about a Book.
+type Review struct {
+	// Id identifies the review.
+	Id string
+	// Isbn is the ISBN of the book reviewed.
+	Isbn int64
+	// User is the user who wrote the review.
+	User string
+	// Rating is the rating of the book, from 1 to 5.
+	Rating int32
+	// Text is the opinion of the user, which may be empty.
+	Text string
+	// Created is when the review was submitted.
+	Created *google_protobuf.Timestamp
+	// Updated is when the review was last edited,
+	// or the time it was created if it was not.
+	Updated *google_protobuf.Timestamp
+	// HelpfulVotes is the number of users who
+	// found the review helpful.
+	HelpfulVotes int32
+}
+
+// GetId gets the Id of the Review.
+func (m *Review) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// GetIsbn gets the Isbn of the Review.
+func (m *Review) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetUser gets the User of the Review.
+func (m *Review) GetUser() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.User
+}
+
+// GetRating gets the Rating of the Review.
+func (m *Review) GetRating() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Rating
+}
+
+// GetText gets the Text of the Review.
+func (m *Review) GetText() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Text
+}
+
+// GetCreated gets the Created of the Review.
+func (m *Review) GetCreated() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Created
+}
+
+// GetUpdated gets the Updated of the Review.
+func (m *Review) GetUpdated() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Updated
+}
+
+// GetHelpfulVotes gets the HelpfulVotes of the Review.
+func (m *Review) GetHelpfulVotes() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.HelpfulVotes
+}
+
+// MarshalToWriter marshals Review to the provided writer.
+func (m *Review) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(2, m.Isbn)
+	}
+
+	if len(m.User) > 0 {
+		writer.WriteString(3, m.User)
+	}
+
+	if m.Rating != 0 {
+		writer.WriteInt32(4, m.Rating)
+	}
+
+	if len(m.Text) > 0 {
+		writer.WriteString(5, m.Text)
+	}
+
+	if m.Created != nil {
+		writer.WriteMessage(6, func() {
+			m.Created.MarshalToWriter(writer)
+		})
+	}
+
+	if m.Updated != nil {
+		writer.WriteMessage(7, func() {
+			m.Updated.MarshalToWriter(writer)
+		})
+	}
+
+	if m.HelpfulVotes != 0 {
+		writer.WriteInt32(8, m.HelpfulVotes)
+	}
+
+	return
+}
+
+// Marshal marshals Review to a slice of bytes.
+func (m *Review) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Review from the provided reader.
+func (m *Review) UnmarshalFromReader(reader jspb.Reader) *Review {
+	for reader.Next() {
+		if m == nil {
+			m = &Review{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		case 2:
+			m.Isbn = reader.ReadInt64()
+		case 3:
+			m.User = reader.ReadString()
+		case 4:
+			m.Rating = reader.ReadInt32()
+		case 5:
+			m.Text = reader.ReadString()
+		case 6:
+			reader.ReadMessage(func() {
+				m.Created = m.Created.UnmarshalFromReader(reader)
+			})
+		case 7:
+			reader.ReadMessage(func() {
+				m.Updated = m.Updated.UnmarshalFromReader(reader)
+			})
+		case 8:
+			m.HelpfulVotes = reader.ReadInt32()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Review from a slice of bytes.
+func (m *Review) Unmarshal(rawBytes []byte) (*Review, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SubmitReviewRequest is the input to the SubmitReview method.
+type SubmitReviewRequest struct {
+	// Isbn is the ISBN of the book to review.
+	Isbn int64
+	// Rating is the rating of the book, from 1 to 5.
+	Rating int32
+	Text   string
+}
+
+// GetIsbn gets the Isbn of the SubmitReviewRequest.
+func (m *SubmitReviewRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetRating gets the Rating of the SubmitReviewRequest.
+func (m *SubmitReviewRequest) GetRating() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Rating
+}
+
+// GetText gets the Text of the SubmitReviewRequest.
+func (m *SubmitReviewRequest) GetText() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Text
+}
+
+// MarshalToWriter marshals SubmitReviewRequest to the provided writer.
+func (m *SubmitReviewRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	if m.Rating != 0 {
+		writer.WriteInt32(2, m.Rating)
+	}
+
+	if len(m.Text) > 0 {
+		writer.WriteString(3, m.Text)
+	}
+
+	return
+}
+
+// Marshal marshals SubmitReviewRequest to a slice of bytes.
+func (m *SubmitReviewRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a SubmitReviewRequest from the provided reader.
+func (m *SubmitReviewRequest) UnmarshalFromReader(reader jspb.Reader) *SubmitReviewRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &SubmitReviewRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		case 2:
+			m.Rating = reader.ReadInt32()
+		case 3:
+			m.Text = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a SubmitReviewRequest from a slice of bytes.
+func (m *SubmitReviewRequest) Unmarshal(rawBytes []byte) (*SubmitReviewRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// UpdateReviewRequest is the input to the UpdateReview method.
+type UpdateReviewRequest struct {
+	// ReviewId is the Id of the review to edit.
+	ReviewId string
+	// Rating is the new rating of the book, from 1 to 5.
+	Rating int32
+	// Text is the new text of the review.
+	Text string
+}
+
+// GetReviewId gets the ReviewId of the UpdateReviewRequest.
+func (m *UpdateReviewRequest) GetReviewId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.ReviewId
+}
+
+// GetRating gets the Rating of the UpdateReviewRequest.
+func (m *UpdateReviewRequest) GetRating() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Rating
+}
+
+// GetText gets the Text of the UpdateReviewRequest.
+func (m *UpdateReviewRequest) GetText() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Text
+}
+
+// MarshalToWriter marshals UpdateReviewRequest to the provided writer.
+func (m *UpdateReviewRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.ReviewId) > 0 {
+		writer.WriteString(1, m.ReviewId)
+	}
+
+	if m.Rating != 0 {
+		writer.WriteInt32(2, m.Rating)
+	}
+
+	if len(m.Text) > 0 {
+		writer.WriteString(3, m.Text)
+	}
+
+	return
+}
+
+// Marshal marshals UpdateReviewRequest to a slice of bytes.
+func (m *UpdateReviewRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a UpdateReviewRequest from the provided reader.
+func (m *UpdateReviewRequest) UnmarshalFromReader(reader jspb.Reader) *UpdateReviewRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &UpdateReviewRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.ReviewId = reader.ReadString()
+		case 2:
+			m.Rating = reader.ReadInt32()
+		case 3:
+			m.Text = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a UpdateReviewRequest from a slice of bytes.
+func (m *UpdateReviewRequest) Unmarshal(rawBytes []byte) (*UpdateReviewRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// DeleteReviewRequest is the input to the DeleteReview method.
+type DeleteReviewRequest struct {
+	// ReviewId is the Id of the review to delete.
+	ReviewId string
+}
+
+// GetReviewId gets the ReviewId of the DeleteReviewRequest.
+func (m *DeleteReviewRequest) GetReviewId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.ReviewId
+}
+
+// MarshalToWriter marshals DeleteReviewRequest to the provided writer.
+func (m *DeleteReviewRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.ReviewId) > 0 {
+		writer.WriteString(1, m.ReviewId)
+	}
+
+	return
+}
+
+// Marshal marshals DeleteReviewRequest to a slice of bytes.
+func (m *DeleteReviewRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a DeleteReviewRequest from the provided reader.
+func (m *DeleteReviewRequest) UnmarshalFromReader(reader jspb.Reader) *DeleteReviewRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &DeleteReviewRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.ReviewId = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a DeleteReviewRequest from a slice of bytes.
+func (m *DeleteReviewRequest) Unmarshal(rawBytes []byte) (*DeleteReviewRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// MarkReviewHelpfulRequest is the input to the MarkReviewHelpful method.
+type MarkReviewHelpfulRequest struct {
+	// ReviewId is the Id of the review found helpful.
+	ReviewId string
+}
+
+// GetReviewId gets the ReviewId of the MarkReviewHelpfulRequest.
+func (m *MarkReviewHelpfulRequest) GetReviewId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.ReviewId
+}
+
+// MarshalToWriter marshals MarkReviewHelpfulRequest to the provided writer.
+func (m *MarkReviewHelpfulRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.ReviewId) > 0 {
+		writer.WriteString(1, m.ReviewId)
+	}
+
+	return
+}
+
+// Marshal marshals MarkReviewHelpfulRequest to a slice of bytes.
+func (m *MarkReviewHelpfulRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a MarkReviewHelpfulRequest from the provided reader.
+func (m *MarkReviewHelpfulRequest) UnmarshalFromReader(reader jspb.Reader) *MarkReviewHelpfulRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &MarkReviewHelpfulRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.ReviewId = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a MarkReviewHelpfulRequest from a slice of bytes.
+func (m *MarkReviewHelpfulRequest) Unmarshal(rawBytes []byte) (*MarkReviewHelpfulRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListReviewsRequest is the input to the ListReviews method.
+type ListReviewsRequest struct {
+	// Isbn is the ISBN of the book to list the reviews of.
+	Isbn  int64
+	Order ReviewOrder
+	// PageSize is the largest number of reviews returned. If
+	// 0, 10 reviews are returned. At most 50 are returned.
+	PageSize int32
+	// PageToken is the NextPageToken of the previous
+	// page, if not listing the first page. The order
+	// must be the same as for the previous page.
+	PageToken string
+}
+
+// GetIsbn gets the Isbn of the ListReviewsRequest.
+func (m *ListReviewsRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetOrder gets the Order of the ListReviewsRequest.
+func (m *ListReviewsRequest) GetOrder() (x ReviewOrder) {
+	if m == nil {
+		return x
+	}
+	return m.Order
+}
+
+// GetPageSize gets the PageSize of the ListReviewsRequest.
+func (m *ListReviewsRequest) GetPageSize() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.PageSize
+}
+
+// GetPageToken gets the PageToken of the ListReviewsRequest.
+func (m *ListReviewsRequest) GetPageToken() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.PageToken
+}
+
+// MarshalToWriter marshals ListReviewsRequest to the provided writer.
+func (m *ListReviewsRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	if int(m.Order) != 0 {
+		writer.WriteEnum(2, int(m.Order))
+	}
+
+	if m.PageSize != 0 {
+		writer.WriteInt32(3, m.PageSize)
+	}
+
+	if len(m.PageToken) > 0 {
+		writer.WriteString(4, m.PageToken)
+	}
+
+	return
+}
+
+// Marshal marshals ListReviewsRequest to a slice of bytes.
+func (m *ListReviewsRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListReviewsRequest from the provided reader.
+func (m *ListReviewsRequest) UnmarshalFromReader(reader jspb.Reader) *ListReviewsRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListReviewsRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		case 2:
+			m.Order = ReviewOrder(reader.ReadEnum())
+		case 3:
+			m.PageSize = reader.ReadInt32()
+		case 4:
+			m.PageToken = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListReviewsRequest from a slice of bytes.
+func (m *ListReviewsRequest) Unmarshal(rawBytes []byte) (*ListReviewsRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RecommendBooksRequest is the input to the RecommendBooks method.
+type RecommendBooksRequest struct {
+	// Isbn is the ISBN of the book to recommend books like.
+	Isbn int64
+	// Limit is the largest number of books recommended. If
+	// 0, 5 books are recommended. At most 20 are recommended.
+	Limit int32
+}
+
+// GetIsbn gets the Isbn of the RecommendBooksRequest.
+func (m *RecommendBooksRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetLimit gets the Limit of the RecommendBooksRequest.
+func (m *RecommendBooksRequest) GetLimit() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Limit
+}
+
+// MarshalToWriter marshals RecommendBooksRequest to the provided writer.
+func (m *RecommendBooksRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	if m.Limit != 0 {
+		writer.WriteInt32(2, m.Limit)
+	}
+
+	return
+}
+
+// Marshal marshals RecommendBooksRequest to a slice of bytes.
+func (m *RecommendBooksRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a RecommendBooksRequest from the provided reader.
+func (m *RecommendBooksRequest) UnmarshalFromReader(reader jspb.Reader) *RecommendBooksRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &RecommendBooksRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		case 2:
+			m.Limit = reader.ReadInt32()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a RecommendBooksRequest from a slice of bytes.
+func (m *RecommendBooksRequest) Unmarshal(rawBytes []byte) (*RecommendBooksRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Recommendation is a Book recommended to the
+// readers of the Book of a RecommendBooksRequest.
+type Recommendation struct {
+	Book   *Book
+	Reason Recommendation_Reason
+	// Score is the similarity of the readers of the books,
+	// from 0 to 1, if the Reason is SIMILAR_READERS.
+	Score float64
+}
+
+// GetBook gets the Book of the Recommendation.
+func (m *Recommendation) GetBook() (x *Book) {
+	if m == nil {
+		return x
+	}
+	return m.Book
+}
+
+// GetReason gets the Reason of the Recommendation.
+func (m *Recommendation) GetReason() (x Recommendation_Reason) {
+	if m == nil {
+		return x
+	}
+	return m.Reason
+}
+
+// GetScore gets the Score of the Recommendation.
+func (m *Recommendation) GetScore() (x float64) {
+	if m == nil {
+		return x
+	}
+	return m.Score
+}
+
+// MarshalToWriter marshals Recommendation to the provided writer.
+func (m *Recommendation) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Book != nil {
+		writer.WriteMessage(1, func() {
+			m.Book.MarshalToWriter(writer)
+		})
+	}
+
+	if int(m.Reason) != 0 {
+		writer.WriteEnum(2, int(m.Reason))
+	}
+
+	if m.Score != 0 {
+		writer.WriteFloat64(3, m.Score)
+	}
+
+	return
+}
+
+// Marshal marshals Recommendation to a slice of bytes.
+func (m *Recommendation) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Recommendation from the provided reader.
+func (m *Recommendation) UnmarshalFromReader(reader jspb.Reader) *Recommendation {
+	for reader.Next() {
+		if m == nil {
+			m = &Recommendation{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Book = m.Book.UnmarshalFromReader(reader)
+			})
+		case 2:
+			m.Reason = Recommendation_Reason(reader.ReadEnum())
+		case 3:
+			m.Score = reader.ReadFloat64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Recommendation from a slice of bytes.
+func (m *Recommendation) Unmarshal(rawBytes []byte) (*Recommendation, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RecommendBooksResponse is the output of the RecommendBooks method.
+type RecommendBooksResponse struct {
+	// Recommendations are the books recommended, those
+	// of SIMILAR_READERS first, ordered by Score.
+	Recommendations []*Recommendation
+	// ModelBuilt is when the similarity of books was last
+	// computed, or unset if it has not been computed yet.
+	ModelBuilt *google_protobuf.Timestamp
+}
+
+// GetRecommendations gets the Recommendations of the RecommendBooksResponse.
+func (m *RecommendBooksResponse) GetRecommendations() (x []*Recommendation) {
+	if m == nil {
+		return x
+	}
+	return m.Recommendations
+}
+
+// GetModelBuilt gets the ModelBuilt of the RecommendBooksResponse.
+func (m *RecommendBooksResponse) GetModelBuilt() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.ModelBuilt
+}
+
+// MarshalToWriter marshals RecommendBooksResponse to the provided writer.
+func (m *RecommendBooksResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Recommendations {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	if m.ModelBuilt != nil {
+		writer.WriteMessage(2, func() {
+			m.ModelBuilt.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals RecommendBooksResponse to a slice of bytes.
+func (m *RecommendBooksResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a RecommendBooksResponse from the provided reader.
+func (m *RecommendBooksResponse) UnmarshalFromReader(reader jspb.Reader) *RecommendBooksResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &RecommendBooksResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Recommendations = append(m.Recommendations, new(Recommendation).UnmarshalFromReader(reader))
+			})
+		case 2:
+			reader.ReadMessage(func() {
+				m.ModelBuilt = m.ModelBuilt.UnmarshalFromReader(reader)
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a RecommendBooksResponse from a slice of bytes.
+func (m *RecommendBooksResponse) Unmarshal(rawBytes []byte) (*RecommendBooksResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ReadingEntry is a Book on the reading lists of a user,
+// with how far they got.
+type ReadingEntry struct {
+	// Isbn is the ISBN of the book.
+	Isbn int64
+	// User is the user reading the book.
+	User  string
+	Shelf ReadingShelf
+	// Progress is how far the user got, in the pages of
+	// books or the playback position of audiobooks.
+	//
+	// Types that are valid to be assigned to Progress:
+	//	*ReadingEntry_Page
+	//	*ReadingEntry_PositionSeconds
+	Progress isReadingEntry_Progress
+	// Started is when the book was first moved to READING or
+	// FINISHED, and Finished when it was moved to FINISHED,
+	// if it is on that list.
+	Started  *google_protobuf.Timestamp
+	Finished *google_protobuf.Timestamp
+	// UpdatedAt is when the entry was last changed, on the
+	// device of the user. Changes older than it are ignored.
+	UpdatedAt *google_protobuf.Timestamp
+	// Removed is set for books removed from the reading lists,
+	// so that devices syncing with AfterSequence remove them too.
+	Removed bool
+	// Sequence is the number the server gave the last change of
+	// the entry. Later changes have larger numbers, whatever the
+	// clocks of the devices making them say.
+	Sequence int64
+}
+
+// isReadingEntry_Progress is used to distinguish types assignable to Progress
+type isReadingEntry_Progress interface{ isReadingEntry_Progress() }
+
+// ReadingEntry_Page is assignable to Progress
+type ReadingEntry_Page struct {
+	Page int32
+}
+
+// ReadingEntry_PositionSeconds is assignable to Progress
+type ReadingEntry_PositionSeconds struct {
+	PositionSeconds int32
+}
+
+func (*ReadingEntry_Page) isReadingEntry_Progress()            {}
+func (*ReadingEntry_PositionSeconds) isReadingEntry_Progress() {}
+
+// GetProgress gets the Progress of the ReadingEntry.
+func (m *ReadingEntry) GetProgress() (x isReadingEntry_Progress) {
+	if m == nil {
+		return x
+	}
+	return m.Progress
+}
+
+// GetIsbn gets the Isbn of the ReadingEntry.
+func (m *ReadingEntry) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetUser gets the User of the ReadingEntry.
+func (m *ReadingEntry) GetUser() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.User
+}
+
+// GetShelf gets the Shelf of the ReadingEntry.
+func (m *ReadingEntry) GetShelf() (x ReadingShelf) {
+	if m == nil {
+		return x
+	}
+	return m.Shelf
+}
+
+// GetPage gets the Page of the ReadingEntry.
+func (m *ReadingEntry) GetPage() (x int32) {
+	if v, ok := m.GetProgress().(*ReadingEntry_Page); ok {
+		return v.Page
+	}
+	return x
+}
+
+// GetPositionSeconds gets the PositionSeconds of the ReadingEntry.
+func (m *ReadingEntry) GetPositionSeconds() (x int32) {
+	if v, ok := m.GetProgress().(*ReadingEntry_PositionSeconds); ok {
+		return v.PositionSeconds
+	}
+	return x
+}
+
+// GetStarted gets the Started of the ReadingEntry.
+func (m *ReadingEntry) GetStarted() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Started
+}
+
+// GetFinished gets the Finished of the ReadingEntry.
+func (m *ReadingEntry) GetFinished() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Finished
+}
+
+// GetUpdatedAt gets the UpdatedAt of the ReadingEntry.
+func (m *ReadingEntry) GetUpdatedAt() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.UpdatedAt
+}
+
+// GetRemoved gets the Removed of the ReadingEntry.
+func (m *ReadingEntry) GetRemoved() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.Removed
+}
+
+// GetSequence gets the Sequence of the ReadingEntry.
+func (m *ReadingEntry) GetSequence() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Sequence
+}
+
+// MarshalToWriter marshals ReadingEntry to the provided writer.
+func (m *ReadingEntry) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	switch t := m.Progress.(type) {
+	case *ReadingEntry_Page:
+		if t.Page != 0 {
+			writer.WriteInt32(4, t.Page)
+		}
+	case *ReadingEntry_PositionSeconds:
+		if t.PositionSeconds != 0 {
+			writer.WriteInt32(5, t.PositionSeconds)
+		}
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	if len(m.User) > 0 {
+		writer.WriteString(2, m.User)
+	}
+
+	if int(m.Shelf) != 0 {
+		writer.WriteEnum(3, int(m.Shelf))
+	}
+
+	if m.Started != nil {
+		writer.WriteMessage(6, func() {
+			m.Started.MarshalToWriter(writer)
+		})
+	}
+
+	if m.Finished != nil {
+		writer.WriteMessage(7, func() {
+			m.Finished.MarshalToWriter(writer)
+		})
+	}
+
+	if m.UpdatedAt != nil {
+		writer.WriteMessage(8, func() {
+			m.UpdatedAt.MarshalToWriter(writer)
+		})
+	}
+
+	if m.Removed {
+		writer.WriteBool(9, m.Removed)
+	}
+
+	if m.Sequence != 0 {
+		writer.WriteInt64(10, m.Sequence)
+	}
+
+	return
+}
+
+// Marshal marshals ReadingEntry to a slice of bytes.
+func (m *ReadingEntry) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ReadingEntry from the provided reader.
+func (m *ReadingEntry) UnmarshalFromReader(reader jspb.Reader) *ReadingEntry {
+	for reader.Next() {
+		if m == nil {
+			m = &ReadingEntry{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		case 2:
+			m.User = reader.ReadString()
+		case 3:
+			m.Shelf = ReadingShelf(reader.ReadEnum())
+		case 4:
+			m.Progress = &ReadingEntry_Page{
+				Page: reader.ReadInt32(),
+			}
+		case 5:
+			m.Progress = &ReadingEntry_PositionSeconds{
+				PositionSeconds: reader.ReadInt32(),
+			}
+		case 6:
+			reader.ReadMessage(func() {
+				m.Started = m.Started.UnmarshalFromReader(reader)
+			})
+		case 7:
+			reader.ReadMessage(func() {
+				m.Finished = m.Finished.UnmarshalFromReader(reader)
+			})
+		case 8:
+			reader.ReadMessage(func() {
+				m.UpdatedAt = m.UpdatedAt.UnmarshalFromReader(reader)
+			})
+		case 9:
+			m.Removed = reader.ReadBool()
+		case 10:
+			m.Sequence = reader.ReadInt64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ReadingEntry from a slice of bytes.
+func (m *ReadingEntry) Unmarshal(rawBytes []byte) (*ReadingEntry, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// UpdateReadingRequest is the input to the UpdateReading method.
+type UpdateReadingRequest struct {
+	// Isbn is the ISBN of the book.
+	Isbn  int64
+	Shelf ReadingShelf
+	// Progress is how far the user got. Audiobooks take a
+	// PositionSeconds, and other books a Page. If unset, books
+	// moved to FINISHED are set to their end, and other books
+	// keep their progress.
+	//
+	// Types that are valid to be assigned to Progress:
+	//	*UpdateReadingRequest_Page
+	//	*UpdateReadingRequest_PositionSeconds
+	Progress isUpdateReadingRequest_Progress
+	// UpdatedAt is when the change was made on the device of
+	// the user. If unset, the time of the server is used.
+	UpdatedAt *google_protobuf.Timestamp
+}
+
+// isUpdateReadingRequest_Progress is used to distinguish types assignable to Progress
+type isUpdateReadingRequest_Progress interface{ isUpdateReadingRequest_Progress() }
+
+// UpdateReadingRequest_Page is assignable to Progress
+type UpdateReadingRequest_Page struct {
+	Page int32
+}
+
+// UpdateReadingRequest_PositionSeconds is assignable to Progress
+type UpdateReadingRequest_PositionSeconds struct {
+	PositionSeconds int32
+}
+
+func (*UpdateReadingRequest_Page) isUpdateReadingRequest_Progress()            {}
+func (*UpdateReadingRequest_PositionSeconds) isUpdateReadingRequest_Progress() {}
+
+// GetProgress gets the Progress of the UpdateReadingRequest.
+func (m *UpdateReadingRequest) GetProgress() (x isUpdateReadingRequest_Progress) {
+	if m == nil {
+		return x
+	}
+	return m.Progress
+}
+
+// GetIsbn gets the Isbn of the UpdateReadingRequest.
+func (m *UpdateReadingRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetShelf gets the Shelf of the UpdateReadingRequest.
+func (m *UpdateReadingRequest) GetShelf() (x ReadingShelf) {
+	if m == nil {
+		return x
+	}
+	return m.Shelf
+}
+
+// GetPage gets the Page of the UpdateReadingRequest.
+func (m *UpdateReadingRequest) GetPage() (x int32) {
+	if v, ok := m.GetProgress().(*UpdateReadingRequest_Page); ok {
+		return v.Page
+	}
+	return x
+}
+
+// GetPositionSeconds gets the PositionSeconds of the UpdateReadingRequest.
+func (m *UpdateReadingRequest) GetPositionSeconds() (x int32) {
+	if v, ok := m.GetProgress().(*UpdateReadingRequest_PositionSeconds); ok {
+		return v.PositionSeconds
+	}
+	return x
+}
+
+// GetUpdatedAt gets the UpdatedAt of the UpdateReadingRequest.
+func (m *UpdateReadingRequest) GetUpdatedAt() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.UpdatedAt
+}
+
+// MarshalToWriter marshals UpdateReadingRequest to the provided writer.
+func (m *UpdateReadingRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	switch t := m.Progress.(type) {
+	case *UpdateReadingRequest_Page:
+		if t.Page != 0 {
+			writer.WriteInt32(3, t.Page)
+		}
+	case *UpdateReadingRequest_PositionSeconds:
+		if t.PositionSeconds != 0 {
+			writer.WriteInt32(4, t.PositionSeconds)
+		}
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	if int(m.Shelf) != 0 {
+		writer.WriteEnum(2, int(m.Shelf))
+	}
+
+	if m.UpdatedAt != nil {
+		writer.WriteMessage(5, func() {
+			m.UpdatedAt.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals UpdateReadingRequest to a slice of bytes.
+func (m *UpdateReadingRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a UpdateReadingRequest from the provided reader.
+func (m *UpdateReadingRequest) UnmarshalFromReader(reader jspb.Reader) *UpdateReadingRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &UpdateReadingRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		case 2:
+			m.Shelf = ReadingShelf(reader.ReadEnum())
+		case 3:
+			m.Progress = &UpdateReadingRequest_Page{
+				Page: reader.ReadInt32(),
+			}
+		case 4:
+			m.Progress = &UpdateReadingRequest_PositionSeconds{
+				PositionSeconds: reader.ReadInt32(),
+			}
+		case 5:
+			reader.ReadMessage(func() {
+				m.UpdatedAt = m.UpdatedAt.UnmarshalFromReader(reader)
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a UpdateReadingRequest from a slice of bytes.
+func (m *UpdateReadingRequest) Unmarshal(rawBytes []byte) (*UpdateReadingRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RemoveReadingRequest is the input to the RemoveReading method.
+type RemoveReadingRequest struct {
+	// Isbn is the ISBN of the book.
+	Isbn int64
+	// UpdatedAt is when the book was removed on the device of
+	// the user. If unset, the time of the server is used.
+	UpdatedAt *google_protobuf.Timestamp
+}
+
+// GetIsbn gets the Isbn of the RemoveReadingRequest.
+func (m *RemoveReadingRequest) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetUpdatedAt gets the UpdatedAt of the RemoveReadingRequest.
+func (m *RemoveReadingRequest) GetUpdatedAt() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.UpdatedAt
+}
+
+// MarshalToWriter marshals RemoveReadingRequest to the provided writer.
+func (m *RemoveReadingRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(1, m.Isbn)
+	}
+
+	if m.UpdatedAt != nil {
+		writer.WriteMessage(2, func() {
+			m.UpdatedAt.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals RemoveReadingRequest to a slice of bytes.
+func (m *RemoveReadingRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a RemoveReadingRequest from the provided reader.
+func (m *RemoveReadingRequest) UnmarshalFromReader(reader jspb.Reader) *RemoveReadingRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &RemoveReadingRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Isbn = reader.ReadInt64()
+		case 2:
+			reader.ReadMessage(func() {
+				m.UpdatedAt = m.UpdatedAt.UnmarshalFromReader(reader)
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a RemoveReadingRequest from a slice of bytes.
+func (m *RemoveReadingRequest) Unmarshal(rawBytes []byte) (*RemoveReadingRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListReadingRequest is the input to the ListReading method.
+type ListReadingRequest struct {
+	// Shelves are the reading lists to list. If empty, all are.
+	Shelves []ReadingShelf
+	// AfterSequence only lists the entries changed after the
+	// change with that Sequence, including the books removed.
+	// Devices send the largest Sequence of the entries they last
+	// listed. If 0, all entries but the books removed are listed.
+	AfterSequence int64
+}
+
+// GetShelves gets the Shelves of the ListReadingRequest.
+func (m *ListReadingRequest) GetShelves() (x []ReadingShelf) {
+	if m == nil {
+		return x
+	}
+	return m.Shelves
+}
+
+// GetAfterSequence gets the AfterSequence of the ListReadingRequest.
+func (m *ListReadingRequest) GetAfterSequence() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.AfterSequence
+}
+
+// MarshalToWriter marshals ListReadingRequest to the provided writer.
+func (m *ListReadingRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Shelves) > 0 {
+		var ints []int
+		for _, enum := range m.Shelves {
+			ints = append(ints, int(enum))
+		}
+		writer.WriteEnumSlice(1, ints)
+	}
+
+	if m.AfterSequence != 0 {
+		writer.WriteInt64(2, m.AfterSequence)
+	}
+
+	return
+}
+
+// Marshal marshals ListReadingRequest to a slice of bytes.
+func (m *ListReadingRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListReadingRequest from the provided reader.
+func (m *ListReadingRequest) UnmarshalFromReader(reader jspb.Reader) *ListReadingRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListReadingRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			values := reader.ReadEnumSlice()
+			for _, enum := range values {
+				m.Shelves = append(m.Shelves, ReadingShelf(enum))
+			}
+		case 2:
+			m.AfterSequence = reader.ReadInt64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListReadingRequest from a slice of bytes.
+func (m *ListReadingRequest) Unmarshal(rawBytes []byte) (*ListReadingRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListReadingResponse is the output of the ListReading method.
+type ListReadingResponse struct {
+	// Entries are the entries, most recently updated first.
+	Entries []*ReadingEntry
+}
+
+// GetEntries gets the Entries of the ListReadingResponse.
+func (m *ListReadingResponse) GetEntries() (x []*ReadingEntry) {
+	if m == nil {
+		return x
+	}
+	return m.Entries
+}
+
+// MarshalToWriter marshals ListReadingResponse to the provided writer.
+func (m *ListReadingResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Entries {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ListReadingResponse to a slice of bytes.
+func (m *ListReadingResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListReadingResponse from the provided reader.
+func (m *ListReadingResponse) UnmarshalFromReader(reader jspb.Reader) *ListReadingResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ListReadingResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Entries = append(m.Entries, new(ReadingEntry).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListReadingResponse from a slice of bytes.
+func (m *ListReadingResponse) Unmarshal(rawBytes []byte) (*ListReadingResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ListReviewsResponse is the output of the ListReviews method.
+type ListReviewsResponse struct {
+	Reviews []*Review
+	// NextPageToken lists the next page of reviews,
+	// or is empty if this is the last page.
+	NextPageToken string
+}
+
+// GetReviews gets the Reviews of the ListReviewsResponse.
+func (m *ListReviewsResponse) GetReviews() (x []*Review) {
+	if m == nil {
+		return x
+	}
+	return m.Reviews
+}
+
+// GetNextPageToken gets the NextPageToken of the ListReviewsResponse.
+func (m *ListReviewsResponse) GetNextPageToken() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.NextPageToken
+}
+
+// MarshalToWriter marshals ListReviewsResponse to the provided writer.
+func (m *ListReviewsResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Reviews {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	if len(m.NextPageToken) > 0 {
+		writer.WriteString(2, m.NextPageToken)
+	}
+
+	return
+}
+
+// Marshal marshals ListReviewsResponse to a slice of bytes.
+func (m *ListReviewsResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListReviewsResponse from the provided reader.
+func (m *ListReviewsResponse) UnmarshalFromReader(reader jspb.Reader) *ListReviewsResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ListReviewsResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Reviews = append(m.Reviews, new(Review).UnmarshalFromReader(reader))
+			})
+		case 2:
+			m.NextPageToken = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListReviewsResponse from a slice of bytes.
+func (m *ListReviewsResponse) Unmarshal(rawBytes []byte) (*ListReviewsResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// BookMessage is used to discuss books
+type BookMessage struct {
+	// Types that are valid to be assigned to Content:
+	//	*BookMessage_Name
+	//	*BookMessage_Message
+	Content isBookMessage_Content
+}
+
+// isBookMessage_Content is used to distinguish types assignable to Content
+type isBookMessage_Content interface{ isBookMessage_Content() }
+
+// BookMessage_Name is assignable to Content
+type BookMessage_Name struct {
+	// Name is the name of the person who is sending this message.
+	// It should be sent as the first message on the stream.
+	Name string
+}
+
+// BookMessage_Message is assignable to Content
+type BookMessage_Message struct {
+	// Message is any message the user wishes to send.
+	Message string
+}
+
+func (*BookMessage_Name) isBookMessage_Content()    {}
+func (*BookMessage_Message) isBookMessage_Content() {}
+
+// GetContent gets the Content of the BookMessage.
+func (m *BookMessage) GetContent() (x isBookMessage_Content) {
+	if m == nil {
+		return x
+	}
+	return m.Content
+}
+
+// GetName gets the Name of the BookMessage.
+func (m *BookMessage) GetName() (x string) {
+	if v, ok := m.GetContent().(*BookMessage_Name); ok {
+		return v.Name
+	}
+	return x
+}
+
+// GetMessage gets the Message of the BookMessage.
+func (m *BookMessage) GetMessage() (x string) {
+	if v, ok := m.GetContent().(*BookMessage_Message); ok {
+		return v.Message
+	}
+	return x
+}
+
+// MarshalToWriter marshals BookMessage to the provided writer.
+func (m *BookMessage) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	switch t := m.Content.(type) {
+	case *BookMessage_Name:
+		if len(t.Name) > 0 {
+			writer.WriteString(1, t.Name)
+		}
+	case *BookMessage_Message:
+		if len(t.Message) > 0 {
+			writer.WriteString(2, t.Message)
+		}
+	}
+
+	return
+}
+
+// Marshal marshals BookMessage to a slice of bytes.
+func (m *BookMessage) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a BookMessage from the provided reader.
+func (m *BookMessage) UnmarshalFromReader(reader jspb.Reader) *BookMessage {
+	for reader.Next() {
+		if m == nil {
+			m = &BookMessage{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Content = &BookMessage_Name{
+				Name: reader.ReadString(),
+			}
+		case 2:
+			m.Content = &BookMessage_Message{
+				Message: reader.ReadString(),
+			}
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a BookMessage from a slice of bytes.
+func (m *BookMessage) Unmarshal(rawBytes []byte) (*BookMessage, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// BookResponse is used to discuss books
+type BookResponse struct {
+	// Message is a message from a user.
+	Message string
+}
+
+// GetMessage gets the Message of the BookResponse.
+func (m *BookResponse) GetMessage() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Message
+}
+
+// MarshalToWriter marshals BookResponse to the provided writer.
+func (m *BookResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Message) > 0 {
+		writer.WriteString(2, m.Message)
+	}
+
+	return
+}
+
+// Marshal marshals BookResponse to a slice of bytes.
+func (m *BookResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a BookResponse from the provided reader.
+func (m *BookResponse) UnmarshalFromReader(reader jspb.Reader) *BookResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &BookResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 2:
+			m.Message = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a BookResponse from a slice of bytes.
+func (m *BookResponse) Unmarshal(rawBytes []byte) (*BookResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ImportOptions configures an import of books.
+type ImportOptions struct {
+	// DryRun validates the books without importing them.
+	DryRun bool
+}
+
+// GetDryRun gets the DryRun of the ImportOptions.
+func (m *ImportOptions) GetDryRun() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.DryRun
+}
+
+// MarshalToWriter marshals ImportOptions to the provided writer.
+func (m *ImportOptions) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.DryRun {
+		writer.WriteBool(1, m.DryRun)
+	}
+
+	return
+}
+
+// Marshal marshals ImportOptions to a slice of bytes.
+func (m *ImportOptions) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ImportOptions from the provided reader.
+func (m *ImportOptions) UnmarshalFromReader(reader jspb.Reader) *ImportOptions {
+	for reader.Next() {
+		if m == nil {
+			m = &ImportOptions{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.DryRun = reader.ReadBool()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ImportOptions from a slice of bytes.
+func (m *ImportOptions) Unmarshal(rawBytes []byte) (*ImportOptions, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ImportBooksRequest is the input to the ImportBooks method.
+type ImportBooksRequest struct {
+	// Types that are valid to be assigned to Content:
+	//	*ImportBooksRequest_Options
+	//	*ImportBooksRequest_Book
+	Content isImportBooksRequest_Content
+}
+
+// isImportBooksRequest_Content is used to distinguish types assignable to Content
+type isImportBooksRequest_Content interface{ isImportBooksRequest_Content() }
+
+// ImportBooksRequest_Options is assignable to Content
+type ImportBooksRequest_Options struct {
+	// Options configures the import. If sent, it should
+	// be sent as the first message on the stream.
+	Options *ImportOptions
+}
+
+// ImportBooksRequest_Book is assignable to Content
+type ImportBooksRequest_Book struct {
+	// Book is a book to import.
+	Book *Book
+}
+
+func (*ImportBooksRequest_Options) isImportBooksRequest_Content() {}
+func (*ImportBooksRequest_Book) isImportBooksRequest_Content()    {}
+
+// GetContent gets the Content of the ImportBooksRequest.
+func (m *ImportBooksRequest) GetContent() (x isImportBooksRequest_Content) {
+	if m == nil {
+		return x
+	}
+	return m.Content
+}
+
+// GetOptions gets the Options of the ImportBooksRequest.
+func (m *ImportBooksRequest) GetOptions() (x *ImportOptions) {
+	if v, ok := m.GetContent().(*ImportBooksRequest_Options); ok {
+		return v.Options
+	}
+	return x
+}
+
+// GetBook gets the Book of the ImportBooksRequest.
+func (m *ImportBooksRequest) GetBook() (x *Book) {
+	if v, ok := m.GetContent().(*ImportBooksRequest_Book); ok {
+		return v.Book
+	}
+	return x
+}
+
+// MarshalToWriter marshals ImportBooksRequest to the provided writer.
+func (m *ImportBooksRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	switch t := m.Content.(type) {
+	case *ImportBooksRequest_Options:
+		if t.Options != nil {
+			writer.WriteMessage(1, func() {
+				t.Options.MarshalToWriter(writer)
+			})
+		}
+	case *ImportBooksRequest_Book:
+		if t.Book != nil {
+			writer.WriteMessage(2, func() {
+				t.Book.MarshalToWriter(writer)
+			})
+		}
+	}
+
+	return
+}
+
+// Marshal marshals ImportBooksRequest to a slice of bytes.
+func (m *ImportBooksRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ImportBooksRequest from the provided reader.
+func (m *ImportBooksRequest) UnmarshalFromReader(reader jspb.Reader) *ImportBooksRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ImportBooksRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Content = &ImportBooksRequest_Options{
+					Options: new(ImportOptions).UnmarshalFromReader(reader),
+				}
+			})
+		case 2:
+			reader.ReadMessage(func() {
+				m.Content = &ImportBooksRequest_Book{
+					Book: new(Book).UnmarshalFromReader(reader),
+				}
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ImportBooksRequest from a slice of bytes.
+func (m *ImportBooksRequest) Unmarshal(rawBytes []byte) (*ImportBooksRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ImportError describes a book that could not be imported.
+type ImportError struct {
+	// Index is the index of the book among
+	// the books sent, starting from 0.
+	Index int32
+	// Isbn is the ISBN of the book.
+	Isbn int64
+	// Message describes why the book could not be imported.
+	Message string
+}
+
+// GetIndex gets the Index of the ImportError.
+func (m *ImportError) GetIndex() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Index
+}
+
+// GetIsbn gets the Isbn of the ImportError.
+func (m *ImportError) GetIsbn() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Isbn
+}
+
+// GetMessage gets the Message of the ImportError.
+func (m *ImportError) GetMessage() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Message
+}
+
+// MarshalToWriter marshals ImportError to the provided writer.
+func (m *ImportError) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Index != 0 {
+		writer.WriteInt32(1, m.Index)
+	}
+
+	if m.Isbn != 0 {
+		writer.WriteInt64(2, m.Isbn)
+	}
+
+	if len(m.Message) > 0 {
+		writer.WriteString(3, m.Message)
+	}
+
+	return
+}
+
+// Marshal marshals ImportError to a slice of bytes.
+func (m *ImportError) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ImportError from the provided reader.
+func (m *ImportError) UnmarshalFromReader(reader jspb.Reader) *ImportError {
+	for reader.Next() {
+		if m == nil {
+			m = &ImportError{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Index = reader.ReadInt32()
+		case 2:
+			m.Isbn = reader.ReadInt64()
+		case 3:
+			m.Message = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ImportError from a slice of bytes.
+func (m *ImportError) Unmarshal(rawBytes []byte) (*ImportError, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ImportBooksResponse is the output of the ImportBooks method.
+type ImportBooksResponse struct {
+	// Created is the number of books added to the library.
+	Created int32
+	// Updated is the number of books in the library
+	// replaced by a book with the same ISBN.
+	Updated int32
+	// Errors describes the books that could not be imported.
+	Errors []*ImportError
+}
+
+// GetCreated gets the Created of the ImportBooksResponse.
+func (m *ImportBooksResponse) GetCreated() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Created
+}
+
+// GetUpdated gets the Updated of the ImportBooksResponse.
+func (m *ImportBooksResponse) GetUpdated() (x int32) {
+	if m == nil {
+		return x
+	}
+	return m.Updated
+}
+
+// GetErrors gets the Errors of the ImportBooksResponse.
+func (m *ImportBooksResponse) GetErrors() (x []*ImportError) {
+	if m == nil {
+		return x
+	}
+	return m.Errors
+}
+
+// MarshalToWriter marshals ImportBooksResponse to the provided writer.
+func (m *ImportBooksResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Created != 0 {
+		writer.WriteInt32(1, m.Created)
+	}
+
+	if m.Updated != 0 {
+		writer.WriteInt32(2, m.Updated)
+	}
+
+	for _, msg := range m.Errors {
+		writer.WriteMessage(3, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ImportBooksResponse to a slice of bytes.
+func (m *ImportBooksResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ImportBooksResponse from the provided reader.
+func (m *ImportBooksResponse) UnmarshalFromReader(reader jspb.Reader) *ImportBooksResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ImportBooksResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Created = reader.ReadInt32()
+		case 2:
+			m.Updated = reader.ReadInt32()
+		case 3:
+			reader.ReadMessage(func() {
+				m.Errors = append(m.Errors, new(ImportError).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ImportBooksResponse from a slice of bytes.
+func (m *ImportBooksResponse) Unmarshal(rawBytes []byte) (*ImportBooksResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ExportBooksRequest is the input to the ExportBooks method.
+type ExportBooksRequest struct {
+	// ResumeToken is the resume token of the last
+	// message received by an interrupted export,
+	// to continue it from the next book.
+	// If empty, the export starts from the first book.
+	ResumeToken string
+}
+
+// GetResumeToken gets the ResumeToken of the ExportBooksRequest.
+func (m *ExportBooksRequest) GetResumeToken() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.ResumeToken
+}
+
+// MarshalToWriter marshals ExportBooksRequest to the provided writer.
+func (m *ExportBooksRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.ResumeToken) > 0 {
+		writer.WriteString(1, m.ResumeToken)
+	}
+
+	return
+}
+
+// Marshal marshals ExportBooksRequest to a slice of bytes.
+func (m *ExportBooksRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ExportBooksRequest from the provided reader.
+func (m *ExportBooksRequest) UnmarshalFromReader(reader jspb.Reader) *ExportBooksRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ExportBooksRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.ResumeToken = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ExportBooksRequest from a slice of bytes.
+func (m *ExportBooksRequest) Unmarshal(rawBytes []byte) (*ExportBooksRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ExportBooksResponse is the output of the ExportBooks method.
+type ExportBooksResponse struct {
+	// Book is a book in the library.
+	Book *Book
+	// ResumeToken continues the export after this book.
+	ResumeToken string
+}
+
+// GetBook gets the Book of the ExportBooksResponse.
+func (m *ExportBooksResponse) GetBook() (x *Book) {
+	if m == nil {
+		return x
+	}
+	return m.Book
+}
+
+// GetResumeToken gets the ResumeToken of the ExportBooksResponse.
+func (m *ExportBooksResponse) GetResumeToken() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.ResumeToken
+}
+
+// MarshalToWriter marshals ExportBooksResponse to the provided writer.
+func (m *ExportBooksResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.Book != nil {
+		writer.WriteMessage(1, func() {
+			m.Book.MarshalToWriter(writer)
+		})
+	}
+
+	if len(m.ResumeToken) > 0 {
+		writer.WriteString(2, m.ResumeToken)
+	}
+
+	return
+}
+
+// Marshal marshals ExportBooksResponse to a slice of bytes.
+func (m *ExportBooksResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ExportBooksResponse from the provided reader.
+func (m *ExportBooksResponse) UnmarshalFromReader(reader jspb.Reader) *ExportBooksResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ExportBooksResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.Book = m.Book.UnmarshalFromReader(reader)
+			})
+		case 2:
+			m.ResumeToken = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ExportBooksResponse from a slice of bytes.
+func (m *ExportBooksResponse) Unmarshal(rawBytes []byte) (*ExportBooksResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// WatchBooksRequest is the input to the WatchBooks method.
+type WatchBooksRequest struct {
+	// AuthorPrefix is the prefix with which to match
+	// against the author of the books to watch.
+	AuthorPrefix string
+	// AfterSequence resumes watching after the event with
+	// this sequence number. If 0, only changes made after
+	// the request are sent.
+	AfterSequence int64
+	// IncludeExisting sends an EXISTING event for each
+	// book matching the author prefix, before any changes.
+	IncludeExisting bool
+	// AuthorId, if set, only matches books
+	// with an author with this Id.
+	AuthorId string
+}
+
+// GetAuthorPrefix gets the AuthorPrefix of the WatchBooksRequest.
+func (m *WatchBooksRequest) GetAuthorPrefix() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.AuthorPrefix
+}
+
+// GetAfterSequence gets the AfterSequence of the WatchBooksRequest.
+func (m *WatchBooksRequest) GetAfterSequence() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.AfterSequence
+}
+
+// GetIncludeExisting gets the IncludeExisting of the WatchBooksRequest.
+func (m *WatchBooksRequest) GetIncludeExisting() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.IncludeExisting
+}
+
+// GetAuthorId gets the AuthorId of the WatchBooksRequest.
+func (m *WatchBooksRequest) GetAuthorId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.AuthorId
+}
+
+// MarshalToWriter marshals WatchBooksRequest to the provided writer.
+func (m *WatchBooksRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.AuthorPrefix) > 0 {
+		writer.WriteString(1, m.AuthorPrefix)
+	}
+
+	if m.AfterSequence != 0 {
+		writer.WriteInt64(2, m.AfterSequence)
+	}
+
+	if m.IncludeExisting {
+		writer.WriteBool(3, m.IncludeExisting)
+	}
+
+	if len(m.AuthorId) > 0 {
+		writer.WriteString(4, m.AuthorId)
+	}
+
+	return
+}
+
+// Marshal marshals WatchBooksRequest to a slice of bytes.
+func (m *WatchBooksRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a WatchBooksRequest from the provided reader.
+func (m *WatchBooksRequest) UnmarshalFromReader(reader jspb.Reader) *WatchBooksRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &WatchBooksRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.AuthorPrefix = reader.ReadString()
+		case 2:
+			m.AfterSequence = reader.ReadInt64()
+		case 3:
+			m.IncludeExisting = reader.ReadBool()
+		case 4:
+			m.AuthorId = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a WatchBooksRequest from a slice of bytes.
+func (m *WatchBooksRequest) Unmarshal(rawBytes []byte) (*WatchBooksRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// BookEvent describes a change to the books in the library.
+type BookEvent struct {
+	// Type is the type of the event.
+	Type BookEvent_Type
+	// Sequence is the sequence number of the change,
+	// with which watching can be resumed after it.
+	// Existing events have the sequence number of the
+	// last change made before the watch started.
+	Sequence int64
+	// Book is the book after the change, or
+	// before the change for deleted events, with
+	// its availability and rating when sent.
+	Book *Book
+}
+
+// GetType gets the Type of the BookEvent.
+func (m *BookEvent) GetType() (x BookEvent_Type) {
+	if m == nil {
+		return x
+	}
+	return m.Type
+}
+
+// GetSequence gets the Sequence of the BookEvent.
+func (m *BookEvent) GetSequence() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Sequence
+}
+
+// GetBook gets the Book of the BookEvent.
+func (m *BookEvent) GetBook() (x *Book) {
+	if m == nil {
+		return x
+	}
+	return m.Book
+}
+
+// MarshalToWriter marshals BookEvent to the provided writer.
+func (m *BookEvent) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if int(m.Type) != 0 {
+		writer.WriteEnum(1, int(m.Type))
+	}
+
+	if m.Sequence != 0 {
+		writer.WriteInt64(2, m.Sequence)
+	}
+
+	if m.Book != nil {
+		writer.WriteMessage(3, func() {
+			m.Book.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals BookEvent to a slice of bytes.
+func (m *BookEvent) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a BookEvent from the provided reader.
+func (m *BookEvent) UnmarshalFromReader(reader jspb.Reader) *BookEvent {
+	for reader.Next() {
+		if m == nil {
+			m = &BookEvent{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Type = BookEvent_Type(reader.ReadEnum())
+		case 2:
+			m.Sequence = reader.ReadInt64()
+		case 3:
+			reader.ReadMessage(func() {
+				m.Book = m.Book.UnmarshalFromReader(reader)
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a BookEvent from a slice of bytes.
+func (m *BookEvent) Unmarshal(rawBytes []byte) (*BookEvent, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// User is the public profile of a user account.
+type User struct {
+	// Name identifies the user, and is used to log in.
+	Name string
+	// DisplayName is the name shown to other users, such as
+	// in BookChat. No two users have the same display name,
+	// ignoring case.
+	DisplayName string
+	Bio         string
+	// FavouriteSubjects are subjects of books the user likes.
+	FavouriteSubjects []string
+	// Created is when the user registered.
+	Created *google_protobuf.Timestamp
+}
+
+// GetName gets the Name of the User.
+func (m *User) GetName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Name
+}
+
+// GetDisplayName gets the DisplayName of the User.
+func (m *User) GetDisplayName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.DisplayName
+}
+
+// GetBio gets the Bio of the User.
+func (m *User) GetBio() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Bio
+}
+
+// GetFavouriteSubjects gets the FavouriteSubjects of the User.
+func (m *User) GetFavouriteSubjects() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.FavouriteSubjects
+}
+
+// GetCreated gets the Created of the User.
+func (m *User) GetCreated() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Created
+}
+
+// MarshalToWriter marshals User to the provided writer.
+func (m *User) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Name) > 0 {
+		writer.WriteString(1, m.Name)
+	}
+
+	if len(m.DisplayName) > 0 {
+		writer.WriteString(2, m.DisplayName)
+	}
+
+	if len(m.Bio) > 0 {
+		writer.WriteString(3, m.Bio)
+	}
+
+	for _, val := range m.FavouriteSubjects {
+		writer.WriteString(4, val)
+	}
+
+	if m.Created != nil {
+		writer.WriteMessage(5, func() {
+			m.Created.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals User to a slice of bytes.
+func (m *User) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a User from the provided reader.
+func (m *User) UnmarshalFromReader(reader jspb.Reader) *User {
+	for reader.Next() {
+		if m == nil {
+			m = &User{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Name = reader.ReadString()
+		case 2:
+			m.DisplayName = reader.ReadString()
+		case 3:
+			m.Bio = reader.ReadString()
+		case 4:
+			m.FavouriteSubjects = append(m.FavouriteSubjects, reader.ReadString())
+		case 5:
+			reader.ReadMessage(func() {
+				m.Created = m.Created.UnmarshalFromReader(reader)
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a User from a slice of bytes.
+func (m *User) Unmarshal(rawBytes []byte) (*User, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RegisterRequest is the input to the Register method.
+type RegisterRequest struct {
+	// Name is the name of the user, 3 to 32 lower case
+	// letters, digits, dashes or underscores.
+	Name string
+	// Password is the password of the user,
+	// at least 8 characters and at most 72 bytes.
+	Password string
+	// DisplayName is the display name of the user,
+	// or the Name of the user if not set.
+	DisplayName string
+}
+
+// GetName gets the Name of the RegisterRequest.
+func (m *RegisterRequest) GetName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Name
+}
+
+// GetPassword gets the Password of the RegisterRequest.
+func (m *RegisterRequest) GetPassword() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Password
+}
+
+// GetDisplayName gets the DisplayName of the RegisterRequest.
+func (m *RegisterRequest) GetDisplayName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.DisplayName
+}
+
+// MarshalToWriter marshals RegisterRequest to the provided writer.
+func (m *RegisterRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Name) > 0 {
+		writer.WriteString(1, m.Name)
+	}
+
+	if len(m.Password) > 0 {
+		writer.WriteString(2, m.Password)
+	}
+
+	if len(m.DisplayName) > 0 {
+		writer.WriteString(3, m.DisplayName)
+	}
+
+	return
+}
+
+// Marshal marshals RegisterRequest to a slice of bytes.
+func (m *RegisterRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a RegisterRequest from the provided reader.
+func (m *RegisterRequest) UnmarshalFromReader(reader jspb.Reader) *RegisterRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &RegisterRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Name = reader.ReadString()
+		case 2:
+			m.Password = reader.ReadString()
+		case 3:
+			m.DisplayName = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a RegisterRequest from a slice of bytes.
+func (m *RegisterRequest) Unmarshal(rawBytes []byte) (*RegisterRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// LoginRequest is the input to the Login method.
+type LoginRequest struct {
+	Name     string
+	Password string
+}
+
+// GetName gets the Name of the LoginRequest.
+func (m *LoginRequest) GetName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Name
+}
+
+// GetPassword gets the Password of the LoginRequest.
+func (m *LoginRequest) GetPassword() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Password
+}
+
+// MarshalToWriter marshals LoginRequest to the provided writer.
+func (m *LoginRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Name) > 0 {
+		writer.WriteString(1, m.Name)
+	}
+
+	if len(m.Password) > 0 {
+		writer.WriteString(2, m.Password)
+	}
+
+	return
+}
+
+// Marshal marshals LoginRequest to a slice of bytes.
+func (m *LoginRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a LoginRequest from the provided reader.
+func (m *LoginRequest) UnmarshalFromReader(reader jspb.Reader) *LoginRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &LoginRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Name = reader.ReadString()
+		case 2:
+			m.Password = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a LoginRequest from a slice of bytes.
+func (m *LoginRequest) Unmarshal(rawBytes []byte) (*LoginRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// LoginResponse is the output of the Login method.
+type LoginResponse struct {
+	User *User
+	// Session identifies the session of the user in the
+	// "session" request metadata of the calls made by them.
+	Session string
+	// Expires is when the session expires.
+	Expires *google_protobuf.Timestamp
+}
+
+// GetUser gets the User of the LoginResponse.
+func (m *LoginResponse) GetUser() (x *User) {
+	if m == nil {
+		return x
+	}
+	return m.User
+}
+
+// GetSession gets the Session of the LoginResponse.
+func (m *LoginResponse) GetSession() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Session
+}
+
+// GetExpires gets the Expires of the LoginResponse.
+func (m *LoginResponse) GetExpires() (x *google_protobuf.Timestamp) {
+	if m == nil {
+		return x
+	}
+	return m.Expires
+}
+
+// MarshalToWriter marshals LoginResponse to the provided writer.
+func (m *LoginResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if m.User != nil {
+		writer.WriteMessage(1, func() {
+			m.User.MarshalToWriter(writer)
+		})
+	}
+
+	if len(m.Session) > 0 {
+		writer.WriteString(2, m.Session)
+	}
+
+	if m.Expires != nil {
+		writer.WriteMessage(3, func() {
+			m.Expires.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals LoginResponse to a slice of bytes.
+func (m *LoginResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a LoginResponse from the provided reader.
+func (m *LoginResponse) UnmarshalFromReader(reader jspb.Reader) *LoginResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &LoginResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			reader.ReadMessage(func() {
+				m.User = m.User.UnmarshalFromReader(reader)
+			})
+		case 2:
+			m.Session = reader.ReadString()
+		case 3:
+			reader.ReadMessage(func() {
+				m.Expires = m.Expires.UnmarshalFromReader(reader)
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a LoginResponse from a slice of bytes.
+func (m *LoginResponse) Unmarshal(rawBytes []byte) (*LoginResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetUserRequest is the input to the GetUser method.
+type GetUserRequest struct {
+	// Name is the name of the user.
+	Name string
+}
+
+// GetName gets the Name of the GetUserRequest.
+func (m *GetUserRequest) GetName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Name
+}
+
+// MarshalToWriter marshals GetUserRequest to the provided writer.
+func (m *GetUserRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Name) > 0 {
+		writer.WriteString(1, m.Name)
+	}
+
+	return
+}
+
+// Marshal marshals GetUserRequest to a slice of bytes.
+func (m *GetUserRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a GetUserRequest from the provided reader.
+func (m *GetUserRequest) UnmarshalFromReader(reader jspb.Reader) *GetUserRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &GetUserRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Name = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a GetUserRequest from a slice of bytes.
+func (m *GetUserRequest) Unmarshal(rawBytes []byte) (*GetUserRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// UpdateProfileRequest is the input to the UpdateProfile method.
+type UpdateProfileRequest struct {
+	// DisplayName is the new display name, or
+	// the Name of the user if not set.
+	DisplayName       string
+	Bio               string
+	FavouriteSubjects []string
+}
+
+// GetDisplayName gets the DisplayName of the UpdateProfileRequest.
+func (m *UpdateProfileRequest) GetDisplayName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.DisplayName
+}
+
+// GetBio gets the Bio of the UpdateProfileRequest.
+func (m *UpdateProfileRequest) GetBio() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Bio
+}
+
+// GetFavouriteSubjects gets the FavouriteSubjects of the UpdateProfileRequest.
+func (m *UpdateProfileRequest) GetFavouriteSubjects() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.FavouriteSubjects
+}
+
+// MarshalToWriter marshals UpdateProfileRequest to the provided writer.
+func (m *UpdateProfileRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.DisplayName) > 0 {
+		writer.WriteString(1, m.DisplayName)
+	}
+
+	if len(m.Bio) > 0 {
+		writer.WriteString(2, m.Bio)
+	}
+
+	for _, val := range m.FavouriteSubjects {
+		writer.WriteString(3, val)
+	}
+
+	return
+}
+
+// Marshal marshals UpdateProfileRequest to a slice of bytes.
+func (m *UpdateProfileRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a UpdateProfileRequest from the provided reader.
+func (m *UpdateProfileRequest) UnmarshalFromReader(reader jspb.Reader) *UpdateProfileRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &UpdateProfileRequest{}
 		}
-	case *BookMessage_Message:
-		if len(t.Message) > 0 {
-			writer.WriteString(2, t.Message)
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.DisplayName = reader.ReadString()
+		case 2:
+			m.Bio = reader.ReadString()
+		case 3:
+			m.FavouriteSubjects = append(m.FavouriteSubjects, reader.ReadString())
+		default:
+			reader.SkipField()
 		}
 	}
 
-	return
+	return m
+}
+
+// Unmarshal unmarshals a UpdateProfileRequest from a slice of bytes.
+func (m *UpdateProfileRequest) Unmarshal(rawBytes []byte) (*UpdateProfileRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpcweb.Client
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpcweb package it is being compiled against.
+const _ = grpcweb.GrpcWebPackageIsVersion3
+
+// Client API for BookService service
+
+// BookService exposes GetBook and QueryBooks,
+// which allow querying of the library.
+//
+// The collection, circulation, review and reading list methods
+// identify the user making the call by the "session" request
+// metadata, with the session of a UserService login. They return
+// an Unauthenticated error if the session is not valid, and all
+// but the collection methods also without a session. Collections
+// made without a session have no owner.
+type BookServiceClient interface {
+	// GetBook returns a Book from the library
+	// that matches the ISBN provided, if found.
+	// Otherwise it returns a NotFound error.
+	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpcweb.CallOption) (*Book, error)
+	// QueryBooks returns all Books whos author
+	// matches the author prefix provided, as a stream
+	// of Books. If the branch provided does not exist,
+	// it returns a NotFound error.
+	QueryBooks(ctx context.Context, in *QueryBooksRequest, opts ...grpcweb.CallOption) (BookService_QueryBooksClient, error)
+	// MakeCollection takes a stream of books and returns a Book collection,
+	// which is stored and owned by the user making the call. Only the ISBNs
+	// of the books sent are used, the collection holds the books in the
+	// library with those ISBNs. Books that are not in the library or that
+	// are sent more than once are described by the errors of the response.
+	// If the collection would have more books than the server allows, it
+	// returns a ResourceExhausted error. The name and
+	// visibility of the collection are taken from the "collection-name"
+	// and "collection-visibility" request metadata. Collections are
+	// private by default, or unlisted if made without a session.
+	MakeCollection(ctx context.Context, opts ...grpcweb.CallOption) (BookService_MakeCollectionClient, error)
+	// BookChat allows discussion about books
+	BookChat(ctx context.Context, opts ...grpcweb.CallOption) (BookService_BookChatClient, error)
+	// ImportBooks takes a stream of books and adds them to the library,
+	// replacing any books with the same ISBN. Books that are not valid
+	// are not imported, and are described in the response.
+	// Nothing is imported if the import is a dry run. It is meant for
+	// administrators, who send the admin token of the server as the
+	// "admin-token" request metadata. Without it, it returns a
+	// PermissionDenied error. Imports of more books than the server allows
+	// return a ResourceExhausted error, and import nothing.
+	ImportBooks(ctx context.Context, opts ...grpcweb.CallOption) (BookService_ImportBooksClient, error)
+	// ExportBooks returns all Books in the library, ordered by ISBN,
+	// as a stream. Each message has a resume token, with which an
+	// interrupted export can be continued where it left off.
+	ExportBooks(ctx context.Context, in *ExportBooksRequest, opts ...grpcweb.CallOption) (BookService_ExportBooksClient, error)
+	// WatchBooks returns the changes made to the Books whose author
+	// matches the author prefix provided, as a stream of events.
+	// If the changes to resume after are no longer available, it
+	// returns an OutOfRange error. If the client falls too far behind,
+	// it returns a ResourceExhausted error, after which it can resume.
+	WatchBooks(ctx context.Context, in *WatchBooksRequest, opts ...grpcweb.CallOption) (BookService_WatchBooksClient, error)
+	// GetAuthor returns the Author with the Id provided, if found.
+	// Otherwise it returns a NotFound error.
+	GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpcweb.CallOption) (*Author, error)
+	// ListAuthors returns the Authors whose name
+	// matches the name prefix provided.
+	ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpcweb.CallOption) (*ListAuthorsResponse, error)
+	// GetPublisher returns the Publisher with the Id provided, if found.
+	// Otherwise it returns a NotFound error.
+	GetPublisher(ctx context.Context, in *GetPublisherRequest, opts ...grpcweb.CallOption) (*Publisher, error)
+	// GetCollection returns the Collection with the Id provided, if it
+	// can be seen by the user. Otherwise it returns a NotFound error.
+	GetCollection(ctx context.Context, in *GetCollectionRequest, opts ...grpcweb.CallOption) (*Collection, error)
+	// ListCollections returns the Collections owned by the
+	// user and the public Collections of other users.
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpcweb.CallOption) (*ListCollectionsResponse, error)
+	// AddToCollection adds the Books with the ISBNs provided to a
+	// Collection owned by the user, and returns the Collection.
+	// Books already in the Collection are not added again. If the
+	// Collection would have more books than the server allows,
+	// it returns a ResourceExhausted error.
+	AddToCollection(ctx context.Context, in *AddToCollectionRequest, opts ...grpcweb.CallOption) (*Collection, error)
+	// RemoveFromCollection removes the Books with the ISBNs provided
+	// from a Collection owned by the user, and returns the Collection.
+	RemoveFromCollection(ctx context.Context, in *RemoveFromCollectionRequest, opts ...grpcweb.CallOption) (*Collection, error)
+	// DeleteCollection deletes a Collection owned by the user,
+	// and returns it.
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpcweb.CallOption) (*Collection, error)
+	// ListCopies returns the Copies of the Book with the ISBN provided.
+	ListCopies(ctx context.Context, in *ListCopiesRequest, opts ...grpcweb.CallOption) (*ListCopiesResponse, error)
+	// ListBranches returns the Branches of the library.
+	ListBranches(ctx context.Context, in *ListBranchesRequest, opts ...grpcweb.CallOption) (*ListBranchesResponse, error)
+	// AddCopy adds a Copy of the Book with the ISBN provided at the
+	// Branch provided, and returns it. If there is no such Book or
+	// Branch, it returns a NotFound error. It is meant for administrators,
+	// who send the admin token of the server as the "admin-token" request
+	// metadata. Without it, it returns a PermissionDenied error.
+	AddCopy(ctx context.Context, in *AddCopyRequest, opts ...grpcweb.CallOption) (*Copy, error)
+	// CheckOut lends a Copy to the user, and returns the Loan. The Loan
+	// is due after a period depending on the BookType of the Book. If
+	// the Copy is on loan, or no Copy of the Book is available, it
+	// returns a FailedPrecondition error.
+	CheckOut(ctx context.Context, in *CheckOutRequest, opts ...grpcweb.CallOption) (*Loan, error)
+	// Return ends the Loan of a Copy, and returns the Loan. If
+	// the Copy is not on loan, it returns a FailedPrecondition error.
+	// Loans are returned by their borrower, or by administrators sending
+	// the admin token as the "admin-token" request metadata. Returning
+	// the Loan of another user returns a PermissionDenied error.
+	Return(ctx context.Context, in *ReturnRequest, opts ...grpcweb.CallOption) (*Loan, error)
+	// Renew extends a Loan of the user, and returns the Loan. If the Loan
+	// has ended or has been renewed too many times, it returns a
+	// FailedPrecondition error.
+	Renew(ctx context.Context, in *RenewRequest, opts ...grpcweb.CallOption) (*Loan, error)
+	// ListLoans returns the Loans of the user.
+	ListLoans(ctx context.Context, in *ListLoansRequest, opts ...grpcweb.CallOption) (*ListLoansResponse, error)
+	// PlaceHold queues the user for a Copy of the Book with the ISBN
+	// provided, and returns the Hold. Copies returned are kept for
+	// the first Hold in the queue, which is then READY, and expires
+	// if the Copy is not checked out in time. If the user already has
+	// a Hold on the Book, it returns an AlreadyExists error.
+	PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpcweb.CallOption) (*Hold, error)
+	// CancelHold cancels a Hold of the user, and returns it.
+	CancelHold(ctx context.Context, in *CancelHoldRequest, opts ...grpcweb.CallOption) (*Hold, error)
+	// ListHolds returns the Holds of the user.
+	ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpcweb.CallOption) (*ListHoldsResponse, error)
+	// WatchMyHolds streams the WAITING and READY Holds of the user,
+	// followed by the Holds of the user as they change, for example
+	// when a Copy is ready to be picked up.
+	WatchMyHolds(ctx context.Context, in *WatchMyHoldsRequest, opts ...grpcweb.CallOption) (BookService_WatchMyHoldsClient, error)
+	// ListJobs returns the status of the background jobs of the server, which
+	// compute fines and recommendations, expire holds and purge the chat history.
+	// It is meant for administrators, who send the admin token of the server as
+	// the "admin-token" request metadata. Without it, it returns a
+	// PermissionDenied error.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpcweb.CallOption) (*ListJobsResponse, error)
+	// SubmitReview adds the review of a Book by the user, and returns
+	// it. Users can review a Book once, after which they edit their
+	// Review with UpdateReview. If there is no such Book, it returns a
+	// NotFound error.
+	SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpcweb.CallOption) (*Review, error)
+	// UpdateReview changes the rating and text of a Review by the
+	// user, and returns it. Reviews by other users cannot be changed.
+	UpdateReview(ctx context.Context, in *UpdateReviewRequest, opts ...grpcweb.CallOption) (*Review, error)
+	// DeleteReview deletes a Review by the user, and returns it.
+	DeleteReview(ctx context.Context, in *DeleteReviewRequest, opts ...grpcweb.CallOption) (*Review, error)
+	// MarkReviewHelpful records that the user found a Review helpful,
+	// and returns it. Users cannot vote for their own reviews, or
+	// vote for a Review twice.
+	MarkReviewHelpful(ctx context.Context, in *MarkReviewHelpfulRequest, opts ...grpcweb.CallOption) (*Review, error)
+	// ListReviews returns a page of the Reviews of a Book.
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpcweb.CallOption) (*ListReviewsResponse, error)
+	// RecommendBooks returns books liked by the readers who liked the Book
+	// with the ISBN provided, as found in their collections, loans and
+	// ratings by a background job. Books without enough such readers get
+	// books by the same authors or publisher. If there is no such Book,
+	// it returns a NotFound error.
+	RecommendBooks(ctx context.Context, in *RecommendBooksRequest, opts ...grpcweb.CallOption) (*RecommendBooksResponse, error)
+	// UpdateReading puts a Book on a reading list of the user, with their
+	// progress, and returns the entry. If the entry was changed after
+	// UpdatedAt, for example on another device, the change is ignored and
+	// the entry returned as it is. If there is no such Book, it returns a
+	// NotFound error.
+	UpdateReading(ctx context.Context, in *UpdateReadingRequest, opts ...grpcweb.CallOption) (*ReadingEntry, error)
+	// RemoveReading removes a Book from the reading lists of the user, and
+	// returns the entry removed. Like UpdateReading, it is ignored if the
+	// entry was changed after UpdatedAt. If the Book is not on the reading
+	// lists, it returns a NotFound error.
+	RemoveReading(ctx context.Context, in *RemoveReadingRequest, opts ...grpcweb.CallOption) (*ReadingEntry, error)
+	// ListReading lists the reading lists of the user.
+	ListReading(ctx context.Context, in *ListReadingRequest, opts ...grpcweb.CallOption) (*ListReadingResponse, error)
+}
+
+type bookServiceClient struct {
+	client *grpcweb.Client
+}
+
+// NewBookServiceClient creates a new gRPC-Web client.
+func NewBookServiceClient(hostname string, opts ...grpcweb.DialOption) BookServiceClient {
+	return &bookServiceClient{
+		client: grpcweb.NewClient(hostname, "library.BookService", opts...),
+	}
+}
+
+func (c *bookServiceClient) GetBook(ctx context.Context, in *GetBookRequest, opts ...grpcweb.CallOption) (*Book, error) {
+	resp, err := c.client.RPCCall(ctx, "GetBook", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Book).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) QueryBooks(ctx context.Context, in *QueryBooksRequest, opts ...grpcweb.CallOption) (BookService_QueryBooksClient, error) {
+	srv, err := c.client.NewClientStream(ctx, false, true, "QueryBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.SendMsg(in.Marshal())
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookServiceQueryBooksClient{srv}, nil
+}
+
+type BookService_QueryBooksClient interface {
+	Recv() (*Book, error)
+	grpcweb.ClientStream
+}
+
+type bookServiceQueryBooksClient struct {
+	grpcweb.ClientStream
+}
+
+func (x *bookServiceQueryBooksClient) Recv() (*Book, error) {
+	resp, err := x.RecvMsg()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Book).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) MakeCollection(ctx context.Context, opts ...grpcweb.CallOption) (BookService_MakeCollectionClient, error) {
+	srv, err := c.client.NewClientStream(ctx, true, false, "MakeCollection", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookServiceMakeCollectionClient{srv}, nil
+}
+
+type BookService_MakeCollectionClient interface {
+	Send(*Book) error
+	CloseAndRecv() (*Collection, error)
+	grpcweb.ClientStream
+}
+
+type bookServiceMakeCollectionClient struct {
+	grpcweb.ClientStream
+}
+
+func (x *bookServiceMakeCollectionClient) Send(req *Book) error {
+	return x.SendMsg(req.Marshal())
+}
+
+func (x *bookServiceMakeCollectionClient) CloseAndRecv() (*Collection, error) {
+	err := x.CloseSend()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := x.RecvMsg()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Collection).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) BookChat(ctx context.Context, opts ...grpcweb.CallOption) (BookService_BookChatClient, error) {
+	srv, err := c.client.NewClientStream(ctx, true, true, "BookChat", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookServiceBookChatClient{srv}, nil
+}
+
+type BookService_BookChatClient interface {
+	Send(*BookMessage) error
+	Recv() (*BookResponse, error)
+	grpcweb.ClientStream
+}
+
+type bookServiceBookChatClient struct {
+	grpcweb.ClientStream
+}
+
+func (x *bookServiceBookChatClient) Send(req *BookMessage) error {
+	return x.SendMsg(req.Marshal())
+}
+
+func (x *bookServiceBookChatClient) Recv() (*BookResponse, error) {
+	resp, err := x.RecvMsg()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(BookResponse).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) ImportBooks(ctx context.Context, opts ...grpcweb.CallOption) (BookService_ImportBooksClient, error) {
+	srv, err := c.client.NewClientStream(ctx, true, false, "ImportBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookServiceImportBooksClient{srv}, nil
+}
+
+type BookService_ImportBooksClient interface {
+	Send(*ImportBooksRequest) error
+	CloseAndRecv() (*ImportBooksResponse, error)
+	grpcweb.ClientStream
+}
+
+type bookServiceImportBooksClient struct {
+	grpcweb.ClientStream
+}
+
+func (x *bookServiceImportBooksClient) Send(req *ImportBooksRequest) error {
+	return x.SendMsg(req.Marshal())
+}
+
+func (x *bookServiceImportBooksClient) CloseAndRecv() (*ImportBooksResponse, error) {
+	err := x.CloseSend()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := x.RecvMsg()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(ImportBooksResponse).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) ExportBooks(ctx context.Context, in *ExportBooksRequest, opts ...grpcweb.CallOption) (BookService_ExportBooksClient, error) {
+	srv, err := c.client.NewClientStream(ctx, false, true, "ExportBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.SendMsg(in.Marshal())
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookServiceExportBooksClient{srv}, nil
+}
+
+type BookService_ExportBooksClient interface {
+	Recv() (*ExportBooksResponse, error)
+	grpcweb.ClientStream
+}
+
+type bookServiceExportBooksClient struct {
+	grpcweb.ClientStream
+}
+
+func (x *bookServiceExportBooksClient) Recv() (*ExportBooksResponse, error) {
+	resp, err := x.RecvMsg()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(ExportBooksResponse).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) WatchBooks(ctx context.Context, in *WatchBooksRequest, opts ...grpcweb.CallOption) (BookService_WatchBooksClient, error) {
+	srv, err := c.client.NewClientStream(ctx, false, true, "WatchBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.SendMsg(in.Marshal())
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookServiceWatchBooksClient{srv}, nil
+}
+
+type BookService_WatchBooksClient interface {
+	Recv() (*BookEvent, error)
+	grpcweb.ClientStream
+}
+
+type bookServiceWatchBooksClient struct {
+	grpcweb.ClientStream
 }
 
-// Marshal marshals BookMessage to a slice of bytes.
-func (m *BookMessage) Marshal() []byte {
-	writer := jspb.NewWriter()
-	m.MarshalToWriter(writer)
-	return writer.GetResult()
+func (x *bookServiceWatchBooksClient) Recv() (*BookEvent, error) {
+	resp, err := x.RecvMsg()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(BookEvent).Unmarshal(resp)
 }
 
-// UnmarshalFromReader unmarshals a BookMessage from the provided reader.
-func (m *BookMessage) UnmarshalFromReader(reader jspb.Reader) *BookMessage {
-	for reader.Next() {
-		if m == nil {
-			m = &BookMessage{}
-		}
+func (c *bookServiceClient) GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpcweb.CallOption) (*Author, error) {
+	resp, err := c.client.RPCCall(ctx, "GetAuthor", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
 
-		switch reader.GetFieldNumber() {
-		case 1:
-			m.Content = &BookMessage_Name{
-				Name: reader.ReadString(),
-			}
-		case 2:
-			m.Content = &BookMessage_Message{
-				Message: reader.ReadString(),
-			}
-		default:
-			reader.SkipField()
-		}
+	return new(Author).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpcweb.CallOption) (*ListAuthorsResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListAuthors", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	return m
+	return new(ListAuthorsResponse).Unmarshal(resp)
 }
 
-// Unmarshal unmarshals a BookMessage from a slice of bytes.
-func (m *BookMessage) Unmarshal(rawBytes []byte) (*BookMessage, error) {
-	reader := jspb.NewReader(rawBytes)
+func (c *bookServiceClient) GetPublisher(ctx context.Context, in *GetPublisherRequest, opts ...grpcweb.CallOption) (*Publisher, error) {
+	resp, err := c.client.RPCCall(ctx, "GetPublisher", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
 
-	m = m.UnmarshalFromReader(reader)
+	return new(Publisher).Unmarshal(resp)
+}
 
-	if err := reader.Err(); err != nil {
+func (c *bookServiceClient) GetCollection(ctx context.Context, in *GetCollectionRequest, opts ...grpcweb.CallOption) (*Collection, error) {
+	resp, err := c.client.RPCCall(ctx, "GetCollection", in.Marshal(), opts...)
+	if err != nil {
 		return nil, err
 	}
 
-	return m, nil
+	return new(Collection).Unmarshal(resp)
 }
 
-// BookResponse is used to discuss books
-type BookResponse struct {
-	// Message is a message from a user.
-	Message string
+func (c *bookServiceClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpcweb.CallOption) (*ListCollectionsResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListCollections", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(ListCollectionsResponse).Unmarshal(resp)
 }
 
-// GetMessage gets the Message of the BookResponse.
-func (m *BookResponse) GetMessage() (x string) {
-	if m == nil {
-		return x
+func (c *bookServiceClient) AddToCollection(ctx context.Context, in *AddToCollectionRequest, opts ...grpcweb.CallOption) (*Collection, error) {
+	resp, err := c.client.RPCCall(ctx, "AddToCollection", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
 	}
-	return m.Message
+
+	return new(Collection).Unmarshal(resp)
 }
 
-// MarshalToWriter marshals BookResponse to the provided writer.
-func (m *BookResponse) MarshalToWriter(writer jspb.Writer) {
-	if m == nil {
-		return
+func (c *bookServiceClient) RemoveFromCollection(ctx context.Context, in *RemoveFromCollectionRequest, opts ...grpcweb.CallOption) (*Collection, error) {
+	resp, err := c.client.RPCCall(ctx, "RemoveFromCollection", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(m.Message) > 0 {
-		writer.WriteString(2, m.Message)
+	return new(Collection).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpcweb.CallOption) (*Collection, error) {
+	resp, err := c.client.RPCCall(ctx, "DeleteCollection", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	return
+	return new(Collection).Unmarshal(resp)
 }
 
-// Marshal marshals BookResponse to a slice of bytes.
-func (m *BookResponse) Marshal() []byte {
-	writer := jspb.NewWriter()
-	m.MarshalToWriter(writer)
-	return writer.GetResult()
+func (c *bookServiceClient) ListCopies(ctx context.Context, in *ListCopiesRequest, opts ...grpcweb.CallOption) (*ListCopiesResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListCopies", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(ListCopiesResponse).Unmarshal(resp)
 }
 
-// UnmarshalFromReader unmarshals a BookResponse from the provided reader.
-func (m *BookResponse) UnmarshalFromReader(reader jspb.Reader) *BookResponse {
-	for reader.Next() {
-		if m == nil {
-			m = &BookResponse{}
-		}
+func (c *bookServiceClient) ListBranches(ctx context.Context, in *ListBranchesRequest, opts ...grpcweb.CallOption) (*ListBranchesResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListBranches", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
 
-		switch reader.GetFieldNumber() {
-		case 2:
-			m.Message = reader.ReadString()
-		default:
-			reader.SkipField()
-		}
+	return new(ListBranchesResponse).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) AddCopy(ctx context.Context, in *AddCopyRequest, opts ...grpcweb.CallOption) (*Copy, error) {
+	resp, err := c.client.RPCCall(ctx, "AddCopy", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	return m
+	return new(Copy).Unmarshal(resp)
 }
 
-// Unmarshal unmarshals a BookResponse from a slice of bytes.
-func (m *BookResponse) Unmarshal(rawBytes []byte) (*BookResponse, error) {
-	reader := jspb.NewReader(rawBytes)
+func (c *bookServiceClient) CheckOut(ctx context.Context, in *CheckOutRequest, opts ...grpcweb.CallOption) (*Loan, error) {
+	resp, err := c.client.RPCCall(ctx, "CheckOut", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
 
-	m = m.UnmarshalFromReader(reader)
+	return new(Loan).Unmarshal(resp)
+}
 
-	if err := reader.Err(); err != nil {
+func (c *bookServiceClient) Return(ctx context.Context, in *ReturnRequest, opts ...grpcweb.CallOption) (*Loan, error) {
+	resp, err := c.client.RPCCall(ctx, "Return", in.Marshal(), opts...)
+	if err != nil {
 		return nil, err
 	}
 
-	return m, nil
+	return new(Loan).Unmarshal(resp)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpcweb.Client
+func (c *bookServiceClient) Renew(ctx context.Context, in *RenewRequest, opts ...grpcweb.CallOption) (*Loan, error) {
+	resp, err := c.client.RPCCall(ctx, "Renew", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpcweb package it is being compiled against.
-const _ = grpcweb.GrpcWebPackageIsVersion3
+	return new(Loan).Unmarshal(resp)
+}
 
-// Client API for BookService service
+func (c *bookServiceClient) ListLoans(ctx context.Context, in *ListLoansRequest, opts ...grpcweb.CallOption) (*ListLoansResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListLoans", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
 
-// BookService exposes GetBook and QueryBooks,
-// which allow querying of the library.
-type BookServiceClient interface {
-	// GetBook returns a Book from the library
-	// that matches the ISBN provided, if found.
-	// Otherwise it returns a NotFound error.
-	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpcweb.CallOption) (*Book, error)
-	// QueryBooks returns all Books whos author
-	// matches the author prefix provided, as a stream
-	// of Books.
-	QueryBooks(ctx context.Context, in *QueryBooksRequest, opts ...grpcweb.CallOption) (BookService_QueryBooksClient, error)
-	// MakeCollection takes a stream of books and returns a Book collection.
-	MakeCollection(ctx context.Context, opts ...grpcweb.CallOption) (BookService_MakeCollectionClient, error)
-	// BookChat allows discussion about books
-	BookChat(ctx context.Context, opts ...grpcweb.CallOption) (BookService_BookChatClient, error)
+	return new(ListLoansResponse).Unmarshal(resp)
 }
 
-type bookServiceClient struct {
-	client *grpcweb.Client
+func (c *bookServiceClient) PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpcweb.CallOption) (*Hold, error) {
+	resp, err := c.client.RPCCall(ctx, "PlaceHold", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Hold).Unmarshal(resp)
 }
 
-// NewBookServiceClient creates a new gRPC-Web client.
-func NewBookServiceClient(hostname string, opts ...grpcweb.DialOption) BookServiceClient {
-	return &bookServiceClient{
-		client: grpcweb.NewClient(hostname, "library.BookService", opts...),
+func (c *bookServiceClient) CancelHold(ctx context.Context, in *CancelHoldRequest, opts ...grpcweb.CallOption) (*Hold, error) {
+	resp, err := c.client.RPCCall(ctx, "CancelHold", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
 	}
+
+	return new(Hold).Unmarshal(resp)
 }
 
-func (c *bookServiceClient) GetBook(ctx context.Context, in *GetBookRequest, opts ...grpcweb.CallOption) (*Book, error) {
-	resp, err := c.client.RPCCall(ctx, "GetBook", in.Marshal(), opts...)
+func (c *bookServiceClient) ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpcweb.CallOption) (*ListHoldsResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListHolds", in.Marshal(), opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return new(Book).Unmarshal(resp)
+	return new(ListHoldsResponse).Unmarshal(resp)
 }
 
-func (c *bookServiceClient) QueryBooks(ctx context.Context, in *QueryBooksRequest, opts ...grpcweb.CallOption) (BookService_QueryBooksClient, error) {
-	srv, err := c.client.NewClientStream(ctx, false, true, "QueryBooks", opts...)
+func (c *bookServiceClient) WatchMyHolds(ctx context.Context, in *WatchMyHoldsRequest, opts ...grpcweb.CallOption) (BookService_WatchMyHoldsClient, error) {
+	srv, err := c.client.NewClientStream(ctx, false, true, "WatchMyHolds", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -773,92 +8267,181 @@ func (c *bookServiceClient) QueryBooks(ctx context.Context, in *QueryBooksReques
 		return nil, err
 	}
 
-	return &bookServiceQueryBooksClient{srv}, nil
+	return &bookServiceWatchMyHoldsClient{srv}, nil
 }
 
-type BookService_QueryBooksClient interface {
-	Recv() (*Book, error)
+type BookService_WatchMyHoldsClient interface {
+	Recv() (*Hold, error)
 	grpcweb.ClientStream
 }
 
-type bookServiceQueryBooksClient struct {
+type bookServiceWatchMyHoldsClient struct {
 	grpcweb.ClientStream
 }
 
-func (x *bookServiceQueryBooksClient) Recv() (*Book, error) {
+func (x *bookServiceWatchMyHoldsClient) Recv() (*Hold, error) {
 	resp, err := x.RecvMsg()
 	if err != nil {
 		return nil, err
 	}
 
-	return new(Book).Unmarshal(resp)
+	return new(Hold).Unmarshal(resp)
 }
 
-func (c *bookServiceClient) MakeCollection(ctx context.Context, opts ...grpcweb.CallOption) (BookService_MakeCollectionClient, error) {
-	srv, err := c.client.NewClientStream(ctx, true, false, "MakeCollection", opts...)
+func (c *bookServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpcweb.CallOption) (*ListJobsResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListJobs", in.Marshal(), opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &bookServiceMakeCollectionClient{srv}, nil
+	return new(ListJobsResponse).Unmarshal(resp)
 }
 
-type BookService_MakeCollectionClient interface {
-	Send(*Book) error
-	CloseAndRecv() (*Collection, error)
-	grpcweb.ClientStream
+func (c *bookServiceClient) SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpcweb.CallOption) (*Review, error) {
+	resp, err := c.client.RPCCall(ctx, "SubmitReview", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Review).Unmarshal(resp)
 }
 
-type bookServiceMakeCollectionClient struct {
-	grpcweb.ClientStream
+func (c *bookServiceClient) UpdateReview(ctx context.Context, in *UpdateReviewRequest, opts ...grpcweb.CallOption) (*Review, error) {
+	resp, err := c.client.RPCCall(ctx, "UpdateReview", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Review).Unmarshal(resp)
 }
 
-func (x *bookServiceMakeCollectionClient) Send(req *Book) error {
-	return x.SendMsg(req.Marshal())
+func (c *bookServiceClient) DeleteReview(ctx context.Context, in *DeleteReviewRequest, opts ...grpcweb.CallOption) (*Review, error) {
+	resp, err := c.client.RPCCall(ctx, "DeleteReview", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Review).Unmarshal(resp)
 }
 
-func (x *bookServiceMakeCollectionClient) CloseAndRecv() (*Collection, error) {
-	err := x.CloseSend()
+func (c *bookServiceClient) MarkReviewHelpful(ctx context.Context, in *MarkReviewHelpfulRequest, opts ...grpcweb.CallOption) (*Review, error) {
+	resp, err := c.client.RPCCall(ctx, "MarkReviewHelpful", in.Marshal(), opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := x.RecvMsg()
+	return new(Review).Unmarshal(resp)
+}
+
+func (c *bookServiceClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpcweb.CallOption) (*ListReviewsResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListReviews", in.Marshal(), opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return new(Collection).Unmarshal(resp)
+	return new(ListReviewsResponse).Unmarshal(resp)
 }
 
-func (c *bookServiceClient) BookChat(ctx context.Context, opts ...grpcweb.CallOption) (BookService_BookChatClient, error) {
-	srv, err := c.client.NewClientStream(ctx, true, true, "BookChat", opts...)
+func (c *bookServiceClient) RecommendBooks(ctx context.Context, in *RecommendBooksRequest, opts ...grpcweb.CallOption) (*RecommendBooksResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "RecommendBooks", in.Marshal(), opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &bookServiceBookChatClient{srv}, nil
+	return new(RecommendBooksResponse).Unmarshal(resp)
 }
 
-type BookService_BookChatClient interface {
-	Send(*BookMessage) error
-	Recv() (*BookResponse, error)
-	grpcweb.ClientStream
+func (c *bookServiceClient) UpdateReading(ctx context.Context, in *UpdateReadingRequest, opts ...grpcweb.CallOption) (*ReadingEntry, error) {
+	resp, err := c.client.RPCCall(ctx, "UpdateReading", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(ReadingEntry).Unmarshal(resp)
 }
 
-type bookServiceBookChatClient struct {
-	grpcweb.ClientStream
+func (c *bookServiceClient) RemoveReading(ctx context.Context, in *RemoveReadingRequest, opts ...grpcweb.CallOption) (*ReadingEntry, error) {
+	resp, err := c.client.RPCCall(ctx, "RemoveReading", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(ReadingEntry).Unmarshal(resp)
 }
 
-func (x *bookServiceBookChatClient) Send(req *BookMessage) error {
-	return x.SendMsg(req.Marshal())
+func (c *bookServiceClient) ListReading(ctx context.Context, in *ListReadingRequest, opts ...grpcweb.CallOption) (*ListReadingResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListReading", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(ListReadingResponse).Unmarshal(resp)
 }
 
-func (x *bookServiceBookChatClient) Recv() (*BookResponse, error) {
-	resp, err := x.RecvMsg()
+// Client API for UserService service
+
+// UserService manages the accounts of users.
+type UserServiceClient interface {
+	// Register creates the account of a user, and returns its profile. If
+	// the name or display name is taken, it returns an AlreadyExists error.
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpcweb.CallOption) (*User, error)
+	// Login starts a session of a user. If the name or password is wrong,
+	// it returns an Unauthenticated error. After 5 failed logins in a row,
+	// the account is locked for 15 minutes, during which logins return
+	// the same error, even with the right password.
+	Login(ctx context.Context, in *LoginRequest, opts ...grpcweb.CallOption) (*LoginResponse, error)
+	// GetUser returns the profile of a user. If there is no
+	// such user, it returns a NotFound error.
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpcweb.CallOption) (*User, error)
+	// UpdateProfile changes the profile of the user of the session
+	// in the request metadata, and returns it. If the display name
+	// is taken, it returns an AlreadyExists error.
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpcweb.CallOption) (*User, error)
+}
+
+type userServiceClient struct {
+	client *grpcweb.Client
+}
+
+// NewUserServiceClient creates a new gRPC-Web client.
+func NewUserServiceClient(hostname string, opts ...grpcweb.DialOption) UserServiceClient {
+	return &userServiceClient{
+		client: grpcweb.NewClient(hostname, "library.UserService", opts...),
+	}
+}
+
+func (c *userServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpcweb.CallOption) (*User, error) {
+	resp, err := c.client.RPCCall(ctx, "Register", in.Marshal(), opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return new(BookResponse).Unmarshal(resp)
+	return new(User).Unmarshal(resp)
+}
+
+func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpcweb.CallOption) (*LoginResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "Login", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(LoginResponse).Unmarshal(resp)
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpcweb.CallOption) (*User, error) {
+	resp, err := c.client.RPCCall(ctx, "GetUser", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(User).Unmarshal(resp)
+}
+
+func (c *userServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpcweb.CallOption) (*User, error) {
+	resp, err := c.client.RPCCall(ctx, "UpdateProfile", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(User).Unmarshal(resp)
 }
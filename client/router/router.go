@@ -0,0 +1,78 @@
+// Package router implements client side routing
+// using the browser history API.
+package router
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"honnef.co/go/js/dom"
+	r "myitcv.io/react"
+)
+
+var (
+	listeners      = map[int]func(string){}
+	nextListenerID int
+	popState       func(*js.Object)
+)
+
+// Path returns the path of the current location.
+func Path() string {
+	return dom.GetWindow().Location().Pathname
+}
+
+// Navigate adds path to the browser history and
+// notifies all listeners of the new location.
+func Navigate(path string) {
+	if path == Path() {
+		return
+	}
+	dom.GetWindow().History().PushState(nil, "", path)
+	notify(path)
+}
+
+// Listen registers fn to be called with the new path whenever the location
+// changes, be it through Navigate or the browser back and forward buttons.
+// Calling the returned function unregisters fn.
+func Listen(fn func(path string)) func() {
+	if popState == nil {
+		popState = dom.GetWindow().AddEventListener("popstate", false, func(dom.Event) {
+			notify(Path())
+		})
+	}
+
+	id := nextListenerID
+	nextListenerID++
+	listeners[id] = fn
+	return func() {
+		delete(listeners, id)
+		if len(listeners) == 0 && popState != nil {
+			dom.GetWindow().RemoveEventListener("popstate", false, popState)
+			popState = nil
+		}
+	}
+}
+
+func notify(path string) {
+	for _, fn := range listeners {
+		fn(path)
+	}
+}
+
+// Link returns an anchor element which navigates to path when clicked,
+// without reloading the page. The Href and OnClick fields of props
+// are overwritten. props may be nil.
+func Link(path string, props *r.AProps, children ...r.Element) *r.AElem {
+	var p r.AProps
+	if props != nil {
+		p = *props
+	}
+	p.Href = path
+	p.OnClick = navigate(path)
+	return r.A(&p, children...)
+}
+
+type navigate string
+
+func (n navigate) OnClick(se *r.SyntheticMouseEvent) {
+	se.PreventDefault()
+	Navigate(string(n))
+}
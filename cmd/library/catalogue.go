@@ -0,0 +1,292 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/internal/bookfmt"
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func formatNames() string {
+	var names []string
+	for _, f := range bookfmt.Formats {
+		names = append(names, string(f))
+	}
+	return strings.Join(names, ", ")
+}
+
+// fileFormat returns the format named by the format flag,
+// or the format of the file fileName if the flag is not set.
+func fileFormat(format, fileName string) (bookfmt.Format, error) {
+	if format != "" {
+		return bookfmt.ParseFormat(format)
+	}
+	if fileName == "" || fileName == "-" {
+		return "", fmt.Errorf("the --format flag is required when using stdin or stdout")
+	}
+	return bookfmt.FormatFromFileName(fileName)
+}
+
+// rowError is an error in a row of an imported file.
+type rowError struct {
+	row int
+	msg string
+}
+
+func newImportCmd(opts *options, in io.Reader, out io.Writer) *cobra.Command {
+	var format, adminTokenFile string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import books into the library from FILE",
+		Long: "Import books into the library from FILE, or stdin if FILE is -.\n" +
+			"Books with the same ISBN as a book in the library replace it.\n" +
+			"Rows that are not valid books are reported, and not imported.\n" +
+			"Importing requires the admin token of the server.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := fileFormat(format, args[0])
+			if err != nil {
+				return err
+			}
+			r := in
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				r = file
+			}
+			dec, err := bookfmt.NewDecoder(f, r)
+			if err != nil {
+				return err
+			}
+			token, err := ioutil.ReadFile(adminTokenFile)
+			if err != nil {
+				return err
+			}
+
+			client, closeClient, err := newClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			ctx, cancel := callContext(opts)
+			defer cancel()
+			ctx = metadata.AppendToOutgoingContext(ctx, "admin-token", strings.TrimSpace(string(token)))
+			stream, err := client.ImportBooks(ctx)
+			if err != nil {
+				return err
+			}
+			err = stream.Send(&library.ImportBooksRequest{
+				Content: &library.ImportBooksRequest_Options{
+					Options: &library.ImportOptions{DryRun: dryRun},
+				},
+			})
+			var rows []int
+			var rowErrs []rowError
+			if err == nil {
+				rows, rowErrs, err = sendBooks(dec, stream)
+			}
+			// Sending fails with io.EOF if the call
+			// has failed, CloseAndRecv returns why.
+			if err != nil && err != io.EOF {
+				return err
+			}
+			resp, err := stream.CloseAndRecv()
+			if err != nil {
+				return err
+			}
+
+			for _, e := range resp.GetErrors() {
+				row := 0
+				if int(e.Index) < len(rows) {
+					row = rows[e.Index]
+				}
+				rowErrs = append(rowErrs, rowError{row: row, msg: e.Message})
+			}
+			sort.SliceStable(rowErrs, func(i, j int) bool {
+				return rowErrs[i].row < rowErrs[j].row
+			})
+			for _, e := range rowErrs {
+				fmt.Fprintf(out, "row %d: %s\n", e.row, e.msg)
+			}
+			if dryRun {
+				fmt.Fprintf(out, "Dry run: would create %s and update %s\n", plural(resp.Created, "book"), plural(resp.Updated, "book"))
+			} else {
+				fmt.Fprintf(out, "Created %s and updated %s\n", plural(resp.Created, "book"), plural(resp.Updated, "book"))
+			}
+			if len(rowErrs) > 0 {
+				return fmt.Errorf("%s could not be imported", plural(int32(len(rowErrs)), "row"))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "", "format of FILE, one of "+formatNames()+", instead of the format of its extension")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate the books without importing them")
+	cmd.Flags().StringVar(&adminTokenFile, "admin-token-file", "", "file with the admin token of the server")
+	cmd.MarkFlagRequired("admin-token-file")
+	return cmd
+}
+
+// sendBooks sends the books decoded by dec on stream, and returns
+// the row of each book sent and the errors of the rows not sent.
+func sendBooks(dec bookfmt.Decoder, stream library.BookService_ImportBooksClient) ([]int, []rowError, error) {
+	var rows []int
+	var rowErrs []rowError
+	for {
+		bk, row, err := dec.Decode()
+		if err == io.EOF {
+			return rows, rowErrs, nil
+		}
+		if rerr, ok := err.(*bookfmt.RowError); ok {
+			rowErrs = append(rowErrs, rowError{row: rerr.Row, msg: rerr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		err = stream.Send(&library.ImportBooksRequest{
+			Content: &library.ImportBooksRequest_Book{Book: bk},
+		})
+		if err != nil {
+			return rows, rowErrs, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func newExportCmd(opts *options, out io.Writer) *cobra.Command {
+	var format string
+	var retries int
+	cmd := &cobra.Command{
+		Use:   "export [FILE]",
+		Short: "Export the books in the library to FILE",
+		Long: "Export the books in the library to FILE, or stdout if FILE is - or omitted.\n" +
+			"Books are exported in ISBN order. An interrupted export is resumed from\n" +
+			"the last book received.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fileName := "-"
+			if len(args) > 0 {
+				fileName = args[0]
+			}
+			f, err := fileFormat(format, fileName)
+			if err != nil {
+				return err
+			}
+
+			client, closeClient, err := newClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			w := out
+			var file *os.File
+			if fileName != "-" {
+				file, err = os.Create(fileName)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				w = file
+			}
+			enc, err := bookfmt.NewEncoder(f, w)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := callContext(opts)
+			defer cancel()
+			var token string
+			for attempt := 0; ; attempt++ {
+				token, err = exportBooks(ctx, client, token, enc)
+				if err == nil {
+					break
+				}
+				if attempt == retries || !retryable(err) {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "Export interrupted: %v, resuming\n", err)
+				select {
+				case <-time.After(retryDelay << uint(attempt)):
+				case <-ctx.Done():
+					return err
+				}
+			}
+			err = enc.Close()
+			if err != nil {
+				return err
+			}
+			if file != nil {
+				return file.Close()
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "", "format of FILE, one of "+formatNames()+", instead of the format of its extension")
+	cmd.Flags().IntVar(&retries, "retries", 3, "number of times to resume an interrupted export")
+	return cmd
+}
+
+// retryDelay is the delay before resuming an interrupted export
+// the first time. It doubles with each following attempt.
+var retryDelay = 500 * time.Millisecond
+
+// exportBooks encodes the books exported after token with enc,
+// and returns the resume token of the last book encoded.
+func exportBooks(ctx context.Context, client library.BookServiceClient, token string, enc bookfmt.Encoder) (string, error) {
+	stream, err := client.ExportBooks(ctx, &library.ExportBooksRequest{ResumeToken: token})
+	if err != nil {
+		return token, err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return token, nil
+		}
+		if err != nil {
+			return token, err
+		}
+		err = enc.Encode(resp.GetBook())
+		if err != nil {
+			return token, err
+		}
+		token = resp.GetResumeToken()
+	}
+}
+
+// retryable reports whether an export that
+// failed with err should be resumed.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.Internal:
+		return true
+	}
+	return false
+}
+
+func plural(n int32, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,279 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func newGetCmd(opts *options, out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ISBN",
+		Short: "Get a book by its ISBN",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			isbn, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid ISBN %q", args[0])
+			}
+			client, closeClient, err := newClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			ctx, cancel := callContext(opts)
+			defer cancel()
+			bk, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: isbn})
+			if err != nil {
+				return err
+			}
+			return printBooks(out, opts.output, []*library.Book{bk})
+		},
+	}
+}
+
+func newQueryCmd(opts *options, out io.Writer) *cobra.Command {
+	var authorID, branchID string
+	cmd := &cobra.Command{
+		Use:   "query [AUTHOR_PREFIX]",
+		Short: "List the books with authors starting with AUTHOR_PREFIX",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &library.QueryBooksRequest{AuthorId: authorID, AvailableAtBranch: branchID}
+			if len(args) > 0 {
+				req.AuthorPrefix = args[0]
+			}
+			client, closeClient, err := newClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			ctx, cancel := callContext(opts)
+			defer cancel()
+			stream, err := client.QueryBooks(ctx, req)
+			if err != nil {
+				return err
+			}
+			var books []*library.Book
+			for {
+				bk, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				books = append(books, bk)
+			}
+			return printBooks(out, opts.output, books)
+		},
+	}
+	cmd.Flags().StringVar(&authorID, "author-id", "", "only list the books of the author with this ID, see the authors command")
+	cmd.Flags().StringVar(&branchID, "available-at", "", "only list the books with a copy available at the branch with this ID, see the branches command")
+	return cmd
+}
+
+func newBranchesCmd(opts *options, out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "branches",
+		Short: "List the branches of the library",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, closeClient, err := newClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			ctx, cancel := callContext(opts)
+			defer cancel()
+			resp, err := client.ListBranches(ctx, &library.ListBranchesRequest{})
+			if err != nil {
+				return err
+			}
+			return printBranches(out, opts.output, resp.GetBranches())
+		},
+	}
+}
+
+func newAuthorsCmd(opts *options, out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "authors [NAME_PREFIX]",
+		Short: "List the authors with names starting with NAME_PREFIX",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &library.ListAuthorsRequest{}
+			if len(args) > 0 {
+				req.NamePrefix = args[0]
+			}
+			client, closeClient, err := newClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			ctx, cancel := callContext(opts)
+			defer cancel()
+			resp, err := client.ListAuthors(ctx, req)
+			if err != nil {
+				return err
+			}
+			return printAuthors(out, opts.output, resp.GetAuthors())
+		},
+	}
+}
+
+func newCollectCmd(opts *options, in io.Reader, out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "collect",
+		Short: "Make a collection of the books with the ISBNs read from stdin",
+		Long: "Make a collection of the books with the ISBNs read from stdin.\n" +
+			"ISBNs are separated by whitespace. ISBNs of books that are not in the\n" +
+			"library, or that were already read, are reported.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, closeClient, err := newClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			ctx, cancel := callContext(opts)
+			defer cancel()
+			stream, err := client.MakeCollection(ctx)
+			if err != nil {
+				return err
+			}
+			sc := bufio.NewScanner(in)
+			sc.Split(bufio.ScanWords)
+			for sc.Scan() {
+				isbn, err := strconv.ParseInt(sc.Text(), 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid ISBN %q", sc.Text())
+				}
+				err = stream.Send(&library.Book{Isbn: isbn})
+				if err == io.EOF {
+					// The call has failed, CloseAndRecv returns why
+					break
+				}
+				if err != nil {
+					return err
+				}
+			}
+			if err := sc.Err(); err != nil {
+				return err
+			}
+			collection, err := stream.CloseAndRecv()
+			if err != nil {
+				return err
+			}
+			err = printBooks(out, opts.output, collection.GetBooks())
+			if err != nil {
+				return err
+			}
+			for _, e := range collection.GetErrors() {
+				fmt.Fprintf(out, "ISBN %d: %s\n", e.GetIsbn(), e.GetMessage())
+			}
+			if n := len(collection.GetErrors()); n > 0 {
+				return fmt.Errorf("%s could not be collected", plural(int32(n), "book"))
+			}
+			return nil
+		},
+	}
+}
+
+func newChatCmd(opts *options, in io.Reader, out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "chat NAME",
+		Short: "Join the book chat as NAME",
+		Long: "Join the book chat as NAME. Every line read from stdin is sent\n" +
+			"to the chat, and the messages of the chat are printed to stdout.\n" +
+			"The chat is left at the end of stdin. Servers with users only let\n" +
+			"the user logged in with --session chat under their display name.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, closeClient, err := newClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			ctx, cancel := context.WithCancel(sessionContext(opts))
+			defer cancel()
+			stream, err := client.BookChat(ctx)
+			if err != nil {
+				return err
+			}
+			err = stream.Send(&library.BookMessage{
+				Content: &library.BookMessage_Name{Name: args[0]},
+			})
+			if err != nil {
+				return recvErr(stream, err)
+			}
+
+			recvErrChan := make(chan error, 1)
+			go func() {
+				for {
+					msg, err := stream.Recv()
+					if err == io.EOF {
+						close(recvErrChan)
+						return
+					}
+					if err != nil {
+						recvErrChan <- err
+						return
+					}
+					fmt.Fprintln(out, msg.GetMessage())
+				}
+			}()
+
+			sc := bufio.NewScanner(in)
+			for sc.Scan() {
+				line := strings.TrimSpace(sc.Text())
+				if line == "" {
+					continue
+				}
+				err = stream.Send(&library.BookMessage{
+					Content: &library.BookMessage_Message{Message: line},
+				})
+				if err != nil {
+					// The chat has ended, and the receiving
+					// goroutine reports the reason.
+					break
+				}
+			}
+			if err := sc.Err(); err != nil {
+				return err
+			}
+			// If the chat has already ended, CloseSend fails, and
+			// the receiving goroutine reports the reason instead.
+			_ = stream.CloseSend()
+			return <-recvErrChan
+		},
+	}
+}
+
+// recvErr returns the status of stream after
+// sending on it failed with err.
+func recvErr(stream library.BookService_BookChatClient, err error) error {
+	if err != io.EOF {
+		return err
+	}
+	_, err = stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
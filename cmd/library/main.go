@@ -0,0 +1,199 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Command library is a command line client for the BookService
+// and the UserService.
+// It talks to the server using either native gRPC or gRPC-Web.
+//
+//	$ library get 140008381
+//	$ library query George --output json
+//	$ library authors Lewis
+//	$ echo 140008381 60929871 | library collect
+//	$ library register alice < password.txt
+//	$ library chat Alice --session $(library login alice < password.txt)
+//	$ library import books.csv --dry-run --admin-token-file token
+//	$ library export books.xml
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/johanbrandhorst/grpcweb-example/grpcwebclient"
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func main() {
+	cmd := newRootCmd(os.Stdin, os.Stdout)
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// options holds the values of the global flags.
+type options struct {
+	addr               string
+	transport          string
+	plaintext          bool
+	caCert             string
+	insecureSkipVerify bool
+	serverName         string
+	output             string
+	timeout            time.Duration
+	session            string
+}
+
+func newRootCmd(in io.Reader, out io.Writer) *cobra.Command {
+	opts := &options{}
+	cmd := &cobra.Command{
+		Use:          "library",
+		Short:        "library is a command line client for the BookService",
+		SilenceUsage: true,
+		// Errors are printed to stderr by main
+		SilenceErrors: true,
+	}
+	cmd.SetOutput(out)
+
+	f := cmd.PersistentFlags()
+	f.StringVar(&opts.addr, "addr", "localhost:10000", "address of the server")
+	f.StringVar(&opts.transport, "transport", "grpc", `transport to use, one of "grpc", "grpcweb" or "grpcweb-text"`)
+	f.BoolVar(&opts.plaintext, "plaintext", false, "connect without TLS")
+	f.StringVar(&opts.caCert, "ca-cert", "", "PEM file with the CA certificate to verify the server with, instead of the system roots")
+	f.BoolVar(&opts.insecureSkipVerify, "insecure-skip-verify", false, "do not verify the certificate of the server")
+	f.StringVar(&opts.serverName, "server-name", "", "name to verify the certificate of the server against, instead of the host of addr")
+	f.StringVarP(&opts.output, "output", "o", "table", `format to print books in, "table" or "json"`)
+	f.DurationVar(&opts.timeout, "timeout", 10*time.Second, "timeout of calls, except for chat")
+	f.StringVar(&opts.session, "session", "", "session printed by login, to make calls as the user logged in")
+
+	cmd.AddCommand(
+		newGetCmd(opts, out),
+		newQueryCmd(opts, out),
+		newAuthorsCmd(opts, out),
+		newBranchesCmd(opts, out),
+		newCollectCmd(opts, in, out),
+		newChatCmd(opts, in, out),
+		newImportCmd(opts, in, out),
+		newExportCmd(opts, out),
+		newRegisterCmd(opts, in, out),
+		newLoginCmd(opts, in, out),
+	)
+	return cmd
+}
+
+// conn is a connection to a server, over native gRPC
+// or gRPC-Web, to make the clients of its services with.
+type conn struct {
+	cc    *grpc.ClientConn
+	web   *grpcwebclient.Client
+	close func()
+}
+
+// newClient returns a client for the server described by opts,
+// and a function that closes its connection.
+func newClient(opts *options) (library.BookServiceClient, func(), error) {
+	c, err := dial(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.web != nil {
+		return grpcwebclient.NewBookServiceClient(c.web), c.close, nil
+	}
+	return library.NewBookServiceClient(c.cc), c.close, nil
+}
+
+// newUserClient is like newClient, but returns
+// a client of the UserService of the server.
+func newUserClient(opts *options) (library.UserServiceClient, func(), error) {
+	c, err := dial(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.web != nil {
+		return grpcwebclient.NewUserServiceClient(c.web), c.close, nil
+	}
+	return library.NewUserServiceClient(c.cc), c.close, nil
+}
+
+// dial connects to the server described by opts.
+func dial(opts *options) (*conn, error) {
+	var tlsConfig *tls.Config
+	if !opts.plaintext {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: opts.insecureSkipVerify,
+			ServerName:         opts.serverName,
+		}
+		if opts.caCert != "" {
+			pem, err := ioutil.ReadFile(opts.caCert)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in %q", opts.caCert)
+			}
+		}
+	}
+
+	switch opts.transport {
+	case "grpc":
+		dialOpt := grpc.WithInsecure()
+		if tlsConfig != nil {
+			dialOpt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+		}
+		cc, err := grpc.Dial(opts.addr, dialOpt)
+		if err != nil {
+			return nil, err
+		}
+		return &conn{cc: cc, close: func() { cc.Close() }}, nil
+	case "grpcweb", "grpcweb-text":
+		transport := &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+		url := "https://" + opts.addr
+		if tlsConfig == nil {
+			url = "http://" + opts.addr
+		}
+		clientOpts := []grpcwebclient.Option{
+			grpcwebclient.WithHTTPClient(&http.Client{Transport: transport}),
+		}
+		if opts.transport == "grpcweb-text" {
+			clientOpts = append(clientOpts, grpcwebclient.WithTextFormat())
+		}
+		web := grpcwebclient.New(url, clientOpts...)
+		return &conn{web: web, close: transport.CloseIdleConnections}, nil
+	}
+	return nil, fmt.Errorf("unknown transport %q", opts.transport)
+}
+
+// callContext returns the context to make calls with,
+// which times out after the duration of the timeout flag.
+func callContext(opts *options) (context.Context, context.CancelFunc) {
+	if opts.timeout <= 0 {
+		return context.WithCancel(sessionContext(opts))
+	}
+	return context.WithTimeout(sessionContext(opts), opts.timeout)
+}
+
+// sessionContext returns a context sending the
+// session of the session flag, if set.
+func sessionContext(opts *options) context.Context {
+	ctx := context.Background()
+	if opts.session != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "session", opts.session)
+	}
+	return ctx
+}
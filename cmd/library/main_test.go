@@ -0,0 +1,414 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/internal/grpcwebtext"
+	"github.com/johanbrandhorst/grpcweb-example/server"
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// newTestServers starts srv serving native gRPC and gRPC-Web,
+// with its users if any, and returns the flags to use each of them.
+func newTestServers(t *testing.T, srv library.BookServiceServer) (map[string][]string, func()) {
+	gs := grpc.NewServer()
+	library.RegisterBookServiceServer(gs, srv)
+	if bs, ok := srv.(*server.BookService); ok && bs.Users != nil {
+		library.RegisterUserServiceServer(gs, bs.Users)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go gs.Serve(lis)
+	hs := httptest.NewServer(grpcwebtext.Handler(grpcweb.WrapServer(gs, grpcweb.WithWebsockets(true))))
+
+	webAddr := strings.TrimPrefix(hs.URL, "http://")
+	return map[string][]string{
+		"GRPC":        {"--plaintext", "--addr", lis.Addr().String()},
+		"GRPCWeb":     {"--plaintext", "--addr", webAddr, "--transport", "grpcweb"},
+		"GRPCWebText": {"--plaintext", "--addr", webAddr, "--transport", "grpcweb-text"},
+	}, func() {
+		hs.Close()
+		gs.Stop()
+	}
+}
+
+func run(in io.Reader, args ...string) (string, error) {
+	out := new(bytes.Buffer)
+	cmd := newRootCmd(in, out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+// writeFile writes content to a temporary file, and returns its name.
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	f, err := ioutil.TempFile("", "library")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+func TestCommands(t *testing.T) {
+	servers, cleanup := newTestServers(t, &server.BookService{})
+	defer cleanup()
+
+	for name, flags := range servers {
+		flags := flags
+		t.Run(name, func(t *testing.T) {
+			t.Run("Get", func(t *testing.T) {
+				out, err := run(nil, append([]string{"get", "140008381"}, flags...)...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := "ISBN       TITLE        AUTHOR         TYPE       PUBLISHER         PUBLISHED  AVAILABLE\n" +
+					"140008381  Animal Farm  George Orwell  HARDCOVER  Secker & Warburg  1945       2/2\n"
+				if out != want {
+					t.Errorf("got\n%s\nwant\n%s", out, want)
+				}
+			})
+
+			t.Run("GetNotFound", func(t *testing.T) {
+				_, err := run(nil, append([]string{"get", "1"}, flags...)...)
+				if err == nil || !strings.Contains(err.Error(), "Book could not be found") {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+
+			t.Run("QueryJSON", func(t *testing.T) {
+				out, err := run(nil, append([]string{"query", "George", "-o", "json"}, flags...)...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				if len(lines) != 2 ||
+					!strings.HasPrefix(lines[0], `{"isbn":"140009728","title":"Nineteen Eighty-Four"`) ||
+					!strings.HasPrefix(lines[1], `{"isbn":"140008381","title":"Animal Farm"`) {
+					t.Errorf("unexpected output:\n%s", out)
+				}
+			})
+
+			t.Run("Authors", func(t *testing.T) {
+				out, err := run(nil, append([]string{"authors", "L"}, flags...)...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := "ID             NAME\n" +
+					"lewis-carroll  Lewis Carroll\n" +
+					"lisa-genova    Lisa Genova\n"
+				if out != want {
+					t.Errorf("got\n%s\nwant\n%s", out, want)
+				}
+			})
+
+			t.Run("QueryAuthorID", func(t *testing.T) {
+				out, err := run(nil, append([]string{"query", "--author-id", "lisa-genova"}, flags...)...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(out, "Still Alice") || strings.Count(out, "\n") != 2 {
+					t.Errorf("unexpected output:\n%s", out)
+				}
+			})
+
+			t.Run("Branches", func(t *testing.T) {
+				out, err := run(nil, append([]string{"branches"}, flags...)...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := "ID         NAME              ADDRESS\n" +
+					"central    Central Library   1 Library Square\n" +
+					"riverside  Riverside Branch  42 River Road\n"
+				if out != want {
+					t.Errorf("got\n%s\nwant\n%s", out, want)
+				}
+			})
+
+			t.Run("QueryAvailableAt", func(t *testing.T) {
+				_, err := run(nil, append([]string{"query", "--available-at", "unknown"}, flags...)...)
+				if status.Code(err) != codes.NotFound {
+					t.Errorf("got error %v, want code %v", err, codes.NotFound)
+				}
+			})
+
+			t.Run("Collect", func(t *testing.T) {
+				out, err := run(strings.NewReader("140008381\n60929871\n"), append([]string{"collect"}, flags...)...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				if len(lines) != 3 || !strings.Contains(lines[1], "Animal Farm") || !strings.Contains(lines[2], "Brave New World") {
+					t.Errorf("unexpected output:\n%s", out)
+				}
+			})
+
+			t.Run("CollectErrors", func(t *testing.T) {
+				out, err := run(strings.NewReader("140008381 1 140008381"), append([]string{"collect", "-o", "json"}, flags...)...)
+				if err == nil || err.Error() != "2 books could not be collected" {
+					t.Errorf("unexpected error: %v", err)
+				}
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				if len(lines) != 3 ||
+					!strings.HasPrefix(lines[0], `{"isbn":"140008381","title":"Animal Farm"`) ||
+					lines[1] != "ISBN 1: Book could not be found" ||
+					lines[2] != "ISBN 140008381: Book was already added" {
+					t.Errorf("unexpected output:\n%s", out)
+				}
+			})
+
+			t.Run("CollectInvalid", func(t *testing.T) {
+				_, err := run(strings.NewReader("1 two"), append([]string{"collect"}, flags...)...)
+				if err == nil || err.Error() != `invalid ISBN "two"` {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+
+			t.Run("Chat", func(t *testing.T) {
+				// Stdin is only closed once the message has been
+				// received, as leaving the chat ends the stream.
+				pr, pw := io.Pipe()
+				out := &syncBuffer{}
+				cmd := newRootCmd(pr, out)
+				cmd.SetArgs(append([]string{"chat", "Alice"}, flags...))
+				errChan := make(chan error, 1)
+				go func() {
+					errChan <- cmd.Execute()
+				}()
+
+				_, err := io.WriteString(pw, "Hello\n\n")
+				if err != nil {
+					t.Fatal(err)
+				}
+				// The history of the chat, which is shared
+				// by the transports, is received first.
+				want := "Alice: Hello\n"
+				deadline := time.Now().Add(5 * time.Second)
+				for !strings.HasSuffix(out.String(), want) && time.Now().Before(deadline) {
+					time.Sleep(10 * time.Millisecond)
+				}
+				pw.Close()
+				err = <-errChan
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.HasSuffix(out.String(), want) {
+					t.Errorf("got %q, want it to end with %q", out.String(), want)
+				}
+			})
+		})
+	}
+}
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestUnknownTransport(t *testing.T) {
+	_, err := run(nil, "get", "1", "--transport", "carrier-pigeon")
+	if err == nil || err.Error() != `unknown transport "carrier-pigeon"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestImportExport(t *testing.T) {
+	for _, name := range []string{"GRPC", "GRPCWeb", "GRPCWebText"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			servers, cleanup := newTestServers(t, &server.BookService{AdminToken: "secret"})
+			defer cleanup()
+			flags := servers[name]
+			wrongToken := writeFile(t, "wrong")
+			defer os.Remove(wrongToken)
+			token := writeFile(t, "secret\n")
+			defer os.Remove(token)
+
+			in := `isbn,title,author,book_type
+140008381,Animal Farm,George Orwell,paperback
+9780141036144,Nineteen Eighty-Four,George Orwell,PAPERBACK
+1,Nineteen Eighty-Four,George Orwell,ebook
+2,Untitled,,PAPERBACK
+`
+			_, err := run(strings.NewReader(in), append([]string{"import", "-", "--format", "csv", "--admin-token-file", wrongToken}, flags...)...)
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("got error %v, want code %v", err, codes.PermissionDenied)
+			}
+
+			out, err := run(strings.NewReader(in), append([]string{"import", "-", "--format", "csv", "--dry-run", "--admin-token-file", token}, flags...)...)
+			if err == nil || err.Error() != "2 rows could not be imported" {
+				t.Errorf("unexpected error: %v", err)
+			}
+			want := `row 4: unknown book type "ebook"
+row 5: missing author
+Dry run: would create 1 book and update 1 book
+`
+			if out != want {
+				t.Errorf("got\n%s\nwant\n%s", out, want)
+			}
+
+			out, err = run(strings.NewReader(in), append([]string{"import", "-", "--format", "csv", "--admin-token-file", token}, flags...)...)
+			if err == nil {
+				t.Error("got no error")
+			}
+			if !strings.HasSuffix(out, "Created 1 book and updated 1 book\n") {
+				t.Errorf("unexpected output:\n%s", out)
+			}
+
+			out, err = run(nil, append([]string{"export", "--format", "csv"}, flags...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(out, "\n")
+			if len(lines) != 8 ||
+				lines[0] != "isbn,title,author,book_type,publisher,self_published,publication_date" ||
+				lines[2] != "140008381,Animal Farm,George Orwell,PAPERBACK,,," ||
+				lines[6] != "9780141036144,Nineteen Eighty-Four,George Orwell,PAPERBACK,,," {
+				t.Errorf("unexpected output:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestUsers(t *testing.T) {
+	users := &server.UserService{PasswordCost: bcrypt.MinCost}
+	servers, cleanup := newTestServers(t, &server.BookService{Users: users})
+	defer cleanup()
+
+	for name, flags := range servers {
+		flags := flags
+		user := strings.ToLower(name)
+		t.Run(name, func(t *testing.T) {
+			out, err := run(strings.NewReader("correct horse\n"), append([]string{"register", user, "--display-name", name}, flags...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "Registered " + user + " as " + name + "\n"; out != want {
+				t.Errorf("got %q, want %q", out, want)
+			}
+
+			_, err = run(strings.NewReader("wrong horse\n"), append([]string{"login", user}, flags...)...)
+			if status.Code(err) != codes.Unauthenticated {
+				t.Errorf("got error %v, want code %v", err, codes.Unauthenticated)
+			}
+			out, err = run(strings.NewReader("correct horse\n"), append([]string{"login", user}, flags...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			session := strings.TrimSpace(out)
+
+			// Only the user logged in chats under their display name
+			_, err = run(strings.NewReader(""), append([]string{"chat", name}, flags...)...)
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("got error %v, want code %v", err, codes.PermissionDenied)
+			}
+			_, err = run(strings.NewReader(""), append([]string{"chat", name, "--session", session}, flags...)...)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFormatRequired(t *testing.T) {
+	_, err := run(nil, "export")
+	if err == nil || err.Error() != "the --format flag is required when using stdin or stdout" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// interruptingService interrupts the first
+// export after two books have been sent.
+type interruptingService struct {
+	server.BookService
+	interrupted bool
+}
+
+func (s *interruptingService) ExportBooks(req *library.ExportBooksRequest, srv library.BookService_ExportBooksServer) error {
+	if s.interrupted {
+		return s.BookService.ExportBooks(req, srv)
+	}
+	s.interrupted = true
+	return s.BookService.ExportBooks(req, &interruptingStream{BookService_ExportBooksServer: srv})
+}
+
+type interruptingStream struct {
+	library.BookService_ExportBooksServer
+	sent int
+}
+
+func (s *interruptingStream) Send(resp *library.ExportBooksResponse) error {
+	if s.sent == 2 {
+		return status.Error(codes.Unavailable, "interrupted")
+	}
+	s.sent++
+	return s.BookService_ExportBooksServer.Send(resp)
+}
+
+func TestExportResume(t *testing.T) {
+	retryDelay = 0
+	for _, name := range []string{"GRPC", "GRPCWeb", "GRPCWebText"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			servers, cleanup := newTestServers(t, &interruptingService{})
+			defer cleanup()
+
+			out, err := run(nil, append([]string{"export", "--format", "jsonl"}, servers[name]...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var isbns []string
+			for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+				var bk library.Book
+				err = jsonpb.UnmarshalString(line, &bk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				isbns = append(isbns, strconv.FormatInt(bk.Isbn, 10))
+			}
+			want := "60929871 140008381 140009728 1501107739 9780140301694"
+			if got := strings.Join(isbns, " "); got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+	}
+}
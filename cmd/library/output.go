@@ -0,0 +1,140 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// printBooks prints books to w in the given format.
+func printBooks(w io.Writer, format string, books []*library.Book) error {
+	switch format {
+	case "table":
+		return printTable(w, books)
+	case "json":
+		return printJSON(w, books)
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+// printTable prints books as a table, one book per row.
+func printTable(w io.Writer, books []*library.Book) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ISBN\tTITLE\tAUTHOR\tTYPE\tPUBLISHER\tPUBLISHED\tAVAILABLE")
+	for _, bk := range books {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			bk.GetIsbn(),
+			bk.GetTitle(),
+			bk.GetAuthor(),
+			bk.GetBookType(),
+			publisher(bk),
+			published(bk),
+			available(bk),
+		)
+	}
+	return tw.Flush()
+}
+
+// printAuthors prints authors to w in the given format.
+func printAuthors(w io.Writer, format string, authors []*library.Author) error {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME")
+		for _, a := range authors {
+			fmt.Fprintf(tw, "%s\t%s\n", a.GetId(), a.GetName())
+		}
+		return tw.Flush()
+	case "json":
+		msgs := make([]proto.Message, len(authors))
+		for i, a := range authors {
+			msgs[i] = a
+		}
+		return printMessages(w, msgs)
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+// printBranches prints branches to w in the given format.
+func printBranches(w io.Writer, format string, branches []*library.Branch) error {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tADDRESS")
+		for _, br := range branches {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", br.GetId(), br.GetName(), br.GetAddress())
+		}
+		return tw.Flush()
+	case "json":
+		msgs := make([]proto.Message, len(branches))
+		for i, br := range branches {
+			msgs[i] = br
+		}
+		return printMessages(w, msgs)
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+// printJSON prints books as JSON, one object per line.
+func printJSON(w io.Writer, books []*library.Book) error {
+	msgs := make([]proto.Message, len(books))
+	for i, bk := range books {
+		msgs[i] = bk
+	}
+	return printMessages(w, msgs)
+}
+
+// printMessages prints msgs as JSON, one object per line.
+func printMessages(w io.Writer, msgs []proto.Message) error {
+	m := &jsonpb.Marshaler{OrigName: true}
+	for _, msg := range msgs {
+		s, err := m.MarshalToString(msg)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, s)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func publisher(bk *library.Book) string {
+	if bk.GetSelfPublished() {
+		return "Self published"
+	}
+	if p := bk.GetPublisher(); p != nil {
+		return p.GetName()
+	}
+	return "-"
+}
+
+// available describes the copies of bk available
+// out of all its copies, such as "1/2".
+func available(bk *library.Book) string {
+	a := bk.GetAvailability()
+	if a == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", a.GetAvailable(), a.GetCopies())
+}
+
+func published(bk *library.Book) string {
+	t, err := ptypes.Timestamp(bk.GetPublicationDate())
+	if err != nil {
+		return "-"
+	}
+	return strconv.Itoa(t.In(time.UTC).Year())
+}
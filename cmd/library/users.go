@@ -0,0 +1,96 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// readPassword returns the first line of in, without the newline.
+func readPassword(in io.Reader) (string, error) {
+	sc := bufio.NewScanner(in)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return "", err
+		}
+		return "", errors.New("the password should be the first line of stdin")
+	}
+	return strings.TrimRight(sc.Text(), "\r"), nil
+}
+
+func newRegisterCmd(opts *options, in io.Reader, out io.Writer) *cobra.Command {
+	var displayName string
+	cmd := &cobra.Command{
+		Use:   "register NAME",
+		Short: "Register the user NAME, with the password read from stdin",
+		Long: "Register the user NAME, with the password on the first line of stdin.\n" +
+			"Names are 3 to 32 lower case letters, digits, dashes or underscores,\n" +
+			"and passwords at least 8 characters.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, err := readPassword(in)
+			if err != nil {
+				return err
+			}
+			client, closeClient, err := newUserClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			ctx, cancel := callContext(opts)
+			defer cancel()
+			user, err := client.Register(ctx, &library.RegisterRequest{
+				Name:        args[0],
+				Password:    password,
+				DisplayName: displayName,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Registered %s as %s\n", user.Name, user.DisplayName)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&displayName, "display-name", "", "display name of the user, instead of NAME")
+	return cmd
+}
+
+func newLoginCmd(opts *options, in io.Reader, out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "login NAME",
+		Short: "Log in as the user NAME, with the password read from stdin",
+		Long: "Log in as the user NAME, with the password on the first line of stdin,\n" +
+			"and print the session to pass to the --session flag of other commands.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, err := readPassword(in)
+			if err != nil {
+				return err
+			}
+			client, closeClient, err := newUserClient(opts)
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			ctx, cancel := callContext(opts)
+			defer cancel()
+			resp, err := client.Login(ctx, &library.LoginRequest{Name: args[0], Password: password})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, resp.Session)
+			return nil
+		},
+	}
+}
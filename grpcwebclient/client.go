@@ -0,0 +1,216 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Package grpcwebclient implements a gRPC-Web client on top of net/http,
+// for Go programs that need to talk to a gRPC-Web server, for example
+// through HTTP/1.1 proxies that do not support gRPC.
+//
+// Unary and server streaming calls are supported. Client streaming calls
+// are supported by sending all messages in a single request once the
+// client has finished sending. Bidirectional streaming calls are made over
+// a WebSocket connection, using the protocol of the Improbable gRPC-Web proxy.
+package grpcwebclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Client makes gRPC-Web calls to a server.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	text       bool
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient configures the Client to make requests with c,
+// instead of http.DefaultClient. Use it to configure TLS and proxies.
+func WithHTTPClient(c *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = c
+	}
+}
+
+// WithTextFormat configures the Client to use the base64 encoded text
+// format of gRPC-Web, instead of the binary format. The text format
+// can pass through proxies that do not handle binary data.
+func WithTextFormat() Option {
+	return func(client *Client) {
+		client.text = true
+	}
+}
+
+// New returns a Client making calls to the server at baseURL,
+// for example https://localhost:10000.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Invoke makes the unary call to method with in as the request message,
+// storing the response message in out. method is the full name of the
+// method, for example /library.BookService/GetBook.
+func (c *Client) Invoke(ctx context.Context, method string, in, out proto.Message) error {
+	stream, err := c.NewServerStream(ctx, method, in)
+	if err != nil {
+		return err
+	}
+	return stream.recvOne(out)
+}
+
+// NewServerStream makes the server streaming call to method with
+// in as the request message. The response messages are read
+// with the RecvMsg method of the returned stream.
+func (c *Client) NewServerStream(ctx context.Context, method string, in proto.Message) (*ServerStream, error) {
+	body, err := encodeFrame(nil, in)
+	if err != nil {
+		return nil, err
+	}
+	return c.call(ctx, method, body)
+}
+
+// NewClientStream starts the client streaming call to method.
+// The messages sent on the returned stream are buffered
+// until its CloseAndRecv method is called.
+func (c *Client) NewClientStream(ctx context.Context, method string) *ClientStream {
+	return &ClientStream{
+		ctx:    ctx,
+		client: c,
+		method: method,
+	}
+}
+
+func (c *Client) call(ctx context.Context, method string, body []byte) (*ServerStream, error) {
+	contentType := "application/grpc-web+proto"
+	if c.text {
+		contentType = "application/grpc-web-text+proto"
+		body = []byte(base64.StdEncoding.EncodeToString(body))
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+method, bytes.NewReader(body))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	req.Header.Set("X-Grpc-Web", "1")
+	setRequestMetadata(ctx, req.Header)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, status.Errorf(httpStatusCode(resp.StatusCode), "unexpected HTTP status: %s", resp.Status)
+	}
+	return newServerStream(ctx, resp, c.text), nil
+}
+
+// setRequestMetadata sets the timeout and the
+// outgoing metadata of ctx as headers in h.
+func setRequestMetadata(ctx context.Context, h http.Header) {
+	if deadline, ok := ctx.Deadline(); ok {
+		h.Set("Grpc-Timeout", encodeTimeout(time.Until(deadline)))
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for key, values := range md {
+		for _, value := range values {
+			if strings.HasSuffix(key, "-bin") {
+				value = base64.StdEncoding.EncodeToString([]byte(value))
+			}
+			h.Add(key, value)
+		}
+	}
+}
+
+// encodeFrame appends msg to b as a length-prefixed data frame.
+func encodeFrame(b []byte, msg proto.Message) ([]byte, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal request: %v", err)
+	}
+	var prefix [5]byte
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(payload)))
+	b = append(b, prefix[:]...)
+	return append(b, payload...), nil
+}
+
+// encodeTimeout encodes d as a Grpc-Timeout header value.
+func encodeTimeout(d time.Duration) string {
+	if d <= 0 {
+		return "1n"
+	}
+	// The value may have at most 8 digits, so the largest
+	// unit that represents d exactly enough is used.
+	units := []struct {
+		unit string
+		d    time.Duration
+	}{
+		{"n", time.Nanosecond},
+		{"u", time.Microsecond},
+		{"m", time.Millisecond},
+		{"S", time.Second},
+		{"M", time.Minute},
+		{"H", time.Hour},
+	}
+	for _, u := range units {
+		if d/u.d < 1e8 {
+			// Round up, so that the timeout is never shortened
+			return fmt.Sprintf("%d%s", (d+u.d-1)/u.d, u.unit)
+		}
+	}
+	return fmt.Sprintf("%dH", 99999999)
+}
+
+// contextError returns the status error for err,
+// which occurred while making a call with ctx.
+func contextError(ctx context.Context, err error) error {
+	switch ctx.Err() {
+	case context.Canceled:
+		return status.Error(codes.Canceled, ctx.Err().Error())
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+// httpStatusCode maps HTTP status codes to gRPC codes as described in
+// https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md
+func httpStatusCode(code int) codes.Code {
+	switch code {
+	case http.StatusBadRequest:
+		return codes.Internal
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	}
+	return codes.Unknown
+}
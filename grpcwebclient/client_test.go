@@ -0,0 +1,306 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package grpcwebclient_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/grpcwebclient"
+	"github.com/johanbrandhorst/grpcweb-example/internal/grpcwebtext"
+	"github.com/johanbrandhorst/grpcweb-example/server"
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// headerService echoes the metadata of
+// the request in the header and trailer.
+type headerService struct {
+	server.BookService
+}
+
+func (*headerService) GetBook(ctx context.Context, req *library.GetBookRequest) (*library.Book, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	err := grpc.SendHeader(ctx, metadata.Pairs("echo", strings.Join(md["echo"], ",")))
+	if err != nil {
+		return nil, err
+	}
+	err = grpc.SetTrailer(ctx, metadata.Pairs("echo-bin", strings.Join(md["echo-bin"], ",")))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, status.Error(codes.InvalidArgument, "missing deadline")
+	}
+	return &library.Book{Isbn: req.Isbn}, nil
+}
+
+func newTestServer(srv library.BookServiceServer) (*httptest.Server, func()) {
+	gs := grpc.NewServer()
+	library.RegisterBookServiceServer(gs, srv)
+	hs := httptest.NewServer(grpcwebtext.Handler(grpcweb.WrapServer(gs, grpcweb.WithWebsockets(true))))
+	return hs, func() {
+		hs.Close()
+		gs.Stop()
+	}
+}
+
+func testClients(url string) map[string]library.BookServiceClient {
+	return map[string]library.BookServiceClient{
+		"Binary": grpcwebclient.NewBookServiceClient(grpcwebclient.New(url)),
+		"Text":   grpcwebclient.NewBookServiceClient(grpcwebclient.New(url, grpcwebclient.WithTextFormat())),
+	}
+}
+
+func testContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 5*time.Second)
+}
+
+func TestBookService(t *testing.T) {
+	for _, name := range []string{"Binary", "Text"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			srv, cleanup := newTestServer(&server.BookService{AdminToken: "secret"})
+			defer cleanup()
+			client := testClients(srv.URL)[name]
+
+			t.Run("GetBook", func(t *testing.T) {
+				ctx, cancel := testContext()
+				defer cancel()
+				bk, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: 140008381})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if bk.Title != "Animal Farm" {
+					t.Errorf("unexpected book: %v", bk)
+				}
+			})
+
+			t.Run("GetBookNotFound", func(t *testing.T) {
+				ctx, cancel := testContext()
+				defer cancel()
+				_, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: 1})
+				st := status.Convert(err)
+				if st.Code() != codes.NotFound || st.Message() != "Book could not be found" {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+
+			t.Run("QueryBooks", func(t *testing.T) {
+				ctx, cancel := testContext()
+				defer cancel()
+				stream, err := client.QueryBooks(ctx, &library.QueryBooksRequest{AuthorPrefix: "George"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				var titles []string
+				for {
+					bk, err := stream.Recv()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+					titles = append(titles, bk.Title)
+				}
+				if strings.Join(titles, ", ") != "Nineteen Eighty-Four, Animal Farm" {
+					t.Errorf("unexpected books: %q", titles)
+				}
+			})
+
+			t.Run("MakeCollection", func(t *testing.T) {
+				ctx, cancel := testContext()
+				defer cancel()
+				stream, err := client.MakeCollection(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, isbn := range []int64{140008381, 60929871} {
+					err = stream.Send(&library.Book{Isbn: isbn})
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+				c, err := stream.CloseAndRecv()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(c.Books) != 2 || c.Books[0].Title != "Animal Farm" || c.Books[1].Title != "Brave New World" {
+					t.Errorf("unexpected collection: %v", c)
+				}
+			})
+
+			t.Run("WatchBooks", func(t *testing.T) {
+				ctx, cancel := testContext()
+				defer cancel()
+				stream, err := client.WatchBooks(ctx, &library.WatchBooksRequest{AuthorPrefix: "George", IncludeExisting: true})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := 0; i < 2; i++ {
+					ev, err := stream.Recv()
+					if err != nil {
+						t.Fatal(err)
+					}
+					if ev.Type != library.BookEvent_EXISTING {
+						t.Errorf("unexpected event: %v", ev)
+					}
+				}
+
+				// Events are received as the changes are made
+				imp, err := client.ImportBooks(metadata.AppendToOutgoingContext(ctx, "admin-token", "secret"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = imp.Send(&library.ImportBooksRequest{Content: &library.ImportBooksRequest_Book{
+					Book: &library.Book{Isbn: 1, Title: "Homage to Catalonia", Author: "George Orwell"},
+				}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := imp.CloseAndRecv(); err != nil {
+					t.Fatal(err)
+				}
+				ev, err := stream.Recv()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ev.Type != library.BookEvent_CREATED || ev.Book.Title != "Homage to Catalonia" {
+					t.Errorf("unexpected event: %v", ev)
+				}
+			})
+
+			t.Run("BookChat", func(t *testing.T) {
+				ctx, cancel := testContext()
+				defer cancel()
+				stream, err := client.BookChat(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, msg := range []*library.BookMessage{
+					{Content: &library.BookMessage_Name{Name: "Alice " + name}},
+					{Content: &library.BookMessage_Message{Message: "Hello"}},
+				} {
+					err = stream.Send(msg)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+				resp, err := stream.Recv()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := "Alice " + name + ": Hello"; resp.Message != want {
+					t.Errorf("got %q, want %q", resp.Message, want)
+				}
+				err = stream.CloseSend()
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = stream.Recv()
+				if err != io.EOF {
+					t.Errorf("got %v, want EOF", err)
+				}
+			})
+
+			t.Run("BookChatMissingName", func(t *testing.T) {
+				ctx, cancel := testContext()
+				defer cancel()
+				stream, err := client.BookChat(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = stream.Send(&library.BookMessage{Content: &library.BookMessage_Message{Message: "Hello"}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = stream.Recv()
+				if status.Code(err) != codes.FailedPrecondition {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+		})
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	srv, cleanup := newTestServer(&headerService{})
+	defer cleanup()
+
+	c := grpcwebclient.New(srv.URL)
+	ctx, cancel := testContext()
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "echo", "hello", "echo-bin", "\x00\x01")
+	stream, err := c.NewServerStream(ctx, "/library.BookService/GetBook", &library.GetBookRequest{Isbn: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bk library.Book
+	err = stream.RecvMsg(&bk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.RecvMsg(&bk)
+	if err != io.EOF {
+		t.Fatalf("got %v, want EOF", err)
+	}
+	header, _ := stream.Header()
+	if v := header["echo"]; len(v) != 1 || v[0] != "hello" {
+		t.Errorf("unexpected header: %v", header)
+	}
+	if v := stream.Trailer()["echo-bin"]; len(v) != 1 || v[0] != "\x00\x01" {
+		t.Errorf("unexpected trailer: %v", stream.Trailer())
+	}
+}
+
+func TestCancel(t *testing.T) {
+	srv, cleanup := newTestServer(&server.BookService{})
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := grpcwebclient.NewBookServiceClient(grpcwebclient.New(srv.URL)).GetBook(ctx, &library.GetBookRequest{Isbn: 1})
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	ctx, cancel := testContext()
+	defer cancel()
+	_, err := grpcwebclient.NewBookServiceClient(grpcwebclient.New(srv.URL)).GetBook(ctx, &library.GetBookRequest{Isbn: 1})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMaxMessageSize(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/grpc-web+proto")
+		// The prefix of a frame of 5 MB, which is never sent
+		w.Write([]byte{0, 0, 0x50, 0, 0})
+	}))
+	defer srv.Close()
+
+	ctx, cancel := testContext()
+	defer cancel()
+	_, err := grpcwebclient.NewBookServiceClient(grpcwebclient.New(srv.URL)).GetBook(ctx, &library.GetBookRequest{Isbn: 1})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
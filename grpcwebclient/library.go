@@ -0,0 +1,474 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package grpcwebclient
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+type bookServiceClient struct {
+	c *Client
+}
+
+// NewBookServiceClient returns a library.BookServiceClient making
+// its calls with c. The grpc.CallOptions of the calls are ignored.
+// BookChat is made over a WebSocket connection, so the server must
+// have WebSockets enabled.
+func NewBookServiceClient(c *Client) library.BookServiceClient {
+	return &bookServiceClient{c: c}
+}
+
+func (b *bookServiceClient) GetBook(ctx context.Context, in *library.GetBookRequest, _ ...grpc.CallOption) (*library.Book, error) {
+	out := new(library.Book)
+	err := b.c.Invoke(ctx, "/library.BookService/GetBook", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) QueryBooks(ctx context.Context, in *library.QueryBooksRequest, _ ...grpc.CallOption) (library.BookService_QueryBooksClient, error) {
+	stream, err := b.c.NewServerStream(ctx, "/library.BookService/QueryBooks", in)
+	if err != nil {
+		return nil, err
+	}
+	return &queryBooksClient{stream}, nil
+}
+
+type queryBooksClient struct {
+	*ServerStream
+}
+
+func (x *queryBooksClient) Recv() (*library.Book, error) {
+	m := new(library.Book)
+	if err := x.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (b *bookServiceClient) MakeCollection(ctx context.Context, _ ...grpc.CallOption) (library.BookService_MakeCollectionClient, error) {
+	return &makeCollectionClient{b.c.NewClientStream(ctx, "/library.BookService/MakeCollection")}, nil
+}
+
+type makeCollectionClient struct {
+	*ClientStream
+}
+
+func (x *makeCollectionClient) Send(m *library.Book) error {
+	return x.SendMsg(m)
+}
+
+func (x *makeCollectionClient) CloseAndRecv() (*library.Collection, error) {
+	m := new(library.Collection)
+	if err := x.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (b *bookServiceClient) BookChat(ctx context.Context, _ ...grpc.CallOption) (library.BookService_BookChatClient, error) {
+	stream, err := b.c.NewBidiStream(ctx, "/library.BookService/BookChat")
+	if err != nil {
+		return nil, err
+	}
+	return &bookChatClient{stream}, nil
+}
+
+type bookChatClient struct {
+	*BidiStream
+}
+
+func (x *bookChatClient) Send(m *library.BookMessage) error {
+	return x.SendMsg(m)
+}
+
+func (x *bookChatClient) Recv() (*library.BookResponse, error) {
+	m := new(library.BookResponse)
+	if err := x.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (b *bookServiceClient) ImportBooks(ctx context.Context, _ ...grpc.CallOption) (library.BookService_ImportBooksClient, error) {
+	return &importBooksClient{b.c.NewClientStream(ctx, "/library.BookService/ImportBooks")}, nil
+}
+
+type importBooksClient struct {
+	*ClientStream
+}
+
+func (x *importBooksClient) Send(m *library.ImportBooksRequest) error {
+	return x.SendMsg(m)
+}
+
+func (x *importBooksClient) CloseAndRecv() (*library.ImportBooksResponse, error) {
+	m := new(library.ImportBooksResponse)
+	if err := x.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (b *bookServiceClient) ExportBooks(ctx context.Context, in *library.ExportBooksRequest, _ ...grpc.CallOption) (library.BookService_ExportBooksClient, error) {
+	stream, err := b.c.NewServerStream(ctx, "/library.BookService/ExportBooks", in)
+	if err != nil {
+		return nil, err
+	}
+	return &exportBooksClient{stream}, nil
+}
+
+type exportBooksClient struct {
+	*ServerStream
+}
+
+func (x *exportBooksClient) Recv() (*library.ExportBooksResponse, error) {
+	m := new(library.ExportBooksResponse)
+	if err := x.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (b *bookServiceClient) WatchBooks(ctx context.Context, in *library.WatchBooksRequest, _ ...grpc.CallOption) (library.BookService_WatchBooksClient, error) {
+	stream, err := b.c.NewServerStream(ctx, "/library.BookService/WatchBooks", in)
+	if err != nil {
+		return nil, err
+	}
+	return &watchBooksClient{stream}, nil
+}
+
+type watchBooksClient struct {
+	*ServerStream
+}
+
+func (x *watchBooksClient) Recv() (*library.BookEvent, error) {
+	m := new(library.BookEvent)
+	if err := x.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (b *bookServiceClient) GetAuthor(ctx context.Context, in *library.GetAuthorRequest, _ ...grpc.CallOption) (*library.Author, error) {
+	out := new(library.Author)
+	err := b.c.Invoke(ctx, "/library.BookService/GetAuthor", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) ListAuthors(ctx context.Context, in *library.ListAuthorsRequest, _ ...grpc.CallOption) (*library.ListAuthorsResponse, error) {
+	out := new(library.ListAuthorsResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/ListAuthors", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) GetPublisher(ctx context.Context, in *library.GetPublisherRequest, _ ...grpc.CallOption) (*library.Publisher, error) {
+	out := new(library.Publisher)
+	err := b.c.Invoke(ctx, "/library.BookService/GetPublisher", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) GetCollection(ctx context.Context, in *library.GetCollectionRequest, _ ...grpc.CallOption) (*library.Collection, error) {
+	out := new(library.Collection)
+	err := b.c.Invoke(ctx, "/library.BookService/GetCollection", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) ListCollections(ctx context.Context, in *library.ListCollectionsRequest, _ ...grpc.CallOption) (*library.ListCollectionsResponse, error) {
+	out := new(library.ListCollectionsResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/ListCollections", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) AddToCollection(ctx context.Context, in *library.AddToCollectionRequest, _ ...grpc.CallOption) (*library.Collection, error) {
+	out := new(library.Collection)
+	err := b.c.Invoke(ctx, "/library.BookService/AddToCollection", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) RemoveFromCollection(ctx context.Context, in *library.RemoveFromCollectionRequest, _ ...grpc.CallOption) (*library.Collection, error) {
+	out := new(library.Collection)
+	err := b.c.Invoke(ctx, "/library.BookService/RemoveFromCollection", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) DeleteCollection(ctx context.Context, in *library.DeleteCollectionRequest, _ ...grpc.CallOption) (*library.Collection, error) {
+	out := new(library.Collection)
+	err := b.c.Invoke(ctx, "/library.BookService/DeleteCollection", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) ListCopies(ctx context.Context, in *library.ListCopiesRequest, _ ...grpc.CallOption) (*library.ListCopiesResponse, error) {
+	out := new(library.ListCopiesResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/ListCopies", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) ListBranches(ctx context.Context, in *library.ListBranchesRequest, _ ...grpc.CallOption) (*library.ListBranchesResponse, error) {
+	out := new(library.ListBranchesResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/ListBranches", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) AddCopy(ctx context.Context, in *library.AddCopyRequest, _ ...grpc.CallOption) (*library.Copy, error) {
+	out := new(library.Copy)
+	err := b.c.Invoke(ctx, "/library.BookService/AddCopy", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) CheckOut(ctx context.Context, in *library.CheckOutRequest, _ ...grpc.CallOption) (*library.Loan, error) {
+	out := new(library.Loan)
+	err := b.c.Invoke(ctx, "/library.BookService/CheckOut", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) Return(ctx context.Context, in *library.ReturnRequest, _ ...grpc.CallOption) (*library.Loan, error) {
+	out := new(library.Loan)
+	err := b.c.Invoke(ctx, "/library.BookService/Return", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) Renew(ctx context.Context, in *library.RenewRequest, _ ...grpc.CallOption) (*library.Loan, error) {
+	out := new(library.Loan)
+	err := b.c.Invoke(ctx, "/library.BookService/Renew", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) ListLoans(ctx context.Context, in *library.ListLoansRequest, _ ...grpc.CallOption) (*library.ListLoansResponse, error) {
+	out := new(library.ListLoansResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/ListLoans", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) PlaceHold(ctx context.Context, in *library.PlaceHoldRequest, _ ...grpc.CallOption) (*library.Hold, error) {
+	out := new(library.Hold)
+	err := b.c.Invoke(ctx, "/library.BookService/PlaceHold", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) CancelHold(ctx context.Context, in *library.CancelHoldRequest, _ ...grpc.CallOption) (*library.Hold, error) {
+	out := new(library.Hold)
+	err := b.c.Invoke(ctx, "/library.BookService/CancelHold", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) ListHolds(ctx context.Context, in *library.ListHoldsRequest, _ ...grpc.CallOption) (*library.ListHoldsResponse, error) {
+	out := new(library.ListHoldsResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/ListHolds", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) WatchMyHolds(ctx context.Context, in *library.WatchMyHoldsRequest, _ ...grpc.CallOption) (library.BookService_WatchMyHoldsClient, error) {
+	stream, err := b.c.NewServerStream(ctx, "/library.BookService/WatchMyHolds", in)
+	if err != nil {
+		return nil, err
+	}
+	return &watchMyHoldsClient{stream}, nil
+}
+
+type watchMyHoldsClient struct {
+	*ServerStream
+}
+
+func (x *watchMyHoldsClient) Recv() (*library.Hold, error) {
+	m := new(library.Hold)
+	if err := x.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (b *bookServiceClient) ListJobs(ctx context.Context, in *library.ListJobsRequest, _ ...grpc.CallOption) (*library.ListJobsResponse, error) {
+	out := new(library.ListJobsResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/ListJobs", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) SubmitReview(ctx context.Context, in *library.SubmitReviewRequest, _ ...grpc.CallOption) (*library.Review, error) {
+	out := new(library.Review)
+	err := b.c.Invoke(ctx, "/library.BookService/SubmitReview", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) UpdateReview(ctx context.Context, in *library.UpdateReviewRequest, _ ...grpc.CallOption) (*library.Review, error) {
+	out := new(library.Review)
+	err := b.c.Invoke(ctx, "/library.BookService/UpdateReview", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) DeleteReview(ctx context.Context, in *library.DeleteReviewRequest, _ ...grpc.CallOption) (*library.Review, error) {
+	out := new(library.Review)
+	err := b.c.Invoke(ctx, "/library.BookService/DeleteReview", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) MarkReviewHelpful(ctx context.Context, in *library.MarkReviewHelpfulRequest, _ ...grpc.CallOption) (*library.Review, error) {
+	out := new(library.Review)
+	err := b.c.Invoke(ctx, "/library.BookService/MarkReviewHelpful", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) ListReviews(ctx context.Context, in *library.ListReviewsRequest, _ ...grpc.CallOption) (*library.ListReviewsResponse, error) {
+	out := new(library.ListReviewsResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/ListReviews", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) RecommendBooks(ctx context.Context, in *library.RecommendBooksRequest, _ ...grpc.CallOption) (*library.RecommendBooksResponse, error) {
+	out := new(library.RecommendBooksResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/RecommendBooks", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) UpdateReading(ctx context.Context, in *library.UpdateReadingRequest, _ ...grpc.CallOption) (*library.ReadingEntry, error) {
+	out := new(library.ReadingEntry)
+	err := b.c.Invoke(ctx, "/library.BookService/UpdateReading", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) RemoveReading(ctx context.Context, in *library.RemoveReadingRequest, _ ...grpc.CallOption) (*library.ReadingEntry, error) {
+	out := new(library.ReadingEntry)
+	err := b.c.Invoke(ctx, "/library.BookService/RemoveReading", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b *bookServiceClient) ListReading(ctx context.Context, in *library.ListReadingRequest, _ ...grpc.CallOption) (*library.ListReadingResponse, error) {
+	out := new(library.ListReadingResponse)
+	err := b.c.Invoke(ctx, "/library.BookService/ListReading", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type userServiceClient struct {
+	c *Client
+}
+
+// NewUserServiceClient returns a library.UserServiceClient making
+// its calls with c. The grpc.CallOptions of the calls are ignored.
+func NewUserServiceClient(c *Client) library.UserServiceClient {
+	return &userServiceClient{c: c}
+}
+
+func (u *userServiceClient) Register(ctx context.Context, in *library.RegisterRequest, _ ...grpc.CallOption) (*library.User, error) {
+	out := new(library.User)
+	err := u.c.Invoke(ctx, "/library.UserService/Register", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (u *userServiceClient) Login(ctx context.Context, in *library.LoginRequest, _ ...grpc.CallOption) (*library.LoginResponse, error) {
+	out := new(library.LoginResponse)
+	err := u.c.Invoke(ctx, "/library.UserService/Login", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (u *userServiceClient) GetUser(ctx context.Context, in *library.GetUserRequest, _ ...grpc.CallOption) (*library.User, error) {
+	out := new(library.User)
+	err := u.c.Invoke(ctx, "/library.UserService/GetUser", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (u *userServiceClient) UpdateProfile(ctx context.Context, in *library.UpdateProfileRequest, _ ...grpc.CallOption) (*library.User, error) {
+	out := new(library.User)
+	err := u.c.Invoke(ctx, "/library.UserService/UpdateProfile", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,341 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package grpcwebclient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/internal/grpcwebtext"
+)
+
+const (
+	// compressedFlag is set in the first byte
+	// of frames with a compressed payload.
+	compressedFlag = 1 << 0
+	// trailerFlag is set in the first byte
+	// of the frame containing the trailers.
+	trailerFlag = 1 << 7
+)
+
+// maxMessageSize is the largest message RecvMsg reads,
+// the default of the clients of the grpc package.
+const maxMessageSize = 4 << 20
+
+// ServerStream is the response of a call. It implements
+// grpc.ClientStream, but sending messages is not supported.
+// To abandon the stream before all messages have been
+// received, cancel the context of the call.
+type ServerStream struct {
+	ctx     context.Context
+	body    io.ReadCloser
+	r       io.Reader
+	header  metadata.MD
+	trailer metadata.MD
+	// headerFrame is set if the header is sent
+	// in a frame before any messages, which is
+	// the case for calls over WebSockets.
+	headerFrame bool
+	// err is the error returned by RecvMsg
+	// once the call has finished.
+	err error
+}
+
+func newServerStream(ctx context.Context, resp *http.Response, text bool) *ServerStream {
+	s := &ServerStream{
+		ctx:    ctx,
+		body:   resp.Body,
+		r:      resp.Body,
+		header: toMetadata(resp.Header),
+	}
+	if text {
+		s.r = grpcwebtext.NewReader(resp.Body)
+	}
+	// Calls failing before any messages are sent
+	// have their status in the headers.
+	if resp.Header.Get("Grpc-Status") != "" {
+		s.finish(resp.Header)
+	}
+	return s
+}
+
+// finish sets the trailer and the final error of the stream from h.
+func (s *ServerStream) finish(h http.Header) {
+	s.trailer = toMetadata(h)
+	s.err = statusFromHeader(h).Err()
+	if s.err == nil {
+		s.err = io.EOF
+	}
+	s.body.Close()
+}
+
+func (s *ServerStream) fail(err error) error {
+	s.err = err
+	s.body.Close()
+	return err
+}
+
+// RecvMsg reads the next message of the response into m,
+// which must be a proto.Message. It returns io.EOF once all
+// messages have been received and the call succeeded, or the
+// error the call failed with. Messages larger than 4 MB fail
+// the call with a ResourceExhausted error.
+func (s *ServerStream) RecvMsg(m interface{}) error {
+	if s.err != nil {
+		return s.err
+	}
+	for {
+		var prefix [5]byte
+		_, err := io.ReadFull(s.r, prefix[:])
+		if err == io.EOF {
+			return s.fail(status.Error(codes.Internal, "response ended without trailers"))
+		}
+		if err != nil {
+			return s.fail(contextError(s.ctx, err))
+		}
+		size := binary.BigEndian.Uint32(prefix[1:])
+		if size > maxMessageSize {
+			return s.fail(status.Errorf(codes.ResourceExhausted, "received message larger than max (%d vs. %d)", size, maxMessageSize))
+		}
+		payload := make([]byte, size)
+		_, err = io.ReadFull(s.r, payload)
+		if err != nil {
+			return s.fail(contextError(s.ctx, err))
+		}
+
+		switch {
+		case prefix[0]&trailerFlag != 0:
+			h, err := parseHeader(payload)
+			if err != nil {
+				return s.fail(status.Errorf(codes.Internal, "failed to parse trailers: %v", err))
+			}
+			// Calls failing before any messages are sent
+			// only have a single frame, with the status.
+			if s.headerFrame && h.Get("Grpc-Status") == "" {
+				s.header = toMetadata(h)
+				s.headerFrame = false
+				continue
+			}
+			s.finish(h)
+			return s.err
+		case prefix[0]&compressedFlag != 0:
+			return s.fail(status.Error(codes.Internal, "compressed messages are not supported"))
+		}
+
+		msg, ok := m.(proto.Message)
+		if !ok {
+			return s.fail(status.Errorf(codes.Internal, "%T is not a proto.Message", m))
+		}
+		err = proto.Unmarshal(payload, msg)
+		if err != nil {
+			return s.fail(status.Errorf(codes.Internal, "failed to unmarshal response: %v", err))
+		}
+		return nil
+	}
+}
+
+// recvOne reads the single message of
+// the response of a unary call into m.
+func (s *ServerStream) recvOne(m proto.Message) error {
+	err := s.RecvMsg(m)
+	if err == io.EOF {
+		return status.Error(codes.Internal, "response contained no message")
+	}
+	if err != nil {
+		return err
+	}
+	err = s.RecvMsg(m)
+	if err == nil {
+		return s.fail(status.Error(codes.Internal, "response contained more than one message"))
+	}
+	if err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// Header returns the header metadata of the response. For calls over
+// WebSockets, it is only available once RecvMsg has returned.
+func (s *ServerStream) Header() (metadata.MD, error) {
+	return s.header, nil
+}
+
+// Trailer returns the trailer metadata of the response.
+// It is only available once RecvMsg has returned an error.
+func (s *ServerStream) Trailer() metadata.MD {
+	return s.trailer
+}
+
+// CloseSend does nothing, as the request
+// has already been sent in full.
+func (s *ServerStream) CloseSend() error {
+	return nil
+}
+
+// Context returns the context of the call.
+func (s *ServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg always fails, as the request
+// has already been sent in full.
+func (s *ServerStream) SendMsg(m interface{}) error {
+	return status.Error(codes.Internal, "SendMsg called on a server stream")
+}
+
+// ClientStream is a client streaming call. It implements
+// grpc.ClientStream. The request is sent when CloseSend is called.
+type ClientStream struct {
+	ctx    context.Context
+	client *Client
+	method string
+	body   []byte
+	// stream is the response, once the request has been sent.
+	stream *ServerStream
+}
+
+// SendMsg adds m, which must be a proto.Message, to the request.
+func (s *ClientStream) SendMsg(m interface{}) error {
+	if s.stream != nil {
+		return status.Error(codes.Internal, "SendMsg called after CloseSend")
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "%T is not a proto.Message", m)
+	}
+	body, err := encodeFrame(s.body, msg)
+	if err != nil {
+		return err
+	}
+	s.body = body
+	return nil
+}
+
+// CloseSend sends the request with all messages sent so far.
+func (s *ClientStream) CloseSend() error {
+	if s.stream != nil {
+		return nil
+	}
+	stream, err := s.client.call(s.ctx, s.method, s.body)
+	if err != nil {
+		return err
+	}
+	s.stream = stream
+	return nil
+}
+
+// RecvMsg reads the single message of the response into m,
+// which must be a proto.Message, sending the request first
+// if CloseSend has not been called.
+func (s *ClientStream) RecvMsg(m interface{}) error {
+	err := s.CloseSend()
+	if err != nil {
+		return err
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "%T is not a proto.Message", m)
+	}
+	return s.stream.recvOne(msg)
+}
+
+// Header returns the header metadata of the response,
+// or nil if the request has not been sent yet.
+func (s *ClientStream) Header() (metadata.MD, error) {
+	if s.stream == nil {
+		return nil, nil
+	}
+	return s.stream.Header()
+}
+
+// Trailer returns the trailer metadata of the response.
+// It is only available once RecvMsg has returned.
+func (s *ClientStream) Trailer() metadata.MD {
+	if s.stream == nil {
+		return nil
+	}
+	return s.stream.Trailer()
+}
+
+// Context returns the context of the call.
+func (s *ClientStream) Context() context.Context {
+	return s.ctx
+}
+
+// parseHeader parses the headers in the payload of a trailer frame.
+func parseHeader(payload []byte) (http.Header, error) {
+	tp := textproto.NewReader(bufio.NewReader(io.MultiReader(
+		bytes.NewReader(payload),
+		strings.NewReader("\r\n"),
+	)))
+	h, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	return http.Header(h), nil
+}
+
+// statusFromHeader returns the status described by the
+// Grpc-Status, Grpc-Message and Grpc-Status-Details-Bin headers in h.
+func statusFromHeader(h http.Header) *status.Status {
+	code, err := strconv.Atoi(h.Get("Grpc-Status"))
+	if err != nil {
+		return status.Newf(codes.Internal, "invalid gRPC status %q", h.Get("Grpc-Status"))
+	}
+	msg := h.Get("Grpc-Message")
+	if unescaped, err := url.PathUnescape(msg); err == nil {
+		msg = unescaped
+	}
+
+	if details := h.Get("Grpc-Status-Details-Bin"); details != "" {
+		b, err := decodeBinHeader(details)
+		if err == nil {
+			st := &spb.Status{}
+			if proto.Unmarshal(b, st) == nil && st.Code == int32(code) {
+				return status.FromProto(st)
+			}
+		}
+	}
+	return status.New(codes.Code(code), msg)
+}
+
+// toMetadata returns the headers in h as metadata.
+func toMetadata(h http.Header) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range h {
+		key = strings.ToLower(key)
+		for _, value := range values {
+			if strings.HasSuffix(key, "-bin") {
+				b, err := decodeBinHeader(value)
+				if err != nil {
+					continue
+				}
+				value = string(b)
+			}
+			md[key] = append(md[key], value)
+		}
+	}
+	return md
+}
+
+// decodeBinHeader decodes the value of a binary header,
+// which may or may not be padded.
+func decodeBinHeader(v string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(v, "="))
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package grpcwebclient
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewBidiStream starts the bidirectional streaming call to method
+// over a WebSocket connection. Cancel the context of the call to
+// close the connection before the call has finished.
+func (c *Client) NewBidiStream(ctx context.Context, method string) (*BidiStream, error) {
+	d := &websocket.Dialer{
+		Subprotocols: []string{"grpc-websockets"},
+		NetDial: func(network, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}
+	if t, ok := c.httpClient.Transport.(*http.Transport); ok {
+		d.TLSClientConfig = t.TLSClientConfig
+		d.Proxy = t.Proxy
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		d.HandshakeTimeout = time.Until(deadline)
+	}
+
+	url := "ws" + strings.TrimPrefix(c.baseURL, "http") + method
+	conn, _, err := d.Dial(url, http.Header{"Origin": []string{c.baseURL}})
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+
+	// The first message contains the request headers
+	h := http.Header{}
+	h.Set("Content-Type", "application/grpc-web+proto")
+	h.Set("X-Grpc-Web", "1")
+	setRequestMetadata(ctx, h)
+	buf := new(bytes.Buffer)
+	h.Write(buf)
+	err = conn.WriteMessage(websocket.BinaryMessage, buf.Bytes())
+	if err != nil {
+		conn.Close()
+		return nil, contextError(ctx, err)
+	}
+
+	r := &wsReader{conn: conn, done: make(chan struct{})}
+	go func() {
+		select {
+		case <-ctx.Done():
+			r.Close()
+		case <-r.done:
+		}
+	}()
+
+	return &BidiStream{
+		ServerStream: &ServerStream{
+			ctx:         ctx,
+			body:        r,
+			r:           r,
+			headerFrame: true,
+		},
+		conn: conn,
+	}, nil
+}
+
+// BidiStream is a bidirectional streaming call. It implements grpc.ClientStream.
+// SendMsg and RecvMsg may be called concurrently with each other.
+type BidiStream struct {
+	*ServerStream
+	conn *websocket.Conn
+	// writeMu serializes writes to conn.
+	writeMu sync.Mutex
+}
+
+func (s *BidiStream) write(b []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.conn.WriteMessage(websocket.BinaryMessage, b)
+}
+
+// SendMsg sends m, which must be a proto.Message. Like the
+// SendMsg of grpc.ClientStream, it returns io.EOF if the call
+// has finished, in which case RecvMsg returns its status.
+func (s *BidiStream) SendMsg(m interface{}) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "%T is not a proto.Message", m)
+	}
+	// Data messages are prefixed with a 0
+	b, err := encodeFrame([]byte{0}, msg)
+	if err != nil {
+		return err
+	}
+	err = s.write(b)
+	if err != nil {
+		return io.EOF
+	}
+	return nil
+}
+
+// CloseSend tells the server that the client has finished sending.
+func (s *BidiStream) CloseSend() error {
+	err := s.write([]byte{1})
+	if err != nil {
+		return io.EOF
+	}
+	return nil
+}
+
+// wsReader reads the data of the binary messages read
+// from conn, as if it was a single stream of bytes.
+type wsReader struct {
+	conn *websocket.Conn
+	// buf holds the part of the last
+	// message that has not been read.
+	buf       []byte
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (r *wsReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		_, msg, err := r.conn.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = msg
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *wsReader) Close() error {
+	var err error
+	r.closeOnce.Do(func() {
+		close(r.done)
+		err = r.conn.Close()
+	})
+	return err
+}
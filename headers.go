@@ -0,0 +1,204 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// securityHeaders configures the headers set on every
+// response by securityHeadersHandler.
+type securityHeaders struct {
+	// StrictTransportSecurity is the value of the
+	// Strict-Transport-Security header.
+	StrictTransportSecurity string
+	// ContentSecurityPolicy is the value of the Content-Security-Policy
+	// header, excluding the frame-ancestors directive.
+	ContentSecurityPolicy string
+	// FrameAncestors is the value of the frame-ancestors directive
+	// of the Content-Security-Policy header.
+	FrameAncestors string
+	// ReferrerPolicy is the value of the Referrer-Policy header.
+	ReferrerPolicy string
+	// PermissionsPolicy is the value of the Permissions-Policy header.
+	PermissionsPolicy string
+	// PathOverrides maps URL path prefixes to headers that replace
+	// the defaults above for matching requests. An empty value removes
+	// the header. If several prefixes match, the longest one is used.
+	PathOverrides map[string]http.Header
+}
+
+// defaultSecurityHeaders returns the headers suitable for the GopherJS
+// frontend served from index. Inline scripts in index are allowed
+// by their hashes. Inline styles are allowed, as both the page itself
+// and the React components use them. The highlight.js output rendered
+// with NewDangerousInnerHTML only uses classes, and needs no exceptions.
+// Images are allowed from Open Library, which hosts the book covers.
+func defaultSecurityHeaders(index []byte) securityHeaders {
+	scriptSrc := []string{"'self'"}
+	for _, script := range inlineScripts.FindAllSubmatch(index, -1) {
+		sum := sha256.Sum256(script[1])
+		scriptSrc = append(scriptSrc, "'sha256-"+base64.StdEncoding.EncodeToString(sum[:])+"'")
+	}
+
+	return securityHeaders{
+		StrictTransportSecurity: "max-age=31536000; includeSubDomains; preload",
+		ContentSecurityPolicy: strings.Join([]string{
+			"default-src 'self'",
+			"script-src " + strings.Join(scriptSrc, " "),
+			"style-src 'self' 'unsafe-inline' https://maxcdn.bootstrapcdn.com",
+			"font-src 'self' https://maxcdn.bootstrapcdn.com",
+			"img-src 'self' data: https://covers.openlibrary.org",
+			"connect-src 'self'",
+			"object-src 'none'",
+			"base-uri 'self'",
+			"form-action 'self'",
+		}, "; "),
+		FrameAncestors:    "'none'",
+		ReferrerPolicy:    "strict-origin-when-cross-origin",
+		PermissionsPolicy: "camera=(), microphone=(), geolocation=(), payment=(), usb=()",
+	}
+}
+
+// inlineScripts matches script elements without a src attribute.
+var inlineScripts = regexp.MustCompile(`(?s)<script>(.*?)</script>`)
+
+// header returns the headers described by s,
+// without any path overrides applied.
+func (s securityHeaders) header() http.Header {
+	h := http.Header{}
+	set := func(key, value string) {
+		if value != "" {
+			h.Set(key, value)
+		}
+	}
+	set("Strict-Transport-Security", s.StrictTransportSecurity)
+	csp := s.ContentSecurityPolicy
+	if s.FrameAncestors != "" {
+		if csp != "" {
+			csp += "; "
+		}
+		csp += "frame-ancestors " + s.FrameAncestors
+	}
+	set("Content-Security-Policy", csp)
+	set("X-Content-Type-Options", "nosniff")
+	set("Referrer-Policy", s.ReferrerPolicy)
+	set("Permissions-Policy", s.PermissionsPolicy)
+	return h
+}
+
+// securityHeadersHandler wraps an http.HandlerFunc such that it sets
+// the headers described by s on every response, including the
+// responses to WebSocket upgrade requests.
+func securityHeadersHandler(s securityHeaders, fn http.HandlerFunc) http.Handler {
+	defaults := s.header()
+	// Pre-compute the headers for each path override,
+	// ordered from the longest to the shortest prefix.
+	type override struct {
+		prefix string
+		header http.Header
+	}
+	var overrides []override
+	for prefix, o := range s.PathOverrides {
+		h := cloneHeader(defaults)
+		for key, values := range o {
+			if len(values) == 0 || values[0] == "" {
+				h.Del(key)
+				continue
+			}
+			h[http.CanonicalHeaderKey(key)] = values
+		}
+		overrides = append(overrides, override{prefix: prefix, header: h})
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		return len(overrides[i].prefix) > len(overrides[j].prefix)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := defaults
+		for _, o := range overrides {
+			if strings.HasPrefix(r.URL.Path, o.prefix) {
+				h = o.header
+				break
+			}
+		}
+		// The values are copied, as handlers may append to them.
+		for key, values := range h {
+			w.Header()[key] = append([]string(nil), values...)
+		}
+		if websocket.IsWebSocketUpgrade(r) {
+			// The WebSocket handshake is written directly to the hijacked
+			// connection, ignoring any headers set on the ResponseWriter.
+			w = &hijackHeaderWriter{ResponseWriter: w, header: h}
+		}
+		fn(w, r)
+	})
+}
+
+func cloneHeader(h http.Header) http.Header {
+	c := make(http.Header, len(h))
+	for key, values := range h {
+		c[key] = append([]string(nil), values...)
+	}
+	return c
+}
+
+// hijackHeaderWriter adds header to the response
+// written directly to a hijacked connection.
+type hijackHeaderWriter struct {
+	http.ResponseWriter
+	header http.Header
+}
+
+func (w *hijackHeaderWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response does not implement http.Hijacker")
+	}
+	conn, brw, err := h.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &headerConn{Conn: conn, header: w.header}, brw, nil
+}
+
+// headerConn inserts header into the first response
+// head written to the connection.
+type headerConn struct {
+	net.Conn
+	header      http.Header
+	wroteHeader bool
+}
+
+func (c *headerConn) Write(p []byte) (int, error) {
+	if c.wroteHeader {
+		return c.Conn.Write(p)
+	}
+	c.wroteHeader = true
+
+	end := bytes.Index(p, []byte("\r\n\r\n"))
+	if end < 0 {
+		return c.Conn.Write(p)
+	}
+	buf := new(bytes.Buffer)
+	buf.Write(p[:end+2])
+	c.header.Write(buf)
+	buf.Write(p[end+2:])
+	_, err := c.Conn.Write(buf.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/net/context"
+
+	"github.com/johanbrandhorst/grpcweb-example/client/compiled"
+	"github.com/johanbrandhorst/grpcweb-example/grpcwebclient"
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func testSecurityHeaders(t *testing.T) securityHeaders {
+	index, err := readFile(compiled.Assets, "/index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return defaultSecurityHeaders(index)
+}
+
+func checkHeaders(t *testing.T, got, want http.Header) {
+	t.Helper()
+	for key := range want {
+		if got.Get(key) != want.Get(key) {
+			t.Errorf("%s: got %q, want %q", key, got.Get(key), want.Get(key))
+		}
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	s := testSecurityHeaders(t)
+	want := s.header()
+	srv, cleanup := newTestServer(t, s)
+	defer cleanup()
+
+	t.Run("Static", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %v", resp.Status)
+		}
+		checkHeaders(t, resp.Header, want)
+	})
+
+	t.Run("GRPCWeb", func(t *testing.T) {
+		req := grpcWebRequest(t, srv.URL+"/library.BookService/GetBook", &library.GetBookRequest{
+			Isbn: 140008381,
+		})
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %v", resp.Status)
+		}
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Contains(b, []byte("Grpc-Status: 0")) {
+			t.Fatalf("unexpected response: %q", b)
+		}
+		checkHeaders(t, resp.Header, want)
+	})
+
+	t.Run("WebSocket", func(t *testing.T) {
+		d := websocket.Dialer{
+			Subprotocols: []string{"grpc-websockets"},
+		}
+		conn, resp, err := d.Dial(
+			"ws"+strings.TrimPrefix(srv.URL, "http")+"/library.BookService/BookChat",
+			http.Header{"Origin": []string{srv.URL}},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		if resp.StatusCode != http.StatusSwitchingProtocols {
+			t.Fatalf("unexpected status: %v", resp.Status)
+		}
+		checkHeaders(t, resp.Header, want)
+	})
+}
+
+func TestSecurityHeadersCopied(t *testing.T) {
+	s := testSecurityHeaders(t)
+	want := s.header()
+	// Handlers changing the values of the headers in place
+	// must not change the headers of other responses.
+	h := securityHeadersHandler(s, func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["Content-Security-Policy"][0] += "; img-src *"
+	})
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		if got := w.Header().Get("Content-Security-Policy"); got != want.Get("Content-Security-Policy")+"; img-src *" {
+			t.Fatalf("request %d: got Content-Security-Policy %q", i, got)
+		}
+	}
+}
+
+func TestSecurityHeadersPathOverrides(t *testing.T) {
+	s := testSecurityHeaders(t)
+	s.PathOverrides = map[string]http.Header{
+		"/library.BookService/": {
+			"Content-Security-Policy": []string{""},
+		},
+		"/library.BookService/GetBook": {
+			"Referrer-Policy": []string{"no-referrer"},
+		},
+	}
+	srv, cleanup := newTestServer(t, s)
+	defer cleanup()
+
+	resp, err := http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	checkHeaders(t, resp.Header, s.header())
+
+	resp, err = http.DefaultClient.Do(grpcWebRequest(t, srv.URL+"/library.BookService/QueryBooks", &library.QueryBooksRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if csp := resp.Header.Get("Content-Security-Policy"); csp != "" {
+		t.Errorf("unexpected Content-Security-Policy: %q", csp)
+	}
+	if rp := resp.Header.Get("Referrer-Policy"); rp != s.ReferrerPolicy {
+		t.Errorf("unexpected Referrer-Policy: %q", rp)
+	}
+
+	resp, err = http.DefaultClient.Do(grpcWebRequest(t, srv.URL+"/library.BookService/GetBook", &library.GetBookRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if csp := resp.Header.Get("Content-Security-Policy"); csp == "" {
+		t.Error("expected Content-Security-Policy for the longest matching prefix")
+	}
+	if rp := resp.Header.Get("Referrer-Policy"); rp != "no-referrer" {
+		t.Errorf("unexpected Referrer-Policy: %q", rp)
+	}
+}
+
+func TestDefaultSecurityHeadersInlineScripts(t *testing.T) {
+	s := defaultSecurityHeaders([]byte("<html><script>alert(1)</script><script src=\"/client.js\"></script></html>"))
+	// echo -n "alert(1)" | openssl dgst -sha256 -binary | base64
+	const hash = "'sha256-bhHHL3z2vDgxUt0W3dWQOrprscmda2Y5pLsLg4GF+pI='"
+	if !strings.Contains(s.ContentSecurityPolicy, hash) {
+		t.Errorf("Content-Security-Policy %q does not allow inline script", s.ContentSecurityPolicy)
+	}
+	if strings.Contains(s.ContentSecurityPolicy, "unsafe-eval") {
+		t.Errorf("Content-Security-Policy %q allows eval", s.ContentSecurityPolicy)
+	}
+}
+
+func TestDefaultSecurityHeadersCovers(t *testing.T) {
+	s := testSecurityHeaders(t)
+	var imgSrc []string
+	for _, directive := range strings.Split(s.ContentSecurityPolicy, ";") {
+		fields := strings.Fields(directive)
+		if len(fields) > 0 && fields[0] == "img-src" {
+			imgSrc = fields[1:]
+		}
+	}
+
+	srv, cleanup := newTestServer(t, s)
+	defer cleanup()
+	client := grpcwebclient.NewBookServiceClient(grpcwebclient.New(srv.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.QueryBooks(ctx, &library.QueryBooksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		bk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bk.CoverImageUrl == "" {
+			continue
+		}
+		u, err := url.Parse(bk.CoverImageUrl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		allowed := false
+		for _, src := range imgSrc {
+			if src == u.Scheme+"://"+u.Host {
+				allowed = true
+			}
+		}
+		if !allowed {
+			t.Errorf("img-src %q does not allow the cover of %q, %s", imgSrc, bk.Title, bk.CoverImageUrl)
+		}
+	}
+}
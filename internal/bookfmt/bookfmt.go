@@ -0,0 +1,114 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Package bookfmt reads and writes books in the file formats
+// supported for importing and exporting the library catalogue:
+//
+//	csv   comma separated values, with a header row naming the columns
+//	jsonl JSON Lines, using the protobuf JSON mapping of library.Book
+//	onix  a subset of ONIX for Books 3.0, with reference tags
+package bookfmt
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// Format is a file format for books.
+type Format string
+
+// The supported formats.
+const (
+	CSV   Format = "csv"
+	JSONL Format = "jsonl"
+	ONIX  Format = "onix"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{CSV, JSONL, ONIX}
+
+// ParseFormat returns the format named name.
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q", name)
+}
+
+// FormatFromFileName returns the format of the file
+// named fileName, according to its extension.
+func FormatFromFileName(fileName string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return CSV, nil
+	case ".jsonl", ".ndjson":
+		return JSONL, nil
+	case ".xml", ".onix":
+		return ONIX, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %q from its extension", fileName)
+}
+
+// RowError is returned by Decoder.Decode when a row
+// of the input does not describe a valid book.
+// Decoding can continue with the next row.
+type RowError struct {
+	// Row is the number of the row, as returned by Decode.
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// Decoder reads books.
+type Decoder interface {
+	// Decode returns the next book, and the number of the row it was
+	// read from. Rows are lines for JSON Lines, records counting the
+	// header for CSV, and Product elements for ONIX, counting from 1.
+	// Decode returns a *RowError if the row is not a valid book, and
+	// io.EOF once all books have been read.
+	Decode() (*library.Book, int, error)
+}
+
+// Encoder writes books.
+type Encoder interface {
+	// Encode writes bk.
+	Encode(bk *library.Book) error
+	// Close finishes writing, after all books have been encoded.
+	// It does not close the underlying writer.
+	Close() error
+}
+
+// NewDecoder returns a Decoder reading books in format f from r.
+func NewDecoder(f Format, r io.Reader) (Decoder, error) {
+	switch f {
+	case CSV:
+		return newCSVDecoder(r), nil
+	case JSONL:
+		return newJSONLDecoder(r), nil
+	case ONIX:
+		return newONIXDecoder(r), nil
+	}
+	return nil, fmt.Errorf("unknown format %q", f)
+}
+
+// NewEncoder returns an Encoder writing books in format f to w.
+func NewEncoder(f Format, w io.Writer) (Encoder, error) {
+	switch f {
+	case CSV:
+		return newCSVEncoder(w), nil
+	case JSONL:
+		return newJSONLEncoder(w), nil
+	case ONIX:
+		return newONIXEncoder(w), nil
+	}
+	return nil, fmt.Errorf("unknown format %q", f)
+}
@@ -0,0 +1,265 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package bookfmt
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func date(year int, month time.Month, day int) *library.Book {
+	ts, err := ptypes.TimestampProto(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		panic(err)
+	}
+	return &library.Book{PublicationDate: ts}
+}
+
+func testBooks() []*library.Book {
+	animalFarm := date(1945, time.August, 17)
+	animalFarm.Isbn = 140008381
+	animalFarm.Title = "Animal Farm"
+	animalFarm.Author = "George Orwell"
+	animalFarm.PublishingMethod = &library.Book_Publisher{
+		Publisher: &library.Publisher{Name: "Secker & Warburg"},
+	}
+
+	stillAlice := date(2007, time.January, 1)
+	stillAlice.Isbn = 9781501107733
+	stillAlice.Title = `Still "Alice", again`
+	stillAlice.Author = "Lisa Genova"
+	stillAlice.BookType = library.BookType_AUDIOBOOK
+	stillAlice.PublishingMethod = &library.Book_SelfPublished{SelfPublished: true}
+
+	return []*library.Book{animalFarm, stillAlice}
+}
+
+// decodeAll decodes the books of in, and the
+// errors of the rows that could not be decoded.
+func decodeAll(t *testing.T, f Format, in string) ([]*library.Book, []string) {
+	dec, err := NewDecoder(f, strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var books []*library.Book
+	var rowErrs []string
+	for {
+		bk, _, err := dec.Decode()
+		if err == io.EOF {
+			return books, rowErrs
+		}
+		if rerr, ok := err.(*RowError); ok {
+			rowErrs = append(rowErrs, rerr.Error())
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		books = append(books, bk)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, f := range Formats {
+		f := f
+		t.Run(string(f), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			enc, err := NewEncoder(f, buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, bk := range testBooks() {
+				err = enc.Encode(bk)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			err = enc.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			books, rowErrs := decodeAll(t, f, buf.String())
+			if len(rowErrs) > 0 {
+				t.Fatalf("unexpected errors: %q", rowErrs)
+			}
+			want := testBooks()
+			if len(books) != len(want) {
+				t.Fatalf("got %d books, want %d:\n%s", len(books), len(want), buf)
+			}
+			for i := range want {
+				if !proto.Equal(books[i], want[i]) {
+					t.Errorf("got %v, want %v", books[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	for _, f := range Formats {
+		buf := new(bytes.Buffer)
+		enc, err := NewEncoder(f, buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = enc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		books, rowErrs := decodeAll(t, f, buf.String())
+		if len(books) != 0 || len(rowErrs) != 0 {
+			t.Errorf("%s: got %v and %q, want nothing", f, books, rowErrs)
+		}
+	}
+}
+
+func TestCSV(t *testing.T) {
+	in := `Title, ISBN, Self_Published
+Nineteen Eighty-Four, 140009728,
+Animal Farm, 140008381
+Still Alice, 1501107739, yes
+Still Alice, 1501107739, true
+`
+	books, rowErrs := decodeAll(t, CSV, in)
+	want := []*library.Book{
+		{Isbn: 140009728, Title: "Nineteen Eighty-Four"},
+		{
+			Isbn:             1501107739,
+			Title:            "Still Alice",
+			PublishingMethod: &library.Book_SelfPublished{SelfPublished: true},
+		},
+	}
+	if len(books) != len(want) || !proto.Equal(books[0], want[0]) || !proto.Equal(books[1], want[1]) {
+		t.Errorf("got %v, want %v", books, want)
+	}
+	wantErrs := []string{
+		"row 3: wrong number of fields",
+		`row 4: invalid self_published "yes", should be true or false`,
+	}
+	if strings.Join(rowErrs, "\n") != strings.Join(wantErrs, "\n") {
+		t.Errorf("got errors %q, want %q", rowErrs, wantErrs)
+	}
+}
+
+func TestCSVHeader(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                  "missing header row",
+		"title,author\n":    `missing column "isbn"`,
+		"isbn,pages\n":      `unknown column "pages", columns should be some of isbn, title, author, book_type, publisher, self_published, publication_date`,
+		"isbn,title,isbn\n": `duplicate column "isbn"`,
+	} {
+		dec, err := NewDecoder(CSV, strings.NewReader(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = dec.Decode()
+		if err == nil || err.Error() != want {
+			t.Errorf("%q: got %v, want %q", in, err, want)
+		}
+	}
+}
+
+func TestJSONL(t *testing.T) {
+	in := `{"isbn": "140008381", "title": "Animal Farm", "bookType": "PAPERBACK"}
+
+{"isbn": "140009728", "pages": 328}
+{"isbn": 1501107739, "publisher": {"name": "Scout Press"}}
+`
+	books, rowErrs := decodeAll(t, JSONL, in)
+	want := []*library.Book{
+		{Isbn: 140008381, Title: "Animal Farm", BookType: library.BookType_PAPERBACK},
+		{
+			Isbn:             1501107739,
+			PublishingMethod: &library.Book_Publisher{Publisher: &library.Publisher{Name: "Scout Press"}},
+		},
+	}
+	if len(books) != len(want) || !proto.Equal(books[0], want[0]) || !proto.Equal(books[1], want[1]) {
+		t.Errorf("got %v, want %v", books, want)
+	}
+	if len(rowErrs) != 1 || !strings.HasPrefix(rowErrs[0], "row 3: invalid book: ") {
+		t.Errorf("unexpected errors: %q", rowErrs)
+	}
+}
+
+func TestONIX(t *testing.T) {
+	in := `<?xml version="1.0"?>
+<ONIXMessage release="3.0" xmlns="http://ns.editeur.org/onix/3.0/reference">
+  <Header><Sender><SenderName>Publisher</SenderName></Sender></Header>
+  <Product>
+    <RecordReference>1</RecordReference>
+    <ProductIdentifier><ProductIDType>01</ProductIDType><IDValue>abc</IDValue></ProductIdentifier>
+    <ProductIdentifier><ProductIDType>15</ProductIDType><IDValue>978-0-14-000838-9</IDValue></ProductIdentifier>
+    <DescriptiveDetail>
+      <ProductForm>BC</ProductForm>
+      <TitleDetail>
+        <TitleType>01</TitleType>
+        <TitleElement>
+          <TitleElementLevel>01</TitleElementLevel>
+          <TitlePrefix>The</TitlePrefix>
+          <TitleWithoutPrefix>Animal Farm</TitleWithoutPrefix>
+        </TitleElement>
+      </TitleDetail>
+      <Contributor><ContributorRole>B01</ContributorRole><PersonName>An Editor</PersonName></Contributor>
+      <Contributor><ContributorRole>A01</ContributorRole><PersonName>George Orwell</PersonName></Contributor>
+      <Contributor><ContributorRole>A01</ContributorRole><PersonName>Someone Else</PersonName></Contributor>
+      <Language><LanguageRole>01</LanguageRole><LanguageCode>eng</LanguageCode></Language>
+    </DescriptiveDetail>
+    <PublishingDetail>
+      <Publisher><PublishingRole>01</PublishingRole><PublisherName>Penguin</PublisherName></Publisher>
+      <PublishingDate><PublishingDateRole>01</PublishingDateRole><Date dateformat="05">1945</Date></PublishingDate>
+    </PublishingDetail>
+  </Product>
+  <Product>
+    <ProductIdentifier><ProductIDType>02</ProductIDType><IDValue>014000838X</IDValue></ProductIdentifier>
+  </Product>
+  <Product>
+    <ProductIdentifier><ProductIDType>02</ProductIDType><IDValue>0140008381</IDValue></ProductIdentifier>
+    <DescriptiveDetail><ProductForm>EA</ProductForm></DescriptiveDetail>
+  </Product>
+</ONIXMessage>
+`
+	books, rowErrs := decodeAll(t, ONIX, in)
+	want := date(1945, time.January, 1)
+	want.Isbn = 9780140008389
+	want.Title = "The Animal Farm"
+	want.Author = "George Orwell, Someone Else"
+	want.BookType = library.BookType_PAPERBACK
+	want.PublishingMethod = &library.Book_Publisher{Publisher: &library.Publisher{Name: "Penguin"}}
+	if len(books) != 1 || !proto.Equal(books[0], want) {
+		t.Errorf("got %v, want %v", books, want)
+	}
+	wantErrs := []string{
+		`row 2: unsupported ISBN "014000838X"`,
+		`row 3: unsupported product form "EA"`,
+	}
+	if strings.Join(rowErrs, "\n") != strings.Join(wantErrs, "\n") {
+		t.Errorf("got errors %q, want %q", rowErrs, wantErrs)
+	}
+}
+
+func TestFormatFromFileName(t *testing.T) {
+	for name, want := range map[string]Format{
+		"books.csv":       CSV,
+		"books.JSONL":     JSONL,
+		"dir.d/books.xml": ONIX,
+	} {
+		f, err := FormatFromFileName(name)
+		if err != nil || f != want {
+			t.Errorf("%s: got %q, %v, want %q", name, f, err, want)
+		}
+	}
+	_, err := FormatFromFileName("books")
+	if err == nil {
+		t.Error("got no error for a file without an extension")
+	}
+}
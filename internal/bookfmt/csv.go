@@ -0,0 +1,210 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package bookfmt
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// csvColumns are the columns of the CSV format, in the order they are
+// written. When reading, the columns may be in any order, and all but
+// isbn may be omitted.
+var csvColumns = []string{
+	"isbn",
+	"title",
+	"author",
+	"book_type",
+	"publisher",
+	"self_published",
+	"publication_date",
+}
+
+// dateLayout is the layout of publication dates in CSV files.
+const dateLayout = "2006-01-02"
+
+type csvDecoder struct {
+	r   *csv.Reader
+	row int
+	// columns maps the index of each column to its
+	// name, once the header row has been read.
+	columns []string
+}
+
+func newCSVDecoder(r io.Reader) *csvDecoder {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	return &csvDecoder{r: cr}
+}
+
+func (d *csvDecoder) readHeader() error {
+	header, err := d.r.Read()
+	if err == io.EOF {
+		return errors.New("missing header row")
+	}
+	if err != nil {
+		return err
+	}
+	d.row++
+	hasIsbn := false
+	for _, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, c := range csvColumns {
+			known = known || name == c
+		}
+		if !known {
+			return fmt.Errorf("unknown column %q, columns should be some of %s", name, strings.Join(csvColumns, ", "))
+		}
+		for _, c := range d.columns {
+			if c == name {
+				return fmt.Errorf("duplicate column %q", name)
+			}
+		}
+		hasIsbn = hasIsbn || name == "isbn"
+		d.columns = append(d.columns, name)
+	}
+	if !hasIsbn {
+		return errors.New(`missing column "isbn"`)
+	}
+	return nil
+}
+
+func (d *csvDecoder) Decode() (*library.Book, int, error) {
+	if d.columns == nil {
+		err := d.readHeader()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	record, err := d.r.Read()
+	if err != nil {
+		if pe, ok := err.(*csv.ParseError); ok && pe.Err == csv.ErrFieldCount {
+			d.row++
+			return nil, d.row, &RowError{Row: d.row, Err: pe.Err}
+		}
+		return nil, 0, err
+	}
+	d.row++
+
+	bk := &library.Book{}
+	var publisher string
+	var selfPublished bool
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		var err error
+		switch d.columns[i] {
+		case "isbn":
+			bk.Isbn, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				err = fmt.Errorf("invalid ISBN %q", value)
+			}
+		case "title":
+			bk.Title = value
+		case "author":
+			bk.Author = value
+		case "book_type":
+			bt, ok := library.BookType_value[strings.ToUpper(value)]
+			if !ok {
+				err = fmt.Errorf("unknown book type %q", value)
+			}
+			bk.BookType = library.BookType(bt)
+		case "publisher":
+			publisher = value
+		case "self_published":
+			selfPublished, err = strconv.ParseBool(value)
+			if err != nil {
+				err = fmt.Errorf("invalid self_published %q, should be true or false", value)
+			}
+		case "publication_date":
+			var t time.Time
+			t, err = time.Parse(dateLayout, value)
+			if err != nil {
+				err = fmt.Errorf("invalid publication date %q, should be YYYY-MM-DD", value)
+				break
+			}
+			bk.PublicationDate, err = ptypes.TimestampProto(t)
+		}
+		if err != nil {
+			return nil, d.row, &RowError{Row: d.row, Err: err}
+		}
+	}
+
+	switch {
+	case selfPublished && publisher != "":
+		return nil, d.row, &RowError{Row: d.row, Err: errors.New("a self published book cannot have a publisher")}
+	case selfPublished:
+		bk.PublishingMethod = &library.Book_SelfPublished{SelfPublished: true}
+	case publisher != "":
+		bk.PublishingMethod = &library.Book_Publisher{Publisher: &library.Publisher{Name: publisher}}
+	}
+	return bk, d.row, nil
+}
+
+type csvEncoder struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.wroteHeader {
+		return nil
+	}
+	e.wroteHeader = true
+	return e.w.Write(csvColumns)
+}
+
+func (e *csvEncoder) Encode(bk *library.Book) error {
+	err := e.writeHeader()
+	if err != nil {
+		return err
+	}
+	var date string
+	if bk.PublicationDate != nil {
+		t, err := ptypes.Timestamp(bk.PublicationDate)
+		if err != nil {
+			return err
+		}
+		date = t.UTC().Format(dateLayout)
+	}
+	var selfPublished string
+	if _, ok := bk.PublishingMethod.(*library.Book_SelfPublished); ok {
+		selfPublished = strconv.FormatBool(bk.GetSelfPublished())
+	}
+	return e.w.Write([]string{
+		strconv.FormatInt(bk.Isbn, 10),
+		bk.Title,
+		bk.Author,
+		bk.BookType.String(),
+		bk.GetPublisher().GetName(),
+		selfPublished,
+		date,
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	// Write the header even if there were no books
+	err := e.writeHeader()
+	if err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
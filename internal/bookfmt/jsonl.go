@@ -0,0 +1,75 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package bookfmt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// maxLineLength is the length of the longest line
+// that can be read from a JSON Lines file.
+const maxLineLength = 1 << 20
+
+type jsonlDecoder struct {
+	sc  *bufio.Scanner
+	row int
+}
+
+func newJSONLDecoder(r io.Reader) *jsonlDecoder {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxLineLength)
+	return &jsonlDecoder{sc: sc}
+}
+
+func (d *jsonlDecoder) Decode() (*library.Book, int, error) {
+	for d.sc.Scan() {
+		d.row++
+		line := strings.TrimSpace(d.sc.Text())
+		if line == "" {
+			continue
+		}
+		bk := &library.Book{}
+		err := jsonpb.UnmarshalString(line, bk)
+		if err != nil {
+			return nil, d.row, &RowError{Row: d.row, Err: fmt.Errorf("invalid book: %v", err)}
+		}
+		return bk, d.row, nil
+	}
+	if err := d.sc.Err(); err != nil {
+		return nil, 0, err
+	}
+	return nil, 0, io.EOF
+}
+
+type jsonlEncoder struct {
+	w io.Writer
+	m *jsonpb.Marshaler
+}
+
+func newJSONLEncoder(w io.Writer) *jsonlEncoder {
+	return &jsonlEncoder{
+		w: w,
+		m: &jsonpb.Marshaler{OrigName: true},
+	}
+}
+
+func (e *jsonlEncoder) Encode(bk *library.Book) error {
+	s, err := e.m.MarshalToString(bk)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(e.w, s+"\n")
+	return err
+}
+
+func (e *jsonlEncoder) Close() error {
+	return nil
+}
@@ -0,0 +1,380 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package bookfmt
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// The subset of ONIX for Books 3.0 supported describes each book with a
+// Product element holding its ISBN, product form, title, authors,
+// publisher and publication date. Other elements are ignored when reading.
+// Books without a publisher are self published.
+
+const (
+	onixNamespace = "http://ns.editeur.org/onix/3.0/reference"
+	onixSender    = "grpcweb-example"
+)
+
+// Codes from the ONIX code lists used.
+const (
+	// List 5, product identifier type
+	onixISBN10 = "02"
+	onixISBN13 = "15"
+	// List 1, notification type: confirmed
+	onixConfirmed = "03"
+	// List 2, product composition: single-item retail product
+	onixSingleItem = "00"
+	// List 15, title type: distinctive title
+	onixDistinctiveTitle = "01"
+	// List 149, title element level: product
+	onixProductLevel = "01"
+	// List 17, contributor role: by (author)
+	onixAuthor = "A01"
+	// List 45, publishing role: publisher
+	onixPublisher = "01"
+	// List 163, publishing date role: publication date
+	onixPublicationDate = "01"
+	// List 55, date format: YYYYMMDD
+	onixDateFormat = "00"
+)
+
+// onixProductForms maps book types to ONIX product forms (list 150).
+var onixProductForms = map[library.BookType]string{
+	library.BookType_HARDCOVER: "BB",
+	library.BookType_PAPERBACK: "BC",
+	library.BookType_AUDIOBOOK: "AJ",
+}
+
+type onixProduct struct {
+	XMLName            xml.Name                `xml:"Product"`
+	RecordReference    string                  `xml:"RecordReference"`
+	NotificationType   string                  `xml:"NotificationType"`
+	ProductIdentifiers []onixProductIdentifier `xml:"ProductIdentifier"`
+	DescriptiveDetail  onixDescriptiveDetail   `xml:"DescriptiveDetail"`
+	PublishingDetail   *onixPublishingDetail   `xml:"PublishingDetail,omitempty"`
+}
+
+type onixProductIdentifier struct {
+	ProductIDType string `xml:"ProductIDType"`
+	IDValue       string `xml:"IDValue"`
+}
+
+type onixDescriptiveDetail struct {
+	ProductComposition string            `xml:"ProductComposition"`
+	ProductForm        string            `xml:"ProductForm"`
+	TitleDetails       []onixTitleDetail `xml:"TitleDetail"`
+	Contributors       []onixContributor `xml:"Contributor"`
+}
+
+type onixTitleDetail struct {
+	TitleType     string             `xml:"TitleType"`
+	TitleElements []onixTitleElement `xml:"TitleElement"`
+}
+
+type onixTitleElement struct {
+	TitleElementLevel  string `xml:"TitleElementLevel"`
+	TitleText          string `xml:"TitleText,omitempty"`
+	TitlePrefix        string `xml:"TitlePrefix,omitempty"`
+	TitleWithoutPrefix string `xml:"TitleWithoutPrefix,omitempty"`
+}
+
+type onixContributor struct {
+	SequenceNumber  int    `xml:"SequenceNumber,omitempty"`
+	ContributorRole string `xml:"ContributorRole"`
+	PersonName      string `xml:"PersonName"`
+}
+
+type onixPublishingDetail struct {
+	Publishers      []onixPublisherDetail `xml:"Publisher"`
+	PublishingDates []onixPublishingDate  `xml:"PublishingDate"`
+}
+
+type onixPublisherDetail struct {
+	PublishingRole string `xml:"PublishingRole"`
+	PublisherName  string `xml:"PublisherName"`
+}
+
+type onixPublishingDate struct {
+	PublishingDateRole string   `xml:"PublishingDateRole"`
+	Date               onixDate `xml:"Date"`
+}
+
+type onixDate struct {
+	Format string `xml:"dateformat,attr,omitempty"`
+	Value  string `xml:",chardata"`
+}
+
+type onixDecoder struct {
+	d   *xml.Decoder
+	row int
+}
+
+func newONIXDecoder(r io.Reader) *onixDecoder {
+	return &onixDecoder{d: xml.NewDecoder(r)}
+}
+
+func (d *onixDecoder) Decode() (*library.Book, int, error) {
+	for {
+		tok, err := d.d.Token()
+		if err != nil {
+			return nil, 0, err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "Product" {
+			continue
+		}
+		d.row++
+		var p onixProduct
+		err = d.d.DecodeElement(&p, &se)
+		if err != nil {
+			return nil, 0, err
+		}
+		bk, err := p.book()
+		if err != nil {
+			return nil, d.row, &RowError{Row: d.row, Err: err}
+		}
+		return bk, d.row, nil
+	}
+}
+
+// book returns the book described by p.
+func (p *onixProduct) book() (*library.Book, error) {
+	bk := &library.Book{}
+
+	for _, id := range p.ProductIdentifiers {
+		if id.ProductIDType != onixISBN10 && id.ProductIDType != onixISBN13 {
+			continue
+		}
+		value := strings.Replace(strings.TrimSpace(id.IDValue), "-", "", -1)
+		isbn, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported ISBN %q", id.IDValue)
+		}
+		bk.Isbn = isbn
+		break
+	}
+	if bk.Isbn == 0 {
+		return nil, errors.New("missing ISBN")
+	}
+
+	form := strings.TrimSpace(p.DescriptiveDetail.ProductForm)
+	switch {
+	case form == onixProductForms[library.BookType_HARDCOVER]:
+		bk.BookType = library.BookType_HARDCOVER
+	case form == onixProductForms[library.BookType_PAPERBACK]:
+		bk.BookType = library.BookType_PAPERBACK
+	case strings.HasPrefix(form, "A"):
+		// All audio forms
+		bk.BookType = library.BookType_AUDIOBOOK
+	default:
+		return nil, fmt.Errorf("unsupported product form %q", form)
+	}
+
+	for _, td := range p.DescriptiveDetail.TitleDetails {
+		if td.TitleType != onixDistinctiveTitle {
+			continue
+		}
+		for _, te := range td.TitleElements {
+			if te.TitleElementLevel != onixProductLevel {
+				continue
+			}
+			bk.Title = te.TitleText
+			if bk.Title == "" {
+				bk.Title = strings.TrimSpace(te.TitlePrefix + " " + te.TitleWithoutPrefix)
+			}
+		}
+	}
+
+	var authors []string
+	for _, c := range p.DescriptiveDetail.Contributors {
+		if c.ContributorRole == onixAuthor {
+			authors = append(authors, c.PersonName)
+		}
+	}
+	bk.Author = strings.Join(authors, ", ")
+
+	bk.PublishingMethod = &library.Book_SelfPublished{SelfPublished: true}
+	if pd := p.PublishingDetail; pd != nil {
+		for _, pub := range pd.Publishers {
+			if pub.PublishingRole == onixPublisher {
+				bk.PublishingMethod = &library.Book_Publisher{
+					Publisher: &library.Publisher{Name: pub.PublisherName},
+				}
+				break
+			}
+		}
+		for _, date := range pd.PublishingDates {
+			if date.PublishingDateRole != onixPublicationDate {
+				continue
+			}
+			t, err := date.Date.time()
+			if err != nil {
+				return nil, err
+			}
+			bk.PublicationDate, err = ptypes.TimestampProto(t)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return bk, nil
+}
+
+// time returns the time of d, which must be
+// a year, a year and month, or a full date.
+func (d onixDate) time() (time.Time, error) {
+	value := strings.TrimSpace(d.Value)
+	for _, layout := range []string{"20060102", "200601", "2006"} {
+		if len(value) != len(layout) {
+			continue
+		}
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported publication date %q", d.Value)
+}
+
+type onixEncoder struct {
+	e           *xml.Encoder
+	wroteHeader bool
+	// now returns the time the message is sent.
+	now func() time.Time
+}
+
+func newONIXEncoder(w io.Writer) *onixEncoder {
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	return &onixEncoder{e: e, now: time.Now}
+}
+
+func (e *onixEncoder) writeHeader() error {
+	if e.wroteHeader {
+		return nil
+	}
+	e.wroteHeader = true
+	err := e.e.EncodeToken(xml.ProcInst{Target: "xml", Inst: []byte(`version="1.0" encoding="UTF-8"`)})
+	if err != nil {
+		return err
+	}
+	err = e.e.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "ONIXMessage"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "release"}, Value: "3.0"},
+			{Name: xml.Name{Local: "xmlns"}, Value: onixNamespace},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	type sender struct {
+		SenderName string `xml:"SenderName"`
+	}
+	header := struct {
+		XMLName      xml.Name `xml:"Header"`
+		Sender       sender   `xml:"Sender"`
+		SentDateTime string   `xml:"SentDateTime"`
+	}{
+		Sender:       sender{SenderName: onixSender},
+		SentDateTime: e.now().UTC().Format("20060102T1504Z"),
+	}
+	return e.e.Encode(header)
+}
+
+func (e *onixEncoder) Encode(bk *library.Book) error {
+	err := e.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	form, ok := onixProductForms[bk.BookType]
+	if !ok {
+		return fmt.Errorf("unsupported book type %v", bk.BookType)
+	}
+	p := onixProduct{
+		RecordReference:  fmt.Sprintf("%s.%d", onixSender, bk.Isbn),
+		NotificationType: onixConfirmed,
+		DescriptiveDetail: onixDescriptiveDetail{
+			ProductComposition: onixSingleItem,
+			ProductForm:        form,
+			TitleDetails: []onixTitleDetail{{
+				TitleType: onixDistinctiveTitle,
+				TitleElements: []onixTitleElement{{
+					TitleElementLevel: onixProductLevel,
+					TitleText:         bk.Title,
+				}},
+			}},
+		},
+	}
+	// ISBNs with 10 digits or fewer are
+	// ISBN-10s, with their leading zeros.
+	if bk.Isbn < 1e10 {
+		p.ProductIdentifiers = []onixProductIdentifier{{
+			ProductIDType: onixISBN10,
+			IDValue:       fmt.Sprintf("%010d", bk.Isbn),
+		}}
+	} else {
+		p.ProductIdentifiers = []onixProductIdentifier{{
+			ProductIDType: onixISBN13,
+			IDValue:       fmt.Sprintf("%013d", bk.Isbn),
+		}}
+	}
+	if bk.Author != "" {
+		p.DescriptiveDetail.Contributors = []onixContributor{{
+			SequenceNumber:  1,
+			ContributorRole: onixAuthor,
+			PersonName:      bk.Author,
+		}}
+	}
+
+	pd := &onixPublishingDetail{}
+	if pub := bk.GetPublisher(); pub != nil {
+		pd.Publishers = []onixPublisherDetail{{
+			PublishingRole: onixPublisher,
+			PublisherName:  pub.Name,
+		}}
+	}
+	if bk.PublicationDate != nil {
+		t, err := ptypes.Timestamp(bk.PublicationDate)
+		if err != nil {
+			return err
+		}
+		pd.PublishingDates = []onixPublishingDate{{
+			PublishingDateRole: onixPublicationDate,
+			Date: onixDate{
+				Format: onixDateFormat,
+				Value:  t.UTC().Format("20060102"),
+			},
+		}}
+	}
+	if len(pd.Publishers) > 0 || len(pd.PublishingDates) > 0 {
+		p.PublishingDetail = pd
+	}
+	return e.e.Encode(p)
+}
+
+func (e *onixEncoder) Close() error {
+	// Write the header even if there were no books
+	err := e.writeHeader()
+	if err != nil {
+		return err
+	}
+	err = e.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "ONIXMessage"}})
+	if err != nil {
+		return err
+	}
+	return e.e.Flush()
+}
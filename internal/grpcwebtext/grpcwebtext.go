@@ -0,0 +1,139 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Package grpcwebtext implements the base64 encoded text format of gRPC-Web.
+package grpcwebtext
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Handler wraps a gRPC-Web handler such that it also supports the
+// text format of gRPC-Web, which grpcweb.WrappedGrpcServer does not.
+// Text requests are decoded and passed on as binary requests, and
+// the binary responses are encoded before they are sent to the client.
+func Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType := r.Header.Get("Content-Type")
+		if r.Method != http.MethodPost || !strings.HasPrefix(contentType, "application/grpc-web-text") {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		r.Header.Set("Content-Type", "application/grpc-web+proto")
+		r.Body = &base64Body{
+			ReadCloser: r.Body,
+			r:          NewReader(r.Body),
+		}
+		tw := &grpcWebTextResponseWriter{
+			ResponseWriter: w,
+			contentType:    contentType,
+		}
+		h.ServeHTTP(tw, r)
+		tw.close()
+	})
+}
+
+type base64Body struct {
+	io.ReadCloser
+	r io.Reader
+}
+
+func (b *base64Body) Read(p []byte) (int, error) {
+	return b.r.Read(p)
+}
+
+type base64Reader struct {
+	r       io.Reader
+	buf     [512]byte
+	quantum []byte
+	decoded []byte
+	err     error
+}
+
+// NewReader returns a reader decoding the standard base64 encoded data
+// read from r. Unlike base64.NewDecoder, it supports padding in the middle
+// of the input, as gRPC-Web allows the concatenation of separately
+// encoded chunks.
+func NewReader(r io.Reader) io.Reader {
+	return &base64Reader{r: r}
+}
+
+func (b *base64Reader) Read(p []byte) (int, error) {
+	for len(b.decoded) == 0 && b.err == nil {
+		n, err := b.r.Read(b.buf[:])
+		for _, c := range b.buf[:n] {
+			b.quantum = append(b.quantum, c)
+			if len(b.quantum) < 4 {
+				continue
+			}
+			var out [3]byte
+			m, decErr := base64.StdEncoding.Decode(out[:], b.quantum)
+			b.quantum = b.quantum[:0]
+			if decErr != nil {
+				err = decErr
+				break
+			}
+			b.decoded = append(b.decoded, out[:m]...)
+		}
+		if err == io.EOF && len(b.quantum) != 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		b.err = err
+	}
+
+	n := copy(p, b.decoded)
+	b.decoded = b.decoded[n:]
+	if len(b.decoded) == 0 {
+		return n, b.err
+	}
+	return n, nil
+}
+
+// grpcWebTextResponseWriter base64 encodes everything written to it.
+// The encoding is padded whenever the response is flushed, so
+// that clients can decode every chunk they receive.
+type grpcWebTextResponseWriter struct {
+	http.ResponseWriter
+	contentType string
+	enc         io.WriteCloser
+	wroteHeader bool
+}
+
+func (w *grpcWebTextResponseWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.Header().Set("Content-Type", w.contentType)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *grpcWebTextResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.enc == nil {
+		w.enc = base64.NewEncoder(base64.StdEncoding, w.ResponseWriter)
+	}
+	return w.enc.Write(b)
+}
+
+// close writes any partially encoded data.
+func (w *grpcWebTextResponseWriter) close() {
+	if w.enc != nil {
+		w.enc.Close()
+		w.enc = nil
+	}
+}
+
+func (w *grpcWebTextResponseWriter) Flush() {
+	w.close()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *grpcWebTextResponseWriter) CloseNotify() <-chan bool {
+	return w.ResponseWriter.(http.CloseNotifier).CloseNotify()
+}
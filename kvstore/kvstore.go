@@ -0,0 +1,122 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Package kvstore provides a generic key-value store, which several
+// replicas of the server can share, and implementations of it kept
+// in memory or in a directory.
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNotFound is returned by a Store when the requested key does not exist.
+var ErrNotFound = errors.New("kvstore: key not found")
+
+// Store is a key-value store shared between replicas.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the value stored under key.
+	// If there is no such key, Get returns ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores value under key, replacing any existing value.
+	Set(ctx context.Context, key string, value []byte) error
+	// Delete removes key from the store. Deleting a key
+	// that does not exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryStore is an in-memory Store. It is not shared between
+// processes, and is mostly useful for testing.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		values: map[string][]byte{},
+	}
+}
+
+// Get implements Store.
+func (m *MemoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	value, ok := m.values[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+// Set implements Store.
+func (m *MemoryStore) Set(ctx context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete implements Store.
+func (m *MemoryStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+	return nil
+}
+
+// DirStore is a Store keeping each value in a file of the directory
+// it names, which is created if needed. Keys are escaped to file
+// names, so they may contain slashes.
+type DirStore string
+
+func (d DirStore) path(key string) string {
+	return filepath.Join(string(d), url.PathEscape(key))
+}
+
+// Get implements Store.
+func (d DirStore) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := ioutil.ReadFile(d.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+// Set implements Store. Values are written to a temporary
+// file first, so that they are replaced atomically.
+func (d DirStore) Set(ctx context.Context, key string, value []byte) error {
+	if err := os.MkdirAll(string(d), 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(string(d), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(value); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), d.path(key))
+}
+
+// Delete implements Store.
+func (d DirStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(d.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package kvstore_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/johanbrandhorst/grpcweb-example/kvstore"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := kvstore.NewMemoryStore()
+	value := []byte("value")
+	if err := store.Set(ctx, "key", value); err != nil {
+		t.Fatal(err)
+	}
+	// The store keeps copies of the values
+	value[0] = 'V'
+	got, err := store.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got[1] = 'A'
+	if got, _ := store.Get(ctx, "key"); string(got) != "value" {
+		t.Errorf("got %q, want %q", got, "value")
+	}
+}
+
+func TestDirStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kvstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+	store := kvstore.DirStore(filepath.Join(dir, "store"))
+
+	if _, err := store.Get(ctx, "users/account/alice"); err != kvstore.ErrNotFound {
+		t.Fatalf("got error %v getting a missing key, want kvstore.ErrNotFound", err)
+	}
+	for _, value := range []string{"first", "second"} {
+		if err := store.Set(ctx, "users/account/alice", []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := store.Get(ctx, "users/account/alice"); err != nil || string(got) != value {
+			t.Errorf("got %q, %v, want %q", got, err, value)
+		}
+	}
+	// Keys with slashes are kept in a single directory
+	files, err := ioutil.ReadDir(string(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].IsDir() {
+		t.Errorf("got %d files in the store, want a single file", len(files))
+	}
+
+	if err := store.Delete(ctx, "users/account/alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "users/account/alice"); err != kvstore.ErrNotFound {
+		t.Errorf("got error %v getting a deleted key, want kvstore.ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "users/account/alice"); err != nil {
+		t.Errorf("got error %v deleting a missing key", err)
+	}
+}
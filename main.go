@@ -4,8 +4,12 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"path"
 	"strings"
@@ -13,19 +17,31 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
-	"github.com/lpar/gzipped"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/grpclog"
 
+	"github.com/johanbrandhorst/grpcweb-example/certcache"
 	"github.com/johanbrandhorst/grpcweb-example/client/compiled"
+	"github.com/johanbrandhorst/grpcweb-example/internal/grpcwebtext"
+	"github.com/johanbrandhorst/grpcweb-example/kvstore"
 	"github.com/johanbrandhorst/grpcweb-example/server"
 	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
 )
 
 var logger *logrus.Logger
-var host = flag.String("host", "", "host to get LetsEncrypt certificate for")
+var (
+	hosts         = flag.String("host", "", "comma separated list of hosts to get LetsEncrypt certificates for")
+	certCache     = flag.String("cert-cache", "/certs", `directory to cache certificates in, or "memory" to keep them in memory`)
+	acmeDirectory = flag.String("acme-directory", autocert.DefaultACMEDirectory, "ACME directory URL to request certificates from")
+	acmeCACert    = flag.String("acme-ca-cert", "", "optional PEM file with the CA certificate of the ACME directory, for testing against e.g. Pebble")
+	userStore     = flag.String("user-store", "", `directory to keep user accounts and sessions in, or "memory" to keep them in memory; defaults to /users with -host, and memory otherwise`)
+	adminToken    = flag.String("admin-token-file", "", "optional file with the token administrators send as the admin-token request metadata to list the background jobs and import books")
+	maxCollection = flag.Int("max-collection-size", server.DefaultMaxCollectionSize, "largest number of books in a collection")
+	maxImport     = flag.Int("max-import-size", server.DefaultMaxImportSize, "largest number of books in an import")
+)
 
 func init() {
 	logger = logrus.StandardLogger()
@@ -43,9 +59,31 @@ func init() {
 func main() {
 	flag.Parse()
 
+	hostList := splitHosts(*hosts)
 	gs := grpc.NewServer()
-	library.RegisterBookServiceServer(gs, &server.BookService{})
-	wrappedServer := grpcweb.WrapServer(gs, grpcweb.WithWebsockets(true))
+	users := &server.UserService{Store: newUserStore(*userStore, hostList)}
+	srv := &server.BookService{MaxCollectionSize: *maxCollection, MaxImportSize: *maxImport, Users: users}
+	if *adminToken != "" {
+		token, err := ioutil.ReadFile(*adminToken)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		srv.AdminToken = strings.TrimSpace(string(token))
+	}
+	library.RegisterBookServiceServer(gs, srv)
+	library.RegisterUserServiceServer(gs, users)
+	go func() {
+		logger.Fatal(srv.RunJobs(context.Background()))
+	}()
+
+	index, err := readFile(compiled.Assets, "/index.html")
+	if err != nil {
+		logger.Fatal(err)
+	}
+	handler, err := newHandler(gs, compiled.Assets, defaultSecurityHeaders(index))
+	if err != nil {
+		logger.Fatal(err)
+	}
 
 	httpsSrv := &http.Server{
 		// These interfere with websocket streams, disable for now
@@ -61,28 +99,20 @@ func main() {
 				tls.X25519,
 			},
 		},
-		Handler: hstsHandler(
-			grpcTrafficSplitter(
-				folderReader(
-					gzipped.FileServer(compiled.Assets).ServeHTTP,
-				),
-				wrappedServer,
-			),
-		),
+		Handler: handler,
 	}
 
 	// Serve on localhost with localhost certs if no host provided
-	if *host == "" {
+	if len(hostList) == 0 {
 		httpsSrv.Addr = "localhost:10000"
 		logger.Info("Serving on https://localhost:10000")
 		logger.Fatal(httpsSrv.ListenAndServeTLS("./insecure/cert.pem", "./insecure/key.pem"))
 	}
 
 	// Create auto-certificate https server
-	m := autocert.Manager{
-		Prompt:     autocert.AcceptTOS,
-		HostPolicy: autocert.HostWhitelist(*host),
-		Cache:      autocert.DirCache("/certs"),
+	m, err := newCertManager(hostList, newCertCache(*certCache), *acmeDirectory, *acmeCACert)
+	if err != nil {
+		logger.Fatal(err)
 	}
 
 	// Create server for redirecting HTTP to HTTPS
@@ -98,23 +128,121 @@ func main() {
 	}()
 
 	httpsSrv.TLSConfig = m.TLSConfig()
-	logger.Info("Serving on https://0.0.0.0:443, authenticating for https://", *host)
+	logger.Info("Serving on https://0.0.0.0:443, authenticating for ", strings.Join(hostList, ", "))
 	logger.Fatal(httpsSrv.ListenAndServeTLS("", ""))
 }
 
-// hstsHandler wraps an http.HandlerFunc such that it sets the HSTS header.
-func hstsHandler(fn http.HandlerFunc) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains; preload")
-		fn(w, r)
-	})
+// newHandler returns the handler serving gRPC, gRPC-Web and gRPC-Web
+// over WebSocket requests with gs, and the frontend from assets
+// for all other requests, setting the headers described by s.
+func newHandler(gs *grpc.Server, assets http.FileSystem, s securityHeaders) (http.Handler, error) {
+	etags, err := assetETags(assets)
+	if err != nil {
+		return nil, err
+	}
+	wrappedServer := grpcweb.WrapServer(gs, grpcweb.WithWebsockets(true))
+
+	return securityHeadersHandler(
+		s,
+		grpcTrafficSplitter(
+			folderReader(
+				cacheHandler(
+					etags,
+					newAssetServer(assets).ServeHTTP,
+				),
+			),
+			grpcwebtext.Handler(wrappedServer),
+		),
+	), nil
+}
+
+// newCertCache returns the autocert.Cache described by the cert-cache flag.
+func newCertCache(cache string) autocert.Cache {
+	if cache == "memory" {
+		return certcache.New(kvstore.NewMemoryStore(), "")
+	}
+	return autocert.DirCache(cache)
+}
+
+// newUserStore returns the store of user accounts described by the
+// user-store flag, when serving hosts.
+func newUserStore(store string, hosts []string) kvstore.Store {
+	if store == "" {
+		store = "memory"
+		if len(hosts) != 0 {
+			store = "/users"
+		}
+	}
+	if store == "memory" {
+		return kvstore.NewMemoryStore()
+	}
+	return kvstore.DirStore(store)
+}
+
+// splitHosts returns the hosts of the comma separated list
+// hosts, without spaces around them or empty hosts.
+func splitHosts(hosts string) []string {
+	var list []string
+	for _, host := range strings.Split(hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			list = append(list, host)
+		}
+	}
+	return list
+}
+
+// newCertManager creates an autocert.Manager requesting certificates
+// for hosts from the ACME directory at directoryURL. If caCertFile is
+// not empty, it is used to verify the TLS certificate of the directory.
+func newCertManager(hosts []string, cache autocert.Cache, directoryURL, caCertFile string) (*autocert.Manager, error) {
+	client := &acme.Client{
+		DirectoryURL: directoryURL,
+	}
+	if caCertFile != "" {
+		pem, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %q", caCertFile)
+		}
+		client.HTTPClient = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs: pool,
+				},
+			},
+		}
+	}
+
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(hosts...),
+		Cache:      cache,
+		Client:     client,
+	}, nil
+}
+
+// readFile reads the named file from fs.
+func readFile(fs http.FileSystem, name string) ([]byte, error) {
+	f, err := fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(f)
 }
 
+// folderReader serves index.html in place of directories and
+// any path that does not look like a file, so that the routes
+// of the client, such as /books/9780140301694, can be linked to
+// directly. Paths of files that do not exist are still not found.
 func folderReader(fn http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if strings.HasSuffix(r.URL.Path, "/") {
-			// Use contents of index.html for directory, if present.
-			r.URL.Path = path.Join(r.URL.Path, "index.html")
+		if strings.HasSuffix(r.URL.Path, "/") || path.Ext(r.URL.Path) == "" {
+			// Use contents of index.html for directory and client routes.
+			r.URL.Path = "/index.html"
 		}
 		fn(w, r)
 	})
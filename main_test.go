@@ -0,0 +1,479 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+
+	"github.com/johanbrandhorst/grpcweb-example/client/compiled"
+	"github.com/johanbrandhorst/grpcweb-example/internal/grpcwebtext"
+	"github.com/johanbrandhorst/grpcweb-example/kvstore"
+	"github.com/johanbrandhorst/grpcweb-example/server"
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func newTestServer(t *testing.T, s securityHeaders) (*httptest.Server, func()) {
+	t.Helper()
+	gs := grpc.NewServer()
+	library.RegisterBookServiceServer(gs, &server.BookService{})
+	handler, err := newHandler(gs, compiled.Assets, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(handler)
+	return srv, func() {
+		srv.Close()
+		gs.Stop()
+	}
+}
+
+// encodeFrames returns msgs as length-prefixed gRPC frames.
+func encodeFrames(t *testing.T, msgs ...proto.Message) []byte {
+	t.Helper()
+	var frames []byte
+	for _, msg := range msgs {
+		b, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		frame := make([]byte, 5, 5+len(b))
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(b)))
+		frames = append(frames, append(frame, b...)...)
+	}
+	return frames
+}
+
+// trailerFlag is set in the first byte of frames
+// containing headers or trailers.
+const trailerFlag = 1 << 7
+
+func readFrame(r io.Reader) (flag byte, payload []byte, err error) {
+	var prefix [5]byte
+	_, err = io.ReadFull(r, prefix[:])
+	if err != nil {
+		return 0, nil, err
+	}
+	payload = make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+	_, err = io.ReadFull(r, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	return prefix[0], payload, nil
+}
+
+func parseTrailer(t *testing.T, payload []byte) http.Header {
+	t.Helper()
+	tp := textproto.NewReader(bufio.NewReader(io.MultiReader(
+		bytes.NewReader(payload),
+		strings.NewReader("\r\n"),
+	)))
+	h, err := tp.ReadMIMEHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return http.Header(h)
+}
+
+func grpcWebRequest(t *testing.T, url string, msgs ...proto.Message) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(encodeFrames(t, msgs...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+	return req
+}
+
+func grpcWebTextRequest(t *testing.T, url string, msgs ...proto.Message) *http.Request {
+	t.Helper()
+	body := base64.StdEncoding.EncodeToString(encodeFrames(t, msgs...))
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/grpc-web-text+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+	return req
+}
+
+// grpcWebResult is the result of a gRPC-Web call.
+type grpcWebResult struct {
+	header   http.Header
+	messages [][]byte
+	trailer  http.Header
+}
+
+// status returns the gRPC status of the call, which is sent in the
+// headers instead of the trailers if the response has no messages.
+func (r grpcWebResult) status() string {
+	if s := r.trailer.Get("Grpc-Status"); s != "" {
+		return s
+	}
+	return r.header.Get("Grpc-Status")
+}
+
+func doGRPCWeb(t *testing.T, req *http.Request) grpcWebResult {
+	t.Helper()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %v", resp.Status)
+	}
+
+	var body io.Reader = resp.Body
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc-web-text") {
+		if ct := resp.Header.Get("Content-Type"); ct != req.Header.Get("Content-Type") {
+			t.Fatalf("unexpected Content-Type: %q", ct)
+		}
+		body = grpcwebtext.NewReader(resp.Body)
+	}
+
+	res := grpcWebResult{header: resp.Header, trailer: http.Header{}}
+	for {
+		flag, payload, err := readFrame(body)
+		if err == io.EOF {
+			return res
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if flag&trailerFlag != 0 {
+			res.trailer = parseTrailer(t, payload)
+			continue
+		}
+		res.messages = append(res.messages, payload)
+	}
+}
+
+func TestGRPCWeb(t *testing.T) {
+	srv, cleanup := newTestServer(t, testSecurityHeaders(t))
+	defer cleanup()
+
+	formats := []struct {
+		name       string
+		newRequest func(*testing.T, string, ...proto.Message) *http.Request
+	}{
+		{name: "Binary", newRequest: grpcWebRequest},
+		{name: "Text", newRequest: grpcWebTextRequest},
+	}
+	for _, format := range formats {
+		format := format
+		call := func(t *testing.T, method string, msgs ...proto.Message) grpcWebResult {
+			t.Helper()
+			return doGRPCWeb(t, format.newRequest(t, srv.URL+"/library.BookService/"+method, msgs...))
+		}
+
+		t.Run(format.name, func(t *testing.T) {
+			t.Run("GetBook", func(t *testing.T) {
+				res := call(t, "GetBook", &library.GetBookRequest{Isbn: 140008381})
+				if res.status() != "0" {
+					t.Fatalf("unexpected gRPC status: %q", res.status())
+				}
+				if len(res.messages) != 1 {
+					t.Fatalf("got %d messages, want 1", len(res.messages))
+				}
+				var bk library.Book
+				err := proto.Unmarshal(res.messages[0], &bk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if bk.Title != "Animal Farm" {
+					t.Errorf("unexpected book: %v", bk)
+				}
+			})
+
+			t.Run("GetBookNotFound", func(t *testing.T) {
+				res := call(t, "GetBook", &library.GetBookRequest{Isbn: 1})
+				// NotFound
+				if res.status() != "5" {
+					t.Fatalf("unexpected gRPC status: %q", res.status())
+				}
+				if len(res.messages) != 0 {
+					t.Fatalf("got %d messages, want none", len(res.messages))
+				}
+			})
+
+			t.Run("QueryBooks", func(t *testing.T) {
+				res := call(t, "QueryBooks", &library.QueryBooksRequest{AuthorPrefix: "George"})
+				if res.status() != "0" {
+					t.Fatalf("unexpected gRPC status: %q", res.status())
+				}
+				var titles []string
+				for _, msg := range res.messages {
+					var bk library.Book
+					err := proto.Unmarshal(msg, &bk)
+					if err != nil {
+						t.Fatal(err)
+					}
+					titles = append(titles, bk.Title)
+				}
+				if strings.Join(titles, ", ") != "Nineteen Eighty-Four, Animal Farm" {
+					t.Errorf("unexpected books: %q", titles)
+				}
+			})
+
+			t.Run("MakeCollection", func(t *testing.T) {
+				res := call(t, "MakeCollection",
+					&library.Book{Isbn: 140008381},
+					&library.Book{Isbn: 60929871},
+				)
+				if res.status() != "0" {
+					t.Fatalf("unexpected gRPC status: %q", res.status())
+				}
+				if len(res.messages) != 1 {
+					t.Fatalf("got %d messages, want 1", len(res.messages))
+				}
+				var c library.Collection
+				err := proto.Unmarshal(res.messages[0], &c)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(c.Books) != 2 || c.Books[0].Title != "Animal Farm" || c.Books[1].Title != "Brave New World" {
+					t.Errorf("unexpected collection: %v", c)
+				}
+			})
+		})
+	}
+}
+
+// wsStream is a gRPC-Web call over the
+// WebSocket protocol of grpcweb.WrappedGrpcServer.
+type wsStream struct {
+	t    *testing.T
+	conn *websocket.Conn
+	// buf holds the part of the last
+	// message that has not been read.
+	buf []byte
+	// header is the header of the response,
+	// which is sent before any messages.
+	header http.Header
+}
+
+func dialGRPCWebSocket(t *testing.T, srv *httptest.Server, method string) *wsStream {
+	t.Helper()
+	d := websocket.Dialer{
+		Subprotocols: []string{"grpc-websockets"},
+	}
+	conn, _, err := d.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"/library.BookService/"+method,
+		http.Header{"Origin": []string{srv.URL}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first message contains the request headers
+	err = conn.WriteMessage(websocket.BinaryMessage, []byte(
+		"content-type: application/grpc-web+proto\r\nx-grpc-web: 1\r\n",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &wsStream{t: t, conn: conn}
+}
+
+func (s *wsStream) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		_, msg, err := s.conn.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+		s.buf = msg
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+func (s *wsStream) send(msg proto.Message) {
+	s.t.Helper()
+	// Data messages are prefixed with a 0
+	err := s.conn.WriteMessage(websocket.BinaryMessage, append([]byte{0}, encodeFrames(s.t, msg)...))
+	if err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+func (s *wsStream) closeSend() {
+	s.t.Helper()
+	err := s.conn.WriteMessage(websocket.BinaryMessage, []byte{1})
+	if err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+// recv reads the next message of the response into msg.
+// It returns the trailer of the response instead if there
+// are no more messages.
+func (s *wsStream) recv(msg proto.Message) http.Header {
+	s.t.Helper()
+	for {
+		flag, payload, err := readFrame(s)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		if flag&trailerFlag == 0 {
+			err = proto.Unmarshal(payload, msg)
+			if err != nil {
+				s.t.Fatal(err)
+			}
+			return nil
+		}
+		h := parseTrailer(s.t, payload)
+		// Responses without messages only have a single
+		// frame, with both the header and the trailer.
+		if s.header == nil && h.Get("Grpc-Status") == "" {
+			s.header = h
+			continue
+		}
+		return h
+	}
+}
+
+func (s *wsStream) expect(want string) {
+	s.t.Helper()
+	var msg library.BookResponse
+	trailer := s.recv(&msg)
+	if trailer != nil {
+		s.t.Fatalf("unexpected trailer: %v", trailer)
+	}
+	if msg.Message != want {
+		s.t.Fatalf("got message %q, want %q", msg.Message, want)
+	}
+}
+
+func (s *wsStream) expectStatus(want string) {
+	s.t.Helper()
+	var msg library.BookResponse
+	trailer := s.recv(&msg)
+	if trailer == nil {
+		s.t.Fatalf("unexpected message: %v", msg)
+	}
+	if trailer.Get("Grpc-Status") != want {
+		s.t.Fatalf("unexpected gRPC status: %q", trailer.Get("Grpc-Status"))
+	}
+}
+
+func TestGRPCWebSocket(t *testing.T) {
+	srv, cleanup := newTestServer(t, testSecurityHeaders(t))
+	defer cleanup()
+
+	t.Run("MakeCollection", func(t *testing.T) {
+		stream := dialGRPCWebSocket(t, srv, "MakeCollection")
+		defer stream.conn.Close()
+		stream.send(&library.Book{Isbn: 140008381})
+		stream.send(&library.Book{Isbn: 60929871})
+		stream.closeSend()
+
+		var c library.Collection
+		if trailer := stream.recv(&c); trailer != nil {
+			t.Fatalf("unexpected trailer: %v", trailer)
+		}
+		if len(c.Books) != 2 || c.Books[0].Title != "Animal Farm" || c.Books[1].Title != "Brave New World" {
+			t.Errorf("unexpected collection: %v", c)
+		}
+		if trailer := stream.recv(&c); trailer.Get("Grpc-Status") != "0" {
+			t.Fatalf("unexpected trailer: %v", trailer)
+		}
+	})
+
+	t.Run("BookChat", func(t *testing.T) {
+		// join joins the chat as name, expecting
+		// the messages of the history provided.
+		join := func(name string, history ...string) *wsStream {
+			stream := dialGRPCWebSocket(t, srv, "BookChat")
+			stream.send(&library.BookMessage{Content: &library.BookMessage_Name{Name: name}})
+			for _, msg := range history {
+				stream.expect(msg)
+			}
+			// Users receive their own messages,
+			// so this returns once the user has joined.
+			stream.send(&library.BookMessage{Content: &library.BookMessage_Message{Message: "ping"}})
+			stream.expect(name + ": ping")
+			return stream
+		}
+
+		alice := join("alice")
+		defer alice.conn.Close()
+		bob := join("bob", "alice has joined the chat", "alice: ping")
+		defer bob.conn.Close()
+		alice.expect("bob has joined the chat")
+		alice.expect("bob: ping")
+
+		bob.send(&library.BookMessage{Content: &library.BookMessage_Message{Message: "hi alice"}})
+		bob.expect("bob: hi alice")
+		alice.expect("bob: hi alice")
+
+		bob.closeSend()
+		bob.expectStatus("0")
+		alice.expect("bob has left the chat")
+
+		impostor := dialGRPCWebSocket(t, srv, "BookChat")
+		defer impostor.conn.Close()
+		impostor.send(&library.BookMessage{Content: &library.BookMessage_Name{Name: "alice"}})
+		// AlreadyExists
+		impostor.expectStatus("6")
+	})
+}
+
+func TestSplitHosts(t *testing.T) {
+	for _, test := range []struct {
+		hosts string
+		want  []string
+	}{
+		{"", nil},
+		{"example.com", []string{"example.com"}},
+		{"example.com, www.example.com", []string{"example.com", "www.example.com"}},
+		{"example.com,", []string{"example.com"}},
+		{" , ,", nil},
+	} {
+		got := splitHosts(test.hosts)
+		if strings.Join(got, " ") != strings.Join(test.want, " ") || len(got) != len(test.want) {
+			t.Errorf("splitHosts(%q) = %q, want %q", test.hosts, got, test.want)
+		}
+	}
+}
+
+func TestNewUserStore(t *testing.T) {
+	for _, test := range []struct {
+		store string
+		hosts []string
+		// dir is the directory of the store, or "" in memory
+		dir string
+	}{
+		{"", nil, ""},
+		{"", []string{"example.com"}, "/users"},
+		{"memory", []string{"example.com"}, ""},
+		{"/var/lib/users", nil, "/var/lib/users"},
+	} {
+		switch s := newUserStore(test.store, test.hosts).(type) {
+		case kvstore.DirStore:
+			if string(s) != test.dir {
+				t.Errorf("newUserStore(%q, %q) stores users in %s, want %q", test.store, test.hosts, s, test.dir)
+			}
+		case *kvstore.MemoryStore:
+			if test.dir != "" {
+				t.Errorf("newUserStore(%q, %q) stores users in memory, want %s", test.store, test.hosts, test.dir)
+			}
+		default:
+			t.Errorf("newUserStore(%q, %q) = %T", test.store, test.hosts, s)
+		}
+	}
+}
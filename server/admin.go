@@ -0,0 +1,31 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/subtle"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminTokenKey is the request metadata key of the admin token.
+const adminTokenKey = "admin-token"
+
+// isAdmin reports whether the request metadata
+// of ctx holds the admin token.
+func (s *BookService) isAdmin(ctx context.Context) bool {
+	token := metadataValue(ctx, adminTokenKey)
+	return s.AdminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.AdminToken)) == 1
+}
+
+// checkAdmin returns a PermissionDenied error saying that
+// action requires the admin token, unless ctx holds it.
+func (s *BookService) checkAdmin(ctx context.Context, action string) error {
+	if !s.isAdmin(ctx) {
+		return status.Errorf(codes.PermissionDenied, "%s requires the admin token", action)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func (s *BookService) GetAuthor(ctx context.Context, req *library.GetAuthorRequest) (*library.Author, error) {
+	if a, ok := s.c.Author(req.Id); ok {
+		return a, nil
+	}
+
+	return nil, status.Errorf(codes.NotFound, "Author could not be found")
+}
+
+func (s *BookService) ListAuthors(ctx context.Context, req *library.ListAuthorsRequest) (*library.ListAuthorsResponse, error) {
+	return &library.ListAuthorsResponse{
+		Authors: s.c.Authors(req.NamePrefix),
+	}, nil
+}
+
+func (s *BookService) GetPublisher(ctx context.Context, req *library.GetPublisherRequest) (*library.Publisher, error) {
+	if p, ok := s.c.Publisher(req.Id); ok {
+		return p, nil
+	}
+
+	return nil, status.Errorf(codes.NotFound, "Publisher could not be found")
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func authorIDs(authors []*library.Author) []string {
+	var ids []string
+	for _, a := range authors {
+		ids = append(ids, a.Id)
+	}
+	return ids
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAuthorsAndPublishers(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx, cancel := testContext()
+	defer cancel()
+
+	a, err := client.GetAuthor(ctx, &library.GetAuthorRequest{Id: "george-orwell"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Name != "George Orwell" {
+		t.Errorf("got author %v, want George Orwell", a)
+	}
+	_, err = client.GetAuthor(ctx, &library.GetAuthorRequest{Id: "eric-blair"})
+	checkCode(t, err, codes.NotFound)
+
+	p, err := client.GetPublisher(ctx, &library.GetPublisherRequest{Id: "secker-warburg"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Secker & Warburg" {
+		t.Errorf("got publisher %v, want Secker & Warburg", p)
+	}
+	_, err = client.GetPublisher(ctx, &library.GetPublisherRequest{Id: "penguin"})
+	checkCode(t, err, codes.NotFound)
+
+	resp, err := client.ListAuthors(ctx, &library.ListAuthorsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"aldous-huxley", "george-orwell", "john-tenniel", "lewis-carroll", "lisa-genova"}
+	if got := authorIDs(resp.Authors); !equalIDs(got, want) {
+		t.Errorf("got authors %v, want %v", got, want)
+	}
+	resp, err = client.ListAuthors(ctx, &library.ListAuthorsRequest{NamePrefix: "L"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := authorIDs(resp.Authors); !equalIDs(got, want[3:]) {
+		t.Errorf("got authors %v, want %v", got, want[3:])
+	}
+
+	bks := query(t, client, &library.QueryBooksRequest{AuthorId: "george-orwell"})
+	if len(bks) != 2 || bks[0].Title != "Nineteen Eighty-Four" || bks[1].Title != "Animal Farm" {
+		t.Errorf("got books %v, want the books of George Orwell", bks)
+	}
+	bks = query(t, client, &library.QueryBooksRequest{AuthorId: "john-tenniel"})
+	if len(bks) != 0 {
+		t.Errorf("got books %v, want no books by the illustrator", bks)
+	}
+}
+
+func TestImportReferences(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	byID := &library.Book{
+		Isbn:  1,
+		Title: "Homage to Catalonia",
+		Contributors: []*library.Contributor{
+			{AuthorId: "george-orwell"},
+		},
+		PublishingMethod: &library.Book_Publisher{
+			Publisher: &library.Publisher{Id: "secker-warburg"},
+		},
+	}
+	byName := &library.Book{
+		Isbn:   2,
+		Title:  "The Invisible Orwell",
+		Author: "George  Orwell",
+	}
+	invalid := []*library.Book{
+		{
+			Isbn: 3, Title: "Unknown Author",
+			Contributors: []*library.Contributor{{AuthorId: "eric-blair"}},
+		},
+		{
+			Isbn: 3, Title: "Misnamed Author",
+			Contributors: []*library.Contributor{{Name: "Eric Blair", AuthorId: "george-orwell"}},
+		},
+		{
+			Isbn: 3, Title: "Unknown Publisher", Author: "Anonymous",
+			PublishingMethod: &library.Book_Publisher{Publisher: &library.Publisher{Id: "penguin"}},
+		},
+	}
+	wantErrs := []string{
+		`unknown author "eric-blair"`,
+		`author "george-orwell" is named "George Orwell", not "Eric Blair"`,
+		`unknown publisher "penguin"`,
+	}
+	resp := importBooks(t, client, nil, append([]*library.Book{byID, byName}, invalid...)...)
+	if resp.Created != 2 || len(resp.Errors) != len(wantErrs) {
+		t.Fatalf("got %v, want 2 books created and errors %q", resp, wantErrs)
+	}
+	for i, e := range resp.Errors {
+		if e.Message != wantErrs[i] {
+			t.Errorf("got error %q, want %q", e.Message, wantErrs[i])
+		}
+	}
+
+	ctx, cancel := testContext()
+	defer cancel()
+	bk, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: byID.Isbn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bk.Author != "George Orwell" || bk.Contributors[0].Name != "George Orwell" || bk.GetPublisher().GetName() != "Secker & Warburg" {
+		t.Errorf("references were not resolved: %v", bk)
+	}
+
+	// Authors with new names are added,
+	// with Ids that do not clash.
+	bk, err = client.GetBook(ctx, &library.GetBookRequest{Isbn: byName.Isbn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := bk.Contributors[0].AuthorId; id != "george-orwell-2" {
+		t.Errorf("got author %q, want george-orwell-2", id)
+	}
+}
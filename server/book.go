@@ -0,0 +1,88 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"strings"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// authors returns the names of the authors of bk. Books without
+// contributors with the author role have the author as their only author.
+func authors(bk *library.Book) []string {
+	var names []string
+	for _, c := range bk.Contributors {
+		if c.Role == library.ContributorRole_AUTHOR {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) == 0 && bk.Author != "" {
+		names = append(names, bk.Author)
+	}
+	return names
+}
+
+// hasAuthorPrefix reports whether the author of bk,
+// or any of its authors, starts with prefix.
+func hasAuthorPrefix(bk *library.Book, prefix string) bool {
+	if strings.HasPrefix(bk.Author, prefix) {
+		return true
+	}
+	for _, name := range authors(bk) {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAuthorID reports whether the author with the Id id is an author of bk.
+func hasAuthorID(bk *library.Book, id string) bool {
+	for _, c := range bk.Contributors {
+		if c.Role == library.ContributorRole_AUTHOR && c.AuthorId == id {
+			return true
+		}
+	}
+	return false
+}
+
+// bookFilter matches the books that are queried or watched.
+type bookFilter struct {
+	authorPrefix string
+	// authorID, if set, only matches the
+	// books of the author with this Id.
+	authorID string
+}
+
+func (f bookFilter) match(bk *library.Book) bool {
+	if f.authorID != "" && !hasAuthorID(bk, f.authorID) {
+		return false
+	}
+	return hasAuthorPrefix(bk, f.authorPrefix)
+}
+
+// normalizeBook sets the fields of bk that can be derived
+// from other fields, so that clients that only know about
+// some of the fields see the same book as those that know
+// about all of them.
+func normalizeBook(bk *library.Book) {
+	// The summaries are not stored, but computed
+	// from the copies and reviews of the book.
+	bk.Availability = nil
+	bk.RatingCount = 0
+	bk.AverageRating = 0
+	if bk.Author == "" {
+		bk.Author = strings.Join(authors(bk), ", ")
+		return
+	}
+	for _, c := range bk.Contributors {
+		if c.Role == library.ContributorRole_AUTHOR {
+			return
+		}
+	}
+	// Books from clients that do not know about
+	// contributors reference their author by name.
+	bk.Contributors = append([]*library.Contributor{{Name: bk.Author}}, bk.Contributors...)
+}
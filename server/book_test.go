@@ -0,0 +1,56 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func TestContributors(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	book := &library.Book{
+		Isbn:  9780141182438,
+		Title: "The Best of Orwell and Huxley",
+		Contributors: []*library.Contributor{
+			{Name: "George Orwell", Role: library.ContributorRole_AUTHOR},
+			{Name: "Aldous Huxley", Role: library.ContributorRole_AUTHOR},
+			{Name: "Anonymous", Role: library.ContributorRole_EDITOR},
+		},
+	}
+	resp := importBooks(t, client, nil, book)
+	if len(resp.Errors) > 0 {
+		t.Fatal(resp.Errors)
+	}
+
+	ctx, cancel := testContext()
+	defer cancel()
+	got, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: book.Isbn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Clients that only know about the
+	// author still see all of the authors.
+	if got.Author != "George Orwell, Aldous Huxley" {
+		t.Errorf("got author %q, want the authors joined", got.Author)
+	}
+
+	for _, prefix := range []string{"George", "Aldous"} {
+		found := false
+		for _, bk := range queryBooks(t, client, prefix) {
+			found = found || bk.Isbn == book.Isbn
+		}
+		if !found {
+			t.Errorf("querying for %q did not find the book", prefix)
+		}
+	}
+	for _, bk := range queryBooks(t, client, "Anonymous") {
+		if bk.Isbn == book.Isbn {
+			t.Error("querying for the editor found the book")
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// branches are the branches of the library. The
+// copies of the default books are spread across them.
+var branches = []*library.Branch{
+	{Id: "central", Name: "Central Library", Address: "1 Library Square"},
+	{Id: "riverside", Name: "Riverside Branch", Address: "42 River Road"},
+}
+
+func branchKey(id string) string { return "branch/" + id }
+
+// availability returns the availability of the book with the ISBN isbn.
+func availability(t *tx, isbn int64) *library.Availability {
+	a := &library.Availability{}
+	byBranch := map[string]*library.BranchAvailability{}
+	for _, cp := range copies(t, isbn) {
+		ba, ok := byBranch[cp.BranchId]
+		if !ok {
+			ba = &library.BranchAvailability{BranchId: cp.BranchId}
+			br := &library.Branch{}
+			if t.Get(branchKey(cp.BranchId), br) {
+				ba.BranchName = br.Name
+			}
+			byBranch[cp.BranchId] = ba
+		}
+		a.Copies++
+		ba.Copies++
+		switch {
+		case cp.OnLoan:
+			ba.OnLoan++
+		case cp.OnHold:
+			ba.OnHold++
+		default:
+			a.Available++
+			ba.Available++
+		}
+	}
+	for _, k := range t.Keys(branchKey("")) {
+		if ba, ok := byBranch[strings.TrimPrefix(k, branchKey(""))]; ok {
+			a.Branches = append(a.Branches, ba)
+		}
+	}
+	return a
+}
+
+// availableAt returns the number of copies
+// available at the branch with the Id branchID.
+func availableAt(a *library.Availability, branchID string) int32 {
+	for _, ba := range a.Branches {
+		if ba.BranchId == branchID {
+			return ba.Available
+		}
+	}
+	return 0
+}
+
+// hasBranch reports whether the branch with the Id id exists.
+func (s *BookService) hasBranch(id string) bool {
+	var ok bool
+	s.circ.store().View(func(t *tx) error {
+		ok = t.Has(branchKey(id))
+		return nil
+	})
+	return ok
+}
+
+func (s *BookService) ListBranches(ctx context.Context, req *library.ListBranchesRequest) (*library.ListBranchesResponse, error) {
+	resp := &library.ListBranchesResponse{}
+	err := s.circ.store().View(func(t *tx) error {
+		for _, k := range t.Keys(branchKey("")) {
+			br := &library.Branch{}
+			t.Get(k, br)
+			resp.Branches = append(resp.Branches, br)
+		}
+		return nil
+	})
+	return resp, err
+}
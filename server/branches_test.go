@@ -0,0 +1,108 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func TestListBranches(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx, cancel := testContext()
+	defer cancel()
+
+	resp, err := client.ListBranches(ctx, &library.ListBranchesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Branches) != len(branches) {
+		t.Fatalf("got branches %v, want %v", resp.Branches, branches)
+	}
+	for i, br := range resp.Branches {
+		if !proto.Equal(br, branches[i]) {
+			t.Errorf("got branch %v, want %v", br, branches[i])
+		}
+	}
+}
+
+func TestAvailability(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx, cancel := client.userContext(t, "", adminTokenKey, testAdminToken)
+	defer cancel()
+
+	// The copy at central is kept for bob, and
+	// both copies at riverside are on loan
+	isbn := books[3].Isbn
+	l := checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: isbn, BranchId: "central"})
+	checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: isbn, BranchId: "riverside"})
+	_, err := client.AddCopy(ctx, &library.AddCopyRequest{Isbn: isbn, BranchId: "riverside"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: isbn, BranchId: "riverside"})
+	placeHold(t, client, "bob", isbn)
+	returnCopy(t, client, l.CopyId)
+
+	bk, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: isbn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &library.Availability{
+		Copies:    3,
+		Available: 0,
+		Branches: []*library.BranchAvailability{
+			{BranchId: "central", BranchName: "Central Library", Copies: 1, OnHold: 1},
+			{BranchId: "riverside", BranchName: "Riverside Branch", Copies: 2, OnLoan: 2},
+		},
+	}
+	if !proto.Equal(bk.Availability, want) {
+		t.Errorf("got availability %v, want %v", bk.Availability, want)
+	}
+
+	// Only books with a copy available at the branch are queried
+	queryAt := func(branchID string) ([]int64, error) {
+		stream, err := client.QueryBooks(ctx, &library.QueryBooksRequest{AvailableAtBranch: branchID})
+		if err != nil {
+			return nil, err
+		}
+		var isbns []int64
+		for {
+			bk, err := stream.Recv()
+			if err == io.EOF {
+				return isbns, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if availableAt(bk.Availability, branchID) == 0 {
+				t.Errorf("got book %d without copies available at %s", bk.Isbn, branchID)
+			}
+			isbns = append(isbns, bk.Isbn)
+		}
+	}
+	checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: books[0].Isbn, BranchId: "central"})
+	for branchID, want := range map[string][]int64{
+		"central":   {140009728, 9780140301694, 1501107739},
+		"riverside": {60929871, 140009728, 9780140301694, 1501107739},
+	} {
+		isbns, err := queryAt(branchID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(isbns) != fmt.Sprint(want) {
+			t.Errorf("got ISBNs %v available at %s, want %v", isbns, branchID, want)
+		}
+	}
+	_, err = queryAt("unknown")
+	checkCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,369 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+const (
+	// maxChanges is the number of changes
+	// kept for resuming watches.
+	maxChanges = 1000
+	// watchBuffer is the number of changes buffered
+	// for each watch before it has fallen behind.
+	watchBuffer = 100
+)
+
+// catalogue holds the books of the library.
+// The zero value holds the default books.
+type catalogue struct {
+	once  sync.Once
+	mu    sync.RWMutex
+	books []*library.Book
+	// authors and publishers hold the authors
+	// and publishers of the books, by Id.
+	authors    map[string]*library.Author
+	publishers map[string]*library.Publisher
+	// seq is the sequence number of the last change.
+	seq int64
+	// changes holds the last changes, up to maxChanges.
+	changes []change
+	watches map[*watch]bool
+}
+
+// change is a change to the catalogue.
+type change struct {
+	seq int64
+	// old is nil for added books.
+	old, new *library.Book
+}
+
+// watch receives the changes to the catalogue.
+type watch struct {
+	// Seq is the sequence number of the last
+	// change made before the watch started.
+	Seq int64
+	// Existing holds the books in the catalogue
+	// when the watch started, if requested.
+	Existing []*library.Book
+	// Backlog holds the changes made before the watch
+	// started after the sequence number requested.
+	Backlog []change
+	// C receives the changes made after the watch started.
+	// It is closed if the watch falls behind.
+	C chan change
+}
+
+var (
+	errFutureSequence = errors.New("sequence number has not been reached")
+	errSequenceGone   = errors.New("changes after the sequence number are no longer available")
+)
+
+// load populates the catalogue with
+// the default books the first time.
+func (c *catalogue) load() {
+	c.once.Do(func() {
+		c.authors = map[string]*library.Author{}
+		c.publishers = map[string]*library.Publisher{}
+		for _, bk := range books {
+			bk = proto.Clone(bk).(*library.Book)
+			// The default books name the authors
+			// and publishers they reference by Id.
+			for _, ct := range bk.Contributors {
+				if ct.AuthorId != "" {
+					c.authors[ct.AuthorId] = &library.Author{Id: ct.AuthorId, Name: ct.Name}
+				}
+			}
+			if p := bk.GetPublisher(); p.GetId() != "" {
+				c.publishers[p.Id] = &library.Publisher{Id: p.Id, Name: p.Name}
+			}
+			c.resolve(bk)
+			c.books = append(c.books, bk)
+		}
+	})
+}
+
+// Get returns the book with the ISBN isbn, if found.
+func (c *catalogue) Get(isbn int64) (*library.Book, bool) {
+	c.load()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, bk := range c.books {
+		if bk.Isbn == isbn {
+			return bk, true
+		}
+	}
+	return nil, false
+}
+
+// Query returns the books matched by f.
+func (c *catalogue) Query(f bookFilter) []*library.Book {
+	c.load()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var found []*library.Book
+	for _, bk := range c.books {
+		if f.match(bk) {
+			found = append(found, bk)
+		}
+	}
+	return found
+}
+
+// After returns the books with ISBNs greater than isbn, ordered by ISBN.
+func (c *catalogue) After(isbn int64) []*library.Book {
+	c.load()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var found []*library.Book
+	for _, bk := range c.books {
+		if bk.Isbn > isbn {
+			found = append(found, bk)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Isbn < found[j].Isbn
+	})
+	return found
+}
+
+// Put adds bks to the catalogue, replacing any books with the same
+// ISBN, and returns the number of books added and replaced.
+// Books in the catalogue must not be modified, so bks are owned
+// by the catalogue once they have been added. The authors and
+// publishers of bks are resolved, see CheckReferences.
+func (c *catalogue) Put(bks ...*library.Book) (created, updated int) {
+	c.load()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	index := make(map[int64]int, len(c.books))
+	for i, bk := range c.books {
+		index[bk.Isbn] = i
+	}
+	for _, bk := range bks {
+		c.resolve(bk)
+		if i, ok := index[bk.Isbn]; ok {
+			c.record(c.books[i], bk)
+			c.books[i] = bk
+			updated++
+			continue
+		}
+		c.record(nil, bk)
+		index[bk.Isbn] = len(c.books)
+		c.books = append(c.books, bk)
+		created++
+	}
+	return created, updated
+}
+
+// record records the change of the book old to new,
+// and sends it to the watches. c.mu must be held.
+func (c *catalogue) record(old, new *library.Book) {
+	c.seq++
+	ch := change{seq: c.seq, old: old, new: new}
+	if len(c.changes) == maxChanges {
+		c.changes = append(c.changes[:0], c.changes[1:]...)
+	}
+	c.changes = append(c.changes, ch)
+	for w := range c.watches {
+		select {
+		case w.C <- ch:
+		default:
+			// Never block on a watch that has fallen behind,
+			// it can be resumed from the last change received.
+			close(w.C)
+			delete(c.watches, w)
+		}
+	}
+}
+
+// Watch starts watching the changes made after the change with the
+// sequence number after, or the changes made from now on if after
+// is 0. If existing is set, the books in the catalogue are returned
+// in the watch. Stop the watch with Unwatch.
+func (c *catalogue) Watch(after int64, existing bool) (*watch, error) {
+	c.load()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &watch{
+		Seq: c.seq,
+		C:   make(chan change, watchBuffer),
+	}
+	if after > c.seq {
+		return nil, errFutureSequence
+	}
+	if after > 0 && after < c.seq {
+		if len(c.changes) == 0 || c.changes[0].seq > after+1 {
+			return nil, errSequenceGone
+		}
+		for _, ch := range c.changes {
+			if ch.seq > after {
+				w.Backlog = append(w.Backlog, ch)
+			}
+		}
+	}
+	if existing {
+		w.Existing = append([]*library.Book(nil), c.books...)
+	}
+	if c.watches == nil {
+		c.watches = map[*watch]bool{}
+	}
+	c.watches[w] = true
+	return w, nil
+}
+
+// Unwatch stops the watch w.
+func (c *catalogue) Unwatch(w *watch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watches[w] {
+		delete(c.watches, w)
+		close(w.C)
+	}
+}
+
+// Author returns the author with the Id id, if found.
+func (c *catalogue) Author(id string) (*library.Author, bool) {
+	c.load()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	a, ok := c.authors[id]
+	return a, ok
+}
+
+// Authors returns the authors with names
+// starting with namePrefix, ordered by name.
+func (c *catalogue) Authors(namePrefix string) []*library.Author {
+	c.load()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var found []*library.Author
+	for _, a := range c.authors {
+		if strings.HasPrefix(a.Name, namePrefix) {
+			found = append(found, a)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Name != found[j].Name {
+			return found[i].Name < found[j].Name
+		}
+		return found[i].Id < found[j].Id
+	})
+	return found
+}
+
+// Publisher returns the publisher with the Id id, if found.
+func (c *catalogue) Publisher(id string) (*library.Publisher, bool) {
+	c.load()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	p, ok := c.publishers[id]
+	return p, ok
+}
+
+// CheckReferences returns an error if bk references an author or publisher
+// that is not in the catalogue, or names them differently. Books passing
+// the check can be put in the catalogue, as authors and publishers are
+// never removed.
+func (c *catalogue) CheckReferences(bk *library.Book) error {
+	c.load()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, ct := range bk.Contributors {
+		if ct.AuthorId == "" {
+			continue
+		}
+		a, ok := c.authors[ct.AuthorId]
+		if !ok {
+			return fmt.Errorf("unknown author %q", ct.AuthorId)
+		}
+		if ct.Name != "" && ct.Name != a.Name {
+			return fmt.Errorf("author %q is named %q, not %q", a.Id, a.Name, ct.Name)
+		}
+	}
+	if p := bk.GetPublisher(); p.GetId() != "" {
+		known, ok := c.publishers[p.Id]
+		if !ok {
+			return fmt.Errorf("unknown publisher %q", p.Id)
+		}
+		if p.Name != "" && p.Name != known.Name {
+			return fmt.Errorf("publisher %q is named %q, not %q", p.Id, known.Name, p.Name)
+		}
+	}
+	return nil
+}
+
+// resolve sets the names of the authors and publishers bk references
+// by Id, and the Ids of those it references by name, adding them to
+// the catalogue if there are none with that name. References must
+// have been checked with CheckReferences. c.mu must be held.
+func (c *catalogue) resolve(bk *library.Book) {
+	for _, ct := range bk.Contributors {
+		if ct.AuthorId != "" {
+			ct.Name = c.authors[ct.AuthorId].Name
+		}
+	}
+	normalizeBook(bk)
+	for _, ct := range bk.Contributors {
+		if ct.AuthorId != "" {
+			continue
+		}
+		for id, a := range c.authors {
+			if a.Name == ct.Name {
+				ct.AuthorId = id
+				break
+			}
+		}
+		if ct.AuthorId == "" {
+			ct.AuthorId = newID(ct.Name, func(id string) bool { return c.authors[id] != nil })
+			c.authors[ct.AuthorId] = &library.Author{Id: ct.AuthorId, Name: ct.Name}
+		}
+	}
+
+	p := bk.GetPublisher()
+	switch {
+	case p == nil:
+	case p.Id != "":
+		p.Name = c.publishers[p.Id].Name
+	default:
+		for id, known := range c.publishers {
+			if known.Name == p.Name {
+				p.Id = id
+				break
+			}
+		}
+		if p.Id == "" {
+			p.Id = newID(p.Name, func(id string) bool { return c.publishers[id] != nil })
+			c.publishers[p.Id] = &library.Publisher{Id: p.Id, Name: p.Name}
+		}
+	}
+}
+
+// newID returns an Id for name, made of its letters and digits
+// separated by dashes, such as "george-orwell" for "George Orwell".
+// Ids already taken are made unique with a number.
+func newID(name string, taken func(id string) bool) string {
+	id := strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+	if id == "" {
+		id = "unnamed"
+	}
+	unique := id
+	for i := 2; taken(unique); i++ {
+		unique = id + "-" + strconv.Itoa(i)
+	}
+	return unique
+}
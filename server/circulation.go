@@ -0,0 +1,418 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+const (
+	// defaultCopies is the number of copies
+	// of each of the default books.
+	defaultCopies = 2
+	// maxRenewals is the number of times a loan can be renewed.
+	maxRenewals = 2
+	// fineCentsPerDay is the fine for each day,
+	// or part of a day, a loan is overdue.
+	fineCentsPerDay = 25
+	// maxFineCents is the largest fine of a loan.
+	maxFineCents = 1000
+)
+
+// loanPeriods are the periods books are lent for, by type.
+var loanPeriods = map[library.BookType]time.Duration{
+	library.BookType_HARDCOVER: 28 * 24 * time.Hour,
+	library.BookType_PAPERBACK: 14 * 24 * time.Hour,
+	library.BookType_AUDIOBOOK: 7 * 24 * time.Hour,
+}
+
+// circulation holds the branches of the library, the copies of books
+// at them, and the loans and holds of the copies, as the records
+// "branch/<id>", "copy/<id>", "loan/<id>" and "hold/<id>" of a store.
+// Copies have the Ids "<isbn>-<n>". The zero value holds the default
+// branches and copies of the default books.
+type circulation struct {
+	once sync.Once
+	st   store
+
+	mu      sync.Mutex
+	watches map[*holdWatch]bool
+}
+
+// store returns the store of c, adding
+// the copies of the default books the first time.
+func (c *circulation) store() *store {
+	c.once.Do(func() {
+		err := c.st.Update(func(t *tx) error {
+			for _, bk := range books {
+				for i := 0; i < defaultCopies; i++ {
+					if err := addCopy(t, bk.Isbn, branches[i%len(branches)].Id); err != nil {
+						return err
+					}
+				}
+			}
+			for _, br := range branches {
+				if err := t.Insert(branchKey(br.Id), br); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	})
+	return &c.st
+}
+
+func copyKey(id string) string { return "copy/" + id }
+func loanKey(id string) string { return "loan/" + id }
+
+// addCopy adds a copy of the book with the ISBN
+// isbn at the branch with the Id branchID.
+func addCopy(t *tx, isbn int64, branchID string) error {
+	id := strconv.FormatInt(isbn, 10) + "-" + t.NextID("copy-"+strconv.FormatInt(isbn, 10))
+	return t.Insert(copyKey(id), &library.Copy{Id: id, Isbn: isbn, BranchId: branchID})
+}
+
+// copies returns the copies of the book with
+// the ISBN isbn, in the order they were added.
+func copies(t *tx, isbn int64) []*library.Copy {
+	var cps []*library.Copy
+	for _, k := range t.Keys(copyKey(strconv.FormatInt(isbn, 10) + "-")) {
+		cp := &library.Copy{}
+		t.Get(k, cp)
+		cps = append(cps, cp)
+	}
+	sort.Slice(cps, func(i, j int) bool {
+		return copyNumber(cps[i].Id) < copyNumber(cps[j].Id)
+	})
+	return cps
+}
+
+// copyNumber returns the number of the copy with the Id id.
+func copyNumber(id string) int64 {
+	n, _ := strconv.ParseInt(id[strings.LastIndex(id, "-")+1:], 10, 64)
+	return n
+}
+
+// loans returns the loans matched by fn, in the order they were made.
+func loans(t *tx, fn func(*library.Loan) bool) []*library.Loan {
+	var found []*library.Loan
+	for _, k := range t.Keys(loanKey("")) {
+		l := &library.Loan{}
+		t.Get(k, l)
+		if fn(l) {
+			found = append(found, l)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return idLess(found[i].Id, found[j].Id)
+	})
+	return found
+}
+
+// fine returns the fine of l if returned at now.
+func fine(l *library.Loan, now time.Time) int64 {
+	due, err := ptypes.Timestamp(l.Due)
+	if err != nil || !now.After(due) {
+		return 0
+	}
+	day := 24 * time.Hour
+	days := int64((now.Sub(due) + day - 1) / day)
+	if days*fineCentsPerDay > maxFineCents {
+		return maxFineCents
+	}
+	return days * fineCentsPerDay
+}
+
+// updateFines updates the fines of the loans not returned, and
+// returns the number of loans overdue and the total of their fines.
+func updateFines(t *tx, now time.Time) (overdue int, total int64, err error) {
+	open := loans(t, func(l *library.Loan) bool {
+		return l.Returned == nil
+	})
+	for _, l := range open {
+		f := fine(l, now)
+		if f > 0 {
+			overdue++
+			total += f
+		}
+		if f == l.FineCents {
+			continue
+		}
+		l.FineCents = f
+		if err := t.Put(loanKey(l.Id), l); err != nil {
+			return 0, 0, err
+		}
+	}
+	return overdue, total, nil
+}
+
+// idLess reports whether the Id a, returned by
+// NextID, was returned before the Id b.
+func idLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// circulate runs fn in a transaction of the store of s, after
+// expiring the holds which were not picked up in time. The
+// changes to holds made are sent to the hold watches.
+func (s *BookService) circulate(fn func(*tx) error) error {
+	return s.circ.store().Update(func(t *tx) error {
+		if _, err := expireReadyHolds(t, s.now()); err != nil {
+			return err
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+		// The transaction is committed once fn succeeds, and
+		// the store is locked until then, so watches receive
+		// the changes in the order they were made.
+		s.circ.publish(t)
+		return nil
+	})
+}
+
+// clock returns the clock of s.
+func (s *BookService) clock() Clock {
+	if s.Clock != nil {
+		return s.Clock
+	}
+	return systemClock{}
+}
+
+// now returns the current time.
+func (s *BookService) now() time.Time {
+	return s.clock().Now()
+}
+
+func (s *BookService) ListCopies(ctx context.Context, req *library.ListCopiesRequest) (*library.ListCopiesResponse, error) {
+	resp := &library.ListCopiesResponse{}
+	s.circ.store().View(func(t *tx) error {
+		resp.Copies = copies(t, req.Isbn)
+		return nil
+	})
+	return resp, nil
+}
+
+func (s *BookService) AddCopy(ctx context.Context, req *library.AddCopyRequest) (*library.Copy, error) {
+	if err := s.checkAdmin(ctx, "adding copies"); err != nil {
+		return nil, err
+	}
+	if req.BranchId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing branch")
+	}
+	if _, ok := s.c.Get(req.Isbn); !ok {
+		return nil, status.Errorf(codes.NotFound, "Book could not be found")
+	}
+	var cp *library.Copy
+	err := s.circulate(func(t *tx) error {
+		if !t.Has(branchKey(req.BranchId)) {
+			return status.Errorf(codes.NotFound, "Branch could not be found")
+		}
+		if err := addCopy(t, req.Isbn, req.BranchId); err != nil {
+			return err
+		}
+		cps := copies(t, req.Isbn)
+		cp = cps[len(cps)-1]
+		return allocate(t, cp, s.now())
+	})
+	return cp, err
+}
+
+func (s *BookService) CheckOut(ctx context.Context, req *library.CheckOutRequest) (*library.Loan, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var loan *library.Loan
+	err = s.circulate(func(t *tx) error {
+		cp := &library.Copy{}
+		if req.CopyId != "" {
+			if !t.Get(copyKey(req.CopyId), cp) {
+				return status.Errorf(codes.NotFound, "Copy could not be found")
+			}
+			if cp.OnLoan {
+				return status.Errorf(codes.FailedPrecondition, "copy %s is on loan", cp.Id)
+			}
+		} else if req.BranchId != "" && !t.Has(branchKey(req.BranchId)) {
+			return status.Errorf(codes.NotFound, "Branch could not be found")
+		} else if cp = availableCopy(t, req.Isbn, user, req.BranchId); cp == nil {
+			return status.Errorf(codes.FailedPrecondition, "no copy of book %d is available", req.Isbn)
+		}
+		hold := readyHold(t, cp.Id)
+		if cp.OnHold && (hold == nil || hold.User != user) {
+			return status.Errorf(codes.FailedPrecondition, "copy %s is kept for a hold", cp.Id)
+		}
+		bk, ok := s.c.Get(cp.Isbn)
+		if !ok {
+			return status.Errorf(codes.NotFound, "Book could not be found")
+		}
+
+		now := s.now()
+		checkedOut, err := ptypes.TimestampProto(now)
+		if err != nil {
+			return err
+		}
+		due, err := ptypes.TimestampProto(now.Add(loanPeriods[bk.BookType]))
+		if err != nil {
+			return err
+		}
+		if hold != nil {
+			hold.State = library.Hold_FULFILLED
+			if err := t.Put(holdKey(hold.Id), hold); err != nil {
+				return err
+			}
+		}
+		cp.OnLoan = true
+		cp.OnHold = false
+		cp.Due = due
+		loan = &library.Loan{
+			Id:         t.NextID("loan"),
+			CopyId:     cp.Id,
+			Isbn:       cp.Isbn,
+			Borrower:   user,
+			CheckedOut: checkedOut,
+			Due:        due,
+		}
+		if err := t.Put(copyKey(cp.Id), cp); err != nil {
+			return err
+		}
+		return t.Insert(loanKey(loan.Id), loan)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+func (s *BookService) Return(ctx context.Context, req *library.ReturnRequest) (*library.Loan, error) {
+	// Administrators return any loan, such as
+	// the loans of the copies left at a branch.
+	var user string
+	if !s.isAdmin(ctx) {
+		var err error
+		user, err = s.sessionUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var loan *library.Loan
+	err := s.circulate(func(t *tx) error {
+		cp := &library.Copy{}
+		if !t.Get(copyKey(req.CopyId), cp) {
+			return status.Errorf(codes.NotFound, "Copy could not be found")
+		}
+		open := loans(t, func(l *library.Loan) bool {
+			return l.CopyId == cp.Id && l.Returned == nil
+		})
+		if !cp.OnLoan || len(open) == 0 {
+			return status.Errorf(codes.FailedPrecondition, "copy %s is not on loan", cp.Id)
+		}
+		loan = open[0]
+		if user != "" && loan.Borrower != user {
+			return status.Error(codes.PermissionDenied, "Loan is of another user")
+		}
+		now := s.now()
+		returned, err := ptypes.TimestampProto(now)
+		if err != nil {
+			return err
+		}
+		loan.Returned = returned
+		loan.FineCents = fine(loan, now)
+		cp.OnLoan = false
+		cp.Due = nil
+		if err := t.Put(loanKey(loan.Id), loan); err != nil {
+			return err
+		}
+		// The copy is kept for the next hold, if any
+		return allocate(t, cp, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+func (s *BookService) Renew(ctx context.Context, req *library.RenewRequest) (*library.Loan, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	loan := &library.Loan{}
+	err = s.circulate(func(t *tx) error {
+		if !t.Get(loanKey(req.LoanId), loan) {
+			return status.Errorf(codes.NotFound, "Loan could not be found")
+		}
+		switch {
+		case loan.Borrower != user:
+			return status.Error(codes.PermissionDenied, "Loan is of another user")
+		case loan.Returned != nil:
+			return status.Errorf(codes.FailedPrecondition, "loan %s has ended", loan.Id)
+		case loan.Renewals >= maxRenewals:
+			return status.Errorf(codes.FailedPrecondition, "loans can be renewed at most %d times", maxRenewals)
+		case len(waitingHolds(t, loan.Isbn)) > 0:
+			return status.Errorf(codes.FailedPrecondition, "other users are waiting for book %d", loan.Isbn)
+		}
+		bk, ok := s.c.Get(loan.Isbn)
+		if !ok {
+			return status.Errorf(codes.NotFound, "Book could not be found")
+		}
+		// Renewed loans are due a loan period after the
+		// renewal, or the current due date if later.
+		due := s.now().Add(loanPeriods[bk.BookType])
+		if current, err := ptypes.Timestamp(loan.Due); err == nil && current.After(due) {
+			due = current
+		}
+		ts, err := ptypes.TimestampProto(due)
+		if err != nil {
+			return err
+		}
+		loan.Due = ts
+		loan.Renewals++
+
+		cp := &library.Copy{}
+		if t.Get(copyKey(loan.CopyId), cp) {
+			cp.Due = loan.Due
+			if err := t.Put(copyKey(cp.Id), cp); err != nil {
+				return err
+			}
+		}
+		return t.Put(loanKey(loan.Id), loan)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+func (s *BookService) ListLoans(ctx context.Context, req *library.ListLoansRequest) (*library.ListLoansResponse, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &library.ListLoansResponse{}
+	err = s.circ.store().View(func(t *tx) error {
+		resp.Loans = loans(t, func(l *library.Loan) bool {
+			return l.Borrower == user && (req.IncludeReturned || l.Returned == nil)
+		})
+		return nil
+	})
+	return resp, err
+}
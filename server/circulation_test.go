@@ -0,0 +1,331 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func checkOut(t *testing.T, client *testClient, user string, req *library.CheckOutRequest) *library.Loan {
+	t.Helper()
+	ctx, cancel := client.userContext(t, user)
+	defer cancel()
+	l, err := client.CheckOut(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func checkDue(t *testing.T, l *library.Loan, want time.Time) {
+	t.Helper()
+	due, err := ptypes.Timestamp(l.Due)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !due.Equal(want) {
+		t.Errorf("loan %s is due %v, want %v", l.Id, due, want)
+	}
+}
+
+func loanIDs(ls []*library.Loan) []string {
+	var ids []string
+	for _, l := range ls {
+		ids = append(ids, l.Id)
+	}
+	return ids
+}
+
+func TestCirculation(t *testing.T) {
+	clock := newFakeClock(testNow)
+	client, cleanup := newServiceClient(t, &BookService{Clock: clock})
+	defer cleanup()
+
+	t.Run("DueDates", func(t *testing.T) {
+		for _, test := range []struct {
+			isbn int64
+			due  time.Duration
+		}{
+			{books[0].Isbn, 28 * 24 * time.Hour}, // hardcover
+			{books[1].Isbn, 14 * 24 * time.Hour}, // paperback
+			{books[2].Isbn, 7 * 24 * time.Hour},  // audiobook
+		} {
+			l := checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: test.isbn})
+			if l.Isbn != test.isbn || l.Borrower != "alice" || l.CopyId == "" {
+				t.Errorf("got loan %v, want a loan of book %d to alice", l, test.isbn)
+			}
+			checkDue(t, l, testNow.Add(test.due))
+		}
+	})
+
+	t.Run("CheckOut", func(t *testing.T) {
+		isbn := books[3].Isbn
+		l := checkOut(t, client, "bob", &library.CheckOutRequest{CopyId: "140008381-2"})
+
+		ctx, cancel := client.userContext(t, "carol")
+		defer cancel()
+		_, err := client.CheckOut(ctx, &library.CheckOutRequest{CopyId: l.CopyId})
+		checkCode(t, err, codes.FailedPrecondition)
+
+		// The first copy is still available
+		other := checkOut(t, client, "carol", &library.CheckOutRequest{Isbn: isbn})
+		if other.CopyId != "140008381-1" {
+			t.Errorf("got copy %s, want 140008381-1", other.CopyId)
+		}
+		_, err = client.CheckOut(ctx, &library.CheckOutRequest{Isbn: isbn})
+		checkCode(t, err, codes.FailedPrecondition)
+
+		resp, err := client.ListCopies(ctx, &library.ListCopiesRequest{Isbn: isbn})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Copies) != 2 || !resp.Copies[0].OnLoan || !resp.Copies[1].OnLoan {
+			t.Errorf("got copies %v, want 2 copies on loan", resp.Copies)
+		}
+
+		_, err = client.AddCopy(ctx, &library.AddCopyRequest{Isbn: isbn, BranchId: "riverside"})
+		checkCode(t, err, codes.PermissionDenied)
+		admin, cancel := client.userContext(t, "", adminTokenKey, testAdminToken)
+		defer cancel()
+		cp, err := client.AddCopy(admin, &library.AddCopyRequest{Isbn: isbn, BranchId: "riverside"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cp.Id != "140008381-3" || cp.OnLoan || cp.BranchId != "riverside" {
+			t.Errorf("got copy %v, want available copy 140008381-3 at riverside", cp)
+		}
+		_, err = client.CheckOut(ctx, &library.CheckOutRequest{Isbn: isbn, BranchId: "central"})
+		checkCode(t, err, codes.FailedPrecondition)
+		checkOut(t, client, "carol", &library.CheckOutRequest{Isbn: isbn, BranchId: "riverside"})
+
+		for _, test := range []struct {
+			name string
+			user string
+			req  *library.CheckOutRequest
+			code codes.Code
+		}{
+			{"NoUser", "", &library.CheckOutRequest{Isbn: books[4].Isbn}, codes.Unauthenticated},
+			{"UnknownCopy", "carol", &library.CheckOutRequest{CopyId: "1-1"}, codes.NotFound},
+			{"UnknownBook", "carol", &library.CheckOutRequest{Isbn: 1}, codes.FailedPrecondition},
+			{"UnknownBranch", "carol", &library.CheckOutRequest{Isbn: books[4].Isbn, BranchId: "unknown"}, codes.NotFound},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				ctx, cancel := client.userContext(t, test.user)
+				defer cancel()
+				_, err := client.CheckOut(ctx, test.req)
+				checkCode(t, err, test.code)
+			})
+		}
+
+		for _, req := range []*library.AddCopyRequest{
+			{Isbn: 1, BranchId: "central"},
+			{Isbn: isbn, BranchId: "unknown"},
+		} {
+			_, err = client.AddCopy(admin, req)
+			checkCode(t, err, codes.NotFound)
+		}
+		_, err = client.AddCopy(admin, &library.AddCopyRequest{Isbn: isbn})
+		checkCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("Return", func(t *testing.T) {
+		l := checkOut(t, client, "dave", &library.CheckOutRequest{Isbn: books[4].Isbn})
+		clock.Advance(time.Hour)
+
+		for _, test := range []struct {
+			name string
+			user string
+			code codes.Code
+		}{
+			{"NoUser", "", codes.Unauthenticated},
+			{"OtherUser", "erin", codes.PermissionDenied},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				ctx, cancel := client.userContext(t, test.user)
+				defer cancel()
+				_, err := client.Return(ctx, &library.ReturnRequest{CopyId: l.CopyId})
+				checkCode(t, err, test.code)
+			})
+		}
+
+		ctx, cancel := client.userContext(t, "dave")
+		defer cancel()
+		returned, err := client.Return(ctx, &library.ReturnRequest{CopyId: l.CopyId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if returned.Id != l.Id || returned.Returned == nil {
+			t.Errorf("got loan %v, want loan %s returned", returned, l.Id)
+		}
+		_, err = client.Return(ctx, &library.ReturnRequest{CopyId: l.CopyId})
+		checkCode(t, err, codes.FailedPrecondition)
+		_, err = client.Return(ctx, &library.ReturnRequest{CopyId: "1-1"})
+		checkCode(t, err, codes.NotFound)
+
+		again := checkOut(t, client, "erin", &library.CheckOutRequest{CopyId: l.CopyId})
+		if again.Id == l.Id {
+			t.Errorf("got loan %s again, want a new loan", again.Id)
+		}
+
+		// Administrators return the loans of any user
+		admin, cancel := client.userContext(t, "", adminTokenKey, testAdminToken)
+		defer cancel()
+		returned, err = client.Return(admin, &library.ReturnRequest{CopyId: again.CopyId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if returned.Id != again.Id || returned.Returned == nil {
+			t.Errorf("got loan %v, want loan %s returned", returned, again.Id)
+		}
+	})
+
+	t.Run("Renew", func(t *testing.T) {
+		l := checkOut(t, client, "frank", &library.CheckOutRequest{Isbn: books[1].Isbn})
+		clock.Advance(10 * 24 * time.Hour)
+
+		ctx, cancel := client.userContext(t, "frank")
+		defer cancel()
+		for i := 0; i < maxRenewals; i++ {
+			renewed, err := client.Renew(ctx, &library.RenewRequest{LoanId: l.Id})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if renewed.Renewals != int32(i+1) {
+				t.Errorf("got %d renewals, want %d", renewed.Renewals, i+1)
+			}
+			checkDue(t, renewed, clock.Now().Add(14*24*time.Hour))
+		}
+		_, err := client.Renew(ctx, &library.RenewRequest{LoanId: l.Id})
+		checkCode(t, err, codes.FailedPrecondition)
+
+		other, cancel := client.userContext(t, "alice")
+		defer cancel()
+		_, err = client.Renew(other, &library.RenewRequest{LoanId: l.Id})
+		checkCode(t, err, codes.PermissionDenied)
+		_, err = client.Renew(ctx, &library.RenewRequest{LoanId: "unknown"})
+		checkCode(t, err, codes.NotFound)
+	})
+
+	t.Run("ListLoans", func(t *testing.T) {
+		ctx, cancel := client.userContext(t, "alice")
+		defer cancel()
+		resp, err := client.ListLoans(ctx, &library.ListLoansRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := loanIDs(resp.Loans); len(got) != 3 || got[0] != "1" || got[1] != "2" || got[2] != "3" {
+			t.Errorf("got loans %v, want [1 2 3]", got)
+		}
+
+		ctx, cancel = client.userContext(t, "dave")
+		defer cancel()
+		resp, err = client.ListLoans(ctx, &library.ListLoansRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Loans) != 0 {
+			t.Errorf("got loans %v, want none", loanIDs(resp.Loans))
+		}
+		resp, err = client.ListLoans(ctx, &library.ListLoansRequest{IncludeReturned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Loans) != 1 || resp.Loans[0].Returned == nil {
+			t.Errorf("got loans %v, want a returned loan", resp.Loans)
+		}
+
+		ctx, cancel = client.userContext(t, "")
+		defer cancel()
+		_, err = client.ListLoans(ctx, &library.ListLoansRequest{})
+		checkCode(t, err, codes.Unauthenticated)
+
+		// Users are only known by their sessions
+		for _, kv := range [][]string{
+			{"user", "carol"},
+			{sessionKey, "no such session"},
+		} {
+			ctx, cancel := client.userContext(t, "", kv...)
+			defer cancel()
+			_, err = client.ListLoans(ctx, &library.ListLoansRequest{})
+			checkCode(t, err, codes.Unauthenticated)
+		}
+	})
+}
+
+func TestCheckOutConcurrently(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	const borrowers = 10
+	var wg sync.WaitGroup
+	loans := make(chan *library.Loan, borrowers)
+	for i := 0; i < borrowers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := client.userContext(t, "alice")
+			defer cancel()
+			l, err := client.CheckOut(ctx, &library.CheckOutRequest{Isbn: books[0].Isbn})
+			if err == nil {
+				loans <- l
+			}
+		}()
+	}
+	wg.Wait()
+	close(loans)
+
+	copies := map[string]bool{}
+	for l := range loans {
+		if copies[l.CopyId] {
+			t.Errorf("copy %s was lent twice", l.CopyId)
+		}
+		copies[l.CopyId] = true
+	}
+	if len(copies) != defaultCopies {
+		t.Errorf("got %d copies lent, want %d", len(copies), defaultCopies)
+	}
+}
+
+func TestStoreRollback(t *testing.T) {
+	var s store
+	failed := errors.New("failed")
+	err := s.Update(func(tx *tx) error {
+		if err := tx.Insert("a", &library.Copy{Id: tx.NextID("copy")}); err != nil {
+			return err
+		}
+		return failed
+	})
+	if err != failed {
+		t.Fatalf("got %v, want %v", err, failed)
+	}
+	err = s.Update(func(tx *tx) error {
+		if tx.Has("a") {
+			t.Error("the changes of a failed transaction were made")
+		}
+		if id := tx.NextID("copy"); id != "1" {
+			t.Errorf("got Id %s, want 1", id)
+		}
+		if err := tx.Insert("a", &library.Copy{}); err != nil {
+			return err
+		}
+		return tx.Insert("a", &library.Copy{})
+	})
+	if err != errExists {
+		t.Errorf("got %v, want %v", err, errExists)
+	}
+	err = s.View(func(tx *tx) error {
+		return tx.Put("a", &library.Copy{})
+	})
+	if err != errReadOnly {
+		t.Errorf("got %v, want %v", err, errReadOnly)
+	}
+}
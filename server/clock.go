@@ -0,0 +1,18 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import "time"
+
+// Clock tells the time, and waits for it to pass.
+type Clock interface {
+	Now() time.Time
+	// After sends the time on the channel returned after d.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,306 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// DefaultMaxCollectionSize is the largest number of books
+// in a collection, unless configured otherwise.
+const DefaultMaxCollectionSize = 100
+
+// Request metadata keys read by the collection methods. Collections
+// are owned by the user logged in with the session in the request
+// metadata, if any.
+const (
+	collectionNameKey       = "collection-name"
+	collectionVisibilityKey = "collection-visibility"
+)
+
+// collections holds the collections made by users.
+// The zero value holds no collections.
+type collections struct {
+	mu   sync.RWMutex
+	byID map[string]*library.Collection
+}
+
+// newCollectionID returns a random Id, which
+// cannot be guessed to find unlisted collections.
+func newCollectionID() string {
+	b := make([]byte, 9)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Add stores c with a new Id, and returns a copy of it.
+func (cs *collections) Add(c *library.Collection) *library.Collection {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.byID == nil {
+		cs.byID = map[string]*library.Collection{}
+	}
+	c.Id = newCollectionID()
+	for cs.byID[c.Id] != nil {
+		c.Id = newCollectionID()
+	}
+	cs.byID[c.Id] = c
+	return proto.Clone(c).(*library.Collection)
+}
+
+// Get returns a copy of the collection with the Id id,
+// or a NotFound error if user cannot see it.
+func (cs *collections) Get(id, user string) (*library.Collection, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	c, err := cs.get(id, user)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(c).(*library.Collection), nil
+}
+
+// get returns the collection with the Id id, or a
+// NotFound error if user cannot see it. cs.mu must be held.
+func (cs *collections) get(id, user string) (*library.Collection, error) {
+	c, ok := cs.byID[id]
+	if !ok || (c.Visibility == library.Visibility_PRIVATE && c.Owner != user) {
+		return nil, status.Error(codes.NotFound, "Collection could not be found")
+	}
+	return c, nil
+}
+
+// List returns copies of the collections of owner, or of all users
+// if owner is empty, that are listed to user, ordered by name.
+func (cs *collections) List(owner, user string) []*library.Collection {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	var found []*library.Collection
+	for _, c := range cs.byID {
+		if owner != "" && c.Owner != owner {
+			continue
+		}
+		if c.Visibility == library.Visibility_PUBLIC || (user != "" && c.Owner == user) {
+			found = append(found, proto.Clone(c).(*library.Collection))
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Name != found[j].Name {
+			return found[i].Name < found[j].Name
+		}
+		return found[i].Id < found[j].Id
+	})
+	return found
+}
+
+// All returns copies of all collections, whoever can see them,
+// for computing the statistics of the library. They must not
+// be shown to users.
+func (cs *collections) All() []*library.Collection {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	var found []*library.Collection
+	for _, c := range cs.byID {
+		found = append(found, proto.Clone(c).(*library.Collection))
+	}
+	return found
+}
+
+// Update calls fn with a copy of the collection with the Id id, if owned
+// by user, and returns it after fn has changed it. The collection is
+// deleted if fn returns true, and left unchanged if fn returns an error.
+func (cs *collections) Update(id, user string, fn func(*library.Collection) (del bool, err error)) (*library.Collection, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	c, err := cs.get(id, user)
+	if err != nil {
+		return nil, err
+	}
+	if c.Owner == "" || c.Owner != user {
+		return nil, status.Error(codes.PermissionDenied, "Collection is owned by another user")
+	}
+	c = proto.Clone(c).(*library.Collection)
+	del, err := fn(c)
+	if err != nil {
+		return nil, err
+	}
+	if del {
+		delete(cs.byID, id)
+	} else {
+		cs.byID[id] = c
+	}
+	return proto.Clone(c).(*library.Collection), nil
+}
+
+func (s *BookService) MakeCollection(srv library.BookService_MakeCollectionServer) error {
+	ctx := srv.Context()
+	owner, err := s.caller(ctx)
+	if err != nil {
+		return err
+	}
+	collection := &library.Collection{
+		Name:  metadataValue(ctx, collectionNameKey),
+		Owner: owner,
+	}
+	if v := metadataValue(ctx, collectionVisibilityKey); v != "" {
+		vis, ok := library.Visibility_value[strings.ToUpper(v)]
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "unknown visibility %q", v)
+		}
+		collection.Visibility = library.Visibility(vis)
+		if collection.Owner == "" && collection.Visibility == library.Visibility_PRIVATE {
+			return status.Error(codes.InvalidArgument, "private collections must be made by a user")
+		}
+	} else if collection.Owner == "" {
+		collection.Visibility = library.Visibility_UNLISTED
+	}
+
+	var errs []*library.CollectionError
+	for index := int32(0); ; index++ {
+		req, err := srv.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		isbn := req.GetIsbn()
+		bk, ok := s.c.Get(isbn)
+		switch {
+		case !ok:
+			errs = append(errs, &library.CollectionError{
+				Index:   index,
+				Isbn:    isbn,
+				Reason:  library.CollectionError_NOT_FOUND,
+				Message: "Book could not be found",
+			})
+		case inCollection(collection, isbn):
+			errs = append(errs, &library.CollectionError{
+				Index:   index,
+				Isbn:    isbn,
+				Reason:  library.CollectionError_DUPLICATE,
+				Message: "Book was already added",
+			})
+		case len(collection.Books) == s.maxCollectionSize():
+			return s.collectionFull()
+		default:
+			collection.Books = append(collection.Books, bk)
+		}
+	}
+
+	resp := s.cs.Add(collection)
+	resp.Errors = errs
+	return srv.SendAndClose(resp)
+}
+
+func (s *BookService) maxCollectionSize() int {
+	if s.MaxCollectionSize > 0 {
+		return s.MaxCollectionSize
+	}
+	return DefaultMaxCollectionSize
+}
+
+func (s *BookService) collectionFull() error {
+	return status.Errorf(codes.ResourceExhausted, "collections can have at most %d books", s.maxCollectionSize())
+}
+
+func (s *BookService) GetCollection(ctx context.Context, req *library.GetCollectionRequest) (*library.Collection, error) {
+	user, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.cs.Get(req.Id, user)
+}
+
+func (s *BookService) ListCollections(ctx context.Context, req *library.ListCollectionsRequest) (*library.ListCollectionsResponse, error) {
+	user, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &library.ListCollectionsResponse{
+		Collections: s.cs.List(req.Owner, user),
+	}, nil
+}
+
+func (s *BookService) AddToCollection(ctx context.Context, req *library.AddToCollectionRequest) (*library.Collection, error) {
+	user, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var bks []*library.Book
+	for _, isbn := range req.Isbns {
+		bk, ok := s.c.Get(isbn)
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "Book %d could not be found", isbn)
+		}
+		bks = append(bks, bk)
+	}
+	return s.cs.Update(req.Id, user, func(c *library.Collection) (bool, error) {
+		for _, bk := range bks {
+			if !inCollection(c, bk.Isbn) {
+				c.Books = append(c.Books, bk)
+			}
+		}
+		if len(c.Books) > s.maxCollectionSize() {
+			return false, s.collectionFull()
+		}
+		return false, nil
+	})
+}
+
+func (s *BookService) RemoveFromCollection(ctx context.Context, req *library.RemoveFromCollectionRequest) (*library.Collection, error) {
+	user, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remove := map[int64]bool{}
+	for _, isbn := range req.Isbns {
+		remove[isbn] = true
+	}
+	return s.cs.Update(req.Id, user, func(c *library.Collection) (bool, error) {
+		var kept []*library.Book
+		for _, bk := range c.Books {
+			if !remove[bk.GetIsbn()] {
+				kept = append(kept, bk)
+			}
+		}
+		c.Books = kept
+		return false, nil
+	})
+}
+
+func (s *BookService) DeleteCollection(ctx context.Context, req *library.DeleteCollectionRequest) (*library.Collection, error) {
+	user, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.cs.Update(req.Id, user, func(*library.Collection) (bool, error) {
+		return true, nil
+	})
+}
+
+// inCollection reports whether the book with the ISBN isbn is in c.
+func inCollection(c *library.Collection, isbn int64) bool {
+	for _, bk := range c.Books {
+		if bk.GetIsbn() == isbn {
+			return true
+		}
+	}
+	return false
+}
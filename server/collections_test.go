@@ -0,0 +1,266 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func makeCollection(t *testing.T, client *testClient, user, name, visibility string, isbns ...int64) *library.Collection {
+	t.Helper()
+	ctx, cancel := client.userContext(t, user, collectionNameKey, name, collectionVisibilityKey, visibility)
+	defer cancel()
+	stream, err := client.MakeCollection(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, isbn := range isbns {
+		err = stream.Send(&library.Book{Isbn: isbn})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func collectionNames(cs []*library.Collection) []string {
+	var names []string
+	for _, c := range cs {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func collectionIsbns(c *library.Collection) []int64 {
+	var isbns []int64
+	for _, bk := range c.Books {
+		isbns = append(isbns, bk.Isbn)
+	}
+	return isbns
+}
+
+func TestCollections(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	private := makeCollection(t, client, "alice", "Favourites", "", books[0].Isbn)
+	unlisted := makeCollection(t, client, "alice", "Dystopias", "unlisted", books[0].Isbn, books[1].Isbn)
+	public := makeCollection(t, client, "bob", "Orwell", "PUBLIC", books[1].Isbn)
+	if private.Owner != "alice" || private.Name != "Favourites" || private.Visibility != library.Visibility_PRIVATE {
+		t.Errorf("got collection %v, want a private collection of alice", private)
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		for _, test := range []struct {
+			user string
+			c    *library.Collection
+			code codes.Code
+		}{
+			{"alice", private, codes.OK},
+			{"bob", private, codes.NotFound},
+			{"", private, codes.NotFound},
+			{"bob", unlisted, codes.OK},
+			{"", public, codes.OK},
+		} {
+			ctx, cancel := client.userContext(t, test.user)
+			c, err := client.GetCollection(ctx, &library.GetCollectionRequest{Id: test.c.Id})
+			cancel()
+			checkCode(t, err, test.code)
+			if err == nil && c.Name != test.c.Name {
+				t.Errorf("got collection %v, want %v", c, test.c)
+			}
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for _, test := range []struct {
+			user, owner string
+			want        []string
+		}{
+			{"alice", "", []string{"Dystopias", "Favourites", "Orwell"}},
+			{"alice", "bob", []string{"Orwell"}},
+			{"bob", "", []string{"Orwell"}},
+			{"", "", []string{"Orwell"}},
+		} {
+			ctx, cancel := client.userContext(t, test.user)
+			resp, err := client.ListCollections(ctx, &library.ListCollectionsRequest{Owner: test.owner})
+			cancel()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := collectionNames(resp.Collections)
+			if len(got) != len(test.want) {
+				t.Errorf("%s listing %q: got %q, want %q", test.user, test.owner, got, test.want)
+				continue
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("%s listing %q: got %q, want %q", test.user, test.owner, got, test.want)
+					break
+				}
+			}
+		}
+	})
+
+	t.Run("AddAndRemove", func(t *testing.T) {
+		ctx, cancel := client.userContext(t, "alice")
+		defer cancel()
+		c, err := client.AddToCollection(ctx, &library.AddToCollectionRequest{
+			Id:    private.Id,
+			Isbns: []int64{books[0].Isbn, books[2].Isbn},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []int64{books[0].Isbn, books[2].Isbn}
+		if got := collectionIsbns(c); !equalIsbns(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if c.Books[1].Title != books[2].Title {
+			t.Errorf("got book %v, want the book in the library", c.Books[1])
+		}
+
+		c, err = client.RemoveFromCollection(ctx, &library.RemoveFromCollectionRequest{
+			Id:    private.Id,
+			Isbns: []int64{books[0].Isbn},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := collectionIsbns(c); !equalIsbns(got, want[1:]) {
+			t.Errorf("got %v, want %v", got, want[1:])
+		}
+
+		_, err = client.AddToCollection(ctx, &library.AddToCollectionRequest{Id: private.Id, Isbns: []int64{1}})
+		checkCode(t, err, codes.NotFound)
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		ctx, cancel := client.userContext(t, "bob")
+		defer cancel()
+		_, err := client.AddToCollection(ctx, &library.AddToCollectionRequest{Id: unlisted.Id, Isbns: []int64{books[2].Isbn}})
+		checkCode(t, err, codes.PermissionDenied)
+		_, err = client.DeleteCollection(ctx, &library.DeleteCollectionRequest{Id: private.Id})
+		checkCode(t, err, codes.NotFound)
+	})
+
+	t.Run("Session", func(t *testing.T) {
+		// Naming the owner without their session is not enough
+		ctx, cancel := client.userContext(t, "", "user", "alice")
+		defer cancel()
+		_, err := client.GetCollection(ctx, &library.GetCollectionRequest{Id: private.Id})
+		checkCode(t, err, codes.NotFound)
+
+		ctx, cancel = client.userContext(t, "", sessionKey, "no such session")
+		defer cancel()
+		_, err = client.GetCollection(ctx, &library.GetCollectionRequest{Id: public.Id})
+		checkCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx, cancel := client.userContext(t, "bob")
+		defer cancel()
+		_, err := client.DeleteCollection(ctx, &library.DeleteCollectionRequest{Id: public.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.GetCollection(ctx, &library.GetCollectionRequest{Id: public.Id})
+		checkCode(t, err, codes.NotFound)
+	})
+
+	t.Run("InvalidVisibility", func(t *testing.T) {
+		for _, test := range []struct{ user, visibility string }{
+			{"alice", "secret"},
+			{"", "private"},
+		} {
+			ctx, cancel := client.userContext(t, test.user, collectionVisibilityKey, test.visibility)
+			stream, err := client.MakeCollection(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = stream.CloseAndRecv()
+			cancel()
+			checkCode(t, err, codes.InvalidArgument)
+		}
+	})
+}
+
+func TestMakeCollectionErrors(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	// Only the ISBNs of the books sent are used
+	c := makeCollection(t, client, "alice", "Mixed", "", books[3].Isbn, 1, books[0].Isbn, books[3].Isbn)
+	want := []int64{books[3].Isbn, books[0].Isbn}
+	if got := collectionIsbns(c); !equalIsbns(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if c.Books[0].Title != books[3].Title || c.Books[1].Title != books[0].Title {
+		t.Errorf("got books %v, want the books in the library", c.Books)
+	}
+	wantErrs := []*library.CollectionError{
+		{Index: 1, Isbn: 1, Reason: library.CollectionError_NOT_FOUND},
+		{Index: 3, Isbn: books[3].Isbn, Reason: library.CollectionError_DUPLICATE},
+	}
+	if len(c.Errors) != len(wantErrs) {
+		t.Fatalf("got errors %v, want %v", c.Errors, wantErrs)
+	}
+	for i, e := range c.Errors {
+		w := wantErrs[i]
+		if e.Index != w.Index || e.Isbn != w.Isbn || e.Reason != w.Reason || e.Message == "" {
+			t.Errorf("got error %v, want %v", e, w)
+		}
+	}
+
+	// Errors are not stored
+	ctx, cancel := client.userContext(t, "alice")
+	defer cancel()
+	got, err := client.GetCollection(ctx, &library.GetCollectionRequest{Id: c.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Errors) != 0 {
+		t.Errorf("got stored errors %v", got.Errors)
+	}
+}
+
+func TestMaxCollectionSize(t *testing.T) {
+	client, cleanup := newServiceClient(t, &BookService{MaxCollectionSize: 2})
+	defer cleanup()
+
+	ctx, cancel := client.userContext(t, "alice")
+	defer cancel()
+	stream, err := client.MakeCollection(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Duplicates and unknown books do not count
+	for _, isbn := range []int64{books[0].Isbn, books[0].Isbn, 1, books[1].Isbn, books[2].Isbn} {
+		err = stream.Send(&library.Book{Isbn: isbn})
+		if err != nil {
+			break
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	checkCode(t, err, codes.ResourceExhausted)
+
+	c := makeCollection(t, client, "alice", "Full", "", books[0].Isbn, books[1].Isbn)
+	_, err = client.AddToCollection(ctx, &library.AddToCollectionRequest{Id: c.Id, Isbns: []int64{books[1].Isbn, books[2].Isbn}})
+	checkCode(t, err, codes.ResourceExhausted)
+	got, err := client.GetCollection(ctx, &library.GetCollectionRequest{Id: c.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Books) != 2 {
+		t.Errorf("got %d books after failing to add, want 2", len(got.Books))
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// resumeTokenPrefix versions the format of resume tokens.
+const resumeTokenPrefix = "v1:"
+
+func (s *BookService) ExportBooks(req *library.ExportBooksRequest, srv library.BookService_ExportBooksServer) error {
+	after, err := decodeResumeToken(req.GetResumeToken())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid resume token: %v", err)
+	}
+
+	// Books are exported in ISBN order, so that books added
+	// while the export is interrupted are still exported if
+	// they come after the book the export is resumed from.
+	for _, bk := range s.c.After(after) {
+		err := srv.Send(&library.ExportBooksResponse{
+			Book:        bk,
+			ResumeToken: encodeResumeToken(bk.Isbn),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeResumeToken returns the resume token
+// continuing an export after the ISBN isbn.
+func encodeResumeToken(isbn int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(resumeTokenPrefix + strconv.FormatInt(isbn, 10)))
+}
+
+// decodeResumeToken returns the ISBN after which the export continued
+// by token should start. An empty token starts from the first book.
+func decodeResumeToken(token string) (int64, error) {
+	if token == "" {
+		return math.MinInt64, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(b), resumeTokenPrefix) {
+		return 0, errors.New("malformed token")
+	}
+	isbn, err := strconv.ParseInt(strings.TrimPrefix(string(b), resumeTokenPrefix), 10, 64)
+	if err != nil {
+		return 0, errors.New("malformed token")
+	}
+	return isbn, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func exportBooks(t *testing.T, client *testClient, token string) ([]*library.ExportBooksResponse, error) {
+	t.Helper()
+	ctx, cancel := testContext()
+	defer cancel()
+	stream, err := client.ExportBooks(ctx, &library.ExportBooksRequest{ResumeToken: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resps []*library.ExportBooksResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return resps, nil
+		}
+		if err != nil {
+			return resps, err
+		}
+		resps = append(resps, resp)
+	}
+}
+
+func isbns(resps []*library.ExportBooksResponse) []int64 {
+	var isbns []int64
+	for _, resp := range resps {
+		isbns = append(isbns, resp.Book.Isbn)
+	}
+	return isbns
+}
+
+func equalIsbns(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestExportBooks(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	all, err := exportBooks(t, client, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int64{60929871, 140008381, 140009728, 1501107739, 9780140301694}
+	if got := isbns(all); !equalIsbns(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	t.Run("Resume", func(t *testing.T) {
+		resps, err := exportBooks(t, client, all[1].ResumeToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := isbns(resps); !equalIsbns(got, want[2:]) {
+			t.Errorf("got %v, want %v", got, want[2:])
+		}
+	})
+
+	t.Run("ResumeAfterChanges", func(t *testing.T) {
+		// Books added after the interruption are
+		// exported if they come after the last book.
+		importBooks(t, client, nil,
+			&library.Book{Isbn: 140008380, Title: "Before", Author: "Anonymous"},
+			&library.Book{Isbn: 140008382, Title: "After", Author: "Anonymous"},
+		)
+		resps, err := exportBooks(t, client, all[1].ResumeToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []int64{140008382, 140009728, 1501107739, 9780140301694}
+		if got := isbns(resps); !equalIsbns(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("ResumeAtEnd", func(t *testing.T) {
+		resps, err := exportBooks(t, client, all[len(all)-1].ResumeToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resps) != 0 {
+			t.Errorf("got %v, want no books", isbns(resps))
+		}
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		for _, token := range []string{"!!", "aGVsbG8", "djE6aGVsbG8"} {
+			_, err := exportBooks(t, client, token)
+			checkCode(t, err, codes.InvalidArgument)
+		}
+	})
+}
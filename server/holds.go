@@ -0,0 +1,347 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// pickupWindow is how long a copy is kept for a ready hold.
+const pickupWindow = 3 * 24 * time.Hour
+
+// holdWatch receives the changes to the holds of a user.
+type holdWatch struct {
+	user string
+	// C receives the holds changed. It is
+	// closed if the watch falls behind.
+	C chan *library.Hold
+}
+
+func holdKey(id string) string { return "hold/" + id }
+
+// active reports whether h has not ended.
+func active(h *library.Hold) bool {
+	return h.State == library.Hold_WAITING || h.State == library.Hold_READY
+}
+
+// holds returns the holds matched by fn, in the order they were placed.
+func holds(t *tx, fn func(*library.Hold) bool) []*library.Hold {
+	var found []*library.Hold
+	for _, k := range t.Keys(holdKey("")) {
+		h := &library.Hold{}
+		t.Get(k, h)
+		if fn(h) {
+			found = append(found, h)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return idLess(found[i].Id, found[j].Id)
+	})
+	return found
+}
+
+// waitingHolds returns the queue for the book with the ISBN isbn.
+func waitingHolds(t *tx, isbn int64) []*library.Hold {
+	return holds(t, func(h *library.Hold) bool {
+		return h.Isbn == isbn && h.State == library.Hold_WAITING
+	})
+}
+
+// readyHold returns the hold the copy with
+// the Id copyID is kept for, or nil if none.
+func readyHold(t *tx, copyID string) *library.Hold {
+	found := holds(t, func(h *library.Hold) bool {
+		return h.CopyId == copyID && h.State == library.Hold_READY
+	})
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0]
+}
+
+// withPosition sets the position of h in the queue, if waiting.
+func withPosition(t *tx, h *library.Hold) *library.Hold {
+	h.Position = 0
+	if h.State != library.Hold_WAITING {
+		return h
+	}
+	for i, w := range waitingHolds(t, h.Isbn) {
+		if w.Id == h.Id {
+			h.Position = int32(i + 1)
+		}
+	}
+	return h
+}
+
+// availableCopy returns the copy of the book with the ISBN isbn which
+// user can check out, preferring the copy kept for a hold of user. If
+// branchID is set, only copies at the branch with that Id are returned.
+func availableCopy(t *tx, isbn int64, user, branchID string) *library.Copy {
+	var available *library.Copy
+	for _, cp := range copies(t, isbn) {
+		if branchID != "" && cp.BranchId != branchID {
+			continue
+		}
+		if cp.OnHold {
+			if h := readyHold(t, cp.Id); h != nil && h.User == user {
+				return cp
+			}
+		}
+		if !cp.OnLoan && !cp.OnHold && available == nil {
+			available = cp
+		}
+	}
+	return available
+}
+
+// allocate keeps cp, which is not on loan, for the first hold in
+// the queue for its book, or makes it available if there is none.
+func allocate(t *tx, cp *library.Copy, now time.Time) error {
+	cp.OnHold = false
+	if queue := waitingHolds(t, cp.Isbn); len(queue) > 0 {
+		h := queue[0]
+		readyUntil, err := ptypes.TimestampProto(now.Add(pickupWindow))
+		if err != nil {
+			return err
+		}
+		h.State = library.Hold_READY
+		h.CopyId = cp.Id
+		h.ReadyUntil = readyUntil
+		if err := t.Put(holdKey(h.Id), h); err != nil {
+			return err
+		}
+		cp.OnHold = true
+	}
+	return t.Put(copyKey(cp.Id), cp)
+}
+
+// release ends the ready hold h with the state provided,
+// and passes its copy on to the next hold, if any.
+func release(t *tx, h *library.Hold, state library.Hold_State, now time.Time) error {
+	h.State = state
+	if err := t.Put(holdKey(h.Id), h); err != nil {
+		return err
+	}
+	cp := &library.Copy{}
+	if !t.Get(copyKey(h.CopyId), cp) {
+		return nil
+	}
+	return allocate(t, cp, now)
+}
+
+// expireReadyHolds expires the ready holds which were not picked
+// up before now, and returns the number of holds expired.
+func expireReadyHolds(t *tx, now time.Time) (int, error) {
+	expired := holds(t, func(h *library.Hold) bool {
+		if h.State != library.Hold_READY {
+			return false
+		}
+		readyUntil, err := ptypes.Timestamp(h.ReadyUntil)
+		return err == nil && !now.Before(readyUntil)
+	})
+	for _, h := range expired {
+		if err := release(t, h, library.Hold_EXPIRED, now); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+// publish sends the holds changed by t to their watches.
+func (c *circulation) publish(t *tx) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.watches) == 0 {
+		return
+	}
+	for _, k := range t.Changed(holdKey("")) {
+		h := &library.Hold{}
+		t.Get(k, h)
+		withPosition(t, h)
+		for w := range c.watches {
+			if w.user != h.User {
+				continue
+			}
+			select {
+			case w.C <- h:
+			default:
+				// Never block on a watch that has fallen behind,
+				// it can start over with the holds of the user.
+				close(w.C)
+				delete(c.watches, w)
+			}
+		}
+	}
+}
+
+// watch starts sending the holds changed to w.
+func (c *circulation) watch(w *holdWatch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watches == nil {
+		c.watches = map[*holdWatch]bool{}
+	}
+	c.watches[w] = true
+}
+
+// unwatch stops the watch w.
+func (c *circulation) unwatch(w *holdWatch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watches[w] {
+		delete(c.watches, w)
+		close(w.C)
+	}
+}
+
+func (s *BookService) PlaceHold(ctx context.Context, req *library.PlaceHoldRequest) (*library.Hold, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.c.Get(req.Isbn); !ok {
+		return nil, status.Errorf(codes.NotFound, "Book could not be found")
+	}
+	hold := &library.Hold{}
+	err = s.circulate(func(t *tx) error {
+		held := holds(t, func(h *library.Hold) bool {
+			return h.Isbn == req.Isbn && h.User == user && active(h)
+		})
+		if len(held) > 0 {
+			return status.Errorf(codes.AlreadyExists, "book %d is already held by hold %s", req.Isbn, held[0].Id)
+		}
+		now := s.now()
+		placed, err := ptypes.TimestampProto(now)
+		if err != nil {
+			return err
+		}
+		id := t.NextID("hold")
+		err = t.Insert(holdKey(id), &library.Hold{
+			Id:     id,
+			Isbn:   req.Isbn,
+			User:   user,
+			State:  library.Hold_WAITING,
+			Placed: placed,
+		})
+		if err != nil {
+			return err
+		}
+		// A copy may be available already, if the book is not lent out.
+		if cp := availableCopy(t, req.Isbn, "", ""); cp != nil {
+			if err := allocate(t, cp, now); err != nil {
+				return err
+			}
+		}
+		t.Get(holdKey(id), hold)
+		withPosition(t, hold)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+func (s *BookService) CancelHold(ctx context.Context, req *library.CancelHoldRequest) (*library.Hold, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hold := &library.Hold{}
+	err = s.circulate(func(t *tx) error {
+		if !t.Get(holdKey(req.HoldId), hold) {
+			return status.Errorf(codes.NotFound, "Hold could not be found")
+		}
+		switch {
+		case hold.User != user:
+			return status.Error(codes.PermissionDenied, "Hold is of another user")
+		case !active(hold):
+			return status.Errorf(codes.FailedPrecondition, "hold %s has ended", hold.Id)
+		case hold.State == library.Hold_READY:
+			return release(t, hold, library.Hold_CANCELLED, s.now())
+		}
+		hold.State = library.Hold_CANCELLED
+		return t.Put(holdKey(hold.Id), hold)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+func (s *BookService) ListHolds(ctx context.Context, req *library.ListHoldsRequest) (*library.ListHoldsResponse, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &library.ListHoldsResponse{}
+	err = s.circulate(func(t *tx) error {
+		resp.Holds = holds(t, func(h *library.Hold) bool {
+			return h.User == user && (req.IncludeEnded || active(h))
+		})
+		for _, h := range resp.Holds {
+			withPosition(t, h)
+		}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *BookService) WatchMyHolds(req *library.WatchMyHoldsRequest, srv library.BookService_WatchMyHoldsServer) error {
+	user, err := s.sessionUser(srv.Context())
+	if err != nil {
+		return err
+	}
+	w := &holdWatch{user: user, C: make(chan *library.Hold, watchBuffer)}
+	// Expire the holds first, so they are not
+	// sent as existing holds and then expired.
+	if err := s.circulate(func(*tx) error { return nil }); err != nil {
+		return err
+	}
+	var existing []*library.Hold
+	err = s.circ.store().View(func(t *tx) error {
+		existing = holds(t, func(h *library.Hold) bool {
+			return h.User == user && active(h)
+		})
+		for _, h := range existing {
+			withPosition(t, h)
+		}
+		// Changes are published while the store is locked for
+		// writing, so watching here means no change is missed
+		// or sent twice.
+		s.circ.watch(w)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	defer s.circ.unwatch(w)
+
+	for _, h := range existing {
+		if err := srv.Send(h); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case h, ok := <-w.C:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "too many changes to holds were not received in time")
+			}
+			if err := srv.Send(h); err != nil {
+				return err
+			}
+		case <-srv.Context().Done():
+			return srv.Context().Err()
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func placeHold(t *testing.T, client *testClient, user string, isbn int64) *library.Hold {
+	t.Helper()
+	ctx, cancel := client.userContext(t, user)
+	defer cancel()
+	h, err := client.PlaceHold(ctx, &library.PlaceHoldRequest{Isbn: isbn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func returnCopy(t *testing.T, client *testClient, copyID string) {
+	t.Helper()
+	ctx, cancel := client.userContext(t, "", adminTokenKey, testAdminToken)
+	defer cancel()
+	_, err := client.Return(ctx, &library.ReturnRequest{CopyId: copyID})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func listHolds(t *testing.T, client *testClient, user string) []*library.Hold {
+	t.Helper()
+	ctx, cancel := client.userContext(t, user)
+	defer cancel()
+	resp, err := client.ListHolds(ctx, &library.ListHoldsRequest{IncludeEnded: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Holds
+}
+
+func checkHold(t *testing.T, h *library.Hold, id string, state library.Hold_State, position int32) {
+	t.Helper()
+	if h.Id != id || h.State != state || h.Position != position {
+		t.Errorf("got hold %v, want hold %s %v at position %d", h, id, state, position)
+	}
+}
+
+func TestHolds(t *testing.T) {
+	clock := newFakeClock(testNow)
+	client, cleanup := newServiceClient(t, &BookService{Clock: clock})
+	defer cleanup()
+
+	isbn := books[2].Isbn
+	first := checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: isbn})
+	second := checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: isbn})
+
+	bob := placeHold(t, client, "bob", isbn)
+	checkHold(t, bob, "1", library.Hold_WAITING, 1)
+	carol := placeHold(t, client, "carol", isbn)
+	checkHold(t, carol, "2", library.Hold_WAITING, 2)
+
+	ctx, cancel := client.userContext(t, "bob")
+	defer cancel()
+	watch, err := client.WatchMyHolds(ctx, &library.WatchMyHoldsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recvHold := func() *library.Hold {
+		t.Helper()
+		h, err := watch.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	checkHold(t, recvHold(), bob.Id, library.Hold_WAITING, 1)
+
+	for _, test := range []struct {
+		name string
+		user string
+		isbn int64
+		code codes.Code
+	}{
+		{"Twice", "bob", isbn, codes.AlreadyExists},
+		{"NoUser", "", isbn, codes.Unauthenticated},
+		{"UnknownBook", "bob", 1, codes.NotFound},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := client.userContext(t, test.user)
+			defer cancel()
+			_, err := client.PlaceHold(ctx, &library.PlaceHoldRequest{Isbn: test.isbn})
+			checkCode(t, err, test.code)
+		})
+	}
+
+	// Loans cannot be renewed while users are waiting
+	alice, cancel := client.userContext(t, "alice")
+	defer cancel()
+	_, err = client.Renew(alice, &library.RenewRequest{LoanId: first.Id})
+	checkCode(t, err, codes.FailedPrecondition)
+
+	// The copy returned is kept for bob, and no one else
+	returnCopy(t, client, first.CopyId)
+	ready := recvHold()
+	checkHold(t, ready, bob.Id, library.Hold_READY, 0)
+	if ready.CopyId != first.CopyId {
+		t.Errorf("got copy %s kept, want %s", ready.CopyId, first.CopyId)
+	}
+	if readyUntil, err := ptypes.Timestamp(ready.ReadyUntil); err != nil || !readyUntil.Equal(testNow.Add(pickupWindow)) {
+		t.Errorf("got hold ready until %v, want %v", readyUntil, testNow.Add(pickupWindow))
+	}
+	dave, cancel := client.userContext(t, "dave")
+	defer cancel()
+	_, err = client.CheckOut(dave, &library.CheckOutRequest{CopyId: first.CopyId})
+	checkCode(t, err, codes.FailedPrecondition)
+	_, err = client.CheckOut(dave, &library.CheckOutRequest{Isbn: isbn})
+	checkCode(t, err, codes.FailedPrecondition)
+
+	l := checkOut(t, client, "bob", &library.CheckOutRequest{Isbn: isbn})
+	if l.CopyId != first.CopyId {
+		t.Errorf("got copy %s, want the copy kept %s", l.CopyId, first.CopyId)
+	}
+	checkHold(t, recvHold(), bob.Id, library.Hold_FULFILLED, 0)
+
+	// carol does not pick up the next copy in time
+	returnCopy(t, client, second.CopyId)
+	checkHold(t, listHolds(t, client, "carol")[0], carol.Id, library.Hold_READY, 0)
+	clock.Advance(pickupWindow)
+	checkHold(t, listHolds(t, client, "carol")[0], carol.Id, library.Hold_EXPIRED, 0)
+	checkOut(t, client, "dave", &library.CheckOutRequest{CopyId: second.CopyId})
+}
+
+func TestCancelHold(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	isbn := books[4].Isbn
+	l := checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: isbn})
+	checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: isbn})
+	bob := placeHold(t, client, "bob", isbn)
+	carol := placeHold(t, client, "carol", isbn)
+	dave := placeHold(t, client, "dave", isbn)
+
+	ctx, cancel := client.userContext(t, "carol")
+	defer cancel()
+	_, err := client.CancelHold(ctx, &library.CancelHoldRequest{HoldId: bob.Id})
+	checkCode(t, err, codes.PermissionDenied)
+	_, err = client.CancelHold(ctx, &library.CancelHoldRequest{HoldId: "unknown"})
+	checkCode(t, err, codes.NotFound)
+	h, err := client.CancelHold(ctx, &library.CancelHoldRequest{HoldId: carol.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHold(t, h, carol.Id, library.Hold_CANCELLED, 0)
+	_, err = client.CancelHold(ctx, &library.CancelHoldRequest{HoldId: carol.Id})
+	checkCode(t, err, codes.FailedPrecondition)
+	checkHold(t, listHolds(t, client, "dave")[0], dave.Id, library.Hold_WAITING, 2)
+
+	// Cancelling a ready hold passes the copy on
+	returnCopy(t, client, l.CopyId)
+	checkHold(t, listHolds(t, client, "bob")[0], bob.Id, library.Hold_READY, 0)
+	ctx, cancel = client.userContext(t, "bob")
+	defer cancel()
+	_, err = client.CancelHold(ctx, &library.CancelHoldRequest{HoldId: bob.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h = listHolds(t, client, "dave")[0]
+	checkHold(t, h, dave.Id, library.Hold_READY, 0)
+	if h.CopyId != l.CopyId {
+		t.Errorf("got copy %s kept, want %s", h.CopyId, l.CopyId)
+	}
+}
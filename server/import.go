@@ -0,0 +1,184 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// DefaultMaxImportSize is the largest number of books
+// sent in an import, if the BookService does not set one.
+const DefaultMaxImportSize = 10000
+
+// maxIsbn is the largest ISBN, with 13 digits.
+const maxIsbn = 9999999999999
+
+// languageCode matches BCP 47 language tags, such as "en" or "en-GB",
+// without checking that the language or subtags exist.
+var languageCode = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{1,8})*$`)
+
+func (s *BookService) ImportBooks(srv library.BookService_ImportBooksServer) error {
+	if err := s.checkAdmin(srv.Context(), "importing books"); err != nil {
+		return err
+	}
+
+	var (
+		opts  *library.ImportOptions
+		valid []*library.Book
+		resp  = &library.ImportBooksResponse{}
+		// seen holds the ISBNs of the books
+		// sent so far, to detect duplicates.
+		seen  = map[int64]bool{}
+		index int32
+	)
+	for {
+		req, err := srv.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch content := req.Content.(type) {
+		case *library.ImportBooksRequest_Options:
+			if opts != nil || index > 0 {
+				return status.Error(codes.FailedPrecondition, "options should be sent as the first message")
+			}
+			opts = content.Options
+		case *library.ImportBooksRequest_Book:
+			if int(index) == s.maxImportSize() {
+				return status.Errorf(codes.ResourceExhausted, "imports can have at most %d books", s.maxImportSize())
+			}
+			bk := content.Book
+			err := validateBook(bk)
+			if err == nil {
+				err = s.c.CheckReferences(bk)
+			}
+			if err == nil && seen[bk.GetIsbn()] {
+				err = errors.New("duplicate ISBN")
+			}
+			if err != nil {
+				resp.Errors = append(resp.Errors, &library.ImportError{
+					Index:   index,
+					Isbn:    bk.GetIsbn(),
+					Message: err.Error(),
+				})
+			} else {
+				seen[bk.Isbn] = true
+				valid = append(valid, bk)
+			}
+			index++
+		default:
+			return status.Error(codes.InvalidArgument, "message should contain options or a book")
+		}
+	}
+
+	if opts.GetDryRun() {
+		// Count what would have been imported
+		for _, bk := range valid {
+			if _, ok := s.c.Get(bk.Isbn); ok {
+				resp.Updated++
+			} else {
+				resp.Created++
+			}
+		}
+		return srv.SendAndClose(resp)
+	}
+
+	created, updated := s.c.Put(valid...)
+	resp.Created, resp.Updated = int32(created), int32(updated)
+	return srv.SendAndClose(resp)
+}
+
+func (s *BookService) maxImportSize() int {
+	if s.MaxImportSize > 0 {
+		return s.MaxImportSize
+	}
+	return DefaultMaxImportSize
+}
+
+// validateBook returns an error describing why bk
+// cannot be added to the library, if it cannot.
+func validateBook(bk *library.Book) error {
+	switch {
+	case bk == nil:
+		return errors.New("missing book")
+	case bk.Isbn <= 0 || bk.Isbn > maxIsbn:
+		return fmt.Errorf("ISBN %d is not a positive number of at most 13 digits", bk.Isbn)
+	case bk.Title == "":
+		return errors.New("missing title")
+	case len(authors(bk)) == 0:
+		return errors.New("missing author")
+	}
+	if _, ok := library.BookType_name[int32(bk.BookType)]; !ok {
+		return fmt.Errorf("unknown book type %d", bk.BookType)
+	}
+	for i, c := range bk.Contributors {
+		if c.GetName() == "" && c.GetAuthorId() == "" {
+			return fmt.Errorf("contributor %d is missing a name", i+1)
+		}
+		if _, ok := library.ContributorRole_name[int32(c.Role)]; !ok {
+			return fmt.Errorf("unknown contributor role %d", c.Role)
+		}
+	}
+	if bk.Series != nil {
+		if bk.Series.Name == "" {
+			return errors.New("missing series name")
+		}
+		if bk.Series.Position < 0 {
+			return fmt.Errorf("series position %d is negative", bk.Series.Position)
+		}
+	}
+	if bk.Language != "" && !languageCode.MatchString(bk.Language) {
+		return fmt.Errorf("invalid language code %q", bk.Language)
+	}
+	switch extent := bk.Extent.(type) {
+	case *library.Book_PageCount:
+		if bk.BookType == library.BookType_AUDIOBOOK {
+			return errors.New("audiobooks have a duration, not a page count")
+		}
+		if extent.PageCount <= 0 {
+			return fmt.Errorf("page count %d is not positive", extent.PageCount)
+		}
+	case *library.Book_DurationSeconds:
+		if bk.BookType != library.BookType_AUDIOBOOK {
+			return errors.New("only audiobooks have a duration")
+		}
+		if extent.DurationSeconds <= 0 {
+			return fmt.Errorf("duration %d is not positive", extent.DurationSeconds)
+		}
+	}
+	for _, subject := range bk.Subjects {
+		if strings.TrimSpace(subject) == "" {
+			return errors.New("empty subject")
+		}
+	}
+	if bk.CoverImageUrl != "" {
+		u, err := url.Parse(bk.CoverImageUrl)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("cover image URL %q is not an HTTP or HTTPS URL", bk.CoverImageUrl)
+		}
+	}
+	if p, ok := bk.PublishingMethod.(*library.Book_Publisher); ok && p.Publisher.GetName() == "" && p.Publisher.GetId() == "" {
+		return errors.New("missing publisher name")
+	}
+	if bk.PublicationDate != nil {
+		if _, err := ptypes.Timestamp(bk.PublicationDate); err != nil {
+			return fmt.Errorf("invalid publication date: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// sendImport imports books with ctx, sending opts first if not nil.
+func sendImport(ctx context.Context, client *testClient, opts *library.ImportOptions, books ...*library.Book) (*library.ImportBooksResponse, error) {
+	stream, err := client.ImportBooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil {
+		err = stream.Send(&library.ImportBooksRequest{
+			Content: &library.ImportBooksRequest_Options{Options: opts},
+		})
+	}
+	for _, bk := range books {
+		if err != nil {
+			break
+		}
+		err = stream.Send(&library.ImportBooksRequest{
+			Content: &library.ImportBooksRequest_Book{Book: bk},
+		})
+	}
+	// Sending fails with io.EOF if the call
+	// has failed, CloseAndRecv returns why.
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return stream.CloseAndRecv()
+}
+
+// importBooks imports books as an administrator.
+func importBooks(t *testing.T, client *testClient, opts *library.ImportOptions, books ...*library.Book) *library.ImportBooksResponse {
+	t.Helper()
+	ctx, cancel := client.userContext(t, "", adminTokenKey, testAdminToken)
+	defer cancel()
+	resp, err := sendImport(ctx, client, opts, books...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestImportBooks(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	newBook := &library.Book{
+		Isbn:     9780141036144,
+		Title:    "Nineteen Eighty-Four",
+		Author:   "George Orwell",
+		BookType: library.BookType_PAPERBACK,
+		PublishingMethod: &library.Book_Publisher{
+			Publisher: &library.Publisher{Name: "Penguin"},
+		},
+	}
+	updated := proto.Clone(books[0]).(*library.Book)
+	updated.Title = "Brave New World (Revised)"
+	invalid := []*library.Book{
+		{Isbn: 0, Title: "No ISBN", Author: "Anonymous"},
+		{Isbn: 12345678901234, Title: "Long ISBN", Author: "Anonymous"},
+		{Isbn: 1, Author: "Anonymous"},
+		{Isbn: 1, Title: "No Author"},
+		{Isbn: 1, Title: "Unknown Type", Author: "Anonymous", BookType: 10},
+		{
+			Isbn: 1, Title: "No Publisher", Author: "Anonymous",
+			PublishingMethod: &library.Book_Publisher{Publisher: &library.Publisher{}},
+		},
+		{
+			Isbn: 1, Title: "Bad Date", Author: "Anonymous",
+			PublicationDate: &timestamp.Timestamp{Nanos: -1},
+		},
+		{
+			Isbn: 1, Title: "Only Editors",
+			Contributors: []*library.Contributor{{Name: "Anonymous", Role: library.ContributorRole_EDITOR}},
+		},
+		{
+			Isbn: 1, Title: "Unnamed Contributor", Author: "Anonymous",
+			Contributors: []*library.Contributor{{Role: library.ContributorRole_TRANSLATOR}},
+		},
+		{Isbn: 1, Title: "Unnamed Series", Author: "Anonymous", Series: &library.Series{Position: 1}},
+		{Isbn: 1, Title: "Bad Language", Author: "Anonymous", Language: "English"},
+		{
+			Isbn: 1, Title: "Long Audiobook", Author: "Anonymous", BookType: library.BookType_AUDIOBOOK,
+			Extent: &library.Book_PageCount{PageCount: 100},
+		},
+		{Isbn: 1, Title: "Short Book", Author: "Anonymous", Extent: &library.Book_PageCount{}},
+		{Isbn: 1, Title: "Bad Cover", Author: "Anonymous", CoverImageUrl: "cover.jpg"},
+		newBook,
+	}
+	wantErrs := []string{
+		"ISBN 0 is not a positive number of at most 13 digits",
+		"ISBN 12345678901234 is not a positive number of at most 13 digits",
+		"missing title",
+		"missing author",
+		"unknown book type 10",
+		"missing publisher name",
+		"invalid publication date: ",
+		"missing author",
+		"contributor 1 is missing a name",
+		"missing series name",
+		`invalid language code "English"`,
+		"audiobooks have a duration, not a page count",
+		"page count 0 is not positive",
+		`cover image URL "cover.jpg" is not an HTTP or HTTPS URL`,
+		"duplicate ISBN",
+	}
+	all := append([]*library.Book{newBook, updated}, invalid...)
+
+	check := func(t *testing.T, resp *library.ImportBooksResponse) {
+		t.Helper()
+		if resp.Created != 1 || resp.Updated != 1 {
+			t.Errorf("got %d created and %d updated, want 1 and 1", resp.Created, resp.Updated)
+		}
+		if len(resp.Errors) != len(wantErrs) {
+			t.Fatalf("got errors %v, want %q", resp.Errors, wantErrs)
+		}
+		for i, e := range resp.Errors {
+			if int(e.Index) != i+2 || e.Isbn != all[i+2].Isbn || !strings.HasPrefix(e.Message, wantErrs[i]) {
+				t.Errorf("got error %v, want index %d and message %q", e, i+2, wantErrs[i])
+			}
+		}
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		check(t, importBooks(t, client, &library.ImportOptions{DryRun: true}, all...))
+		got := queryBooks(t, client, "")
+		if len(got) != len(books) || !proto.Equal(got[0], books[0]) {
+			t.Errorf("dry run changed the books: %v", got)
+		}
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		check(t, importBooks(t, client, nil, all...))
+		got := queryBooks(t, client, "")
+		if len(got) != len(books)+1 {
+			t.Fatalf("got %d books, want %d", len(got), len(books)+1)
+		}
+		// The author and publisher of the
+		// new book are referenced by Id.
+		want := proto.Clone(newBook).(*library.Book)
+		want.Contributors = []*library.Contributor{{Name: "George Orwell", AuthorId: "george-orwell"}}
+		want.GetPublisher().Id = "penguin"
+		if !proto.Equal(got[0], updated) || !proto.Equal(got[len(got)-1], want) {
+			t.Errorf("unexpected books: %v", got)
+		}
+	})
+
+	t.Run("LateOptions", func(t *testing.T) {
+		ctx, cancel := client.userContext(t, "", adminTokenKey, testAdminToken)
+		defer cancel()
+		stream, err := client.ImportBooks(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, req := range []*library.ImportBooksRequest{
+			{Content: &library.ImportBooksRequest_Book{Book: newBook}},
+			{Content: &library.ImportBooksRequest_Options{Options: &library.ImportOptions{}}},
+		} {
+			err = stream.Send(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		_, err = stream.CloseAndRecv()
+		checkCode(t, err, codes.FailedPrecondition)
+	})
+}
+
+func TestImportBooksAdminToken(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+	book := &library.Book{Isbn: 1, Title: "Homage to Catalonia", Author: "George Orwell"}
+	for _, test := range []struct {
+		name  string
+		token string
+		code  codes.Code
+	}{
+		{"NoToken", "", codes.PermissionDenied},
+		{"WrongToken", "secret", codes.PermissionDenied},
+		{"Token", testAdminToken, codes.OK},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := client.userContext(t, "", adminTokenKey, test.token)
+			defer cancel()
+			_, err := sendImport(ctx, client, nil, book)
+			checkCode(t, err, test.code)
+		})
+	}
+}
+
+func TestMaxImportSize(t *testing.T) {
+	client, cleanup := newServiceClient(t, &BookService{MaxImportSize: 2})
+	defer cleanup()
+	ctx, cancel := client.userContext(t, "", adminTokenKey, testAdminToken)
+	defer cancel()
+	_, err := sendImport(ctx, client, nil,
+		&library.Book{Isbn: 1, Title: "Homage to Catalonia", Author: "George Orwell"},
+		&library.Book{Isbn: 2, Title: "Burmese Days", Author: "George Orwell"},
+		&library.Book{Isbn: 3, Title: "Coming Up for Air", Author: "George Orwell"},
+	)
+	checkCode(t, err, codes.ResourceExhausted)
+
+	// Nothing is imported from an import over the limit
+	_, err = client.GetBook(ctx, &library.GetBookRequest{Isbn: 1})
+	checkCode(t, err, codes.NotFound)
+}
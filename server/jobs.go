@@ -0,0 +1,213 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// ErrJobsRunning is returned by RunJobs if the
+// jobs of the BookService are already running.
+var ErrJobsRunning = errors.New("jobs are already running")
+
+// chatHistoryAge is how long messages are kept in the chat history.
+const chatHistoryAge = 24 * time.Hour
+
+// job is a task run periodically.
+type job struct {
+	name     string
+	interval time.Duration
+	// run runs the job at now, and describes the outcome.
+	run func(s *BookService, now time.Time) (string, error)
+}
+
+// jobs are the background jobs, ordered by name.
+var jobs = []job{
+	{"compute-fines", time.Hour, (*BookService).computeFines},
+	{"compute-recommendations", time.Hour, (*BookService).computeRecommendations},
+	{"expire-holds", 10 * time.Minute, (*BookService).expireHolds},
+	{"purge-chat-history", time.Hour, (*BookService).purgeChatHistory},
+}
+
+// scheduler holds the status of the jobs.
+// The zero value has not run any jobs.
+type scheduler struct {
+	mu      sync.Mutex
+	running bool
+	status  map[string]*library.Job
+}
+
+// start marks the jobs as running, and returns
+// false if they were running already.
+func (sc *scheduler) start() bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.running {
+		return false
+	}
+	sc.running = true
+	return true
+}
+
+func (sc *scheduler) stop() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.running = false
+}
+
+// get returns the status of j. sc.mu must be held.
+func (sc *scheduler) get(j job) *library.Job {
+	if sc.status == nil {
+		sc.status = map[string]*library.Job{}
+	}
+	st, ok := sc.status[j.name]
+	if !ok {
+		st = &library.Job{Name: j.name, IntervalSeconds: int64(j.interval / time.Second)}
+		sc.status[j.name] = st
+	}
+	return st
+}
+
+// schedule records that j runs next at next.
+func (sc *scheduler) schedule(j job, next time.Time) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.get(j).NextRun, _ = ptypes.TimestampProto(next)
+}
+
+// record records the outcome of running j at now.
+func (sc *scheduler) record(j job, now time.Time, result string, err error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	st := sc.get(j)
+	st.Runs++
+	st.LastRun, _ = ptypes.TimestampProto(now)
+	st.LastResult = result
+	st.LastError = ""
+	if err != nil {
+		st.LastError = err.Error()
+	}
+}
+
+// List returns the status of the jobs.
+func (sc *scheduler) List() *library.ListJobsResponse {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	resp := &library.ListJobsResponse{Running: sc.running}
+	for _, j := range jobs {
+		resp.Jobs = append(resp.Jobs, proto.Clone(sc.get(j)).(*library.Job))
+	}
+	return resp
+}
+
+// RunJobs runs the background jobs of s, which compute the fines of
+// overdue loans and the recommendations of books, expire holds and
+// purge the chat history, until ctx is done. The jobs first run one
+// interval after RunJobs is called. Only one call of RunJobs runs
+// the jobs at a time, others return ErrJobsRunning, so jobs are
+// never run twice at once.
+func (s *BookService) RunJobs(ctx context.Context) error {
+	if !s.sched.start() {
+		return ErrJobsRunning
+	}
+	defer s.sched.stop()
+
+	clock := s.clock()
+	next := map[string]time.Time{}
+	for _, j := range jobs {
+		next[j.name] = clock.Now().Add(j.interval)
+		s.sched.schedule(j, next[j.name])
+	}
+	for {
+		first := next[jobs[0].name]
+		for _, j := range jobs[1:] {
+			if next[j.name].Before(first) {
+				first = next[j.name]
+			}
+		}
+		select {
+		case <-clock.After(first.Sub(clock.Now())):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		now := clock.Now()
+		for _, j := range jobs {
+			if now.Before(next[j.name]) {
+				continue
+			}
+			result, err := j.run(s, now)
+			next[j.name] = now.Add(j.interval)
+			s.sched.schedule(j, next[j.name])
+			s.sched.record(j, now, result, err)
+		}
+	}
+}
+
+func (s *BookService) ListJobs(ctx context.Context, req *library.ListJobsRequest) (*library.ListJobsResponse, error) {
+	if err := s.checkAdmin(ctx, "listing the jobs"); err != nil {
+		return nil, err
+	}
+	return s.sched.List(), nil
+}
+
+// computeFines updates the fines of the loans not returned.
+func (s *BookService) computeFines(now time.Time) (string, error) {
+	var overdue int
+	var total int64
+	err := s.circ.store().Update(func(t *tx) error {
+		var err error
+		overdue, total, err = updateFines(t, now)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("overdue loans: %d, fines: %d cents", overdue, total), nil
+}
+
+// computeRecommendations builds the model of
+// similar books which RecommendBooks serves.
+func (s *BookService) computeRecommendations(now time.Time) (string, error) {
+	likes := s.likes()
+	similar := similarBooks(likes)
+	s.rec.Set(similar, now)
+	return fmt.Sprintf("readers: %d, books with recommendations: %d", len(likes), len(similar)), nil
+}
+
+// expireHolds expires the holds which were not picked up in time.
+// Holds are also expired by the circulation methods, but this
+// tells the users watching their holds without waiting for one.
+func (s *BookService) expireHolds(now time.Time) (string, error) {
+	var expired int
+	err := s.circ.store().Update(func(t *tx) error {
+		var err error
+		expired, err = expireReadyHolds(t, now)
+		if err != nil {
+			return err
+		}
+		s.circ.publish(t)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("holds expired: %d", expired), nil
+}
+
+// purgeChatHistory removes the messages older
+// than chatHistoryAge from the chat history.
+func (s *BookService) purgeChatHistory(now time.Time) (string, error) {
+	n := s.b.Purge(now.Add(-chatHistoryAge))
+	return fmt.Sprintf("chat messages purged: %d", n), nil
+}
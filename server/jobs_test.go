@@ -0,0 +1,167 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// waitJobs waits until the status of the jobs satisfies fn.
+func waitJobs(t *testing.T, client *testClient, fn func(*library.ListJobsResponse) bool) map[string]*library.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ctx, cancel := client.userContext(t, "", adminTokenKey, testAdminToken)
+		resp, err := client.ListJobs(ctx, &library.ListJobsRequest{})
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fn(resp) {
+			jobs := map[string]*library.Job{}
+			for _, j := range resp.Jobs {
+				jobs[j.Name] = j
+			}
+			return jobs
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("jobs did not reach the status wanted, got %v", resp)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRunJobs(t *testing.T) {
+	clock := newFakeClock(testNow)
+	srv := &BookService{Clock: clock}
+	client, cleanup := newServiceClient(t, srv)
+	defer cleanup()
+
+	l := checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: books[1].Isbn})
+	ctx, cancel := testContext()
+	defer cancel()
+	srv.b.Broadcast(ctx, clock.Now(), "alice: hello", "")
+
+	jobs := waitJobs(t, client, func(*library.ListJobsResponse) bool { return true })
+	if len(jobs) != 4 || jobs["compute-fines"].Runs != 0 || jobs["compute-fines"].IntervalSeconds != 3600 {
+		t.Errorf("got jobs %v, want 4 jobs which have not run", jobs)
+	}
+
+	runCtx, stop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.RunJobs(runCtx)
+	}()
+	waitJobs(t, client, func(resp *library.ListJobsResponse) bool { return resp.Running })
+	if err := srv.RunJobs(runCtx); err != ErrJobsRunning {
+		t.Errorf("got %v from running the jobs twice, want %v", err, ErrJobsRunning)
+	}
+
+	// The paperback is a day overdue
+	clock.Advance(15 * 24 * time.Hour)
+	jobs = waitJobs(t, client, func(resp *library.ListJobsResponse) bool {
+		for _, j := range resp.Jobs {
+			if j.Runs == 0 {
+				return false
+			}
+		}
+		return true
+	})
+	for name, want := range map[string]string{
+		"compute-fines":           "overdue loans: 1, fines: 25 cents",
+		"compute-recommendations": "readers: 1, books with recommendations: 0",
+		"expire-holds":            "holds expired: 0",
+		"purge-chat-history":      "chat messages purged: 1",
+	} {
+		j := jobs[name]
+		if j.Runs != 1 || j.LastResult != want || j.LastError != "" {
+			t.Errorf("got job %v, want a run with result %q", j, want)
+		}
+		lastRun, _ := ptypes.Timestamp(j.LastRun)
+		nextRun, _ := ptypes.Timestamp(j.NextRun)
+		if !lastRun.Equal(clock.Now()) || nextRun.Sub(lastRun) != time.Duration(j.IntervalSeconds)*time.Second {
+			t.Errorf("got job %s run at %v and next at %v, want run at %v", name, lastRun, nextRun, clock.Now())
+		}
+	}
+
+	alice, cancel := client.userContext(t, "alice")
+	defer cancel()
+	resp, err := client.ListLoans(alice, &library.ListLoansRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Loans[0].FineCents != 25 {
+		t.Errorf("got fine of %d cents, want 25", resp.Loans[0].FineCents)
+	}
+	// Returning the copy later fines another day
+	clock.Advance(24 * time.Hour)
+	returned, err := client.Return(alice, &library.ReturnRequest{CopyId: l.CopyId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if returned.FineCents != 50 {
+		t.Errorf("got fine of %d cents, want 50", returned.FineCents)
+	}
+
+	stop()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+	waitJobs(t, client, func(resp *library.ListJobsResponse) bool { return !resp.Running })
+}
+
+func TestFine(t *testing.T) {
+	due, _ := ptypes.TimestampProto(testNow)
+	l := &library.Loan{Due: due}
+	for _, test := range []struct {
+		at   time.Duration
+		want int64
+	}{
+		{-time.Hour, 0},
+		{0, 0},
+		{time.Minute, fineCentsPerDay},
+		{24 * time.Hour, fineCentsPerDay},
+		{24*time.Hour + time.Minute, 2 * fineCentsPerDay},
+		{365 * 24 * time.Hour, maxFineCents},
+	} {
+		if got := fine(l, testNow.Add(test.at)); got != test.want {
+			t.Errorf("got fine %d %v after the due date, want %d", got, test.at, test.want)
+		}
+	}
+}
+
+func TestListJobsAdminToken(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		adminToken string
+		token      string
+		code       codes.Code
+	}{
+		{"Token", "secret", "secret", codes.OK},
+		{"NoToken", "secret", "", codes.PermissionDenied},
+		{"WrongToken", "secret", "secret2", codes.PermissionDenied},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client, cleanup := newServiceClient(t, &BookService{AdminToken: test.adminToken})
+			defer cleanup()
+			ctx, cancel := client.userContext(t, "", adminTokenKey, test.token)
+			defer cancel()
+			_, err := client.ListJobs(ctx, &library.ListJobsRequest{})
+			checkCode(t, err, test.code)
+		})
+	}
+}
+
+func TestListJobsDisabled(t *testing.T) {
+	// Without an admin token, no one lists the jobs
+	_, err := (&BookService{}).ListJobs(context.Background(), &library.ListJobsRequest{})
+	checkCode(t, err, codes.PermissionDenied)
+}
@@ -12,12 +12,79 @@ It is generated from these files:
 
 It has these top-level messages:
 	Publisher
+	Author
+	Contributor
+	Series
 	Book
+	Branch
+	Availability
+	BranchAvailability
 	GetBookRequest
 	QueryBooksRequest
+	GetAuthorRequest
+	ListAuthorsRequest
+	ListAuthorsResponse
+	GetPublisherRequest
 	Collection
+	CollectionError
+	GetCollectionRequest
+	ListCollectionsRequest
+	ListCollectionsResponse
+	AddToCollectionRequest
+	RemoveFromCollectionRequest
+	DeleteCollectionRequest
+	Copy
+	Loan
+	ListCopiesRequest
+	ListCopiesResponse
+	AddCopyRequest
+	CheckOutRequest
+	ReturnRequest
+	RenewRequest
+	ListLoansRequest
+	ListLoansResponse
+	Hold
+	PlaceHoldRequest
+	CancelHoldRequest
+	ListHoldsRequest
+	ListHoldsResponse
+	WatchMyHoldsRequest
+	Job
+	ListBranchesRequest
+	ListBranchesResponse
+	ListJobsRequest
+	ListJobsResponse
+	Review
+	SubmitReviewRequest
+	UpdateReviewRequest
+	DeleteReviewRequest
+	MarkReviewHelpfulRequest
+	ListReviewsRequest
+	RecommendBooksRequest
+	Recommendation
+	RecommendBooksResponse
+	ReadingEntry
+	UpdateReadingRequest
+	RemoveReadingRequest
+	ListReadingRequest
+	ListReadingResponse
+	ListReviewsResponse
 	BookMessage
 	BookResponse
+	ImportOptions
+	ImportBooksRequest
+	ImportError
+	ImportBooksResponse
+	ExportBooksRequest
+	ExportBooksResponse
+	WatchBooksRequest
+	BookEvent
+	User
+	RegisterRequest
+	LoginRequest
+	LoginResponse
+	GetUserRequest
+	UpdateProfileRequest
 */
 package library
 
@@ -72,10 +139,272 @@ func (x BookType) String() string {
 }
 func (BookType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+// ContributorRole describes what a contributor
+// has contributed to a book.
+type ContributorRole int32
+
+const (
+	// Author is a writer of the book.
+	ContributorRole_AUTHOR ContributorRole = 0
+	// Editor is an editor of the book.
+	ContributorRole_EDITOR ContributorRole = 1
+	// Translator translated the book.
+	ContributorRole_TRANSLATOR ContributorRole = 2
+	// Illustrator illustrated the book.
+	ContributorRole_ILLUSTRATOR ContributorRole = 3
+	// Narrator reads an audiobook.
+	ContributorRole_NARRATOR ContributorRole = 4
+	// Foreword is the writer of the foreword.
+	ContributorRole_FOREWORD ContributorRole = 5
+)
+
+var ContributorRole_name = map[int32]string{
+	0: "AUTHOR",
+	1: "EDITOR",
+	2: "TRANSLATOR",
+	3: "ILLUSTRATOR",
+	4: "NARRATOR",
+	5: "FOREWORD",
+}
+var ContributorRole_value = map[string]int32{
+	"AUTHOR":      0,
+	"EDITOR":      1,
+	"TRANSLATOR":  2,
+	"ILLUSTRATOR": 3,
+	"NARRATOR":    4,
+	"FOREWORD":    5,
+}
+
+func (x ContributorRole) String() string {
+	return proto.EnumName(ContributorRole_name, int32(x))
+}
+func (ContributorRole) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+// Visibility describes who can see a Collection.
+type Visibility int32
+
+const (
+	// Private collections can only be seen by their owner.
+	Visibility_PRIVATE Visibility = 0
+	// Unlisted collections can be seen by anyone with their Id,
+	// but are only listed to their owner.
+	Visibility_UNLISTED Visibility = 1
+	// Public collections can be seen and are listed to anyone.
+	Visibility_PUBLIC Visibility = 2
+)
+
+var Visibility_name = map[int32]string{
+	0: "PRIVATE",
+	1: "UNLISTED",
+	2: "PUBLIC",
+}
+var Visibility_value = map[string]int32{
+	"PRIVATE":  0,
+	"UNLISTED": 1,
+	"PUBLIC":   2,
+}
+
+func (x Visibility) String() string {
+	return proto.EnumName(Visibility_name, int32(x))
+}
+func (Visibility) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+// ReviewOrder is the order in which reviews are listed.
+type ReviewOrder int32
+
+const (
+	// NEWEST lists the reviews created last first.
+	ReviewOrder_NEWEST ReviewOrder = 0
+	// MOST_HELPFUL lists the reviews with the most
+	// helpful votes first, and then the newest first.
+	ReviewOrder_MOST_HELPFUL ReviewOrder = 1
+)
+
+var ReviewOrder_name = map[int32]string{
+	0: "NEWEST",
+	1: "MOST_HELPFUL",
+}
+var ReviewOrder_value = map[string]int32{
+	"NEWEST":       0,
+	"MOST_HELPFUL": 1,
+}
+
+func (x ReviewOrder) String() string {
+	return proto.EnumName(ReviewOrder_name, int32(x))
+}
+func (ReviewOrder) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+// ReadingShelf is the reading list a Book is on.
+type ReadingShelf int32
+
+const (
+	// WantToRead books are books the user
+	// has not started reading yet.
+	ReadingShelf_WANT_TO_READ ReadingShelf = 0
+	// Reading books are being read.
+	ReadingShelf_READING ReadingShelf = 1
+	// Finished books have been read.
+	ReadingShelf_FINISHED ReadingShelf = 2
+)
+
+var ReadingShelf_name = map[int32]string{
+	0: "WANT_TO_READ",
+	1: "READING",
+	2: "FINISHED",
+}
+var ReadingShelf_value = map[string]int32{
+	"WANT_TO_READ": 0,
+	"READING":      1,
+	"FINISHED":     2,
+}
+
+func (x ReadingShelf) String() string {
+	return proto.EnumName(ReadingShelf_name, int32(x))
+}
+func (ReadingShelf) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+// Reason is why a book was not added.
+type CollectionError_Reason int32
+
+const (
+	// ReasonUnspecified is never sent, so that
+	// errors without a reason are told apart.
+	CollectionError_REASON_UNSPECIFIED CollectionError_Reason = 0
+	// NotFound is a book with an ISBN
+	// that is not in the library.
+	CollectionError_NOT_FOUND CollectionError_Reason = 1
+	// Duplicate is a book that was
+	// already sent earlier in the stream.
+	CollectionError_DUPLICATE CollectionError_Reason = 2
+)
+
+var CollectionError_Reason_name = map[int32]string{
+	0: "REASON_UNSPECIFIED",
+	1: "NOT_FOUND",
+	2: "DUPLICATE",
+}
+var CollectionError_Reason_value = map[string]int32{
+	"REASON_UNSPECIFIED": 0,
+	"NOT_FOUND":          1,
+	"DUPLICATE":          2,
+}
+
+func (x CollectionError_Reason) String() string {
+	return proto.EnumName(CollectionError_Reason_name, int32(x))
+}
+func (CollectionError_Reason) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{15, 0} }
+
+// State is the state of a hold.
+type Hold_State int32
+
+const (
+	// WAITING holds are waiting for a copy.
+	Hold_WAITING Hold_State = 0
+	// READY holds have a copy kept for the
+	// user to pick up until ReadyUntil.
+	Hold_READY Hold_State = 1
+	// FULFILLED holds ended with the user
+	// checking out the copy.
+	Hold_FULFILLED Hold_State = 2
+	// CANCELLED holds were cancelled by the user.
+	Hold_CANCELLED Hold_State = 3
+	// EXPIRED holds ended with the copy
+	// not picked up in time.
+	Hold_EXPIRED Hold_State = 4
+)
+
+var Hold_State_name = map[int32]string{
+	0: "WAITING",
+	1: "READY",
+	2: "FULFILLED",
+	3: "CANCELLED",
+	4: "EXPIRED",
+}
+var Hold_State_value = map[string]int32{
+	"WAITING":   0,
+	"READY":     1,
+	"FULFILLED": 2,
+	"CANCELLED": 3,
+	"EXPIRED":   4,
+}
+
+func (x Hold_State) String() string {
+	return proto.EnumName(Hold_State_name, int32(x))
+}
+func (Hold_State) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{32, 0} }
+
+// Reason is why a book is recommended.
+type Recommendation_Reason int32
+
+const (
+	// SIMILAR_READERS is a book which readers of the
+	// book collected, borrowed or rated highly.
+	Recommendation_SIMILAR_READERS Recommendation_Reason = 0
+	// SAME_AUTHOR is a book by an author of the book.
+	Recommendation_SAME_AUTHOR Recommendation_Reason = 1
+	// SAME_PUBLISHER is a book of the publisher of the book.
+	Recommendation_SAME_PUBLISHER Recommendation_Reason = 2
+)
+
+var Recommendation_Reason_name = map[int32]string{
+	0: "SIMILAR_READERS",
+	1: "SAME_AUTHOR",
+	2: "SAME_PUBLISHER",
+}
+var Recommendation_Reason_value = map[string]int32{
+	"SIMILAR_READERS": 0,
+	"SAME_AUTHOR":     1,
+	"SAME_PUBLISHER":  2,
+}
+
+func (x Recommendation_Reason) String() string {
+	return proto.EnumName(Recommendation_Reason_name, int32(x))
+}
+func (Recommendation_Reason) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{50, 0} }
+
+// Type is the type of a BookEvent.
+type BookEvent_Type int32
+
+const (
+	// Existing is a book that was in the library
+	// when the watch started.
+	BookEvent_EXISTING BookEvent_Type = 0
+	// Created is a book that was added to the library,
+	// or that now matches the author prefix watched.
+	BookEvent_CREATED BookEvent_Type = 1
+	// Updated is a book that was replaced.
+	BookEvent_UPDATED BookEvent_Type = 2
+	// Deleted is a book that no longer matches
+	// the author prefix watched.
+	BookEvent_DELETED BookEvent_Type = 3
+)
+
+var BookEvent_Type_name = map[int32]string{
+	0: "EXISTING",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "DELETED",
+}
+var BookEvent_Type_value = map[string]int32{
+	"EXISTING": 0,
+	"CREATED":  1,
+	"UPDATED":  2,
+	"DELETED":  3,
+}
+
+func (x BookEvent_Type) String() string {
+	return proto.EnumName(BookEvent_Type_name, int32(x))
+}
+func (BookEvent_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{67, 0} }
+
 // Publisher describes a Book Publisher.
 type Publisher struct {
 	// Name is the name of the Publisher.
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Id identifies the Publisher. Books referencing a publisher by
+	// Id get the name of the publisher, and books naming a publisher
+	// without an Id get the Id of the publisher with that name.
+	Id string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
 }
 
 func (m *Publisher) Reset()                    { *m = Publisher{} }
@@ -90,13 +419,116 @@ func (m *Publisher) GetName() string {
 	return ""
 }
 
+func (m *Publisher) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// Author describes a person who contributes to books.
+type Author struct {
+	// Id identifies the Author.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Name is the name of the Author.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *Author) Reset()                    { *m = Author{} }
+func (m *Author) String() string            { return proto.CompactTextString(m) }
+func (*Author) ProtoMessage()               {}
+func (*Author) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Author) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Author) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// Contributor is a person who has contributed to a book.
+type Contributor struct {
+	// Name is the name of the contributor.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Role is what the contributor has contributed.
+	Role ContributorRole `protobuf:"varint,2,opt,name=role,enum=library.ContributorRole" json:"role,omitempty"`
+	// AuthorId is the Id of the Author who is the contributor. Contributors
+	// referencing an author by AuthorId get the name of the author, and
+	// contributors without an AuthorId get the Id of the author with
+	// their name, who is added to the library if there is none.
+	AuthorId string `protobuf:"bytes,3,opt,name=author_id,json=authorId" json:"author_id,omitempty"`
+}
+
+func (m *Contributor) Reset()                    { *m = Contributor{} }
+func (m *Contributor) String() string            { return proto.CompactTextString(m) }
+func (*Contributor) ProtoMessage()               {}
+func (*Contributor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Contributor) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Contributor) GetRole() ContributorRole {
+	if m != nil {
+		return m.Role
+	}
+	return ContributorRole_AUTHOR
+}
+
+func (m *Contributor) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+// Series describes a series of books.
+type Series struct {
+	// Name is the name of the series.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Position is the position of the book in the series,
+	// starting at 1. It is 0 if the position is not known.
+	Position int32 `protobuf:"varint,2,opt,name=position" json:"position,omitempty"`
+}
+
+func (m *Series) Reset()                    { *m = Series{} }
+func (m *Series) String() string            { return proto.CompactTextString(m) }
+func (*Series) ProtoMessage()               {}
+func (*Series) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Series) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Series) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
 // Book represents a book in the library.
 type Book struct {
 	// Isbn is the ISBN number of the book.
 	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
 	// Title is the title of the book.
 	Title string `protobuf:"bytes,2,opt,name=title" json:"title,omitempty"`
-	// Author is the author of the book.
+	// Author is the author of the book. Books with several
+	// authors list them all, separated by commas. It is
+	// set from the contributors if it is not set.
 	Author string `protobuf:"bytes,3,opt,name=author" json:"author,omitempty"`
 	// BookType is the type of the book.
 	BookType BookType `protobuf:"varint,4,opt,name=book_type,json=bookType,enum=library.BookType" json:"book_type,omitempty"`
@@ -109,14 +541,50 @@ type Book struct {
 	PublishingMethod isBook_PublishingMethod `protobuf_oneof:"publishing_method"`
 	// PublicationDate is the time of publication of the book.
 	PublicationDate *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=publication_date,json=publicationDate" json:"publication_date,omitempty"`
+	// Contributors are the people who have contributed to the book.
+	// Books without contributors with the AUTHOR
+	// role have the author as their only author.
+	Contributors []*Contributor `protobuf:"bytes,8,rep,name=contributors" json:"contributors,omitempty"`
+	// Edition describes the edition of the book, for example "2nd".
+	Edition string `protobuf:"bytes,9,opt,name=edition" json:"edition,omitempty"`
+	// Series is the series the book is part of, if any.
+	Series *Series `protobuf:"bytes,10,opt,name=series" json:"series,omitempty"`
+	// Language is the BCP 47 code of the
+	// language of the book, for example "en-GB".
+	Language string `protobuf:"bytes,11,opt,name=language" json:"language,omitempty"`
+	// Extent is the length of the book.
+	//
+	// Types that are valid to be assigned to Extent:
+	//	*Book_PageCount
+	//	*Book_DurationSeconds
+	Extent isBook_Extent `protobuf_oneof:"extent"`
+	// Description is a summary of the book.
+	Description string `protobuf:"bytes,14,opt,name=description" json:"description,omitempty"`
+	// Subjects are the subjects of the book,
+	// for example "Fiction" or "Dystopias".
+	Subjects []string `protobuf:"bytes,15,rep,name=subjects" json:"subjects,omitempty"`
+	// CoverImageUrl is the URL of an image of the cover of the book.
+	CoverImageUrl string `protobuf:"bytes,16,opt,name=cover_image_url,json=coverImageUrl" json:"cover_image_url,omitempty"`
+	// Availability summarizes the copies of the book. It is only
+	// set on the books returned by GetBook and QueryBooks, and is
+	// ignored on the books imported.
+	Availability *Availability `protobuf:"bytes,17,opt,name=availability" json:"availability,omitempty"`
+	// RatingCount is the number of reviews of the book.
+	// Like Availability, it is only set on the books
+	// returned by GetBook and QueryBooks.
+	RatingCount int32 `protobuf:"varint,18,opt,name=rating_count,json=ratingCount" json:"rating_count,omitempty"`
+	// AverageRating is the average rating of the
+	// reviews of the book, or 0 if there are none.
+	AverageRating float64 `protobuf:"fixed64,19,opt,name=average_rating,json=averageRating" json:"average_rating,omitempty"`
 }
 
 func (m *Book) Reset()                    { *m = Book{} }
 func (m *Book) String() string            { return proto.CompactTextString(m) }
 func (*Book) ProtoMessage()               {}
-func (*Book) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*Book) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 type isBook_PublishingMethod interface{ isBook_PublishingMethod() }
+type isBook_Extent interface{ isBook_Extent() }
 
 type Book_SelfPublished struct {
 	SelfPublished bool `protobuf:"varint,5,opt,name=self_published,json=selfPublished,oneof"`
@@ -124,9 +592,17 @@ type Book_SelfPublished struct {
 type Book_Publisher struct {
 	Publisher *Publisher `protobuf:"bytes,6,opt,name=publisher,oneof"`
 }
+type Book_PageCount struct {
+	PageCount int32 `protobuf:"varint,12,opt,name=page_count,json=pageCount,oneof"`
+}
+type Book_DurationSeconds struct {
+	DurationSeconds int32 `protobuf:"varint,13,opt,name=duration_seconds,json=durationSeconds,oneof"`
+}
 
 func (*Book_SelfPublished) isBook_PublishingMethod() {}
 func (*Book_Publisher) isBook_PublishingMethod()     {}
+func (*Book_PageCount) isBook_Extent()               {}
+func (*Book_DurationSeconds) isBook_Extent()         {}
 
 func (m *Book) GetPublishingMethod() isBook_PublishingMethod {
 	if m != nil {
@@ -134,6 +610,12 @@ func (m *Book) GetPublishingMethod() isBook_PublishingMethod {
 	}
 	return nil
 }
+func (m *Book) GetExtent() isBook_Extent {
+	if m != nil {
+		return m.Extent
+	}
+	return nil
+}
 
 func (m *Book) GetIsbn() int64 {
 	if m != nil {
@@ -184,11 +666,97 @@ func (m *Book) GetPublicationDate() *google_protobuf.Timestamp {
 	return nil
 }
 
+func (m *Book) GetContributors() []*Contributor {
+	if m != nil {
+		return m.Contributors
+	}
+	return nil
+}
+
+func (m *Book) GetEdition() string {
+	if m != nil {
+		return m.Edition
+	}
+	return ""
+}
+
+func (m *Book) GetSeries() *Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+func (m *Book) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *Book) GetPageCount() int32 {
+	if x, ok := m.GetExtent().(*Book_PageCount); ok {
+		return x.PageCount
+	}
+	return 0
+}
+
+func (m *Book) GetDurationSeconds() int32 {
+	if x, ok := m.GetExtent().(*Book_DurationSeconds); ok {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (m *Book) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Book) GetSubjects() []string {
+	if m != nil {
+		return m.Subjects
+	}
+	return nil
+}
+
+func (m *Book) GetCoverImageUrl() string {
+	if m != nil {
+		return m.CoverImageUrl
+	}
+	return ""
+}
+
+func (m *Book) GetAvailability() *Availability {
+	if m != nil {
+		return m.Availability
+	}
+	return nil
+}
+
+func (m *Book) GetRatingCount() int32 {
+	if m != nil {
+		return m.RatingCount
+	}
+	return 0
+}
+
+func (m *Book) GetAverageRating() float64 {
+	if m != nil {
+		return m.AverageRating
+	}
+	return 0
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Book) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Book_OneofMarshaler, _Book_OneofUnmarshaler, _Book_OneofSizer, []interface{}{
 		(*Book_SelfPublished)(nil),
 		(*Book_Publisher)(nil),
+		(*Book_PageCount)(nil),
+		(*Book_DurationSeconds)(nil),
 	}
 }
 
@@ -212,6 +780,18 @@ func _Book_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 	default:
 		return fmt.Errorf("Book.PublishingMethod has unexpected type %T", x)
 	}
+	// extent
+	switch x := m.Extent.(type) {
+	case *Book_PageCount:
+		b.EncodeVarint(12<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.PageCount))
+	case *Book_DurationSeconds:
+		b.EncodeVarint(13<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.DurationSeconds))
+	case nil:
+	default:
+		return fmt.Errorf("Book.Extent has unexpected type %T", x)
+	}
 	return nil
 }
 
@@ -233,6 +813,20 @@ func _Book_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (
 		err := b.DecodeMessage(msg)
 		m.PublishingMethod = &Book_Publisher{msg}
 		return true, err
+	case 12: // extent.page_count
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Extent = &Book_PageCount{int32(x)}
+		return true, err
+	case 13: // extent.duration_seconds
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Extent = &Book_DurationSeconds{int32(x)}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -254,529 +848,4797 @@ func _Book_OneofSizer(msg proto.Message) (n int) {
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
 	}
+	// extent
+	switch x := m.Extent.(type) {
+	case *Book_PageCount:
+		n += proto.SizeVarint(12<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.PageCount))
+	case *Book_DurationSeconds:
+		n += proto.SizeVarint(13<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.DurationSeconds))
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
 	return n
 }
 
-// GetBookRequest is the input to the GetBook method.
-type GetBookRequest struct {
-	// Isbn is the ISBN with which
-	// to match against the ISBN of a book in the library.
-	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+// Branch is a branch of the library, which holds copies of books.
+type Branch struct {
+	// Id identifies the branch.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Name is the name of the branch.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Address is the street address of the branch.
+	Address string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
 }
 
-func (m *GetBookRequest) Reset()                    { *m = GetBookRequest{} }
-func (m *GetBookRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetBookRequest) ProtoMessage()               {}
-func (*GetBookRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (m *Branch) Reset()                    { *m = Branch{} }
+func (m *Branch) String() string            { return proto.CompactTextString(m) }
+func (*Branch) ProtoMessage()               {}
+func (*Branch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
-func (m *GetBookRequest) GetIsbn() int64 {
+func (m *Branch) GetId() string {
 	if m != nil {
-		return m.Isbn
+		return m.Id
 	}
-	return 0
+	return ""
 }
 
-// QueryBooksRequest is the input to the QueryBooks method.
-type QueryBooksRequest struct {
-	// AuthorPrefix is the prefix with which
-	// to match against the author of a book in the library.
-	AuthorPrefix string `protobuf:"bytes,1,opt,name=author_prefix,json=authorPrefix" json:"author_prefix,omitempty"`
+func (m *Branch) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func (m *QueryBooksRequest) Reset()                    { *m = QueryBooksRequest{} }
-func (m *QueryBooksRequest) String() string            { return proto.CompactTextString(m) }
-func (*QueryBooksRequest) ProtoMessage()               {}
-func (*QueryBooksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
-
-func (m *QueryBooksRequest) GetAuthorPrefix() string {
+func (m *Branch) GetAddress() string {
 	if m != nil {
-		return m.AuthorPrefix
+		return m.Address
 	}
 	return ""
 }
 
-// Collection is a collection of books
-type Collection struct {
-	// Books is a list of books
-	Books []*Book `protobuf:"bytes,1,rep,name=books" json:"books,omitempty"`
+// Availability summarizes the copies of a Book.
+type Availability struct {
+	// Copies is the number of copies.
+	Copies int32 `protobuf:"varint,1,opt,name=copies" json:"copies,omitempty"`
+	// Available is the number of copies which can be checked out.
+	Available int32 `protobuf:"varint,2,opt,name=available" json:"available,omitempty"`
+	// Branches summarizes the copies at each branch
+	// with copies of the book, ordered by branch Id.
+	Branches []*BranchAvailability `protobuf:"bytes,3,rep,name=branches" json:"branches,omitempty"`
 }
 
-func (m *Collection) Reset()                    { *m = Collection{} }
-func (m *Collection) String() string            { return proto.CompactTextString(m) }
-func (*Collection) ProtoMessage()               {}
-func (*Collection) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (m *Availability) Reset()                    { *m = Availability{} }
+func (m *Availability) String() string            { return proto.CompactTextString(m) }
+func (*Availability) ProtoMessage()               {}
+func (*Availability) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
-func (m *Collection) GetBooks() []*Book {
+func (m *Availability) GetCopies() int32 {
 	if m != nil {
-		return m.Books
+		return m.Copies
 	}
-	return nil
+	return 0
 }
 
-// BookMessage is used to discuss books
-type BookMessage struct {
-	// Types that are valid to be assigned to Content:
-	//	*BookMessage_Name
-	//	*BookMessage_Message
-	Content isBookMessage_Content `protobuf_oneof:"content"`
+func (m *Availability) GetAvailable() int32 {
+	if m != nil {
+		return m.Available
+	}
+	return 0
 }
 
-func (m *BookMessage) Reset()                    { *m = BookMessage{} }
-func (m *BookMessage) String() string            { return proto.CompactTextString(m) }
-func (*BookMessage) ProtoMessage()               {}
-func (*BookMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *Availability) GetBranches() []*BranchAvailability {
+	if m != nil {
+		return m.Branches
+	}
+	return nil
+}
 
-type isBookMessage_Content interface{ isBookMessage_Content() }
+// BranchAvailability summarizes the copies of a Book at a Branch.
+type BranchAvailability struct {
+	// BranchId is the Id of the branch.
+	BranchId string `protobuf:"bytes,1,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	// BranchName is the name of the branch.
+	BranchName string `protobuf:"bytes,2,opt,name=branch_name,json=branchName" json:"branch_name,omitempty"`
+	// Copies is the number of copies at the branch.
+	Copies int32 `protobuf:"varint,3,opt,name=copies" json:"copies,omitempty"`
+	// Available is the number of copies which can be checked out.
+	Available int32 `protobuf:"varint,4,opt,name=available" json:"available,omitempty"`
+	// OnLoan is the number of copies on loan.
+	OnLoan int32 `protobuf:"varint,5,opt,name=on_loan,json=onLoan" json:"on_loan,omitempty"`
+	// OnHold is the number of copies kept for holds.
+	OnHold int32 `protobuf:"varint,6,opt,name=on_hold,json=onHold" json:"on_hold,omitempty"`
+}
 
-type BookMessage_Name struct {
-	Name string `protobuf:"bytes,1,opt,name=name,oneof"`
+func (m *BranchAvailability) Reset()                    { *m = BranchAvailability{} }
+func (m *BranchAvailability) String() string            { return proto.CompactTextString(m) }
+func (*BranchAvailability) ProtoMessage()               {}
+func (*BranchAvailability) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *BranchAvailability) GetBranchId() string {
+	if m != nil {
+		return m.BranchId
+	}
+	return ""
 }
-type BookMessage_Message struct {
-	Message string `protobuf:"bytes,2,opt,name=message,oneof"`
+
+func (m *BranchAvailability) GetBranchName() string {
+	if m != nil {
+		return m.BranchName
+	}
+	return ""
 }
 
-func (*BookMessage_Name) isBookMessage_Content()    {}
-func (*BookMessage_Message) isBookMessage_Content() {}
+func (m *BranchAvailability) GetCopies() int32 {
+	if m != nil {
+		return m.Copies
+	}
+	return 0
+}
 
-func (m *BookMessage) GetContent() isBookMessage_Content {
+func (m *BranchAvailability) GetAvailable() int32 {
 	if m != nil {
-		return m.Content
+		return m.Available
 	}
-	return nil
+	return 0
 }
 
-func (m *BookMessage) GetName() string {
-	if x, ok := m.GetContent().(*BookMessage_Name); ok {
-		return x.Name
+func (m *BranchAvailability) GetOnLoan() int32 {
+	if m != nil {
+		return m.OnLoan
+	}
+	return 0
+}
+
+func (m *BranchAvailability) GetOnHold() int32 {
+	if m != nil {
+		return m.OnHold
+	}
+	return 0
+}
+
+// GetBookRequest is the input to the GetBook method.
+type GetBookRequest struct {
+	// Isbn is the ISBN with which
+	// to match against the ISBN of a book in the library.
+	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+}
+
+func (m *GetBookRequest) Reset()                    { *m = GetBookRequest{} }
+func (m *GetBookRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetBookRequest) ProtoMessage()               {}
+func (*GetBookRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *GetBookRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+// QueryBooksRequest is the input to the QueryBooks method.
+type QueryBooksRequest struct {
+	// AuthorPrefix is the prefix with which
+	// to match against the author of a book in the library.
+	// Books with several authors match if any author matches.
+	AuthorPrefix string `protobuf:"bytes,1,opt,name=author_prefix,json=authorPrefix" json:"author_prefix,omitempty"`
+	// AuthorId, if set, only matches books
+	// with an author with this Id.
+	AuthorId string `protobuf:"bytes,2,opt,name=author_id,json=authorId" json:"author_id,omitempty"`
+	// AvailableAtBranch, if set, only matches books with
+	// a copy available at the branch with this Id.
+	AvailableAtBranch string `protobuf:"bytes,3,opt,name=available_at_branch,json=availableAtBranch" json:"available_at_branch,omitempty"`
+}
+
+func (m *QueryBooksRequest) Reset()                    { *m = QueryBooksRequest{} }
+func (m *QueryBooksRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryBooksRequest) ProtoMessage()               {}
+func (*QueryBooksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *QueryBooksRequest) GetAuthorPrefix() string {
+	if m != nil {
+		return m.AuthorPrefix
 	}
 	return ""
 }
 
-func (m *BookMessage) GetMessage() string {
-	if x, ok := m.GetContent().(*BookMessage_Message); ok {
-		return x.Message
+func (m *QueryBooksRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
 	}
 	return ""
 }
 
-// XXX_OneofFuncs is for the internal use of the proto package.
-func (*BookMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
-	return _BookMessage_OneofMarshaler, _BookMessage_OneofUnmarshaler, _BookMessage_OneofSizer, []interface{}{
-		(*BookMessage_Name)(nil),
-		(*BookMessage_Message)(nil),
+func (m *QueryBooksRequest) GetAvailableAtBranch() string {
+	if m != nil {
+		return m.AvailableAtBranch
 	}
+	return ""
 }
 
-func _BookMessage_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
-	m := msg.(*BookMessage)
-	// content
-	switch x := m.Content.(type) {
-	case *BookMessage_Name:
-		b.EncodeVarint(1<<3 | proto.WireBytes)
-		b.EncodeStringBytes(x.Name)
-	case *BookMessage_Message:
-		b.EncodeVarint(2<<3 | proto.WireBytes)
-		b.EncodeStringBytes(x.Message)
-	case nil:
-	default:
-		return fmt.Errorf("BookMessage.Content has unexpected type %T", x)
+// GetAuthorRequest is the input to the GetAuthor method.
+type GetAuthorRequest struct {
+	// Id is the Id of the author.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetAuthorRequest) Reset()                    { *m = GetAuthorRequest{} }
+func (m *GetAuthorRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetAuthorRequest) ProtoMessage()               {}
+func (*GetAuthorRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *GetAuthorRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// ListAuthorsRequest is the input to the ListAuthors method.
+type ListAuthorsRequest struct {
+	// NamePrefix is the prefix with which
+	// to match against the name of an author.
+	NamePrefix string `protobuf:"bytes,1,opt,name=name_prefix,json=namePrefix" json:"name_prefix,omitempty"`
+}
+
+func (m *ListAuthorsRequest) Reset()                    { *m = ListAuthorsRequest{} }
+func (m *ListAuthorsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListAuthorsRequest) ProtoMessage()               {}
+func (*ListAuthorsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ListAuthorsRequest) GetNamePrefix() string {
+	if m != nil {
+		return m.NamePrefix
+	}
+	return ""
+}
+
+// ListAuthorsResponse is the output of the ListAuthors method.
+type ListAuthorsResponse struct {
+	// Authors are the authors matching the
+	// name prefix, ordered by name.
+	Authors []*Author `protobuf:"bytes,1,rep,name=authors" json:"authors,omitempty"`
+}
+
+func (m *ListAuthorsResponse) Reset()                    { *m = ListAuthorsResponse{} }
+func (m *ListAuthorsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListAuthorsResponse) ProtoMessage()               {}
+func (*ListAuthorsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ListAuthorsResponse) GetAuthors() []*Author {
+	if m != nil {
+		return m.Authors
 	}
 	return nil
 }
 
-func _BookMessage_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
-	m := msg.(*BookMessage)
-	switch tag {
-	case 1: // content.name
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		x, err := b.DecodeStringBytes()
-		m.Content = &BookMessage_Name{x}
-		return true, err
-	case 2: // content.message
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		x, err := b.DecodeStringBytes()
-		m.Content = &BookMessage_Message{x}
-		return true, err
-	default:
-		return false, nil
+// GetPublisherRequest is the input to the GetPublisher method.
+type GetPublisherRequest struct {
+	// Id is the Id of the publisher.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetPublisherRequest) Reset()                    { *m = GetPublisherRequest{} }
+func (m *GetPublisherRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetPublisherRequest) ProtoMessage()               {}
+func (*GetPublisherRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *GetPublisherRequest) GetId() string {
+	if m != nil {
+		return m.Id
 	}
+	return ""
 }
 
-func _BookMessage_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*BookMessage)
-	// content
-	switch x := m.Content.(type) {
-	case *BookMessage_Name:
-		n += proto.SizeVarint(1<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(len(x.Name)))
-		n += len(x.Name)
-	case *BookMessage_Message:
-		n += proto.SizeVarint(2<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(len(x.Message)))
-		n += len(x.Message)
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+// Collection is a collection of books
+type Collection struct {
+	// Books is a list of books
+	Books []*Book `protobuf:"bytes,1,rep,name=books" json:"books,omitempty"`
+	// Id identifies the collection, and is part of the link
+	// with which the collection can be shared.
+	Id string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	// Name is the name of the collection.
+	Name string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	// Owner is the user who made the collection. Collections
+	// made without a session have no owner, and cannot be changed.
+	Owner string `protobuf:"bytes,4,opt,name=owner" json:"owner,omitempty"`
+	// Visibility is who can see the collection.
+	Visibility Visibility `protobuf:"varint,5,opt,name=visibility,enum=library.Visibility" json:"visibility,omitempty"`
+	// Errors describe the books sent to MakeCollection
+	// that were not added to the collection. They are
+	// only set in the response of MakeCollection.
+	Errors []*CollectionError `protobuf:"bytes,6,rep,name=errors" json:"errors,omitempty"`
+}
+
+func (m *Collection) Reset()                    { *m = Collection{} }
+func (m *Collection) String() string            { return proto.CompactTextString(m) }
+func (*Collection) ProtoMessage()               {}
+func (*Collection) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *Collection) GetBooks() []*Book {
+	if m != nil {
+		return m.Books
 	}
-	return n
+	return nil
 }
 
-// BookResponse is used to discuss books
-type BookResponse struct {
-	// Message is a message from a user.
-	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+func (m *Collection) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func (m *BookResponse) Reset()                    { *m = BookResponse{} }
-func (m *BookResponse) String() string            { return proto.CompactTextString(m) }
-func (*BookResponse) ProtoMessage()               {}
-func (*BookResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (m *Collection) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
 
-func (m *BookResponse) GetMessage() string {
+func (m *Collection) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Collection) GetVisibility() Visibility {
+	if m != nil {
+		return m.Visibility
+	}
+	return Visibility_PRIVATE
+}
+
+func (m *Collection) GetErrors() []*CollectionError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+// CollectionError describes a book sent to
+// MakeCollection that was not added to the collection.
+type CollectionError struct {
+	// Index is the position of the book in the
+	// stream of books sent, starting at 0.
+	Index int32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	// Isbn is the ISBN of the book.
+	Isbn int64 `protobuf:"varint,2,opt,name=isbn" json:"isbn,omitempty"`
+	// Reason is why the book was not added.
+	Reason CollectionError_Reason `protobuf:"varint,3,opt,name=reason,enum=library.CollectionError_Reason" json:"reason,omitempty"`
+	// Message describes the error.
+	Message string `protobuf:"bytes,4,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *CollectionError) Reset()                    { *m = CollectionError{} }
+func (m *CollectionError) String() string            { return proto.CompactTextString(m) }
+func (*CollectionError) ProtoMessage()               {}
+func (*CollectionError) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *CollectionError) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *CollectionError) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *CollectionError) GetReason() CollectionError_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return CollectionError_REASON_UNSPECIFIED
+}
+
+func (m *CollectionError) GetMessage() string {
 	if m != nil {
 		return m.Message
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Publisher)(nil), "library.Publisher")
-	proto.RegisterType((*Book)(nil), "library.Book")
-	proto.RegisterType((*GetBookRequest)(nil), "library.GetBookRequest")
-	proto.RegisterType((*QueryBooksRequest)(nil), "library.QueryBooksRequest")
-	proto.RegisterType((*Collection)(nil), "library.Collection")
-	proto.RegisterType((*BookMessage)(nil), "library.BookMessage")
-	proto.RegisterType((*BookResponse)(nil), "library.BookResponse")
-	proto.RegisterEnum("library.BookType", BookType_name, BookType_value)
+// GetCollectionRequest is the input to the GetCollection method.
+type GetCollectionRequest struct {
+	// Id is the Id of the collection.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetCollectionRequest) Reset()                    { *m = GetCollectionRequest{} }
+func (m *GetCollectionRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetCollectionRequest) ProtoMessage()               {}
+func (*GetCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *GetCollectionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// ListCollectionsRequest is the input to the ListCollections method.
+type ListCollectionsRequest struct {
+	// Owner, if set, only lists the collections of this user.
+	Owner string `protobuf:"bytes,1,opt,name=owner" json:"owner,omitempty"`
+}
+
+func (m *ListCollectionsRequest) Reset()                    { *m = ListCollectionsRequest{} }
+func (m *ListCollectionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListCollectionsRequest) ProtoMessage()               {}
+func (*ListCollectionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *ListCollectionsRequest) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+// ListCollectionsResponse is the output of the ListCollections method.
+type ListCollectionsResponse struct {
+	// Collections are the collections listed,
+	// ordered by name.
+	Collections []*Collection `protobuf:"bytes,1,rep,name=collections" json:"collections,omitempty"`
+}
+
+func (m *ListCollectionsResponse) Reset()                    { *m = ListCollectionsResponse{} }
+func (m *ListCollectionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListCollectionsResponse) ProtoMessage()               {}
+func (*ListCollectionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *ListCollectionsResponse) GetCollections() []*Collection {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+// AddToCollectionRequest is the input to the AddToCollection method.
+type AddToCollectionRequest struct {
+	// Id is the Id of the collection.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Isbns are the ISBNs of the books to add.
+	Isbns []int64 `protobuf:"varint,2,rep,packed,name=isbns" json:"isbns,omitempty"`
+}
+
+func (m *AddToCollectionRequest) Reset()                    { *m = AddToCollectionRequest{} }
+func (m *AddToCollectionRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddToCollectionRequest) ProtoMessage()               {}
+func (*AddToCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *AddToCollectionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AddToCollectionRequest) GetIsbns() []int64 {
+	if m != nil {
+		return m.Isbns
+	}
+	return nil
+}
+
+// RemoveFromCollectionRequest is the input
+// to the RemoveFromCollection method.
+type RemoveFromCollectionRequest struct {
+	// Id is the Id of the collection.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Isbns are the ISBNs of the books to remove.
+	Isbns []int64 `protobuf:"varint,2,rep,packed,name=isbns" json:"isbns,omitempty"`
+}
+
+func (m *RemoveFromCollectionRequest) Reset()                    { *m = RemoveFromCollectionRequest{} }
+func (m *RemoveFromCollectionRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveFromCollectionRequest) ProtoMessage()               {}
+func (*RemoveFromCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *RemoveFromCollectionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RemoveFromCollectionRequest) GetIsbns() []int64 {
+	if m != nil {
+		return m.Isbns
+	}
+	return nil
+}
+
+// DeleteCollectionRequest is the input to the DeleteCollection method.
+type DeleteCollectionRequest struct {
+	// Id is the Id of the collection.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteCollectionRequest) Reset()                    { *m = DeleteCollectionRequest{} }
+func (m *DeleteCollectionRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteCollectionRequest) ProtoMessage()               {}
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *DeleteCollectionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// Copy is a physical copy of a book, which can be borrowed.
+type Copy struct {
+	// Id identifies the copy.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Isbn is the ISBN of the book the copy is of.
+	Isbn int64 `protobuf:"varint,2,opt,name=isbn" json:"isbn,omitempty"`
+	// OnLoan is whether the copy is borrowed.
+	OnLoan bool `protobuf:"varint,3,opt,name=on_loan,json=onLoan" json:"on_loan,omitempty"`
+	// Due is when the copy should be returned, if on loan.
+	Due *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=due" json:"due,omitempty"`
+	// OnHold is whether the copy is kept for
+	// the user of a hold to pick up.
+	OnHold bool `protobuf:"varint,5,opt,name=on_hold,json=onHold" json:"on_hold,omitempty"`
+	// BranchId is the Id of the branch the copy is at.
+	BranchId string `protobuf:"bytes,6,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+}
+
+func (m *Copy) Reset()                    { *m = Copy{} }
+func (m *Copy) String() string            { return proto.CompactTextString(m) }
+func (*Copy) ProtoMessage()               {}
+func (*Copy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *Copy) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Copy) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *Copy) GetOnLoan() bool {
+	if m != nil {
+		return m.OnLoan
+	}
+	return false
+}
+
+func (m *Copy) GetDue() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Due
+	}
+	return nil
+}
+
+func (m *Copy) GetOnHold() bool {
+	if m != nil {
+		return m.OnHold
+	}
+	return false
+}
+
+func (m *Copy) GetBranchId() string {
+	if m != nil {
+		return m.BranchId
+	}
+	return ""
+}
+
+// Loan describes the borrowing of a Copy.
+type Loan struct {
+	// Id identifies the loan.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// CopyId is the Id of the copy borrowed.
+	CopyId string `protobuf:"bytes,2,opt,name=copy_id,json=copyId" json:"copy_id,omitempty"`
+	// Isbn is the ISBN of the book the copy is of.
+	Isbn int64 `protobuf:"varint,3,opt,name=isbn" json:"isbn,omitempty"`
+	// Borrower is the user who borrowed the copy.
+	Borrower string `protobuf:"bytes,4,opt,name=borrower" json:"borrower,omitempty"`
+	// CheckedOut is when the copy was borrowed.
+	CheckedOut *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=checked_out,json=checkedOut" json:"checked_out,omitempty"`
+	// Due is when the copy should be returned.
+	Due *google_protobuf.Timestamp `protobuf:"bytes,6,opt,name=due" json:"due,omitempty"`
+	// Returned is when the copy was returned,
+	// or not set if it has not been returned.
+	Returned *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=returned" json:"returned,omitempty"`
+	// Renewals is the number of times the loan has been renewed.
+	Renewals int32 `protobuf:"varint,8,opt,name=renewals" json:"renewals,omitempty"`
+	// FineCents is the fine for returning the copy late, in
+	// cents. It is updated periodically until the copy is returned.
+	FineCents int64 `protobuf:"varint,9,opt,name=fine_cents,json=fineCents" json:"fine_cents,omitempty"`
+}
+
+func (m *Loan) Reset()                    { *m = Loan{} }
+func (m *Loan) String() string            { return proto.CompactTextString(m) }
+func (*Loan) ProtoMessage()               {}
+func (*Loan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *Loan) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Loan) GetCopyId() string {
+	if m != nil {
+		return m.CopyId
+	}
+	return ""
+}
+
+func (m *Loan) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *Loan) GetBorrower() string {
+	if m != nil {
+		return m.Borrower
+	}
+	return ""
+}
+
+func (m *Loan) GetCheckedOut() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.CheckedOut
+	}
+	return nil
+}
+
+func (m *Loan) GetDue() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Due
+	}
+	return nil
+}
+
+func (m *Loan) GetReturned() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Returned
+	}
+	return nil
+}
+
+func (m *Loan) GetRenewals() int32 {
+	if m != nil {
+		return m.Renewals
+	}
+	return 0
+}
+
+func (m *Loan) GetFineCents() int64 {
+	if m != nil {
+		return m.FineCents
+	}
+	return 0
+}
+
+// ListCopiesRequest is the input to the ListCopies method.
+type ListCopiesRequest struct {
+	// Isbn is the ISBN of the book to list the copies of.
+	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+}
+
+func (m *ListCopiesRequest) Reset()                    { *m = ListCopiesRequest{} }
+func (m *ListCopiesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListCopiesRequest) ProtoMessage()               {}
+func (*ListCopiesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ListCopiesRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+// ListCopiesResponse is the output of the ListCopies method.
+type ListCopiesResponse struct {
+	// Copies are the copies of the book.
+	Copies []*Copy `protobuf:"bytes,1,rep,name=copies" json:"copies,omitempty"`
+}
+
+func (m *ListCopiesResponse) Reset()                    { *m = ListCopiesResponse{} }
+func (m *ListCopiesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListCopiesResponse) ProtoMessage()               {}
+func (*ListCopiesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ListCopiesResponse) GetCopies() []*Copy {
+	if m != nil {
+		return m.Copies
+	}
+	return nil
+}
+
+// AddCopyRequest is the input to the AddCopy method.
+type AddCopyRequest struct {
+	// Isbn is the ISBN of the book to add a copy of.
+	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+	// BranchId is the Id of the branch the copy is at.
+	BranchId string `protobuf:"bytes,2,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+}
+
+func (m *AddCopyRequest) Reset()                    { *m = AddCopyRequest{} }
+func (m *AddCopyRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddCopyRequest) ProtoMessage()               {}
+func (*AddCopyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *AddCopyRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *AddCopyRequest) GetBranchId() string {
+	if m != nil {
+		return m.BranchId
+	}
+	return ""
+}
+
+// CheckOutRequest is the input to the CheckOut method.
+type CheckOutRequest struct {
+	// CopyId is the Id of the copy to borrow.
+	CopyId string `protobuf:"bytes,1,opt,name=copy_id,json=copyId" json:"copy_id,omitempty"`
+	// Isbn is the ISBN of the book to borrow any
+	// available copy of, if CopyId is not set.
+	Isbn int64 `protobuf:"varint,2,opt,name=isbn" json:"isbn,omitempty"`
+	// BranchId, if set, only borrows a copy at the
+	// branch with this Id, if CopyId is not set.
+	BranchId string `protobuf:"bytes,3,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+}
+
+func (m *CheckOutRequest) Reset()                    { *m = CheckOutRequest{} }
+func (m *CheckOutRequest) String() string            { return proto.CompactTextString(m) }
+func (*CheckOutRequest) ProtoMessage()               {}
+func (*CheckOutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *CheckOutRequest) GetCopyId() string {
+	if m != nil {
+		return m.CopyId
+	}
+	return ""
+}
+
+func (m *CheckOutRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *CheckOutRequest) GetBranchId() string {
+	if m != nil {
+		return m.BranchId
+	}
+	return ""
+}
+
+// ReturnRequest is the input to the Return method.
+type ReturnRequest struct {
+	// CopyId is the Id of the copy returned.
+	CopyId string `protobuf:"bytes,1,opt,name=copy_id,json=copyId" json:"copy_id,omitempty"`
+}
+
+func (m *ReturnRequest) Reset()                    { *m = ReturnRequest{} }
+func (m *ReturnRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReturnRequest) ProtoMessage()               {}
+func (*ReturnRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *ReturnRequest) GetCopyId() string {
+	if m != nil {
+		return m.CopyId
+	}
+	return ""
+}
+
+// RenewRequest is the input to the Renew method.
+type RenewRequest struct {
+	// LoanId is the Id of the loan to renew.
+	LoanId string `protobuf:"bytes,1,opt,name=loan_id,json=loanId" json:"loan_id,omitempty"`
+}
+
+func (m *RenewRequest) Reset()                    { *m = RenewRequest{} }
+func (m *RenewRequest) String() string            { return proto.CompactTextString(m) }
+func (*RenewRequest) ProtoMessage()               {}
+func (*RenewRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *RenewRequest) GetLoanId() string {
+	if m != nil {
+		return m.LoanId
+	}
+	return ""
+}
+
+// ListLoansRequest is the input to the ListLoans method.
+type ListLoansRequest struct {
+	// IncludeReturned also lists the loans
+	// of copies that have been returned.
+	IncludeReturned bool `protobuf:"varint,1,opt,name=include_returned,json=includeReturned" json:"include_returned,omitempty"`
+}
+
+func (m *ListLoansRequest) Reset()                    { *m = ListLoansRequest{} }
+func (m *ListLoansRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListLoansRequest) ProtoMessage()               {}
+func (*ListLoansRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *ListLoansRequest) GetIncludeReturned() bool {
+	if m != nil {
+		return m.IncludeReturned
+	}
+	return false
+}
+
+// ListLoansResponse is the output of the ListLoans method.
+type ListLoansResponse struct {
+	// Loans are the loans of the user,
+	// in the order they were made.
+	Loans []*Loan `protobuf:"bytes,1,rep,name=loans" json:"loans,omitempty"`
+}
+
+func (m *ListLoansResponse) Reset()                    { *m = ListLoansResponse{} }
+func (m *ListLoansResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListLoansResponse) ProtoMessage()               {}
+func (*ListLoansResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *ListLoansResponse) GetLoans() []*Loan {
+	if m != nil {
+		return m.Loans
+	}
+	return nil
+}
+
+// Hold is the place of a user in the queue for a Book.
+type Hold struct {
+	// Id identifies the hold.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Isbn is the ISBN of the book held.
+	Isbn int64 `protobuf:"varint,2,opt,name=isbn" json:"isbn,omitempty"`
+	// User is the user who placed the hold.
+	User  string     `protobuf:"bytes,3,opt,name=user" json:"user,omitempty"`
+	State Hold_State `protobuf:"varint,4,opt,name=state,enum=library.Hold_State" json:"state,omitempty"`
+	// Placed is when the hold was placed.
+	Placed *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=placed" json:"placed,omitempty"`
+	// Position is the place of a waiting hold in the
+	// queue for the book, from 1, when it was sent.
+	Position int32 `protobuf:"varint,6,opt,name=position" json:"position,omitempty"`
+	// CopyId is the Id of the copy kept for a ready hold.
+	CopyId string `protobuf:"bytes,7,opt,name=copy_id,json=copyId" json:"copy_id,omitempty"`
+	// ReadyUntil is when the copy kept
+	// for a ready hold is released.
+	ReadyUntil *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=ready_until,json=readyUntil" json:"ready_until,omitempty"`
+}
+
+func (m *Hold) Reset()                    { *m = Hold{} }
+func (m *Hold) String() string            { return proto.CompactTextString(m) }
+func (*Hold) ProtoMessage()               {}
+func (*Hold) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *Hold) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Hold) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *Hold) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Hold) GetState() Hold_State {
+	if m != nil {
+		return m.State
+	}
+	return Hold_WAITING
+}
+
+func (m *Hold) GetPlaced() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Placed
+	}
+	return nil
+}
+
+func (m *Hold) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+func (m *Hold) GetCopyId() string {
+	if m != nil {
+		return m.CopyId
+	}
+	return ""
+}
+
+func (m *Hold) GetReadyUntil() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ReadyUntil
+	}
+	return nil
+}
+
+// PlaceHoldRequest is the input to the PlaceHold method.
+type PlaceHoldRequest struct {
+	// Isbn is the ISBN of the book to hold.
+	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+}
+
+func (m *PlaceHoldRequest) Reset()                    { *m = PlaceHoldRequest{} }
+func (m *PlaceHoldRequest) String() string            { return proto.CompactTextString(m) }
+func (*PlaceHoldRequest) ProtoMessage()               {}
+func (*PlaceHoldRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *PlaceHoldRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+// CancelHoldRequest is the input to the CancelHold method.
+type CancelHoldRequest struct {
+	// HoldId is the Id of the hold to cancel.
+	HoldId string `protobuf:"bytes,1,opt,name=hold_id,json=holdId" json:"hold_id,omitempty"`
+}
+
+func (m *CancelHoldRequest) Reset()                    { *m = CancelHoldRequest{} }
+func (m *CancelHoldRequest) String() string            { return proto.CompactTextString(m) }
+func (*CancelHoldRequest) ProtoMessage()               {}
+func (*CancelHoldRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *CancelHoldRequest) GetHoldId() string {
+	if m != nil {
+		return m.HoldId
+	}
+	return ""
+}
+
+// ListHoldsRequest is the input to the ListHolds method.
+type ListHoldsRequest struct {
+	// IncludeEnded also lists the holds which
+	// were fulfilled, cancelled or expired.
+	IncludeEnded bool `protobuf:"varint,1,opt,name=include_ended,json=includeEnded" json:"include_ended,omitempty"`
+}
+
+func (m *ListHoldsRequest) Reset()                    { *m = ListHoldsRequest{} }
+func (m *ListHoldsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListHoldsRequest) ProtoMessage()               {}
+func (*ListHoldsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *ListHoldsRequest) GetIncludeEnded() bool {
+	if m != nil {
+		return m.IncludeEnded
+	}
+	return false
+}
+
+// ListHoldsResponse is the output of the ListHolds method.
+type ListHoldsResponse struct {
+	// Holds are the holds of the user,
+	// in the order they were placed.
+	Holds []*Hold `protobuf:"bytes,1,rep,name=holds" json:"holds,omitempty"`
+}
+
+func (m *ListHoldsResponse) Reset()                    { *m = ListHoldsResponse{} }
+func (m *ListHoldsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListHoldsResponse) ProtoMessage()               {}
+func (*ListHoldsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *ListHoldsResponse) GetHolds() []*Hold {
+	if m != nil {
+		return m.Holds
+	}
+	return nil
+}
+
+// WatchMyHoldsRequest is the input to the WatchMyHolds method.
+type WatchMyHoldsRequest struct {
+}
+
+func (m *WatchMyHoldsRequest) Reset()                    { *m = WatchMyHoldsRequest{} }
+func (m *WatchMyHoldsRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchMyHoldsRequest) ProtoMessage()               {}
+func (*WatchMyHoldsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+// Job is the status of a background job of the server.
+type Job struct {
+	// Name identifies the job.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// IntervalSeconds is the time between runs of the job.
+	IntervalSeconds int64 `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds" json:"interval_seconds,omitempty"`
+	// Runs is the number of times the job has run.
+	Runs int64 `protobuf:"varint,3,opt,name=runs" json:"runs,omitempty"`
+	// LastRun is when the job last started running.
+	LastRun *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=last_run,json=lastRun" json:"last_run,omitempty"`
+	// LastResult describes the outcome of the last run.
+	LastResult string `protobuf:"bytes,5,opt,name=last_result,json=lastResult" json:"last_result,omitempty"`
+	// LastError is the error of the last run, if it failed.
+	LastError string `protobuf:"bytes,6,opt,name=last_error,json=lastError" json:"last_error,omitempty"`
+	// NextRun is when the job runs next.
+	NextRun *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=next_run,json=nextRun" json:"next_run,omitempty"`
+}
+
+func (m *Job) Reset()                    { *m = Job{} }
+func (m *Job) String() string            { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()               {}
+func (*Job) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *Job) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Job) GetIntervalSeconds() int64 {
+	if m != nil {
+		return m.IntervalSeconds
+	}
+	return 0
+}
+
+func (m *Job) GetRuns() int64 {
+	if m != nil {
+		return m.Runs
+	}
+	return 0
+}
+
+func (m *Job) GetLastRun() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.LastRun
+	}
+	return nil
+}
+
+func (m *Job) GetLastResult() string {
+	if m != nil {
+		return m.LastResult
+	}
+	return ""
+}
+
+func (m *Job) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *Job) GetNextRun() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.NextRun
+	}
+	return nil
+}
+
+// ListBranchesRequest is the input to the ListBranches method.
+type ListBranchesRequest struct {
+}
+
+func (m *ListBranchesRequest) Reset()                    { *m = ListBranchesRequest{} }
+func (m *ListBranchesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListBranchesRequest) ProtoMessage()               {}
+func (*ListBranchesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+// ListBranchesResponse is the output of the ListBranches method.
+type ListBranchesResponse struct {
+	// Branches are the branches, ordered by Id.
+	Branches []*Branch `protobuf:"bytes,1,rep,name=branches" json:"branches,omitempty"`
+}
+
+func (m *ListBranchesResponse) Reset()                    { *m = ListBranchesResponse{} }
+func (m *ListBranchesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListBranchesResponse) ProtoMessage()               {}
+func (*ListBranchesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *ListBranchesResponse) GetBranches() []*Branch {
+	if m != nil {
+		return m.Branches
+	}
+	return nil
+}
+
+// ListJobsRequest is the input to the ListJobs method.
+type ListJobsRequest struct {
+}
+
+func (m *ListJobsRequest) Reset()                    { *m = ListJobsRequest{} }
+func (m *ListJobsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListJobsRequest) ProtoMessage()               {}
+func (*ListJobsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+// ListJobsResponse is the output of the ListJobs method.
+type ListJobsResponse struct {
+	// Running is whether the jobs are being run.
+	Running bool `protobuf:"varint,1,opt,name=running" json:"running,omitempty"`
+	// Jobs are the background jobs, ordered by name.
+	Jobs []*Job `protobuf:"bytes,2,rep,name=jobs" json:"jobs,omitempty"`
+}
+
+func (m *ListJobsResponse) Reset()                    { *m = ListJobsResponse{} }
+func (m *ListJobsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListJobsResponse) ProtoMessage()               {}
+func (*ListJobsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *ListJobsResponse) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
+}
+
+func (m *ListJobsResponse) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+// Review is the rating and opinion of a user about a Book.
+type Review struct {
+	// Id identifies the review.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Isbn is the ISBN of the book reviewed.
+	Isbn int64 `protobuf:"varint,2,opt,name=isbn" json:"isbn,omitempty"`
+	// User is the user who wrote the review.
+	User string `protobuf:"bytes,3,opt,name=user" json:"user,omitempty"`
+	// Rating is the rating of the book, from 1 to 5.
+	Rating int32 `protobuf:"varint,4,opt,name=rating" json:"rating,omitempty"`
+	// Text is the opinion of the user, which may be empty.
+	Text string `protobuf:"bytes,5,opt,name=text" json:"text,omitempty"`
+	// Created is when the review was submitted.
+	Created *google_protobuf.Timestamp `protobuf:"bytes,6,opt,name=created" json:"created,omitempty"`
+	// Updated is when the review was last edited,
+	// or the time it was created if it was not.
+	Updated *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=updated" json:"updated,omitempty"`
+	// HelpfulVotes is the number of users who
+	// found the review helpful.
+	HelpfulVotes int32 `protobuf:"varint,8,opt,name=helpful_votes,json=helpfulVotes" json:"helpful_votes,omitempty"`
+}
+
+func (m *Review) Reset()                    { *m = Review{} }
+func (m *Review) String() string            { return proto.CompactTextString(m) }
+func (*Review) ProtoMessage()               {}
+func (*Review) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *Review) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Review) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *Review) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Review) GetRating() int32 {
+	if m != nil {
+		return m.Rating
+	}
+	return 0
+}
+
+func (m *Review) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *Review) GetCreated() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Review) GetUpdated() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+func (m *Review) GetHelpfulVotes() int32 {
+	if m != nil {
+		return m.HelpfulVotes
+	}
+	return 0
+}
+
+// SubmitReviewRequest is the input to the SubmitReview method.
+type SubmitReviewRequest struct {
+	// Isbn is the ISBN of the book to review.
+	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+	// Rating is the rating of the book, from 1 to 5.
+	Rating int32  `protobuf:"varint,2,opt,name=rating" json:"rating,omitempty"`
+	Text   string `protobuf:"bytes,3,opt,name=text" json:"text,omitempty"`
+}
+
+func (m *SubmitReviewRequest) Reset()                    { *m = SubmitReviewRequest{} }
+func (m *SubmitReviewRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubmitReviewRequest) ProtoMessage()               {}
+func (*SubmitReviewRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *SubmitReviewRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *SubmitReviewRequest) GetRating() int32 {
+	if m != nil {
+		return m.Rating
+	}
+	return 0
+}
+
+func (m *SubmitReviewRequest) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+// UpdateReviewRequest is the input to the UpdateReview method.
+type UpdateReviewRequest struct {
+	// ReviewId is the Id of the review to edit.
+	ReviewId string `protobuf:"bytes,1,opt,name=review_id,json=reviewId" json:"review_id,omitempty"`
+	// Rating is the new rating of the book, from 1 to 5.
+	Rating int32 `protobuf:"varint,2,opt,name=rating" json:"rating,omitempty"`
+	// Text is the new text of the review.
+	Text string `protobuf:"bytes,3,opt,name=text" json:"text,omitempty"`
+}
+
+func (m *UpdateReviewRequest) Reset()                    { *m = UpdateReviewRequest{} }
+func (m *UpdateReviewRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateReviewRequest) ProtoMessage()               {}
+func (*UpdateReviewRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *UpdateReviewRequest) GetReviewId() string {
+	if m != nil {
+		return m.ReviewId
+	}
+	return ""
+}
+
+func (m *UpdateReviewRequest) GetRating() int32 {
+	if m != nil {
+		return m.Rating
+	}
+	return 0
+}
+
+func (m *UpdateReviewRequest) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+// DeleteReviewRequest is the input to the DeleteReview method.
+type DeleteReviewRequest struct {
+	// ReviewId is the Id of the review to delete.
+	ReviewId string `protobuf:"bytes,1,opt,name=review_id,json=reviewId" json:"review_id,omitempty"`
+}
+
+func (m *DeleteReviewRequest) Reset()                    { *m = DeleteReviewRequest{} }
+func (m *DeleteReviewRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteReviewRequest) ProtoMessage()               {}
+func (*DeleteReviewRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *DeleteReviewRequest) GetReviewId() string {
+	if m != nil {
+		return m.ReviewId
+	}
+	return ""
+}
+
+// MarkReviewHelpfulRequest is the input to the MarkReviewHelpful method.
+type MarkReviewHelpfulRequest struct {
+	// ReviewId is the Id of the review found helpful.
+	ReviewId string `protobuf:"bytes,1,opt,name=review_id,json=reviewId" json:"review_id,omitempty"`
+}
+
+func (m *MarkReviewHelpfulRequest) Reset()                    { *m = MarkReviewHelpfulRequest{} }
+func (m *MarkReviewHelpfulRequest) String() string            { return proto.CompactTextString(m) }
+func (*MarkReviewHelpfulRequest) ProtoMessage()               {}
+func (*MarkReviewHelpfulRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *MarkReviewHelpfulRequest) GetReviewId() string {
+	if m != nil {
+		return m.ReviewId
+	}
+	return ""
+}
+
+// ListReviewsRequest is the input to the ListReviews method.
+type ListReviewsRequest struct {
+	// Isbn is the ISBN of the book to list the reviews of.
+	Isbn  int64       `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+	Order ReviewOrder `protobuf:"varint,2,opt,name=order,enum=library.ReviewOrder" json:"order,omitempty"`
+	// PageSize is the largest number of reviews returned. If
+	// 0, 10 reviews are returned. At most 50 are returned.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	// PageToken is the NextPageToken of the previous
+	// page, if not listing the first page. The order
+	// must be the same as for the previous page.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken" json:"page_token,omitempty"`
+}
+
+func (m *ListReviewsRequest) Reset()                    { *m = ListReviewsRequest{} }
+func (m *ListReviewsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListReviewsRequest) ProtoMessage()               {}
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *ListReviewsRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *ListReviewsRequest) GetOrder() ReviewOrder {
+	if m != nil {
+		return m.Order
+	}
+	return ReviewOrder_NEWEST
+}
+
+func (m *ListReviewsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListReviewsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+// RecommendBooksRequest is the input to the RecommendBooks method.
+type RecommendBooksRequest struct {
+	// Isbn is the ISBN of the book to recommend books like.
+	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+	// Limit is the largest number of books recommended. If
+	// 0, 5 books are recommended. At most 20 are recommended.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *RecommendBooksRequest) Reset()                    { *m = RecommendBooksRequest{} }
+func (m *RecommendBooksRequest) String() string            { return proto.CompactTextString(m) }
+func (*RecommendBooksRequest) ProtoMessage()               {}
+func (*RecommendBooksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *RecommendBooksRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *RecommendBooksRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// Recommendation is a Book recommended to the
+// readers of the Book of a RecommendBooksRequest.
+type Recommendation struct {
+	Book   *Book                 `protobuf:"bytes,1,opt,name=book" json:"book,omitempty"`
+	Reason Recommendation_Reason `protobuf:"varint,2,opt,name=reason,enum=library.Recommendation_Reason" json:"reason,omitempty"`
+	// Score is the similarity of the readers of the books,
+	// from 0 to 1, if the Reason is SIMILAR_READERS.
+	Score float64 `protobuf:"fixed64,3,opt,name=score" json:"score,omitempty"`
+}
+
+func (m *Recommendation) Reset()                    { *m = Recommendation{} }
+func (m *Recommendation) String() string            { return proto.CompactTextString(m) }
+func (*Recommendation) ProtoMessage()               {}
+func (*Recommendation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *Recommendation) GetBook() *Book {
+	if m != nil {
+		return m.Book
+	}
+	return nil
+}
+
+func (m *Recommendation) GetReason() Recommendation_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return Recommendation_SIMILAR_READERS
+}
+
+func (m *Recommendation) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+// RecommendBooksResponse is the output of the RecommendBooks method.
+type RecommendBooksResponse struct {
+	// Recommendations are the books recommended, those
+	// of SIMILAR_READERS first, ordered by Score.
+	Recommendations []*Recommendation `protobuf:"bytes,1,rep,name=recommendations" json:"recommendations,omitempty"`
+	// ModelBuilt is when the similarity of books was last
+	// computed, or unset if it has not been computed yet.
+	ModelBuilt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=model_built,json=modelBuilt" json:"model_built,omitempty"`
+}
+
+func (m *RecommendBooksResponse) Reset()                    { *m = RecommendBooksResponse{} }
+func (m *RecommendBooksResponse) String() string            { return proto.CompactTextString(m) }
+func (*RecommendBooksResponse) ProtoMessage()               {}
+func (*RecommendBooksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *RecommendBooksResponse) GetRecommendations() []*Recommendation {
+	if m != nil {
+		return m.Recommendations
+	}
+	return nil
+}
+
+func (m *RecommendBooksResponse) GetModelBuilt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ModelBuilt
+	}
+	return nil
+}
+
+// ReadingEntry is a Book on the reading lists of a user,
+// with how far they got.
+type ReadingEntry struct {
+	// Isbn is the ISBN of the book.
+	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+	// User is the user reading the book.
+	User  string       `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	Shelf ReadingShelf `protobuf:"varint,3,opt,name=shelf,enum=library.ReadingShelf" json:"shelf,omitempty"`
+	// Progress is how far the user got, in the pages of
+	// books or the playback position of audiobooks.
+	//
+	// Types that are valid to be assigned to Progress:
+	//	*ReadingEntry_Page
+	//	*ReadingEntry_PositionSeconds
+	Progress isReadingEntry_Progress `protobuf_oneof:"progress"`
+	// Started is when the book was first moved to READING or
+	// FINISHED, and Finished when it was moved to FINISHED,
+	// if it is on that list.
+	Started  *google_protobuf.Timestamp `protobuf:"bytes,6,opt,name=started" json:"started,omitempty"`
+	Finished *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=finished" json:"finished,omitempty"`
+	// UpdatedAt is when the entry was last changed, on the
+	// device of the user. Changes older than it are ignored.
+	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+	// Removed is set for books removed from the reading lists,
+	// so that devices syncing with AfterSequence remove them too.
+	Removed bool `protobuf:"varint,9,opt,name=removed" json:"removed,omitempty"`
+	// Sequence is the number the server gave the last change of
+	// the entry. Later changes have larger numbers, whatever the
+	// clocks of the devices making them say.
+	Sequence int64 `protobuf:"varint,10,opt,name=sequence" json:"sequence,omitempty"`
+}
+
+func (m *ReadingEntry) Reset()                    { *m = ReadingEntry{} }
+func (m *ReadingEntry) String() string            { return proto.CompactTextString(m) }
+func (*ReadingEntry) ProtoMessage()               {}
+func (*ReadingEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+type isReadingEntry_Progress interface{ isReadingEntry_Progress() }
+
+type ReadingEntry_Page struct {
+	Page int32 `protobuf:"varint,4,opt,name=page,oneof"`
+}
+type ReadingEntry_PositionSeconds struct {
+	PositionSeconds int32 `protobuf:"varint,5,opt,name=position_seconds,json=positionSeconds,oneof"`
+}
+
+func (*ReadingEntry_Page) isReadingEntry_Progress()            {}
+func (*ReadingEntry_PositionSeconds) isReadingEntry_Progress() {}
+
+func (m *ReadingEntry) GetProgress() isReadingEntry_Progress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+func (m *ReadingEntry) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *ReadingEntry) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ReadingEntry) GetShelf() ReadingShelf {
+	if m != nil {
+		return m.Shelf
+	}
+	return ReadingShelf_WANT_TO_READ
+}
+
+func (m *ReadingEntry) GetPage() int32 {
+	if x, ok := m.GetProgress().(*ReadingEntry_Page); ok {
+		return x.Page
+	}
+	return 0
+}
+
+func (m *ReadingEntry) GetPositionSeconds() int32 {
+	if x, ok := m.GetProgress().(*ReadingEntry_PositionSeconds); ok {
+		return x.PositionSeconds
+	}
+	return 0
+}
+
+func (m *ReadingEntry) GetStarted() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Started
+	}
+	return nil
+}
+
+func (m *ReadingEntry) GetFinished() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Finished
+	}
+	return nil
+}
+
+func (m *ReadingEntry) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *ReadingEntry) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+func (m *ReadingEntry) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ReadingEntry) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ReadingEntry_OneofMarshaler, _ReadingEntry_OneofUnmarshaler, _ReadingEntry_OneofSizer, []interface{}{
+		(*ReadingEntry_Page)(nil),
+		(*ReadingEntry_PositionSeconds)(nil),
+	}
+}
+
+func _ReadingEntry_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ReadingEntry)
+	// progress
+	switch x := m.Progress.(type) {
+	case *ReadingEntry_Page:
+		b.EncodeVarint(4<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Page))
+	case *ReadingEntry_PositionSeconds:
+		b.EncodeVarint(5<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.PositionSeconds))
+	case nil:
+	default:
+		return fmt.Errorf("ReadingEntry.Progress has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ReadingEntry_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ReadingEntry)
+	switch tag {
+	case 4: // progress.page
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Progress = &ReadingEntry_Page{int32(x)}
+		return true, err
+	case 5: // progress.position_seconds
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Progress = &ReadingEntry_PositionSeconds{int32(x)}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ReadingEntry_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ReadingEntry)
+	// progress
+	switch x := m.Progress.(type) {
+	case *ReadingEntry_Page:
+		n += proto.SizeVarint(4<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Page))
+	case *ReadingEntry_PositionSeconds:
+		n += proto.SizeVarint(5<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.PositionSeconds))
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// UpdateReadingRequest is the input to the UpdateReading method.
+type UpdateReadingRequest struct {
+	// Isbn is the ISBN of the book.
+	Isbn  int64        `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+	Shelf ReadingShelf `protobuf:"varint,2,opt,name=shelf,enum=library.ReadingShelf" json:"shelf,omitempty"`
+	// Progress is how far the user got. Audiobooks take a
+	// PositionSeconds, and other books a Page. If unset, books
+	// moved to FINISHED are set to their end, and other books
+	// keep their progress.
+	//
+	// Types that are valid to be assigned to Progress:
+	//	*UpdateReadingRequest_Page
+	//	*UpdateReadingRequest_PositionSeconds
+	Progress isUpdateReadingRequest_Progress `protobuf_oneof:"progress"`
+	// UpdatedAt is when the change was made on the device of
+	// the user. If unset, the time of the server is used.
+	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+}
+
+func (m *UpdateReadingRequest) Reset()                    { *m = UpdateReadingRequest{} }
+func (m *UpdateReadingRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateReadingRequest) ProtoMessage()               {}
+func (*UpdateReadingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+type isUpdateReadingRequest_Progress interface{ isUpdateReadingRequest_Progress() }
+
+type UpdateReadingRequest_Page struct {
+	Page int32 `protobuf:"varint,3,opt,name=page,oneof"`
+}
+type UpdateReadingRequest_PositionSeconds struct {
+	PositionSeconds int32 `protobuf:"varint,4,opt,name=position_seconds,json=positionSeconds,oneof"`
+}
+
+func (*UpdateReadingRequest_Page) isUpdateReadingRequest_Progress()            {}
+func (*UpdateReadingRequest_PositionSeconds) isUpdateReadingRequest_Progress() {}
+
+func (m *UpdateReadingRequest) GetProgress() isUpdateReadingRequest_Progress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+func (m *UpdateReadingRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *UpdateReadingRequest) GetShelf() ReadingShelf {
+	if m != nil {
+		return m.Shelf
+	}
+	return ReadingShelf_WANT_TO_READ
+}
+
+func (m *UpdateReadingRequest) GetPage() int32 {
+	if x, ok := m.GetProgress().(*UpdateReadingRequest_Page); ok {
+		return x.Page
+	}
+	return 0
+}
+
+func (m *UpdateReadingRequest) GetPositionSeconds() int32 {
+	if x, ok := m.GetProgress().(*UpdateReadingRequest_PositionSeconds); ok {
+		return x.PositionSeconds
+	}
+	return 0
+}
+
+func (m *UpdateReadingRequest) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*UpdateReadingRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _UpdateReadingRequest_OneofMarshaler, _UpdateReadingRequest_OneofUnmarshaler, _UpdateReadingRequest_OneofSizer, []interface{}{
+		(*UpdateReadingRequest_Page)(nil),
+		(*UpdateReadingRequest_PositionSeconds)(nil),
+	}
+}
+
+func _UpdateReadingRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*UpdateReadingRequest)
+	// progress
+	switch x := m.Progress.(type) {
+	case *UpdateReadingRequest_Page:
+		b.EncodeVarint(3<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Page))
+	case *UpdateReadingRequest_PositionSeconds:
+		b.EncodeVarint(4<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.PositionSeconds))
+	case nil:
+	default:
+		return fmt.Errorf("UpdateReadingRequest.Progress has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _UpdateReadingRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*UpdateReadingRequest)
+	switch tag {
+	case 3: // progress.page
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Progress = &UpdateReadingRequest_Page{int32(x)}
+		return true, err
+	case 4: // progress.position_seconds
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Progress = &UpdateReadingRequest_PositionSeconds{int32(x)}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _UpdateReadingRequest_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*UpdateReadingRequest)
+	// progress
+	switch x := m.Progress.(type) {
+	case *UpdateReadingRequest_Page:
+		n += proto.SizeVarint(3<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Page))
+	case *UpdateReadingRequest_PositionSeconds:
+		n += proto.SizeVarint(4<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.PositionSeconds))
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// RemoveReadingRequest is the input to the RemoveReading method.
+type RemoveReadingRequest struct {
+	// Isbn is the ISBN of the book.
+	Isbn int64 `protobuf:"varint,1,opt,name=isbn" json:"isbn,omitempty"`
+	// UpdatedAt is when the book was removed on the device of
+	// the user. If unset, the time of the server is used.
+	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+}
+
+func (m *RemoveReadingRequest) Reset()                    { *m = RemoveReadingRequest{} }
+func (m *RemoveReadingRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveReadingRequest) ProtoMessage()               {}
+func (*RemoveReadingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *RemoveReadingRequest) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *RemoveReadingRequest) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+// ListReadingRequest is the input to the ListReading method.
+type ListReadingRequest struct {
+	// Shelves are the reading lists to list. If empty, all are.
+	Shelves []ReadingShelf `protobuf:"varint,1,rep,packed,name=shelves,enum=library.ReadingShelf" json:"shelves,omitempty"`
+	// AfterSequence only lists the entries changed after the
+	// change with that Sequence, including the books removed.
+	// Devices send the largest Sequence of the entries they last
+	// listed. If 0, all entries but the books removed are listed.
+	AfterSequence int64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence" json:"after_sequence,omitempty"`
+}
+
+func (m *ListReadingRequest) Reset()                    { *m = ListReadingRequest{} }
+func (m *ListReadingRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListReadingRequest) ProtoMessage()               {}
+func (*ListReadingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *ListReadingRequest) GetShelves() []ReadingShelf {
+	if m != nil {
+		return m.Shelves
+	}
+	return nil
+}
+
+func (m *ListReadingRequest) GetAfterSequence() int64 {
+	if m != nil {
+		return m.AfterSequence
+	}
+	return 0
+}
+
+// ListReadingResponse is the output of the ListReading method.
+type ListReadingResponse struct {
+	// Entries are the entries, most recently updated first.
+	Entries []*ReadingEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *ListReadingResponse) Reset()                    { *m = ListReadingResponse{} }
+func (m *ListReadingResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListReadingResponse) ProtoMessage()               {}
+func (*ListReadingResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *ListReadingResponse) GetEntries() []*ReadingEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// ListReviewsResponse is the output of the ListReviews method.
+type ListReviewsResponse struct {
+	Reviews []*Review `protobuf:"bytes,1,rep,name=reviews" json:"reviews,omitempty"`
+	// NextPageToken lists the next page of reviews,
+	// or is empty if this is the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken" json:"next_page_token,omitempty"`
+}
+
+func (m *ListReviewsResponse) Reset()                    { *m = ListReviewsResponse{} }
+func (m *ListReviewsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListReviewsResponse) ProtoMessage()               {}
+func (*ListReviewsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *ListReviewsResponse) GetReviews() []*Review {
+	if m != nil {
+		return m.Reviews
+	}
+	return nil
+}
+
+func (m *ListReviewsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// BookMessage is used to discuss books
+type BookMessage struct {
+	// Types that are valid to be assigned to Content:
+	//	*BookMessage_Name
+	//	*BookMessage_Message
+	Content isBookMessage_Content `protobuf_oneof:"content"`
+}
+
+func (m *BookMessage) Reset()                    { *m = BookMessage{} }
+func (m *BookMessage) String() string            { return proto.CompactTextString(m) }
+func (*BookMessage) ProtoMessage()               {}
+func (*BookMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+type isBookMessage_Content interface{ isBookMessage_Content() }
+
+type BookMessage_Name struct {
+	Name string `protobuf:"bytes,1,opt,name=name,oneof"`
+}
+type BookMessage_Message struct {
+	Message string `protobuf:"bytes,2,opt,name=message,oneof"`
+}
+
+func (*BookMessage_Name) isBookMessage_Content()    {}
+func (*BookMessage_Message) isBookMessage_Content() {}
+
+func (m *BookMessage) GetContent() isBookMessage_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *BookMessage) GetName() string {
+	if x, ok := m.GetContent().(*BookMessage_Name); ok {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *BookMessage) GetMessage() string {
+	if x, ok := m.GetContent().(*BookMessage_Message); ok {
+		return x.Message
+	}
+	return ""
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*BookMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _BookMessage_OneofMarshaler, _BookMessage_OneofUnmarshaler, _BookMessage_OneofSizer, []interface{}{
+		(*BookMessage_Name)(nil),
+		(*BookMessage_Message)(nil),
+	}
+}
+
+func _BookMessage_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*BookMessage)
+	// content
+	switch x := m.Content.(type) {
+	case *BookMessage_Name:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.Name)
+	case *BookMessage_Message:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.Message)
+	case nil:
+	default:
+		return fmt.Errorf("BookMessage.Content has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _BookMessage_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*BookMessage)
+	switch tag {
+	case 1: // content.name
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Content = &BookMessage_Name{x}
+		return true, err
+	case 2: // content.message
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Content = &BookMessage_Message{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _BookMessage_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*BookMessage)
+	// content
+	switch x := m.Content.(type) {
+	case *BookMessage_Name:
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.Name)))
+		n += len(x.Name)
+	case *BookMessage_Message:
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.Message)))
+		n += len(x.Message)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// BookResponse is used to discuss books
+type BookResponse struct {
+	// Message is a message from a user.
+	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *BookResponse) Reset()                    { *m = BookResponse{} }
+func (m *BookResponse) String() string            { return proto.CompactTextString(m) }
+func (*BookResponse) ProtoMessage()               {}
+func (*BookResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *BookResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// ImportOptions configures an import of books.
+type ImportOptions struct {
+	// DryRun validates the books without importing them.
+	DryRun bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun" json:"dry_run,omitempty"`
+}
+
+func (m *ImportOptions) Reset()                    { *m = ImportOptions{} }
+func (m *ImportOptions) String() string            { return proto.CompactTextString(m) }
+func (*ImportOptions) ProtoMessage()               {}
+func (*ImportOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *ImportOptions) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+// ImportBooksRequest is the input to the ImportBooks method.
+type ImportBooksRequest struct {
+	// Types that are valid to be assigned to Content:
+	//	*ImportBooksRequest_Options
+	//	*ImportBooksRequest_Book
+	Content isImportBooksRequest_Content `protobuf_oneof:"content"`
+}
+
+func (m *ImportBooksRequest) Reset()                    { *m = ImportBooksRequest{} }
+func (m *ImportBooksRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportBooksRequest) ProtoMessage()               {}
+func (*ImportBooksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+type isImportBooksRequest_Content interface{ isImportBooksRequest_Content() }
+
+type ImportBooksRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,oneof"`
+}
+type ImportBooksRequest_Book struct {
+	Book *Book `protobuf:"bytes,2,opt,name=book,oneof"`
+}
+
+func (*ImportBooksRequest_Options) isImportBooksRequest_Content() {}
+func (*ImportBooksRequest_Book) isImportBooksRequest_Content()    {}
+
+func (m *ImportBooksRequest) GetContent() isImportBooksRequest_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *ImportBooksRequest) GetOptions() *ImportOptions {
+	if x, ok := m.GetContent().(*ImportBooksRequest_Options); ok {
+		return x.Options
+	}
+	return nil
+}
+
+func (m *ImportBooksRequest) GetBook() *Book {
+	if x, ok := m.GetContent().(*ImportBooksRequest_Book); ok {
+		return x.Book
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ImportBooksRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ImportBooksRequest_OneofMarshaler, _ImportBooksRequest_OneofUnmarshaler, _ImportBooksRequest_OneofSizer, []interface{}{
+		(*ImportBooksRequest_Options)(nil),
+		(*ImportBooksRequest_Book)(nil),
+	}
+}
+
+func _ImportBooksRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ImportBooksRequest)
+	// content
+	switch x := m.Content.(type) {
+	case *ImportBooksRequest_Options:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Options); err != nil {
+			return err
+		}
+	case *ImportBooksRequest_Book:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Book); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ImportBooksRequest.Content has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ImportBooksRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ImportBooksRequest)
+	switch tag {
+	case 1: // content.options
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ImportOptions)
+		err := b.DecodeMessage(msg)
+		m.Content = &ImportBooksRequest_Options{msg}
+		return true, err
+	case 2: // content.book
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Book)
+		err := b.DecodeMessage(msg)
+		m.Content = &ImportBooksRequest_Book{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ImportBooksRequest_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ImportBooksRequest)
+	// content
+	switch x := m.Content.(type) {
+	case *ImportBooksRequest_Options:
+		s := proto.Size(x.Options)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ImportBooksRequest_Book:
+		s := proto.Size(x.Book)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// ImportError describes a book that could not be imported.
+type ImportError struct {
+	// Index is the index of the book among
+	// the books sent, starting from 0.
+	Index int32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	// Isbn is the ISBN of the book.
+	Isbn int64 `protobuf:"varint,2,opt,name=isbn" json:"isbn,omitempty"`
+	// Message describes why the book could not be imported.
+	Message string `protobuf:"bytes,3,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *ImportError) Reset()                    { *m = ImportError{} }
+func (m *ImportError) String() string            { return proto.CompactTextString(m) }
+func (*ImportError) ProtoMessage()               {}
+func (*ImportError) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *ImportError) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ImportError) GetIsbn() int64 {
+	if m != nil {
+		return m.Isbn
+	}
+	return 0
+}
+
+func (m *ImportError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// ImportBooksResponse is the output of the ImportBooks method.
+type ImportBooksResponse struct {
+	// Created is the number of books added to the library.
+	Created int32 `protobuf:"varint,1,opt,name=created" json:"created,omitempty"`
+	// Updated is the number of books in the library
+	// replaced by a book with the same ISBN.
+	Updated int32 `protobuf:"varint,2,opt,name=updated" json:"updated,omitempty"`
+	// Errors describes the books that could not be imported.
+	Errors []*ImportError `protobuf:"bytes,3,rep,name=errors" json:"errors,omitempty"`
+}
+
+func (m *ImportBooksResponse) Reset()                    { *m = ImportBooksResponse{} }
+func (m *ImportBooksResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportBooksResponse) ProtoMessage()               {}
+func (*ImportBooksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *ImportBooksResponse) GetCreated() int32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ImportBooksResponse) GetUpdated() int32 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *ImportBooksResponse) GetErrors() []*ImportError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+// ExportBooksRequest is the input to the ExportBooks method.
+type ExportBooksRequest struct {
+	// ResumeToken is the resume token of the last
+	// message received by an interrupted export,
+	// to continue it from the next book.
+	// If empty, the export starts from the first book.
+	ResumeToken string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken" json:"resume_token,omitempty"`
+}
+
+func (m *ExportBooksRequest) Reset()                    { *m = ExportBooksRequest{} }
+func (m *ExportBooksRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportBooksRequest) ProtoMessage()               {}
+func (*ExportBooksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *ExportBooksRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+// ExportBooksResponse is the output of the ExportBooks method.
+type ExportBooksResponse struct {
+	// Book is a book in the library.
+	Book *Book `protobuf:"bytes,1,opt,name=book" json:"book,omitempty"`
+	// ResumeToken continues the export after this book.
+	ResumeToken string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken" json:"resume_token,omitempty"`
+}
+
+func (m *ExportBooksResponse) Reset()                    { *m = ExportBooksResponse{} }
+func (m *ExportBooksResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportBooksResponse) ProtoMessage()               {}
+func (*ExportBooksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *ExportBooksResponse) GetBook() *Book {
+	if m != nil {
+		return m.Book
+	}
+	return nil
+}
+
+func (m *ExportBooksResponse) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+// WatchBooksRequest is the input to the WatchBooks method.
+type WatchBooksRequest struct {
+	// AuthorPrefix is the prefix with which to match
+	// against the author of the books to watch.
+	AuthorPrefix string `protobuf:"bytes,1,opt,name=author_prefix,json=authorPrefix" json:"author_prefix,omitempty"`
+	// AfterSequence resumes watching after the event with
+	// this sequence number. If 0, only changes made after
+	// the request are sent.
+	AfterSequence int64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence" json:"after_sequence,omitempty"`
+	// IncludeExisting sends an EXISTING event for each
+	// book matching the author prefix, before any changes.
+	IncludeExisting bool `protobuf:"varint,3,opt,name=include_existing,json=includeExisting" json:"include_existing,omitempty"`
+	// AuthorId, if set, only matches books
+	// with an author with this Id.
+	AuthorId string `protobuf:"bytes,4,opt,name=author_id,json=authorId" json:"author_id,omitempty"`
+}
+
+func (m *WatchBooksRequest) Reset()                    { *m = WatchBooksRequest{} }
+func (m *WatchBooksRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchBooksRequest) ProtoMessage()               {}
+func (*WatchBooksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *WatchBooksRequest) GetAuthorPrefix() string {
+	if m != nil {
+		return m.AuthorPrefix
+	}
+	return ""
+}
+
+func (m *WatchBooksRequest) GetAfterSequence() int64 {
+	if m != nil {
+		return m.AfterSequence
+	}
+	return 0
+}
+
+func (m *WatchBooksRequest) GetIncludeExisting() bool {
+	if m != nil {
+		return m.IncludeExisting
+	}
+	return false
+}
+
+func (m *WatchBooksRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+// BookEvent describes a change to the books in the library.
+type BookEvent struct {
+	// Type is the type of the event.
+	Type BookEvent_Type `protobuf:"varint,1,opt,name=type,enum=library.BookEvent_Type" json:"type,omitempty"`
+	// Sequence is the sequence number of the change,
+	// with which watching can be resumed after it.
+	// Existing events have the sequence number of the
+	// last change made before the watch started.
+	Sequence int64 `protobuf:"varint,2,opt,name=sequence" json:"sequence,omitempty"`
+	// Book is the book after the change, or
+	// before the change for deleted events, with
+	// its availability and rating when sent.
+	Book *Book `protobuf:"bytes,3,opt,name=book" json:"book,omitempty"`
+}
+
+func (m *BookEvent) Reset()                    { *m = BookEvent{} }
+func (m *BookEvent) String() string            { return proto.CompactTextString(m) }
+func (*BookEvent) ProtoMessage()               {}
+func (*BookEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *BookEvent) GetType() BookEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return BookEvent_EXISTING
+}
+
+func (m *BookEvent) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *BookEvent) GetBook() *Book {
+	if m != nil {
+		return m.Book
+	}
+	return nil
+}
+
+// User is the public profile of a user account.
+type User struct {
+	// Name identifies the user, and is used to log in.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// DisplayName is the name shown to other users, such as
+	// in BookChat. No two users have the same display name,
+	// ignoring case.
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName" json:"display_name,omitempty"`
+	Bio         string `protobuf:"bytes,3,opt,name=bio" json:"bio,omitempty"`
+	// FavouriteSubjects are subjects of books the user likes.
+	FavouriteSubjects []string `protobuf:"bytes,4,rep,name=favourite_subjects,json=favouriteSubjects" json:"favourite_subjects,omitempty"`
+	// Created is when the user registered.
+	Created *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=created" json:"created,omitempty"`
+}
+
+func (m *User) Reset()                    { *m = User{} }
+func (m *User) String() string            { return proto.CompactTextString(m) }
+func (*User) ProtoMessage()               {}
+func (*User) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *User) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *User) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *User) GetBio() string {
+	if m != nil {
+		return m.Bio
+	}
+	return ""
+}
+
+func (m *User) GetFavouriteSubjects() []string {
+	if m != nil {
+		return m.FavouriteSubjects
+	}
+	return nil
+}
+
+func (m *User) GetCreated() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+// RegisterRequest is the input to the Register method.
+type RegisterRequest struct {
+	// Name is the name of the user, 3 to 32 lower case
+	// letters, digits, dashes or underscores.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Password is the password of the user,
+	// at least 8 characters and at most 72 bytes.
+	Password string `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+	// DisplayName is the display name of the user,
+	// or the Name of the user if not set.
+	DisplayName string `protobuf:"bytes,3,opt,name=display_name,json=displayName" json:"display_name,omitempty"`
+}
+
+func (m *RegisterRequest) Reset()                    { *m = RegisterRequest{} }
+func (m *RegisterRequest) String() string            { return proto.CompactTextString(m) }
+func (*RegisterRequest) ProtoMessage()               {}
+func (*RegisterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *RegisterRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RegisterRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+func (m *RegisterRequest) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+// LoginRequest is the input to the Login method.
+type LoginRequest struct {
+	Name     string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+}
+
+func (m *LoginRequest) Reset()                    { *m = LoginRequest{} }
+func (m *LoginRequest) String() string            { return proto.CompactTextString(m) }
+func (*LoginRequest) ProtoMessage()               {}
+func (*LoginRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+func (m *LoginRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LoginRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+// LoginResponse is the output of the Login method.
+type LoginResponse struct {
+	User *User `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+	// Session identifies the session of the user in the
+	// "session" request metadata of the calls made by them.
+	Session string `protobuf:"bytes,2,opt,name=session" json:"session,omitempty"`
+	// Expires is when the session expires.
+	Expires *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=expires" json:"expires,omitempty"`
+}
+
+func (m *LoginResponse) Reset()                    { *m = LoginResponse{} }
+func (m *LoginResponse) String() string            { return proto.CompactTextString(m) }
+func (*LoginResponse) ProtoMessage()               {}
+func (*LoginResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+func (m *LoginResponse) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *LoginResponse) GetSession() string {
+	if m != nil {
+		return m.Session
+	}
+	return ""
+}
+
+func (m *LoginResponse) GetExpires() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+// GetUserRequest is the input to the GetUser method.
+type GetUserRequest struct {
+	// Name is the name of the user.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *GetUserRequest) Reset()                    { *m = GetUserRequest{} }
+func (m *GetUserRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetUserRequest) ProtoMessage()               {}
+func (*GetUserRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *GetUserRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// UpdateProfileRequest is the input to the UpdateProfile method.
+type UpdateProfileRequest struct {
+	// DisplayName is the new display name, or
+	// the Name of the user if not set.
+	DisplayName       string   `protobuf:"bytes,1,opt,name=display_name,json=displayName" json:"display_name,omitempty"`
+	Bio               string   `protobuf:"bytes,2,opt,name=bio" json:"bio,omitempty"`
+	FavouriteSubjects []string `protobuf:"bytes,3,rep,name=favourite_subjects,json=favouriteSubjects" json:"favourite_subjects,omitempty"`
+}
+
+func (m *UpdateProfileRequest) Reset()                    { *m = UpdateProfileRequest{} }
+func (m *UpdateProfileRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateProfileRequest) ProtoMessage()               {}
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *UpdateProfileRequest) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *UpdateProfileRequest) GetBio() string {
+	if m != nil {
+		return m.Bio
+	}
+	return ""
+}
+
+func (m *UpdateProfileRequest) GetFavouriteSubjects() []string {
+	if m != nil {
+		return m.FavouriteSubjects
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Publisher)(nil), "library.Publisher")
+	proto.RegisterType((*Author)(nil), "library.Author")
+	proto.RegisterType((*Contributor)(nil), "library.Contributor")
+	proto.RegisterType((*Series)(nil), "library.Series")
+	proto.RegisterType((*Book)(nil), "library.Book")
+	proto.RegisterType((*Branch)(nil), "library.Branch")
+	proto.RegisterType((*Availability)(nil), "library.Availability")
+	proto.RegisterType((*BranchAvailability)(nil), "library.BranchAvailability")
+	proto.RegisterType((*GetBookRequest)(nil), "library.GetBookRequest")
+	proto.RegisterType((*QueryBooksRequest)(nil), "library.QueryBooksRequest")
+	proto.RegisterType((*GetAuthorRequest)(nil), "library.GetAuthorRequest")
+	proto.RegisterType((*ListAuthorsRequest)(nil), "library.ListAuthorsRequest")
+	proto.RegisterType((*ListAuthorsResponse)(nil), "library.ListAuthorsResponse")
+	proto.RegisterType((*GetPublisherRequest)(nil), "library.GetPublisherRequest")
+	proto.RegisterType((*Collection)(nil), "library.Collection")
+	proto.RegisterType((*CollectionError)(nil), "library.CollectionError")
+	proto.RegisterType((*GetCollectionRequest)(nil), "library.GetCollectionRequest")
+	proto.RegisterType((*ListCollectionsRequest)(nil), "library.ListCollectionsRequest")
+	proto.RegisterType((*ListCollectionsResponse)(nil), "library.ListCollectionsResponse")
+	proto.RegisterType((*AddToCollectionRequest)(nil), "library.AddToCollectionRequest")
+	proto.RegisterType((*RemoveFromCollectionRequest)(nil), "library.RemoveFromCollectionRequest")
+	proto.RegisterType((*DeleteCollectionRequest)(nil), "library.DeleteCollectionRequest")
+	proto.RegisterType((*Copy)(nil), "library.Copy")
+	proto.RegisterType((*Loan)(nil), "library.Loan")
+	proto.RegisterType((*ListCopiesRequest)(nil), "library.ListCopiesRequest")
+	proto.RegisterType((*ListCopiesResponse)(nil), "library.ListCopiesResponse")
+	proto.RegisterType((*AddCopyRequest)(nil), "library.AddCopyRequest")
+	proto.RegisterType((*CheckOutRequest)(nil), "library.CheckOutRequest")
+	proto.RegisterType((*ReturnRequest)(nil), "library.ReturnRequest")
+	proto.RegisterType((*RenewRequest)(nil), "library.RenewRequest")
+	proto.RegisterType((*ListLoansRequest)(nil), "library.ListLoansRequest")
+	proto.RegisterType((*ListLoansResponse)(nil), "library.ListLoansResponse")
+	proto.RegisterType((*Hold)(nil), "library.Hold")
+	proto.RegisterType((*PlaceHoldRequest)(nil), "library.PlaceHoldRequest")
+	proto.RegisterType((*CancelHoldRequest)(nil), "library.CancelHoldRequest")
+	proto.RegisterType((*ListHoldsRequest)(nil), "library.ListHoldsRequest")
+	proto.RegisterType((*ListHoldsResponse)(nil), "library.ListHoldsResponse")
+	proto.RegisterType((*WatchMyHoldsRequest)(nil), "library.WatchMyHoldsRequest")
+	proto.RegisterType((*Job)(nil), "library.Job")
+	proto.RegisterType((*ListBranchesRequest)(nil), "library.ListBranchesRequest")
+	proto.RegisterType((*ListBranchesResponse)(nil), "library.ListBranchesResponse")
+	proto.RegisterType((*ListJobsRequest)(nil), "library.ListJobsRequest")
+	proto.RegisterType((*ListJobsResponse)(nil), "library.ListJobsResponse")
+	proto.RegisterType((*Review)(nil), "library.Review")
+	proto.RegisterType((*SubmitReviewRequest)(nil), "library.SubmitReviewRequest")
+	proto.RegisterType((*UpdateReviewRequest)(nil), "library.UpdateReviewRequest")
+	proto.RegisterType((*DeleteReviewRequest)(nil), "library.DeleteReviewRequest")
+	proto.RegisterType((*MarkReviewHelpfulRequest)(nil), "library.MarkReviewHelpfulRequest")
+	proto.RegisterType((*ListReviewsRequest)(nil), "library.ListReviewsRequest")
+	proto.RegisterType((*RecommendBooksRequest)(nil), "library.RecommendBooksRequest")
+	proto.RegisterType((*Recommendation)(nil), "library.Recommendation")
+	proto.RegisterType((*RecommendBooksResponse)(nil), "library.RecommendBooksResponse")
+	proto.RegisterType((*ReadingEntry)(nil), "library.ReadingEntry")
+	proto.RegisterType((*UpdateReadingRequest)(nil), "library.UpdateReadingRequest")
+	proto.RegisterType((*RemoveReadingRequest)(nil), "library.RemoveReadingRequest")
+	proto.RegisterType((*ListReadingRequest)(nil), "library.ListReadingRequest")
+	proto.RegisterType((*ListReadingResponse)(nil), "library.ListReadingResponse")
+	proto.RegisterType((*ListReviewsResponse)(nil), "library.ListReviewsResponse")
+	proto.RegisterType((*BookMessage)(nil), "library.BookMessage")
+	proto.RegisterType((*BookResponse)(nil), "library.BookResponse")
+	proto.RegisterType((*ImportOptions)(nil), "library.ImportOptions")
+	proto.RegisterType((*ImportBooksRequest)(nil), "library.ImportBooksRequest")
+	proto.RegisterType((*ImportError)(nil), "library.ImportError")
+	proto.RegisterType((*ImportBooksResponse)(nil), "library.ImportBooksResponse")
+	proto.RegisterType((*ExportBooksRequest)(nil), "library.ExportBooksRequest")
+	proto.RegisterType((*ExportBooksResponse)(nil), "library.ExportBooksResponse")
+	proto.RegisterType((*WatchBooksRequest)(nil), "library.WatchBooksRequest")
+	proto.RegisterType((*BookEvent)(nil), "library.BookEvent")
+	proto.RegisterType((*User)(nil), "library.User")
+	proto.RegisterType((*RegisterRequest)(nil), "library.RegisterRequest")
+	proto.RegisterType((*LoginRequest)(nil), "library.LoginRequest")
+	proto.RegisterType((*LoginResponse)(nil), "library.LoginResponse")
+	proto.RegisterType((*GetUserRequest)(nil), "library.GetUserRequest")
+	proto.RegisterType((*UpdateProfileRequest)(nil), "library.UpdateProfileRequest")
+	proto.RegisterEnum("library.BookType", BookType_name, BookType_value)
+	proto.RegisterEnum("library.ContributorRole", ContributorRole_name, ContributorRole_value)
+	proto.RegisterEnum("library.Visibility", Visibility_name, Visibility_value)
+	proto.RegisterEnum("library.ReviewOrder", ReviewOrder_name, ReviewOrder_value)
+	proto.RegisterEnum("library.ReadingShelf", ReadingShelf_name, ReadingShelf_value)
+	proto.RegisterEnum("library.CollectionError_Reason", CollectionError_Reason_name, CollectionError_Reason_value)
+	proto.RegisterEnum("library.Hold_State", Hold_State_name, Hold_State_value)
+	proto.RegisterEnum("library.Recommendation_Reason", Recommendation_Reason_name, Recommendation_Reason_value)
+	proto.RegisterEnum("library.BookEvent_Type", BookEvent_Type_name, BookEvent_Type_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for BookService service
+
+type BookServiceClient interface {
+	// GetBook returns a Book from the library
+	// that matches the ISBN provided, if found.
+	// Otherwise it returns a NotFound error.
+	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*Book, error)
+	// QueryBooks returns all Books whos author
+	// matches the author prefix provided, as a stream
+	// of Books. If the branch provided does not exist,
+	// it returns a NotFound error.
+	QueryBooks(ctx context.Context, in *QueryBooksRequest, opts ...grpc.CallOption) (BookService_QueryBooksClient, error)
+	// MakeCollection takes a stream of books and returns a Book collection,
+	// which is stored and owned by the user making the call. Only the ISBNs
+	// of the books sent are used, the collection holds the books in the
+	// library with those ISBNs. Books that are not in the library or that
+	// are sent more than once are described by the errors of the response.
+	// If the collection would have more books than the server allows, it
+	// returns a ResourceExhausted error. The name and
+	// visibility of the collection are taken from the "collection-name"
+	// and "collection-visibility" request metadata. Collections are
+	// private by default, or unlisted if made without a session.
+	MakeCollection(ctx context.Context, opts ...grpc.CallOption) (BookService_MakeCollectionClient, error)
+	// BookChat allows discussion about books
+	BookChat(ctx context.Context, opts ...grpc.CallOption) (BookService_BookChatClient, error)
+	// ImportBooks takes a stream of books and adds them to the library,
+	// replacing any books with the same ISBN. Books that are not valid
+	// are not imported, and are described in the response.
+	// Nothing is imported if the import is a dry run. It is meant for
+	// administrators, who send the admin token of the server as the
+	// "admin-token" request metadata. Without it, it returns a
+	// PermissionDenied error. Imports of more books than the server allows
+	// return a ResourceExhausted error, and import nothing.
+	ImportBooks(ctx context.Context, opts ...grpc.CallOption) (BookService_ImportBooksClient, error)
+	// ExportBooks returns all Books in the library, ordered by ISBN,
+	// as a stream. Each message has a resume token, with which an
+	// interrupted export can be continued where it left off.
+	ExportBooks(ctx context.Context, in *ExportBooksRequest, opts ...grpc.CallOption) (BookService_ExportBooksClient, error)
+	// WatchBooks returns the changes made to the Books whose author
+	// matches the author prefix provided, as a stream of events.
+	// If the changes to resume after are no longer available, it
+	// returns an OutOfRange error. If the client falls too far behind,
+	// it returns a ResourceExhausted error, after which it can resume.
+	WatchBooks(ctx context.Context, in *WatchBooksRequest, opts ...grpc.CallOption) (BookService_WatchBooksClient, error)
+	// GetAuthor returns the Author with the Id provided, if found.
+	// Otherwise it returns a NotFound error.
+	GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpc.CallOption) (*Author, error)
+	// ListAuthors returns the Authors whose name
+	// matches the name prefix provided.
+	ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error)
+	// GetPublisher returns the Publisher with the Id provided, if found.
+	// Otherwise it returns a NotFound error.
+	GetPublisher(ctx context.Context, in *GetPublisherRequest, opts ...grpc.CallOption) (*Publisher, error)
+	// GetCollection returns the Collection with the Id provided, if it
+	// can be seen by the user. Otherwise it returns a NotFound error.
+	GetCollection(ctx context.Context, in *GetCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	// ListCollections returns the Collections owned by the
+	// user and the public Collections of other users.
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	// AddToCollection adds the Books with the ISBNs provided to a
+	// Collection owned by the user, and returns the Collection.
+	// Books already in the Collection are not added again. If the
+	// Collection would have more books than the server allows,
+	// it returns a ResourceExhausted error.
+	AddToCollection(ctx context.Context, in *AddToCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	// RemoveFromCollection removes the Books with the ISBNs provided
+	// from a Collection owned by the user, and returns the Collection.
+	RemoveFromCollection(ctx context.Context, in *RemoveFromCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	// DeleteCollection deletes a Collection owned by the user,
+	// and returns it.
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	// ListCopies returns the Copies of the Book with the ISBN provided.
+	ListCopies(ctx context.Context, in *ListCopiesRequest, opts ...grpc.CallOption) (*ListCopiesResponse, error)
+	// ListBranches returns the Branches of the library.
+	ListBranches(ctx context.Context, in *ListBranchesRequest, opts ...grpc.CallOption) (*ListBranchesResponse, error)
+	// AddCopy adds a Copy of the Book with the ISBN provided at the
+	// Branch provided, and returns it. If there is no such Book or
+	// Branch, it returns a NotFound error. It is meant for administrators,
+	// who send the admin token of the server as the "admin-token" request
+	// metadata. Without it, it returns a PermissionDenied error.
+	AddCopy(ctx context.Context, in *AddCopyRequest, opts ...grpc.CallOption) (*Copy, error)
+	// CheckOut lends a Copy to the user, and returns the Loan. The Loan
+	// is due after a period depending on the BookType of the Book. If
+	// the Copy is on loan, or no Copy of the Book is available, it
+	// returns a FailedPrecondition error.
+	CheckOut(ctx context.Context, in *CheckOutRequest, opts ...grpc.CallOption) (*Loan, error)
+	// Return ends the Loan of a Copy, and returns the Loan. If
+	// the Copy is not on loan, it returns a FailedPrecondition error.
+	// Loans are returned by their borrower, or by administrators sending
+	// the admin token as the "admin-token" request metadata. Returning
+	// the Loan of another user returns a PermissionDenied error.
+	Return(ctx context.Context, in *ReturnRequest, opts ...grpc.CallOption) (*Loan, error)
+	// Renew extends a Loan of the user, and returns the Loan. If the Loan
+	// has ended or has been renewed too many times, it returns a
+	// FailedPrecondition error.
+	Renew(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*Loan, error)
+	// ListLoans returns the Loans of the user.
+	ListLoans(ctx context.Context, in *ListLoansRequest, opts ...grpc.CallOption) (*ListLoansResponse, error)
+	// PlaceHold queues the user for a Copy of the Book with the ISBN
+	// provided, and returns the Hold. Copies returned are kept for
+	// the first Hold in the queue, which is then READY, and expires
+	// if the Copy is not checked out in time. If the user already has
+	// a Hold on the Book, it returns an AlreadyExists error.
+	PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpc.CallOption) (*Hold, error)
+	// CancelHold cancels a Hold of the user, and returns it.
+	CancelHold(ctx context.Context, in *CancelHoldRequest, opts ...grpc.CallOption) (*Hold, error)
+	// ListHolds returns the Holds of the user.
+	ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpc.CallOption) (*ListHoldsResponse, error)
+	// WatchMyHolds streams the WAITING and READY Holds of the user,
+	// followed by the Holds of the user as they change, for example
+	// when a Copy is ready to be picked up.
+	WatchMyHolds(ctx context.Context, in *WatchMyHoldsRequest, opts ...grpc.CallOption) (BookService_WatchMyHoldsClient, error)
+	// ListJobs returns the status of the background jobs of the server, which
+	// compute fines and recommendations, expire holds and purge the chat history.
+	// It is meant for administrators, who send the admin token of the server as
+	// the "admin-token" request metadata. Without it, it returns a
+	// PermissionDenied error.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// SubmitReview adds the review of a Book by the user, and returns
+	// it. Users can review a Book once, after which they edit their
+	// Review with UpdateReview. If there is no such Book, it returns a
+	// NotFound error.
+	SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpc.CallOption) (*Review, error)
+	// UpdateReview changes the rating and text of a Review by the
+	// user, and returns it. Reviews by other users cannot be changed.
+	UpdateReview(ctx context.Context, in *UpdateReviewRequest, opts ...grpc.CallOption) (*Review, error)
+	// DeleteReview deletes a Review by the user, and returns it.
+	DeleteReview(ctx context.Context, in *DeleteReviewRequest, opts ...grpc.CallOption) (*Review, error)
+	// MarkReviewHelpful records that the user found a Review helpful,
+	// and returns it. Users cannot vote for their own reviews, or
+	// vote for a Review twice.
+	MarkReviewHelpful(ctx context.Context, in *MarkReviewHelpfulRequest, opts ...grpc.CallOption) (*Review, error)
+	// ListReviews returns a page of the Reviews of a Book.
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error)
+	// RecommendBooks returns books liked by the readers who liked the Book
+	// with the ISBN provided, as found in their collections, loans and
+	// ratings by a background job. Books without enough such readers get
+	// books by the same authors or publisher. If there is no such Book,
+	// it returns a NotFound error.
+	RecommendBooks(ctx context.Context, in *RecommendBooksRequest, opts ...grpc.CallOption) (*RecommendBooksResponse, error)
+	// UpdateReading puts a Book on a reading list of the user, with their
+	// progress, and returns the entry. If the entry was changed after
+	// UpdatedAt, for example on another device, the change is ignored and
+	// the entry returned as it is. If there is no such Book, it returns a
+	// NotFound error.
+	UpdateReading(ctx context.Context, in *UpdateReadingRequest, opts ...grpc.CallOption) (*ReadingEntry, error)
+	// RemoveReading removes a Book from the reading lists of the user, and
+	// returns the entry removed. Like UpdateReading, it is ignored if the
+	// entry was changed after UpdatedAt. If the Book is not on the reading
+	// lists, it returns a NotFound error.
+	RemoveReading(ctx context.Context, in *RemoveReadingRequest, opts ...grpc.CallOption) (*ReadingEntry, error)
+	// ListReading lists the reading lists of the user.
+	ListReading(ctx context.Context, in *ListReadingRequest, opts ...grpc.CallOption) (*ListReadingResponse, error)
+}
+
+type bookServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBookServiceClient(cc *grpc.ClientConn) BookServiceClient {
+	return &bookServiceClient{cc}
+}
+
+func (c *bookServiceClient) GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*Book, error) {
+	out := new(Book)
+	err := grpc.Invoke(ctx, "/library.BookService/GetBook", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) QueryBooks(ctx context.Context, in *QueryBooksRequest, opts ...grpc.CallOption) (BookService_QueryBooksClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[0], c.cc, "/library.BookService/QueryBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceQueryBooksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BookService_QueryBooksClient interface {
+	Recv() (*Book, error)
+	grpc.ClientStream
+}
+
+type bookServiceQueryBooksClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceQueryBooksClient) Recv() (*Book, error) {
+	m := new(Book)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookServiceClient) MakeCollection(ctx context.Context, opts ...grpc.CallOption) (BookService_MakeCollectionClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[1], c.cc, "/library.BookService/MakeCollection", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceMakeCollectionClient{stream}
+	return x, nil
+}
+
+type BookService_MakeCollectionClient interface {
+	Send(*Book) error
+	CloseAndRecv() (*Collection, error)
+	grpc.ClientStream
+}
+
+type bookServiceMakeCollectionClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceMakeCollectionClient) Send(m *Book) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *bookServiceMakeCollectionClient) CloseAndRecv() (*Collection, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Collection)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookServiceClient) BookChat(ctx context.Context, opts ...grpc.CallOption) (BookService_BookChatClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[2], c.cc, "/library.BookService/BookChat", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceBookChatClient{stream}
+	return x, nil
+}
+
+type BookService_BookChatClient interface {
+	Send(*BookMessage) error
+	Recv() (*BookResponse, error)
+	grpc.ClientStream
+}
+
+type bookServiceBookChatClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceBookChatClient) Send(m *BookMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *bookServiceBookChatClient) Recv() (*BookResponse, error) {
+	m := new(BookResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookServiceClient) ImportBooks(ctx context.Context, opts ...grpc.CallOption) (BookService_ImportBooksClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[3], c.cc, "/library.BookService/ImportBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceImportBooksClient{stream}
+	return x, nil
+}
+
+type BookService_ImportBooksClient interface {
+	Send(*ImportBooksRequest) error
+	CloseAndRecv() (*ImportBooksResponse, error)
+	grpc.ClientStream
+}
+
+type bookServiceImportBooksClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceImportBooksClient) Send(m *ImportBooksRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *bookServiceImportBooksClient) CloseAndRecv() (*ImportBooksResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportBooksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookServiceClient) ExportBooks(ctx context.Context, in *ExportBooksRequest, opts ...grpc.CallOption) (BookService_ExportBooksClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[4], c.cc, "/library.BookService/ExportBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceExportBooksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BookService_ExportBooksClient interface {
+	Recv() (*ExportBooksResponse, error)
+	grpc.ClientStream
+}
+
+type bookServiceExportBooksClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceExportBooksClient) Recv() (*ExportBooksResponse, error) {
+	m := new(ExportBooksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookServiceClient) WatchBooks(ctx context.Context, in *WatchBooksRequest, opts ...grpc.CallOption) (BookService_WatchBooksClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[5], c.cc, "/library.BookService/WatchBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceWatchBooksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BookService_WatchBooksClient interface {
+	Recv() (*BookEvent, error)
+	grpc.ClientStream
+}
+
+type bookServiceWatchBooksClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceWatchBooksClient) Recv() (*BookEvent, error) {
+	m := new(BookEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookServiceClient) GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpc.CallOption) (*Author, error) {
+	out := new(Author)
+	err := grpc.Invoke(ctx, "/library.BookService/GetAuthor", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error) {
+	out := new(ListAuthorsResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/ListAuthors", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) GetPublisher(ctx context.Context, in *GetPublisherRequest, opts ...grpc.CallOption) (*Publisher, error) {
+	out := new(Publisher)
+	err := grpc.Invoke(ctx, "/library.BookService/GetPublisher", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) GetCollection(ctx context.Context, in *GetCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := grpc.Invoke(ctx, "/library.BookService/GetCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	out := new(ListCollectionsResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/ListCollections", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) AddToCollection(ctx context.Context, in *AddToCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := grpc.Invoke(ctx, "/library.BookService/AddToCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) RemoveFromCollection(ctx context.Context, in *RemoveFromCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := grpc.Invoke(ctx, "/library.BookService/RemoveFromCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := grpc.Invoke(ctx, "/library.BookService/DeleteCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListCopies(ctx context.Context, in *ListCopiesRequest, opts ...grpc.CallOption) (*ListCopiesResponse, error) {
+	out := new(ListCopiesResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/ListCopies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListBranches(ctx context.Context, in *ListBranchesRequest, opts ...grpc.CallOption) (*ListBranchesResponse, error) {
+	out := new(ListBranchesResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/ListBranches", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) AddCopy(ctx context.Context, in *AddCopyRequest, opts ...grpc.CallOption) (*Copy, error) {
+	out := new(Copy)
+	err := grpc.Invoke(ctx, "/library.BookService/AddCopy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) CheckOut(ctx context.Context, in *CheckOutRequest, opts ...grpc.CallOption) (*Loan, error) {
+	out := new(Loan)
+	err := grpc.Invoke(ctx, "/library.BookService/CheckOut", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) Return(ctx context.Context, in *ReturnRequest, opts ...grpc.CallOption) (*Loan, error) {
+	out := new(Loan)
+	err := grpc.Invoke(ctx, "/library.BookService/Return", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) Renew(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*Loan, error) {
+	out := new(Loan)
+	err := grpc.Invoke(ctx, "/library.BookService/Renew", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListLoans(ctx context.Context, in *ListLoansRequest, opts ...grpc.CallOption) (*ListLoansResponse, error) {
+	out := new(ListLoansResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/ListLoans", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpc.CallOption) (*Hold, error) {
+	out := new(Hold)
+	err := grpc.Invoke(ctx, "/library.BookService/PlaceHold", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) CancelHold(ctx context.Context, in *CancelHoldRequest, opts ...grpc.CallOption) (*Hold, error) {
+	out := new(Hold)
+	err := grpc.Invoke(ctx, "/library.BookService/CancelHold", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpc.CallOption) (*ListHoldsResponse, error) {
+	out := new(ListHoldsResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/ListHolds", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) WatchMyHolds(ctx context.Context, in *WatchMyHoldsRequest, opts ...grpc.CallOption) (BookService_WatchMyHoldsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[6], c.cc, "/library.BookService/WatchMyHolds", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceWatchMyHoldsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BookService_WatchMyHoldsClient interface {
+	Recv() (*Hold, error)
+	grpc.ClientStream
+}
+
+type bookServiceWatchMyHoldsClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceWatchMyHoldsClient) Recv() (*Hold, error) {
+	m := new(Hold)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/ListJobs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpc.CallOption) (*Review, error) {
+	out := new(Review)
+	err := grpc.Invoke(ctx, "/library.BookService/SubmitReview", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) UpdateReview(ctx context.Context, in *UpdateReviewRequest, opts ...grpc.CallOption) (*Review, error) {
+	out := new(Review)
+	err := grpc.Invoke(ctx, "/library.BookService/UpdateReview", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) DeleteReview(ctx context.Context, in *DeleteReviewRequest, opts ...grpc.CallOption) (*Review, error) {
+	out := new(Review)
+	err := grpc.Invoke(ctx, "/library.BookService/DeleteReview", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) MarkReviewHelpful(ctx context.Context, in *MarkReviewHelpfulRequest, opts ...grpc.CallOption) (*Review, error) {
+	out := new(Review)
+	err := grpc.Invoke(ctx, "/library.BookService/MarkReviewHelpful", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error) {
+	out := new(ListReviewsResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/ListReviews", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) RecommendBooks(ctx context.Context, in *RecommendBooksRequest, opts ...grpc.CallOption) (*RecommendBooksResponse, error) {
+	out := new(RecommendBooksResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/RecommendBooks", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) UpdateReading(ctx context.Context, in *UpdateReadingRequest, opts ...grpc.CallOption) (*ReadingEntry, error) {
+	out := new(ReadingEntry)
+	err := grpc.Invoke(ctx, "/library.BookService/UpdateReading", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) RemoveReading(ctx context.Context, in *RemoveReadingRequest, opts ...grpc.CallOption) (*ReadingEntry, error) {
+	out := new(ReadingEntry)
+	err := grpc.Invoke(ctx, "/library.BookService/RemoveReading", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListReading(ctx context.Context, in *ListReadingRequest, opts ...grpc.CallOption) (*ListReadingResponse, error) {
+	out := new(ListReadingResponse)
+	err := grpc.Invoke(ctx, "/library.BookService/ListReading", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for BookService service
+
+type BookServiceServer interface {
+	// GetBook returns a Book from the library
+	// that matches the ISBN provided, if found.
+	// Otherwise it returns a NotFound error.
+	GetBook(context.Context, *GetBookRequest) (*Book, error)
+	// QueryBooks returns all Books whos author
+	// matches the author prefix provided, as a stream
+	// of Books. If the branch provided does not exist,
+	// it returns a NotFound error.
+	QueryBooks(*QueryBooksRequest, BookService_QueryBooksServer) error
+	// MakeCollection takes a stream of books and returns a Book collection,
+	// which is stored and owned by the user making the call. Only the ISBNs
+	// of the books sent are used, the collection holds the books in the
+	// library with those ISBNs. Books that are not in the library or that
+	// are sent more than once are described by the errors of the response.
+	// If the collection would have more books than the server allows, it
+	// returns a ResourceExhausted error. The name and
+	// visibility of the collection are taken from the "collection-name"
+	// and "collection-visibility" request metadata. Collections are
+	// private by default, or unlisted if made without a session.
+	MakeCollection(BookService_MakeCollectionServer) error
+	// BookChat allows discussion about books
+	BookChat(BookService_BookChatServer) error
+	// ImportBooks takes a stream of books and adds them to the library,
+	// replacing any books with the same ISBN. Books that are not valid
+	// are not imported, and are described in the response.
+	// Nothing is imported if the import is a dry run. It is meant for
+	// administrators, who send the admin token of the server as the
+	// "admin-token" request metadata. Without it, it returns a
+	// PermissionDenied error. Imports of more books than the server allows
+	// return a ResourceExhausted error, and import nothing.
+	ImportBooks(BookService_ImportBooksServer) error
+	// ExportBooks returns all Books in the library, ordered by ISBN,
+	// as a stream. Each message has a resume token, with which an
+	// interrupted export can be continued where it left off.
+	ExportBooks(*ExportBooksRequest, BookService_ExportBooksServer) error
+	// WatchBooks returns the changes made to the Books whose author
+	// matches the author prefix provided, as a stream of events.
+	// If the changes to resume after are no longer available, it
+	// returns an OutOfRange error. If the client falls too far behind,
+	// it returns a ResourceExhausted error, after which it can resume.
+	WatchBooks(*WatchBooksRequest, BookService_WatchBooksServer) error
+	// GetAuthor returns the Author with the Id provided, if found.
+	// Otherwise it returns a NotFound error.
+	GetAuthor(context.Context, *GetAuthorRequest) (*Author, error)
+	// ListAuthors returns the Authors whose name
+	// matches the name prefix provided.
+	ListAuthors(context.Context, *ListAuthorsRequest) (*ListAuthorsResponse, error)
+	// GetPublisher returns the Publisher with the Id provided, if found.
+	// Otherwise it returns a NotFound error.
+	GetPublisher(context.Context, *GetPublisherRequest) (*Publisher, error)
+	// GetCollection returns the Collection with the Id provided, if it
+	// can be seen by the user. Otherwise it returns a NotFound error.
+	GetCollection(context.Context, *GetCollectionRequest) (*Collection, error)
+	// ListCollections returns the Collections owned by the
+	// user and the public Collections of other users.
+	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	// AddToCollection adds the Books with the ISBNs provided to a
+	// Collection owned by the user, and returns the Collection.
+	// Books already in the Collection are not added again. If the
+	// Collection would have more books than the server allows,
+	// it returns a ResourceExhausted error.
+	AddToCollection(context.Context, *AddToCollectionRequest) (*Collection, error)
+	// RemoveFromCollection removes the Books with the ISBNs provided
+	// from a Collection owned by the user, and returns the Collection.
+	RemoveFromCollection(context.Context, *RemoveFromCollectionRequest) (*Collection, error)
+	// DeleteCollection deletes a Collection owned by the user,
+	// and returns it.
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*Collection, error)
+	// ListCopies returns the Copies of the Book with the ISBN provided.
+	ListCopies(context.Context, *ListCopiesRequest) (*ListCopiesResponse, error)
+	// ListBranches returns the Branches of the library.
+	ListBranches(context.Context, *ListBranchesRequest) (*ListBranchesResponse, error)
+	// AddCopy adds a Copy of the Book with the ISBN provided at the
+	// Branch provided, and returns it. If there is no such Book or
+	// Branch, it returns a NotFound error. It is meant for administrators,
+	// who send the admin token of the server as the "admin-token" request
+	// metadata. Without it, it returns a PermissionDenied error.
+	AddCopy(context.Context, *AddCopyRequest) (*Copy, error)
+	// CheckOut lends a Copy to the user, and returns the Loan. The Loan
+	// is due after a period depending on the BookType of the Book. If
+	// the Copy is on loan, or no Copy of the Book is available, it
+	// returns a FailedPrecondition error.
+	CheckOut(context.Context, *CheckOutRequest) (*Loan, error)
+	// Return ends the Loan of a Copy, and returns the Loan. If
+	// the Copy is not on loan, it returns a FailedPrecondition error.
+	// Loans are returned by their borrower, or by administrators sending
+	// the admin token as the "admin-token" request metadata. Returning
+	// the Loan of another user returns a PermissionDenied error.
+	Return(context.Context, *ReturnRequest) (*Loan, error)
+	// Renew extends a Loan of the user, and returns the Loan. If the Loan
+	// has ended or has been renewed too many times, it returns a
+	// FailedPrecondition error.
+	Renew(context.Context, *RenewRequest) (*Loan, error)
+	// ListLoans returns the Loans of the user.
+	ListLoans(context.Context, *ListLoansRequest) (*ListLoansResponse, error)
+	// PlaceHold queues the user for a Copy of the Book with the ISBN
+	// provided, and returns the Hold. Copies returned are kept for
+	// the first Hold in the queue, which is then READY, and expires
+	// if the Copy is not checked out in time. If the user already has
+	// a Hold on the Book, it returns an AlreadyExists error.
+	PlaceHold(context.Context, *PlaceHoldRequest) (*Hold, error)
+	// CancelHold cancels a Hold of the user, and returns it.
+	CancelHold(context.Context, *CancelHoldRequest) (*Hold, error)
+	// ListHolds returns the Holds of the user.
+	ListHolds(context.Context, *ListHoldsRequest) (*ListHoldsResponse, error)
+	// WatchMyHolds streams the WAITING and READY Holds of the user,
+	// followed by the Holds of the user as they change, for example
+	// when a Copy is ready to be picked up.
+	WatchMyHolds(*WatchMyHoldsRequest, BookService_WatchMyHoldsServer) error
+	// ListJobs returns the status of the background jobs of the server, which
+	// compute fines and recommendations, expire holds and purge the chat history.
+	// It is meant for administrators, who send the admin token of the server as
+	// the "admin-token" request metadata. Without it, it returns a
+	// PermissionDenied error.
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// SubmitReview adds the review of a Book by the user, and returns
+	// it. Users can review a Book once, after which they edit their
+	// Review with UpdateReview. If there is no such Book, it returns a
+	// NotFound error.
+	SubmitReview(context.Context, *SubmitReviewRequest) (*Review, error)
+	// UpdateReview changes the rating and text of a Review by the
+	// user, and returns it. Reviews by other users cannot be changed.
+	UpdateReview(context.Context, *UpdateReviewRequest) (*Review, error)
+	// DeleteReview deletes a Review by the user, and returns it.
+	DeleteReview(context.Context, *DeleteReviewRequest) (*Review, error)
+	// MarkReviewHelpful records that the user found a Review helpful,
+	// and returns it. Users cannot vote for their own reviews, or
+	// vote for a Review twice.
+	MarkReviewHelpful(context.Context, *MarkReviewHelpfulRequest) (*Review, error)
+	// ListReviews returns a page of the Reviews of a Book.
+	ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error)
+	// RecommendBooks returns books liked by the readers who liked the Book
+	// with the ISBN provided, as found in their collections, loans and
+	// ratings by a background job. Books without enough such readers get
+	// books by the same authors or publisher. If there is no such Book,
+	// it returns a NotFound error.
+	RecommendBooks(context.Context, *RecommendBooksRequest) (*RecommendBooksResponse, error)
+	// UpdateReading puts a Book on a reading list of the user, with their
+	// progress, and returns the entry. If the entry was changed after
+	// UpdatedAt, for example on another device, the change is ignored and
+	// the entry returned as it is. If there is no such Book, it returns a
+	// NotFound error.
+	UpdateReading(context.Context, *UpdateReadingRequest) (*ReadingEntry, error)
+	// RemoveReading removes a Book from the reading lists of the user, and
+	// returns the entry removed. Like UpdateReading, it is ignored if the
+	// entry was changed after UpdatedAt. If the Book is not on the reading
+	// lists, it returns a NotFound error.
+	RemoveReading(context.Context, *RemoveReadingRequest) (*ReadingEntry, error)
+	// ListReading lists the reading lists of the user.
+	ListReading(context.Context, *ListReadingRequest) (*ListReadingResponse, error)
+}
+
+func RegisterBookServiceServer(s *grpc.Server, srv BookServiceServer) {
+	s.RegisterService(&_BookService_serviceDesc, srv)
+}
+
+func _BookService_GetBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/GetBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetBook(ctx, req.(*GetBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_QueryBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryBooksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).QueryBooks(m, &bookServiceQueryBooksServer{stream})
+}
+
+type BookService_QueryBooksServer interface {
+	Send(*Book) error
+	grpc.ServerStream
+}
+
+type bookServiceQueryBooksServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceQueryBooksServer) Send(m *Book) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BookService_MakeCollection_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookServiceServer).MakeCollection(&bookServiceMakeCollectionServer{stream})
+}
+
+type BookService_MakeCollectionServer interface {
+	SendAndClose(*Collection) error
+	Recv() (*Book, error)
+	grpc.ServerStream
+}
+
+type bookServiceMakeCollectionServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceMakeCollectionServer) SendAndClose(m *Collection) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *bookServiceMakeCollectionServer) Recv() (*Book, error) {
+	m := new(Book)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _BookService_BookChat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookServiceServer).BookChat(&bookServiceBookChatServer{stream})
+}
+
+type BookService_BookChatServer interface {
+	Send(*BookResponse) error
+	Recv() (*BookMessage, error)
+	grpc.ServerStream
+}
+
+type bookServiceBookChatServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceBookChatServer) Send(m *BookResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *bookServiceBookChatServer) Recv() (*BookMessage, error) {
+	m := new(BookMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _BookService_ImportBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookServiceServer).ImportBooks(&bookServiceImportBooksServer{stream})
+}
+
+type BookService_ImportBooksServer interface {
+	SendAndClose(*ImportBooksResponse) error
+	Recv() (*ImportBooksRequest, error)
+	grpc.ServerStream
+}
+
+type bookServiceImportBooksServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceImportBooksServer) SendAndClose(m *ImportBooksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *bookServiceImportBooksServer) Recv() (*ImportBooksRequest, error) {
+	m := new(ImportBooksRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _BookService_ExportBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportBooksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).ExportBooks(m, &bookServiceExportBooksServer{stream})
+}
+
+type BookService_ExportBooksServer interface {
+	Send(*ExportBooksResponse) error
+	grpc.ServerStream
+}
+
+type bookServiceExportBooksServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceExportBooksServer) Send(m *ExportBooksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BookService_WatchBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBooksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).WatchBooks(m, &bookServiceWatchBooksServer{stream})
+}
+
+type BookService_WatchBooksServer interface {
+	Send(*BookEvent) error
+	grpc.ServerStream
+}
+
+type bookServiceWatchBooksServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceWatchBooksServer) Send(m *BookEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BookService_GetAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/GetAuthor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetAuthor(ctx, req.(*GetAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuthorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/ListAuthors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListAuthors(ctx, req.(*ListAuthorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_GetPublisher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublisherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetPublisher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/GetPublisher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetPublisher(ctx, req.(*GetPublisherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_GetCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/GetCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetCollection(ctx, req.(*GetCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/ListCollections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListCollections(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_AddToCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).AddToCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/AddToCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).AddToCollection(ctx, req.(*AddToCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_RemoveFromCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RemoveFromCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/RemoveFromCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RemoveFromCollection(ctx, req.(*RemoveFromCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/DeleteCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListCopies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCopiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListCopies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/ListCopies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListCopies(ctx, req.(*ListCopiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListBranches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBranchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListBranches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/ListBranches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListBranches(ctx, req.(*ListBranchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_AddCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).AddCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/AddCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).AddCopy(ctx, req.(*AddCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_CheckOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).CheckOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/CheckOut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).CheckOut(ctx, req.(*CheckOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_Return_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).Return(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/Return",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).Return(ctx, req.(*ReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_Renew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).Renew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/Renew",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).Renew(ctx, req.(*RenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListLoans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLoansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListLoans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/ListLoans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListLoans(ctx, req.(*ListLoansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_PlaceHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).PlaceHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/PlaceHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).PlaceHold(ctx, req.(*PlaceHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_CancelHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).CancelHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/CancelHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).CancelHold(ctx, req.(*CancelHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHoldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/ListHolds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListHolds(ctx, req.(*ListHoldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_WatchMyHolds_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMyHoldsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).WatchMyHolds(m, &bookServiceWatchMyHoldsServer{stream})
+}
+
+type BookService_WatchMyHoldsServer interface {
+	Send(*Hold) error
+	grpc.ServerStream
+}
+
+type bookServiceWatchMyHoldsServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceWatchMyHoldsServer) Send(m *Hold) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BookService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_SubmitReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).SubmitReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/SubmitReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).SubmitReview(ctx, req.(*SubmitReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_UpdateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).UpdateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/UpdateReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).UpdateReview(ctx, req.(*UpdateReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_DeleteReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).DeleteReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/DeleteReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).DeleteReview(ctx, req.(*DeleteReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_MarkReviewHelpful_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkReviewHelpfulRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).MarkReviewHelpful(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/MarkReviewHelpful",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).MarkReviewHelpful(ctx, req.(*MarkReviewHelpfulRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/ListReviews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_RecommendBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RecommendBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/RecommendBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RecommendBooks(ctx, req.(*RecommendBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_UpdateReading_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReadingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).UpdateReading(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/UpdateReading",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).UpdateReading(ctx, req.(*UpdateReadingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_RemoveReading_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveReadingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RemoveReading(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/RemoveReading",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RemoveReading(ctx, req.(*RemoveReadingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListReading_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReadingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListReading(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.BookService/ListReading",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListReading(ctx, req.(*ListReadingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BookService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "library.BookService",
+	HandlerType: (*BookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBook",
+			Handler:    _BookService_GetBook_Handler,
+		},
+		{
+			MethodName: "GetAuthor",
+			Handler:    _BookService_GetAuthor_Handler,
+		},
+		{
+			MethodName: "ListAuthors",
+			Handler:    _BookService_ListAuthors_Handler,
+		},
+		{
+			MethodName: "GetPublisher",
+			Handler:    _BookService_GetPublisher_Handler,
+		},
+		{
+			MethodName: "GetCollection",
+			Handler:    _BookService_GetCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _BookService_ListCollections_Handler,
+		},
+		{
+			MethodName: "AddToCollection",
+			Handler:    _BookService_AddToCollection_Handler,
+		},
+		{
+			MethodName: "RemoveFromCollection",
+			Handler:    _BookService_RemoveFromCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _BookService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "ListCopies",
+			Handler:    _BookService_ListCopies_Handler,
+		},
+		{
+			MethodName: "ListBranches",
+			Handler:    _BookService_ListBranches_Handler,
+		},
+		{
+			MethodName: "AddCopy",
+			Handler:    _BookService_AddCopy_Handler,
+		},
+		{
+			MethodName: "CheckOut",
+			Handler:    _BookService_CheckOut_Handler,
+		},
+		{
+			MethodName: "Return",
+			Handler:    _BookService_Return_Handler,
+		},
+		{
+			MethodName: "Renew",
+			Handler:    _BookService_Renew_Handler,
+		},
+		{
+			MethodName: "ListLoans",
+			Handler:    _BookService_ListLoans_Handler,
+		},
+		{
+			MethodName: "PlaceHold",
+			Handler:    _BookService_PlaceHold_Handler,
+		},
+		{
+			MethodName: "CancelHold",
+			Handler:    _BookService_CancelHold_Handler,
+		},
+		{
+			MethodName: "ListHolds",
+			Handler:    _BookService_ListHolds_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _BookService_ListJobs_Handler,
+		},
+		{
+			MethodName: "SubmitReview",
+			Handler:    _BookService_SubmitReview_Handler,
+		},
+		{
+			MethodName: "UpdateReview",
+			Handler:    _BookService_UpdateReview_Handler,
+		},
+		{
+			MethodName: "DeleteReview",
+			Handler:    _BookService_DeleteReview_Handler,
+		},
+		{
+			MethodName: "MarkReviewHelpful",
+			Handler:    _BookService_MarkReviewHelpful_Handler,
+		},
+		{
+			MethodName: "ListReviews",
+			Handler:    _BookService_ListReviews_Handler,
+		},
+		{
+			MethodName: "RecommendBooks",
+			Handler:    _BookService_RecommendBooks_Handler,
+		},
+		{
+			MethodName: "UpdateReading",
+			Handler:    _BookService_UpdateReading_Handler,
+		},
+		{
+			MethodName: "RemoveReading",
+			Handler:    _BookService_RemoveReading_Handler,
+		},
+		{
+			MethodName: "ListReading",
+			Handler:    _BookService_ListReading_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "QueryBooks",
+			Handler:       _BookService_QueryBooks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "MakeCollection",
+			Handler:       _BookService_MakeCollection_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "BookChat",
+			Handler:       _BookService_BookChat_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportBooks",
+			Handler:       _BookService_ImportBooks_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportBooks",
+			Handler:       _BookService_ExportBooks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchBooks",
+			Handler:       _BookService_WatchBooks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchMyHolds",
+			Handler:       _BookService_WatchMyHolds_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/library/book_service.proto",
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for BookService service
+// Client API for UserService service
 
-type BookServiceClient interface {
-	// GetBook returns a Book from the library
-	// that matches the ISBN provided, if found.
-	// Otherwise it returns a NotFound error.
-	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*Book, error)
-	// QueryBooks returns all Books whos author
-	// matches the author prefix provided, as a stream
-	// of Books.
-	QueryBooks(ctx context.Context, in *QueryBooksRequest, opts ...grpc.CallOption) (BookService_QueryBooksClient, error)
-	// MakeCollection takes a stream of books and returns a Book collection.
-	MakeCollection(ctx context.Context, opts ...grpc.CallOption) (BookService_MakeCollectionClient, error)
-	// BookChat allows discussion about books
-	BookChat(ctx context.Context, opts ...grpc.CallOption) (BookService_BookChatClient, error)
+type UserServiceClient interface {
+	// Register creates the account of a user, and returns its profile. If
+	// the name or display name is taken, it returns an AlreadyExists error.
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*User, error)
+	// Login starts a session of a user. If the name or password is wrong,
+	// it returns an Unauthenticated error. After 5 failed logins in a row,
+	// the account is locked for 15 minutes, during which logins return
+	// the same error, even with the right password.
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// GetUser returns the profile of a user. If there is no
+	// such user, it returns a NotFound error.
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	// UpdateProfile changes the profile of the user of the session
+	// in the request metadata, and returns it. If the display name
+	// is taken, it returns an AlreadyExists error.
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*User, error)
 }
 
-type bookServiceClient struct {
+type userServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewBookServiceClient(cc *grpc.ClientConn) BookServiceClient {
-	return &bookServiceClient{cc}
+func NewUserServiceClient(cc *grpc.ClientConn) UserServiceClient {
+	return &userServiceClient{cc}
 }
 
-func (c *bookServiceClient) GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*Book, error) {
-	out := new(Book)
-	err := grpc.Invoke(ctx, "/library.BookService/GetBook", in, out, c.cc, opts...)
+func (c *userServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := grpc.Invoke(ctx, "/library.UserService/Register", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *bookServiceClient) QueryBooks(ctx context.Context, in *QueryBooksRequest, opts ...grpc.CallOption) (BookService_QueryBooksClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[0], c.cc, "/library.BookService/QueryBooks", opts...)
+func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := grpc.Invoke(ctx, "/library.UserService/Login", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &bookServiceQueryBooksClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
-}
-
-type BookService_QueryBooksClient interface {
-	Recv() (*Book, error)
-	grpc.ClientStream
-}
-
-type bookServiceQueryBooksClient struct {
-	grpc.ClientStream
+	return out, nil
 }
 
-func (x *bookServiceQueryBooksClient) Recv() (*Book, error) {
-	m := new(Book)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := grpc.Invoke(ctx, "/library.UserService/GetUser", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func (c *bookServiceClient) MakeCollection(ctx context.Context, opts ...grpc.CallOption) (BookService_MakeCollectionClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[1], c.cc, "/library.BookService/MakeCollection", opts...)
+func (c *userServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := grpc.Invoke(ctx, "/library.UserService/UpdateProfile", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &bookServiceMakeCollectionClient{stream}
-	return x, nil
+	return out, nil
 }
 
-type BookService_MakeCollectionClient interface {
-	Send(*Book) error
-	CloseAndRecv() (*Collection, error)
-	grpc.ClientStream
-}
+// Server API for UserService service
 
-type bookServiceMakeCollectionClient struct {
-	grpc.ClientStream
+type UserServiceServer interface {
+	// Register creates the account of a user, and returns its profile. If
+	// the name or display name is taken, it returns an AlreadyExists error.
+	Register(context.Context, *RegisterRequest) (*User, error)
+	// Login starts a session of a user. If the name or password is wrong,
+	// it returns an Unauthenticated error. After 5 failed logins in a row,
+	// the account is locked for 15 minutes, during which logins return
+	// the same error, even with the right password.
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// GetUser returns the profile of a user. If there is no
+	// such user, it returns a NotFound error.
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	// UpdateProfile changes the profile of the user of the session
+	// in the request metadata, and returns it. If the display name
+	// is taken, it returns an AlreadyExists error.
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*User, error)
 }
 
-func (x *bookServiceMakeCollectionClient) Send(m *Book) error {
-	return x.ClientStream.SendMsg(m)
+func RegisterUserServiceServer(s *grpc.Server, srv UserServiceServer) {
+	s.RegisterService(&_UserService_serviceDesc, srv)
 }
 
-func (x *bookServiceMakeCollectionClient) CloseAndRecv() (*Collection, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
+func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	m := new(Collection)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(UserServiceServer).Register(ctx, in)
 	}
-	return m, nil
-}
-
-func (c *bookServiceClient) BookChat(ctx context.Context, opts ...grpc.CallOption) (BookService_BookChatClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_BookService_serviceDesc.Streams[2], c.cc, "/library.BookService/BookChat", opts...)
-	if err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.UserService/Register",
 	}
-	x := &bookServiceBookChatClient{stream}
-	return x, nil
-}
-
-type BookService_BookChatClient interface {
-	Send(*BookMessage) error
-	Recv() (*BookResponse, error)
-	grpc.ClientStream
-}
-
-type bookServiceBookChatClient struct {
-	grpc.ClientStream
-}
-
-func (x *bookServiceBookChatClient) Send(m *BookMessage) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *bookServiceBookChatClient) Recv() (*BookResponse, error) {
-	m := new(BookResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Register(ctx, req.(*RegisterRequest))
 	}
-	return m, nil
-}
-
-// Server API for BookService service
-
-type BookServiceServer interface {
-	// GetBook returns a Book from the library
-	// that matches the ISBN provided, if found.
-	// Otherwise it returns a NotFound error.
-	GetBook(context.Context, *GetBookRequest) (*Book, error)
-	// QueryBooks returns all Books whos author
-	// matches the author prefix provided, as a stream
-	// of Books.
-	QueryBooks(*QueryBooksRequest, BookService_QueryBooksServer) error
-	// MakeCollection takes a stream of books and returns a Book collection.
-	MakeCollection(BookService_MakeCollectionServer) error
-	// BookChat allows discussion about books
-	BookChat(BookService_BookChatServer) error
-}
-
-func RegisterBookServiceServer(s *grpc.Server, srv BookServiceServer) {
-	s.RegisterService(&_BookService_serviceDesc, srv)
+	return interceptor(ctx, in, info, handler)
 }
 
-func _BookService_GetBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBookRequest)
+func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BookServiceServer).GetBook(ctx, in)
+		return srv.(UserServiceServer).Login(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/library.BookService/GetBook",
+		FullMethod: "/library.UserService/Login",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BookServiceServer).GetBook(ctx, req.(*GetBookRequest))
+		return srv.(UserServiceServer).Login(ctx, req.(*LoginRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BookService_QueryBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(QueryBooksRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(BookServiceServer).QueryBooks(m, &bookServiceQueryBooksServer{stream})
-}
-
-type BookService_QueryBooksServer interface {
-	Send(*Book) error
-	grpc.ServerStream
-}
-
-type bookServiceQueryBooksServer struct {
-	grpc.ServerStream
-}
-
-func (x *bookServiceQueryBooksServer) Send(m *Book) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _BookService_MakeCollection_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(BookServiceServer).MakeCollection(&bookServiceMakeCollectionServer{stream})
-}
-
-type BookService_MakeCollectionServer interface {
-	SendAndClose(*Collection) error
-	Recv() (*Book, error)
-	grpc.ServerStream
-}
-
-type bookServiceMakeCollectionServer struct {
-	grpc.ServerStream
-}
-
-func (x *bookServiceMakeCollectionServer) SendAndClose(m *Collection) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func (x *bookServiceMakeCollectionServer) Recv() (*Book, error) {
-	m := new(Book)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
-}
-
-func _BookService_BookChat_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(BookServiceServer).BookChat(&bookServiceBookChatServer{stream})
-}
-
-type BookService_BookChatServer interface {
-	Send(*BookResponse) error
-	Recv() (*BookMessage, error)
-	grpc.ServerStream
-}
-
-type bookServiceBookChatServer struct {
-	grpc.ServerStream
-}
-
-func (x *bookServiceBookChatServer) Send(m *BookResponse) error {
-	return x.ServerStream.SendMsg(m)
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.UserService/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *bookServiceBookChatServer) Recv() (*BookMessage, error) {
-	m := new(BookMessage)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _UserService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/library.UserService/UpdateProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-var _BookService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "library.BookService",
-	HandlerType: (*BookServiceServer)(nil),
+var _UserService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "library.UserService",
+	HandlerType: (*UserServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetBook",
-			Handler:    _BookService_GetBook_Handler,
+			MethodName: "Register",
+			Handler:    _UserService_Register_Handler,
 		},
-	},
-	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "QueryBooks",
-			Handler:       _BookService_QueryBooks_Handler,
-			ServerStreams: true,
+			MethodName: "Login",
+			Handler:    _UserService_Login_Handler,
 		},
 		{
-			StreamName:    "MakeCollection",
-			Handler:       _BookService_MakeCollection_Handler,
-			ClientStreams: true,
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
 		},
 		{
-			StreamName:    "BookChat",
-			Handler:       _BookService_BookChat_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
+			MethodName: "UpdateProfile",
+			Handler:    _UserService_UpdateProfile_Handler,
 		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/library/book_service.proto",
 }
 
 func init() { proto.RegisterFile("proto/library/book_service.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 630 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xb5, 0xfb, 0x95, 0x66, 0xda, 0x84, 0x74, 0x5b, 0xc0, 0xf2, 0xa5, 0x91, 0x8b, 0x84, 0x85,
-	0x84, 0x53, 0xd2, 0x03, 0x95, 0x10, 0x82, 0x24, 0xad, 0x08, 0x54, 0x55, 0x82, 0x29, 0x1c, 0xb8,
-	0x44, 0xb6, 0x33, 0xb5, 0xdd, 0xda, 0x5e, 0xb3, 0xbb, 0x81, 0xe6, 0xcc, 0x2f, 0xe2, 0xdf, 0x21,
-	0x4e, 0xc8, 0x5e, 0x3b, 0xa9, 0x29, 0x42, 0xe2, 0xb6, 0x6f, 0xe6, 0xbd, 0xf5, 0xbc, 0x37, 0x5a,
-	0x43, 0x3b, 0x65, 0x54, 0xd0, 0x4e, 0x14, 0xba, 0xcc, 0x61, 0xf3, 0x8e, 0x4b, 0xe9, 0xf5, 0x84,
-	0x23, 0xfb, 0x1a, 0x7a, 0x68, 0xe5, 0x2d, 0x52, 0x2b, 0x7a, 0xfa, 0xbe, 0x4f, 0xa9, 0x1f, 0x61,
-	0x27, 0x2f, 0xbb, 0xb3, 0xcb, 0x8e, 0x08, 0x63, 0xe4, 0xc2, 0x89, 0x53, 0xc9, 0xd4, 0x8f, 0xfd,
-	0x50, 0x04, 0x33, 0xd7, 0xf2, 0x68, 0xdc, 0xb9, 0xa2, 0x81, 0x93, 0xb8, 0xcc, 0x49, 0xa6, 0x01,
-	0x65, 0x5c, 0x2c, 0x45, 0xf2, 0x7b, 0x3e, 0x4d, 0x03, 0x64, 0x57, 0x5c, 0x2a, 0x8d, 0x7d, 0xa8,
-	0x8f, 0x67, 0x6e, 0x14, 0xf2, 0x00, 0x19, 0x21, 0xb0, 0x96, 0x38, 0x31, 0x6a, 0x6a, 0x5b, 0x35,
-	0xeb, 0x76, 0x7e, 0x36, 0x7e, 0xac, 0xc0, 0x5a, 0x9f, 0xd2, 0xeb, 0xac, 0x19, 0x72, 0x37, 0xc9,
-	0x9b, 0xab, 0x76, 0x7e, 0x26, 0x7b, 0xb0, 0x2e, 0x42, 0x11, 0xa1, 0xb6, 0x92, 0x2b, 0x24, 0x20,
-	0x0f, 0x60, 0xc3, 0x99, 0x89, 0x80, 0x32, 0x6d, 0x35, 0x2f, 0x17, 0x88, 0x58, 0x50, 0xcf, 0x5d,
-	0x8a, 0x79, 0x8a, 0xda, 0x5a, 0x5b, 0x35, 0x9b, 0xdd, 0x1d, 0xab, 0xf0, 0x68, 0x65, 0xdf, 0xb8,
-	0x98, 0xa7, 0x68, 0x6f, 0xba, 0xc5, 0x89, 0x3c, 0x86, 0x26, 0xc7, 0xe8, 0x72, 0x92, 0x16, 0x03,
-	0x4e, 0xb5, 0xf5, 0xb6, 0x6a, 0x6e, 0x0e, 0x15, 0xbb, 0x91, 0xd5, 0xcb, 0xb9, 0xa7, 0xa4, 0x0b,
-	0xf5, 0x92, 0xc3, 0xb4, 0x8d, 0xb6, 0x6a, 0x6e, 0x75, 0xc9, 0xe2, 0xe2, 0x85, 0xbd, 0xa1, 0x62,
-	0x2f, 0x69, 0xe4, 0x14, 0x5a, 0x39, 0xf0, 0x1c, 0x11, 0xd2, 0x64, 0x32, 0x75, 0x04, 0x6a, 0xb5,
-	0x5c, 0xaa, 0x5b, 0x32, 0x6e, 0xab, 0x4c, 0xce, 0xba, 0x28, 0xe3, 0xb6, 0xef, 0xdd, 0xd2, 0x9c,
-	0x38, 0x02, 0xfb, 0xbb, 0xb0, 0x53, 0xdc, 0x19, 0x26, 0xfe, 0x24, 0x46, 0x11, 0xd0, 0xa9, 0xf1,
-	0x08, 0x9a, 0x6f, 0x50, 0x64, 0x8e, 0x6c, 0xfc, 0x32, 0x43, 0x2e, 0xfe, 0x16, 0x9e, 0x71, 0x0c,
-	0x3b, 0xef, 0x67, 0xc8, 0xe6, 0x19, 0x8f, 0x97, 0xc4, 0x03, 0x68, 0xc8, 0xb4, 0x26, 0x29, 0xc3,
-	0xcb, 0xf0, 0xa6, 0xd8, 0xc5, 0xb6, 0x2c, 0x8e, 0xf3, 0x9a, 0xf1, 0x0c, 0x60, 0x40, 0xa3, 0x08,
-	0xbd, 0x6c, 0x0c, 0x72, 0x00, 0xeb, 0x59, 0x64, 0x5c, 0x53, 0xdb, 0xab, 0xe6, 0x56, 0xb7, 0x51,
-	0x89, 0xd4, 0x96, 0x3d, 0xe3, 0x1d, 0x6c, 0x65, 0xf0, 0x1c, 0x39, 0x77, 0x7c, 0x24, 0x7b, 0xb7,
-	0x37, 0x3d, 0x54, 0xe4, 0xae, 0x89, 0x0e, 0xb5, 0x58, 0x12, 0xe4, 0x42, 0x87, 0x8a, 0x5d, 0x16,
-	0xfa, 0x75, 0xa8, 0x79, 0x34, 0x11, 0x98, 0x08, 0xc3, 0x84, 0x6d, 0xe9, 0x8d, 0xa7, 0x34, 0xe1,
-	0x48, 0xb4, 0x3f, 0x64, 0x0b, 0xd1, 0x93, 0xe7, 0xb0, 0x59, 0xee, 0x95, 0x34, 0xa0, 0x3e, 0xec,
-	0xd9, 0x27, 0x83, 0xd1, 0xa7, 0x53, 0xbb, 0xa5, 0x64, 0x70, 0xdc, 0x1b, 0x9f, 0xda, 0xfd, 0xde,
-	0xe0, 0xac, 0xa5, 0x66, 0xb0, 0xf7, 0xf1, 0xe4, 0xed, 0xa8, 0x3f, 0x1a, 0x9d, 0xb5, 0x56, 0xba,
-	0x3f, 0x55, 0x39, 0xef, 0x07, 0xf9, 0x20, 0xc8, 0x11, 0xd4, 0x8a, 0x44, 0xc9, 0xc3, 0x85, 0xbf,
-	0x6a, 0xc6, 0x7a, 0xd5, 0xb8, 0xa1, 0x90, 0x17, 0x00, 0xcb, 0x80, 0x89, 0xbe, 0x68, 0xdf, 0x49,
-	0xfd, 0x8e, 0xf4, 0x50, 0x25, 0xc7, 0xd0, 0x3c, 0x77, 0xae, 0xf1, 0x56, 0xce, 0x55, 0x92, 0xbe,
-	0xbb, 0x80, 0x4b, 0x8e, 0xa1, 0x98, 0x2a, 0x79, 0x29, 0x4d, 0x0f, 0x02, 0x47, 0x90, 0xbd, 0x8a,
-	0xa6, 0x48, 0x5f, 0xbf, 0x5f, 0x5d, 0x51, 0x91, 0x63, 0x26, 0x3e, 0x54, 0xfb, 0xdf, 0xd5, 0x5f,
-	0xaf, 0x5f, 0xfd, 0xe3, 0x3d, 0xfb, 0x2c, 0xf5, 0xbe, 0xa1, 0xfb, 0x14, 0x6f, 0x9c, 0x38, 0x8d,
-	0xb0, 0xe3, 0x45, 0x21, 0x26, 0xc5, 0x33, 0x2f, 0xff, 0x26, 0x9f, 0xff, 0xe7, 0x82, 0xec, 0xa7,
-	0x83, 0xac, 0x7a, 0x81, 0xbb, 0x91, 0xc3, 0xa3, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xa6, 0x8e,
-	0x45, 0x37, 0xa6, 0x04, 0x00, 0x00,
+	// 3876 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x3a, 0x4d, 0x73, 0xdb, 0xc8,
+	0x72, 0x04, 0xbf, 0xd9, 0xa4, 0x24, 0x6a, 0xa4, 0x95, 0xf9, 0x68, 0x6f, 0xac, 0xc5, 0xbe, 0xdd,
+	0xd5, 0x7a, 0xfd, 0xa4, 0x8d, 0xfc, 0xfc, 0xec, 0x2d, 0xd7, 0xdb, 0x5d, 0x8a, 0x82, 0x2c, 0x7a,
+	0x69, 0x49, 0x0f, 0x14, 0xed, 0x97, 0xbc, 0xaa, 0xb0, 0x40, 0x62, 0x24, 0x61, 0x0d, 0x02, 0x0c,
+	0x00, 0xca, 0xd2, 0x56, 0xe5, 0x92, 0x54, 0x72, 0x4b, 0x55, 0x72, 0xcb, 0x2f, 0xc8, 0xfb, 0x03,
+	0x39, 0xa5, 0x2a, 0xc7, 0x5c, 0x73, 0xc8, 0x35, 0xa7, 0xfc, 0x87, 0x9c, 0x52, 0x95, 0xaa, 0x54,
+	0xcf, 0x0c, 0x80, 0x01, 0x09, 0x4a, 0xf2, 0xde, 0x38, 0xdd, 0x3d, 0x8d, 0xee, 0x9e, 0x9e, 0x9e,
+	0xfe, 0x20, 0x6c, 0x4e, 0x3c, 0x37, 0x70, 0x77, 0x6c, 0x6b, 0xe8, 0x19, 0xde, 0xf5, 0xce, 0xd0,
+	0x75, 0xdf, 0x0d, 0x7c, 0xea, 0x5d, 0x5a, 0x23, 0xba, 0xcd, 0x50, 0xa4, 0x24, 0x70, 0xcd, 0x87,
+	0xe7, 0xae, 0x7b, 0x6e, 0xd3, 0x1d, 0x06, 0x1e, 0x4e, 0xcf, 0x76, 0x02, 0x6b, 0x4c, 0xfd, 0xc0,
+	0x18, 0x4f, 0x38, 0x65, 0xf3, 0xf9, 0xb9, 0x15, 0x5c, 0x4c, 0x87, 0xdb, 0x23, 0x77, 0xbc, 0xf3,
+	0xa3, 0x7b, 0x61, 0x38, 0x43, 0xcf, 0x70, 0xcc, 0x0b, 0xd7, 0xf3, 0x83, 0x78, 0x13, 0xff, 0xde,
+	0xb9, 0x3b, 0xb9, 0xa0, 0xde, 0x8f, 0x3e, 0xdf, 0xa9, 0xee, 0x40, 0xe5, 0x64, 0x3a, 0xb4, 0x2d,
+	0xff, 0x82, 0x7a, 0x84, 0x40, 0xde, 0x31, 0xc6, 0xb4, 0xa1, 0x6c, 0x2a, 0x5b, 0x15, 0x9d, 0xfd,
+	0x26, 0xcb, 0x90, 0xb5, 0xcc, 0x46, 0x96, 0x41, 0xb2, 0x96, 0xa9, 0x3e, 0x86, 0x62, 0x6b, 0x1a,
+	0x5c, 0xb8, 0x9e, 0xc0, 0x28, 0x21, 0x26, 0xda, 0x9d, 0x8d, 0x77, 0xab, 0x36, 0x54, 0xdb, 0xae,
+	0x13, 0x78, 0xd6, 0x70, 0x1a, 0xb8, 0xe9, 0x1f, 0x78, 0x0c, 0x79, 0xcf, 0xb5, 0xf9, 0xb6, 0xe5,
+	0xdd, 0xc6, 0xb6, 0x50, 0x7a, 0x5b, 0xda, 0xa7, 0xbb, 0x36, 0xd5, 0x19, 0x15, 0xb9, 0x0f, 0x15,
+	0x83, 0x7d, 0x7e, 0x60, 0x99, 0x8d, 0x1c, 0x63, 0x53, 0xe6, 0x80, 0x8e, 0xa9, 0x3e, 0x87, 0x62,
+	0x8f, 0x7a, 0x16, 0xf5, 0x53, 0x3f, 0xd4, 0x84, 0xf2, 0xc4, 0xf5, 0xad, 0xc0, 0x72, 0x1d, 0xf6,
+	0xb1, 0x82, 0x1e, 0xad, 0xd5, 0xbf, 0x2f, 0x42, 0x7e, 0xcf, 0x75, 0xdf, 0xe1, 0x46, 0xcb, 0x1f,
+	0x3a, 0x6c, 0x63, 0x4e, 0x67, 0xbf, 0xc9, 0x3a, 0x14, 0x02, 0x2b, 0xb0, 0x43, 0xcd, 0xf8, 0x82,
+	0x6c, 0x40, 0x91, 0x7f, 0x58, 0x88, 0x21, 0x56, 0x64, 0x1b, 0x2a, 0xec, 0x2c, 0x83, 0xeb, 0x09,
+	0x6d, 0xe4, 0x99, 0x52, 0xab, 0x91, 0x52, 0xf8, 0x8d, 0xd3, 0xeb, 0x09, 0xd5, 0xcb, 0x43, 0xf1,
+	0x8b, 0x7c, 0x01, 0xcb, 0x3e, 0xb5, 0xcf, 0x06, 0x13, 0x71, 0x0c, 0x66, 0xa3, 0xb0, 0xa9, 0x6c,
+	0x95, 0x0f, 0x33, 0xfa, 0x12, 0xc2, 0xc3, 0xd3, 0x31, 0xc9, 0x2e, 0x54, 0x42, 0x1a, 0xaf, 0x51,
+	0xdc, 0x54, 0xb6, 0xaa, 0xbb, 0x24, 0x62, 0x1c, 0x1d, 0xe2, 0x61, 0x46, 0x8f, 0xc9, 0x88, 0x06,
+	0x75, 0xb6, 0x18, 0x19, 0xa8, 0xe6, 0xc0, 0x34, 0x02, 0xda, 0x28, 0xb1, 0xad, 0xcd, 0x6d, 0xee,
+	0x54, 0xdb, 0xa1, 0x7f, 0x6c, 0x9f, 0x86, 0x4e, 0xa5, 0xaf, 0x48, 0x7b, 0xf6, 0x8d, 0x80, 0x92,
+	0xe7, 0x50, 0x1b, 0xc5, 0xc7, 0xe1, 0x37, 0xca, 0x9b, 0xb9, 0xad, 0xea, 0xee, 0x7a, 0xea, 0x59,
+	0x25, 0x28, 0x49, 0x03, 0x4a, 0xd4, 0xe4, 0x36, 0xaf, 0x30, 0x33, 0x85, 0x4b, 0xf2, 0x05, 0x14,
+	0x7d, 0x76, 0x58, 0x0d, 0x60, 0x02, 0xad, 0x44, 0xdc, 0xf8, 0x19, 0xea, 0x02, 0x8d, 0xe7, 0x66,
+	0x1b, 0xce, 0xf9, 0xd4, 0x38, 0xa7, 0x8d, 0x2a, 0x3f, 0xf1, 0x70, 0x4d, 0x1e, 0x02, 0x4c, 0x8c,
+	0x73, 0x3a, 0x18, 0xb9, 0x53, 0x27, 0x68, 0xd4, 0xf0, 0x54, 0x0f, 0x15, 0xbd, 0x82, 0xb0, 0x36,
+	0x82, 0xc8, 0x57, 0x50, 0x37, 0xa7, 0x1e, 0xd7, 0xde, 0xa7, 0x23, 0xd7, 0x31, 0xfd, 0xc6, 0x92,
+	0x20, 0x5b, 0x09, 0x31, 0x3d, 0x8e, 0x20, 0x9b, 0x50, 0x35, 0xa9, 0x3f, 0xf2, 0xac, 0x09, 0x13,
+	0x78, 0x99, 0x7d, 0x4c, 0x06, 0xa1, 0x2c, 0xfe, 0x74, 0xf8, 0x23, 0x1d, 0x05, 0x7e, 0x63, 0x65,
+	0x33, 0x87, 0xb2, 0x84, 0x6b, 0xf2, 0x39, 0xac, 0x8c, 0xdc, 0x4b, 0xea, 0x0d, 0xac, 0x31, 0x8a,
+	0x34, 0xf5, 0xec, 0x46, 0x9d, 0x71, 0x58, 0x62, 0xe0, 0x0e, 0x42, 0xfb, 0x9e, 0x4d, 0xbe, 0x81,
+	0x9a, 0x71, 0x69, 0x58, 0xb6, 0x31, 0xb4, 0x6c, 0x2b, 0xb8, 0x6e, 0xac, 0x32, 0xf5, 0x3f, 0x8a,
+	0xd4, 0x6f, 0x49, 0x48, 0x3d, 0x41, 0x4a, 0x3e, 0x81, 0x1a, 0x4a, 0xec, 0x9c, 0x0b, 0x85, 0x09,
+	0x73, 0xe3, 0x2a, 0x87, 0x71, 0x85, 0x3f, 0x83, 0x65, 0xe3, 0x92, 0x7a, 0x28, 0x01, 0x07, 0x37,
+	0xd6, 0x36, 0x95, 0x2d, 0x45, 0x5f, 0x12, 0x50, 0x9d, 0x01, 0xf7, 0xd6, 0x60, 0x55, 0x78, 0x09,
+	0x72, 0x1b, 0xd3, 0xe0, 0xc2, 0x35, 0xf7, 0xca, 0x50, 0xa4, 0x57, 0x01, 0x75, 0x02, 0xf5, 0x00,
+	0x8a, 0x7b, 0x9e, 0xe1, 0x8c, 0x2e, 0xee, 0x72, 0xcb, 0xf1, 0x90, 0x0d, 0xd3, 0xf4, 0xa8, 0xef,
+	0x8b, 0xbb, 0x10, 0x2e, 0xd5, 0xbf, 0x82, 0x9a, 0xac, 0x0e, 0x5e, 0x9a, 0x91, 0x3b, 0xc1, 0x43,
+	0x57, 0x98, 0xe8, 0x62, 0x45, 0x1e, 0x40, 0x45, 0x28, 0x2a, 0xae, 0x59, 0x41, 0x8f, 0x01, 0xe4,
+	0x19, 0x94, 0x87, 0x4c, 0x1a, 0x8a, 0x1f, 0x40, 0xd7, 0xbb, 0x1f, 0xdf, 0x28, 0x86, 0x48, 0xd8,
+	0x2c, 0x22, 0x56, 0xff, 0x55, 0x01, 0x32, 0x4f, 0x80, 0x41, 0x84, 0x93, 0x0c, 0x22, 0xd5, 0xc4,
+	0x9e, 0x8e, 0x49, 0x1e, 0x42, 0x55, 0x20, 0x25, 0x3d, 0x81, 0x83, 0x8e, 0x50, 0xdb, 0x58, 0x87,
+	0xdc, 0x62, 0x1d, 0xf2, 0xb3, 0x3a, 0xdc, 0x83, 0x92, 0xeb, 0x0c, 0x6c, 0xd7, 0x70, 0xd8, 0xfd,
+	0x2e, 0xe8, 0x45, 0xd7, 0xe9, 0xba, 0x86, 0x23, 0x10, 0x17, 0xae, 0x6d, 0x36, 0x8a, 0x21, 0xe2,
+	0xd0, 0xb5, 0x4d, 0xf5, 0x97, 0xb0, 0xfc, 0x92, 0x06, 0x18, 0x31, 0x74, 0xfa, 0x97, 0x53, 0xea,
+	0x07, 0x69, 0xc1, 0x49, 0xfd, 0x5b, 0x05, 0x56, 0x7f, 0x37, 0xa5, 0xde, 0x35, 0x12, 0xfa, 0x21,
+	0xe5, 0xa7, 0xb0, 0x24, 0xc2, 0xe4, 0xc4, 0xa3, 0x67, 0xd6, 0x95, 0xd0, 0xb2, 0xc6, 0x81, 0x27,
+	0x0c, 0x96, 0x8c, 0xa5, 0xd9, 0x64, 0x2c, 0x25, 0xdb, 0xb0, 0x16, 0x09, 0x3f, 0x30, 0x82, 0x01,
+	0x37, 0x80, 0x38, 0xdf, 0xd5, 0x08, 0xd5, 0x0a, 0xb8, 0x7d, 0x55, 0x15, 0xea, 0x2f, 0x69, 0xc0,
+	0x9f, 0x86, 0x50, 0x8a, 0x19, 0xdf, 0x51, 0x9f, 0x02, 0xe9, 0x5a, 0xbe, 0x20, 0x8a, 0x64, 0x7d,
+	0x08, 0x55, 0xb4, 0x74, 0x52, 0x52, 0x40, 0x10, 0x97, 0x53, 0xfd, 0x1e, 0xd6, 0x12, 0xdb, 0xfc,
+	0x89, 0xeb, 0xf8, 0x94, 0x7c, 0x09, 0x25, 0x2e, 0x2d, 0x3a, 0x53, 0x2e, 0x11, 0x41, 0x84, 0x18,
+	0x21, 0x5e, 0xfd, 0x0c, 0xd6, 0x5e, 0xd2, 0x20, 0x8a, 0x91, 0x8b, 0xe4, 0xfb, 0x4f, 0x05, 0xa0,
+	0xed, 0xda, 0x36, 0x1d, 0xb1, 0xcb, 0xfe, 0x29, 0x14, 0x30, 0x4a, 0x87, 0xec, 0x97, 0x12, 0x51,
+	0x5c, 0xe7, 0xb8, 0xd9, 0xf7, 0x31, 0xba, 0x1f, 0x39, 0xe9, 0x7e, 0xac, 0x43, 0xc1, 0x7d, 0xef,
+	0x50, 0x8f, 0x79, 0x45, 0x45, 0xe7, 0x0b, 0xf2, 0x04, 0xe0, 0xd2, 0xf2, 0x2d, 0x11, 0x05, 0x0a,
+	0xec, 0xa5, 0x58, 0x8b, 0xbe, 0xf1, 0x26, 0x42, 0xe9, 0x12, 0x19, 0xf9, 0x1a, 0x8a, 0xd4, 0xf3,
+	0x50, 0xe7, 0x22, 0x13, 0x4a, 0x7e, 0x2f, 0x43, 0xc1, 0x35, 0x24, 0xd0, 0x05, 0x9d, 0xfa, 0x1f,
+	0x0a, 0xac, 0xcc, 0xe0, 0x50, 0x20, 0xcb, 0x31, 0xe9, 0x95, 0xb8, 0x85, 0x7c, 0x11, 0xb9, 0x57,
+	0x56, 0x7a, 0xfb, 0x9e, 0x41, 0xd1, 0xa3, 0x86, 0xef, 0x3a, 0x4c, 0xa1, 0xe5, 0xdd, 0x87, 0x8b,
+	0xbe, 0xb7, 0xad, 0x33, 0x32, 0x5d, 0x90, 0x63, 0x4c, 0x18, 0x53, 0xdf, 0xc7, 0xa0, 0xcd, 0xb5,
+	0x0e, 0x97, 0xea, 0xb7, 0x50, 0xe4, 0xb4, 0x64, 0x03, 0x88, 0xae, 0xb5, 0x7a, 0xc7, 0x47, 0x83,
+	0xfe, 0x51, 0xef, 0x44, 0x6b, 0x77, 0x0e, 0x3a, 0xda, 0x7e, 0x3d, 0x43, 0x96, 0xa0, 0x72, 0x74,
+	0x7c, 0x3a, 0x38, 0x38, 0xee, 0x1f, 0xed, 0xd7, 0x15, 0x5c, 0xee, 0xf7, 0x4f, 0xba, 0x9d, 0x76,
+	0xeb, 0x54, 0xab, 0x67, 0xd5, 0xcf, 0x61, 0xfd, 0x25, 0x0d, 0xe2, 0xcf, 0x2f, 0x3a, 0xcd, 0x6d,
+	0xd8, 0x40, 0xb7, 0x89, 0x09, 0x23, 0x8f, 0x8b, 0xce, 0x43, 0x91, 0xce, 0x43, 0x3d, 0x81, 0x7b,
+	0x73, 0xf4, 0xc2, 0xd5, 0x9e, 0x42, 0x75, 0x14, 0x83, 0x85, 0x3f, 0xac, 0xa5, 0x98, 0x42, 0x97,
+	0xe9, 0xd4, 0x6f, 0x61, 0xa3, 0x65, 0x9a, 0xa7, 0xee, 0xad, 0xb2, 0xb2, 0x03, 0xf1, 0x87, 0x8e,
+	0xdf, 0xc8, 0x6e, 0xe6, 0xb6, 0x72, 0x3a, 0x5f, 0xa8, 0x6d, 0xb8, 0xaf, 0xd3, 0xb1, 0x7b, 0x49,
+	0x0f, 0x3c, 0x77, 0xfc, 0x73, 0x99, 0x7c, 0x09, 0xf7, 0xf6, 0xa9, 0x4d, 0x03, 0x7a, 0xbb, 0xc5,
+	0xfe, 0xa8, 0x40, 0xbe, 0xed, 0x4e, 0xae, 0xd3, 0x82, 0xfe, 0x9c, 0x67, 0x48, 0x01, 0x0d, 0x5d,
+	0xa3, 0x1c, 0x05, 0xb4, 0xc7, 0x90, 0x33, 0xa7, 0xfc, 0xd4, 0x6f, 0x4e, 0x33, 0x90, 0x4c, 0x0e,
+	0x7f, 0x85, 0x90, 0x0d, 0x86, 0xbf, 0x64, 0x90, 0x2e, 0x26, 0x83, 0xb4, 0xfa, 0x6f, 0x59, 0xc8,
+	0xb3, 0x8f, 0xcd, 0x4a, 0x7a, 0x0f, 0x4a, 0x23, 0x77, 0x72, 0x1d, 0x47, 0x34, 0x8c, 0xce, 0xd7,
+	0x9d, 0x58, 0x85, 0x9c, 0xa4, 0x42, 0x13, 0xca, 0x43, 0xd7, 0xf3, 0xdc, 0xf7, 0xd1, 0xd5, 0x8c,
+	0xd6, 0xe4, 0x05, 0x54, 0x47, 0x17, 0x74, 0xf4, 0x8e, 0x9a, 0x03, 0x77, 0x1a, 0x34, 0x0a, 0xb7,
+	0x6a, 0x03, 0x82, 0xfc, 0x78, 0x1a, 0x84, 0x26, 0x28, 0xde, 0xcd, 0x04, 0xbf, 0x81, 0xb2, 0x47,
+	0x83, 0xa9, 0xe7, 0x50, 0xf3, 0x0e, 0xc9, 0x59, 0x44, 0x8b, 0xe2, 0x7b, 0xd4, 0xa1, 0xef, 0x0d,
+	0x1b, 0x33, 0x32, 0x96, 0xd0, 0x86, 0x6b, 0xf2, 0x31, 0xc0, 0x99, 0xe5, 0xd0, 0xc1, 0x88, 0x3a,
+	0x81, 0xcf, 0x52, 0xaf, 0x9c, 0x5e, 0x41, 0x48, 0x1b, 0x01, 0xea, 0x17, 0xb0, 0xca, 0x7d, 0x1d,
+	0x5f, 0xae, 0x9b, 0x9e, 0x97, 0x17, 0x40, 0x64, 0x42, 0x71, 0x1f, 0x3e, 0x93, 0x9e, 0xf1, 0x64,
+	0x68, 0x44, 0xf7, 0x09, 0x5f, 0x44, 0xb5, 0x05, 0xcb, 0x2d, 0xd3, 0x64, 0xa0, 0xc5, 0x9f, 0x48,
+	0x1e, 0x74, 0x76, 0xe6, 0xa0, 0xff, 0x00, 0x2b, 0x6d, 0xb4, 0xeb, 0xf1, 0x34, 0x08, 0x79, 0x48,
+	0x47, 0xac, 0xa4, 0x1e, 0x71, 0x76, 0x11, 0xf3, 0xdc, 0x0c, 0xf3, 0x2d, 0x58, 0xd2, 0x99, 0x31,
+	0x6f, 0x63, 0xad, 0x7e, 0x01, 0x35, 0x1d, 0x4d, 0x2b, 0x11, 0xa2, 0xe7, 0x4b, 0x84, 0xb8, 0xec,
+	0x98, 0xea, 0x6f, 0xa1, 0x8e, 0xf6, 0x42, 0xdf, 0x8c, 0xec, 0xfa, 0x25, 0xd4, 0x2d, 0x67, 0x64,
+	0x4f, 0x4d, 0x3a, 0x88, 0xce, 0x59, 0x61, 0xbe, 0xbe, 0x22, 0xe0, 0xba, 0x00, 0xab, 0xcf, 0x61,
+	0x55, 0xda, 0x2e, 0xac, 0xfd, 0x29, 0x14, 0x90, 0xfb, 0xbc, 0xb1, 0x91, 0x4c, 0xe7, 0x38, 0xf5,
+	0xbf, 0xb2, 0x90, 0x67, 0xf7, 0xe6, 0x2e, 0x77, 0x97, 0x40, 0x7e, 0xea, 0xd3, 0xb0, 0x72, 0x61,
+	0xbf, 0xc9, 0x97, 0x50, 0xf0, 0x03, 0xac, 0x0f, 0xf2, 0x33, 0x2f, 0x11, 0x72, 0xdd, 0xee, 0x21,
+	0x4a, 0xe7, 0x14, 0x64, 0x17, 0x8a, 0x13, 0xdb, 0x18, 0x89, 0x52, 0xe5, 0x66, 0x77, 0x15, 0x94,
+	0x89, 0xea, 0xab, 0x98, 0xac, 0xbe, 0x64, 0xb3, 0x97, 0x12, 0x27, 0xfa, 0x02, 0xaa, 0x1e, 0x35,
+	0xcc, 0xeb, 0xc1, 0xd4, 0x09, 0x2c, 0xbb, 0x51, 0xbe, 0xf5, 0x6b, 0xc0, 0xc8, 0xfb, 0x48, 0xad,
+	0xbe, 0x82, 0x02, 0x93, 0x9a, 0x54, 0xa1, 0xf4, 0xb6, 0xd5, 0x39, 0xed, 0x1c, 0xbd, 0xac, 0x67,
+	0x48, 0x05, 0x0a, 0xba, 0xd6, 0xda, 0xff, 0x33, 0xfe, 0xae, 0x1c, 0xf4, 0xbb, 0x07, 0x9d, 0x6e,
+	0x57, 0xdb, 0xaf, 0x67, 0x71, 0xd9, 0x6e, 0x1d, 0xb5, 0x35, 0xb6, 0xcc, 0xe1, 0x2e, 0xed, 0xf7,
+	0x27, 0x1d, 0x5d, 0xdb, 0xaf, 0xe7, 0xd5, 0xcf, 0xa1, 0x7e, 0x82, 0x7a, 0xa0, 0x2d, 0x6e, 0xba,
+	0x2e, 0x8f, 0x61, 0xb5, 0x6d, 0x38, 0x23, 0x6a, 0xcb, 0x84, 0xf7, 0xa0, 0x84, 0xf1, 0x4d, 0x72,
+	0x16, 0x5c, 0x76, 0x4c, 0xf5, 0x19, 0x77, 0x16, 0xa4, 0x95, 0x33, 0xb7, 0xd0, 0x59, 0xa8, 0x63,
+	0x46, 0x9e, 0x52, 0x13, 0x40, 0xcd, 0x31, 0x63, 0x37, 0x11, 0x1b, 0x63, 0x37, 0x41, 0xbe, 0xf3,
+	0x6e, 0xc2, 0x64, 0xe1, 0x38, 0xf5, 0x23, 0x58, 0x7b, 0x6b, 0x04, 0xa3, 0x8b, 0xd7, 0xd7, 0xf2,
+	0x57, 0xd5, 0xbf, 0xcb, 0x42, 0xee, 0x95, 0x3b, 0x4c, 0xad, 0x9b, 0x99, 0xfb, 0x06, 0xd4, 0xbb,
+	0x34, 0xec, 0xa8, 0x84, 0xe2, 0xce, 0xb4, 0x12, 0xc2, 0xc3, 0x02, 0x8a, 0x40, 0xde, 0x9b, 0x3a,
+	0x7e, 0x18, 0x64, 0xf1, 0x37, 0x79, 0x8a, 0xe5, 0x9b, 0x1f, 0x0c, 0xbc, 0xa9, 0x73, 0x87, 0x37,
+	0xa1, 0x84, 0xb4, 0xfa, 0xd4, 0xc1, 0xac, 0x90, 0x6f, 0xa3, 0xfe, 0xd4, 0xe6, 0xf1, 0xb7, 0xa2,
+	0x03, 0xc3, 0x32, 0x08, 0x46, 0x38, 0x46, 0xc0, 0xd2, 0x1c, 0xf1, 0x40, 0x54, 0x10, 0xc2, 0x53,
+	0x9c, 0xa7, 0x50, 0x76, 0xe8, 0x15, 0xff, 0xec, 0xed, 0x41, 0xb5, 0x84, 0xb4, 0xfa, 0xd4, 0x41,
+	0xfb, 0xa0, 0x65, 0xf7, 0x44, 0x05, 0x11, 0xda, 0xa7, 0x0d, 0xeb, 0x49, 0xb0, 0xb0, 0xf9, 0x57,
+	0x52, 0x65, 0x32, 0x9b, 0x84, 0x72, 0x62, 0xa9, 0x1a, 0x59, 0x85, 0x15, 0x64, 0xf2, 0xca, 0x1d,
+	0x46, 0x7c, 0x8f, 0xa0, 0x1e, 0x83, 0x04, 0xcf, 0x06, 0x94, 0xbc, 0xa9, 0xe3, 0x60, 0xe9, 0xc6,
+	0xcf, 0x3e, 0x5c, 0x92, 0x4d, 0xc8, 0xff, 0xe8, 0x0e, 0xf9, 0xfb, 0x5e, 0xdd, 0xad, 0x45, 0x5f,
+	0x7a, 0xe5, 0x0e, 0x75, 0x86, 0x51, 0xff, 0x4f, 0xc1, 0xe4, 0xea, 0xd2, 0xa2, 0xef, 0x7f, 0x76,
+	0x1c, 0xd8, 0x80, 0xa2, 0x28, 0x1c, 0x79, 0x0d, 0x23, 0x56, 0x48, 0x1b, 0xd0, 0xab, 0xf0, 0x24,
+	0xd8, 0x6f, 0xf2, 0x6b, 0x28, 0x8d, 0x3c, 0x6a, 0x04, 0xd4, 0xbc, 0xc3, 0x5b, 0x17, 0x92, 0xe2,
+	0xae, 0xe9, 0xc4, 0x64, 0xbb, 0xee, 0x70, 0x32, 0x82, 0x14, 0x2f, 0xc6, 0x05, 0xb5, 0x27, 0x67,
+	0x53, 0x7b, 0x70, 0xe9, 0x06, 0x34, 0x7c, 0xf2, 0x6a, 0x02, 0xf8, 0x06, 0x61, 0x6a, 0x1f, 0xd6,
+	0x7a, 0xd3, 0xe1, 0xd8, 0x0a, 0xb8, 0x11, 0x6e, 0x7a, 0x76, 0x62, 0x3d, 0xb3, 0xa9, 0x7a, 0xe6,
+	0x62, 0x3d, 0xd5, 0xbf, 0x80, 0xb5, 0x3e, 0x13, 0x23, 0xc9, 0xf6, 0x3e, 0x54, 0x3c, 0x06, 0x90,
+	0xea, 0x48, 0x0e, 0xe8, 0x98, 0x1f, 0xc4, 0x7f, 0x17, 0xd6, 0x78, 0x8e, 0x76, 0x77, 0xfe, 0xea,
+	0x33, 0x68, 0xbc, 0x36, 0xbc, 0x77, 0x7c, 0xc7, 0x21, 0x37, 0xc2, 0x9d, 0x36, 0xfe, 0x83, 0xc2,
+	0xdf, 0x74, 0xbe, 0xf3, 0xa6, 0xd7, 0x9f, 0x3c, 0x82, 0x82, 0xeb, 0x99, 0xd4, 0x13, 0xcd, 0xb9,
+	0xb8, 0xe1, 0xc3, 0xf7, 0x1e, 0x23, 0x4e, 0xe7, 0x24, 0xf8, 0x4d, 0xd6, 0x8a, 0xf1, 0xad, 0x9f,
+	0xa8, 0xa8, 0x8c, 0xcb, 0x08, 0xe8, 0x59, 0x3f, 0x51, 0xbc, 0xac, 0x0c, 0x19, 0xb8, 0xef, 0xa8,
+	0x23, 0x72, 0x2d, 0x46, 0x7e, 0x8a, 0x00, 0xb5, 0x05, 0x1f, 0xe9, 0x74, 0xe4, 0x8e, 0xc7, 0xd4,
+	0x31, 0x13, 0x75, 0xec, 0x82, 0x76, 0x9c, 0x6d, 0x8d, 0xad, 0x40, 0xd8, 0x95, 0x2f, 0xd4, 0x7f,
+	0x57, 0x60, 0x39, 0xe2, 0xc1, 0xba, 0x3a, 0xe4, 0x13, 0xc8, 0x63, 0x8d, 0xc6, 0x36, 0xcf, 0x95,
+	0x6f, 0x0c, 0x45, 0x7e, 0x13, 0x95, 0x37, 0x5c, 0xc3, 0x3f, 0x91, 0x34, 0x94, 0x79, 0xcd, 0x56,
+	0x37, 0xeb, 0x50, 0xf0, 0x47, 0xae, 0xc7, 0x15, 0x55, 0x74, 0xbe, 0x50, 0xf7, 0xa2, 0xca, 0x66,
+	0x0d, 0x56, 0x7a, 0x9d, 0xd7, 0x9d, 0x6e, 0x4b, 0x1f, 0xe0, 0x6b, 0xa3, 0xe9, 0xbd, 0x7a, 0x86,
+	0xac, 0x40, 0xb5, 0xd7, 0x7a, 0xad, 0x0d, 0x5a, 0xfd, 0xd3, 0xc3, 0x63, 0xbd, 0xae, 0x10, 0x02,
+	0xcb, 0x0c, 0x70, 0xd2, 0xdf, 0xeb, 0x76, 0x7a, 0x87, 0x9a, 0x5e, 0xcf, 0xaa, 0xff, 0xa4, 0xc0,
+	0xc6, 0xac, 0x2d, 0x44, 0x60, 0x68, 0xc1, 0x8a, 0x97, 0x90, 0x2a, 0x8c, 0x39, 0xf7, 0x16, 0x48,
+	0xad, 0xcf, 0xd2, 0xe3, 0x83, 0x3a, 0x76, 0x4d, 0x6a, 0x0f, 0x86, 0x53, 0xcb, 0xe6, 0x16, 0xbc,
+	0xe5, 0x41, 0x65, 0xe4, 0x7b, 0x48, 0xad, 0xfe, 0x63, 0x0e, 0xb3, 0x20, 0xc3, 0xb4, 0x9c, 0x73,
+	0xcd, 0x09, 0xbc, 0xeb, 0xd4, 0xd3, 0x09, 0x43, 0x4a, 0x56, 0x0a, 0x29, 0x5f, 0x41, 0xc1, 0xbf,
+	0xa0, 0xf6, 0x99, 0xa8, 0x21, 0x3f, 0x92, 0xc4, 0x65, 0xdc, 0x7a, 0x88, 0xd4, 0x39, 0x0d, 0x59,
+	0x87, 0xfc, 0x24, 0xac, 0x1a, 0x0b, 0x87, 0x19, 0x9d, 0xad, 0xb0, 0x8f, 0x17, 0xa6, 0x0b, 0xd1,
+	0x23, 0x54, 0x10, 0x14, 0x2b, 0x21, 0x26, 0x7c, 0x86, 0x7e, 0x0d, 0x25, 0x3f, 0x30, 0xbc, 0x3b,
+	0x86, 0x25, 0x41, 0x8a, 0x69, 0xf8, 0x99, 0xe5, 0xf0, 0x16, 0xec, 0x1d, 0xd2, 0xf0, 0x90, 0x96,
+	0x7c, 0x03, 0x20, 0x62, 0xd4, 0xc0, 0x08, 0xee, 0x90, 0xa3, 0x54, 0x04, 0x75, 0x2b, 0x60, 0xa1,
+	0x9e, 0x15, 0x78, 0x66, 0xa3, 0x22, 0x42, 0x3d, 0x5f, 0xb2, 0x46, 0x23, 0xde, 0x01, 0x67, 0x44,
+	0x59, 0x7f, 0x34, 0xa7, 0x47, 0xeb, 0x3d, 0x80, 0xf2, 0xc4, 0x73, 0xcf, 0x59, 0x83, 0xed, 0xbf,
+	0x15, 0x58, 0x0f, 0x43, 0x13, 0xb3, 0xe5, 0x4d, 0x37, 0x27, 0x3a, 0x87, 0xec, 0x07, 0x9c, 0x43,
+	0xee, 0xd6, 0x73, 0xc8, 0x2f, 0x3a, 0x87, 0xa4, 0x65, 0x0a, 0x1f, 0x60, 0x99, 0x84, 0x8e, 0x14,
+	0xd6, 0x79, 0x19, 0x7c, 0x07, 0x15, 0x93, 0x9f, 0xcc, 0x7e, 0xc0, 0x27, 0x55, 0x3b, 0x0c, 0x8b,
+	0x89, 0x8f, 0xec, 0x40, 0x09, 0xed, 0x71, 0x29, 0x1e, 0xf8, 0x85, 0x56, 0x0b, 0xa9, 0x58, 0x03,
+	0xf6, 0x2c, 0xa0, 0xde, 0x20, 0x3a, 0x3f, 0xfe, 0xe2, 0x2e, 0x31, 0x68, 0x4f, 0x00, 0xd5, 0x03,
+	0x9e, 0x68, 0x44, 0x5f, 0x13, 0x77, 0x7c, 0x07, 0x4a, 0xd4, 0x09, 0xbc, 0xb8, 0xb4, 0x9a, 0xfb,
+	0x1c, 0xbb, 0x7a, 0x7a, 0x48, 0xa5, 0x5e, 0x84, 0x7c, 0x44, 0x30, 0x8f, 0x9b, 0x63, 0x3c, 0xe0,
+	0xcf, 0xe7, 0x25, 0x9c, 0x54, 0x0f, 0xf1, 0xd8, 0xb7, 0x66, 0x99, 0x92, 0x14, 0xa0, 0xf9, 0xe5,
+	0x5d, 0x42, 0xf0, 0x49, 0x14, 0xa4, 0x5f, 0x41, 0x15, 0xe3, 0xd1, 0x6b, 0xde, 0xc6, 0x41, 0xff,
+	0x88, 0x53, 0x45, 0xf4, 0x0f, 0x31, 0x64, 0x89, 0xda, 0x3e, 0x59, 0x81, 0x08, 0x01, 0x7b, 0x15,
+	0x4c, 0xf3, 0x1d, 0xd6, 0x5f, 0xde, 0x82, 0x1a, 0x6f, 0x6c, 0xc6, 0x39, 0x4f, 0x62, 0x5b, 0xb4,
+	0x09, 0x6b, 0xb4, 0xce, 0x78, 0xe2, 0x7a, 0xc1, 0xf1, 0x84, 0x87, 0xb0, 0x7b, 0x50, 0x32, 0xbd,
+	0x6b, 0x96, 0xd7, 0xf1, 0xf4, 0xa8, 0x68, 0x7a, 0xd7, 0x98, 0xba, 0x5d, 0x01, 0xe1, 0x94, 0x89,
+	0x17, 0x64, 0x17, 0x4a, 0xee, 0x24, 0x0c, 0x96, 0xe8, 0x0d, 0x1b, 0x91, 0x21, 0x12, 0x7c, 0x51,
+	0x50, 0x41, 0x48, 0x3e, 0x15, 0x0f, 0x47, 0x36, 0xe5, 0xe1, 0x40, 0x4d, 0x11, 0x29, 0x6b, 0xf3,
+	0x3b, 0xa8, 0x72, 0x5e, 0x1f, 0xda, 0x5d, 0x93, 0xd4, 0xce, 0x25, 0xd5, 0x7e, 0x0f, 0x6b, 0x09,
+	0x65, 0x62, 0x3b, 0x85, 0x09, 0x17, 0x67, 0x1e, 0x2e, 0x11, 0x13, 0x26, 0x55, 0xfc, 0x5d, 0x0c,
+	0x97, 0xe4, 0x71, 0xd4, 0x32, 0xcc, 0xcd, 0x8c, 0x6d, 0x24, 0xa1, 0xa3, 0x76, 0xe1, 0x33, 0x20,
+	0xda, 0xd5, 0x9c, 0x15, 0x71, 0xf0, 0x40, 0xfd, 0xe9, 0x38, 0x74, 0x10, 0x9e, 0x53, 0x54, 0x39,
+	0x8c, 0xbb, 0xc7, 0x1f, 0x60, 0x4d, 0xbb, 0x9a, 0x97, 0xf8, 0x0e, 0x8f, 0xf0, 0x2c, 0xf3, 0xec,
+	0x3c, 0xf3, 0x3f, 0x2a, 0xb0, 0xca, 0xea, 0x96, 0x0f, 0xef, 0x72, 0xdf, 0xed, 0x3e, 0xca, 0x45,
+	0x3a, 0xbd, 0xb2, 0x7c, 0x96, 0xb8, 0xe5, 0x12, 0x45, 0xba, 0x26, 0xc0, 0xc9, 0xbe, 0x79, 0x7e,
+	0x66, 0x06, 0xf9, 0x2f, 0x0a, 0x54, 0x50, 0x48, 0xed, 0x92, 0xb2, 0xf1, 0x53, 0x9e, 0xcd, 0x01,
+	0x15, 0x16, 0x70, 0xef, 0x25, 0xb4, 0x67, 0x14, 0xdb, 0x6c, 0x1a, 0xc8, 0x88, 0x12, 0x31, 0x3f,
+	0x9b, 0x8c, 0xf9, 0x91, 0x19, 0x73, 0x0b, 0xcd, 0xa8, 0xbe, 0x80, 0x3c, 0x32, 0x23, 0x35, 0x28,
+	0x6b, 0xbf, 0xef, 0xf4, 0x44, 0xbd, 0x5b, 0x85, 0x52, 0x5b, 0xd7, 0x5a, 0xa7, 0x1a, 0x76, 0x52,
+	0xab, 0x50, 0xea, 0x9f, 0xec, 0xb3, 0x45, 0x16, 0x17, 0xfb, 0x5a, 0x57, 0xc3, 0x45, 0x0e, 0xc5,
+	0xce, 0xf7, 0xfd, 0x05, 0x33, 0xe0, 0x4f, 0xa0, 0x66, 0x5a, 0xfe, 0xc4, 0x36, 0xae, 0xe5, 0x99,
+	0x48, 0x55, 0xc0, 0xd8, 0x50, 0xa4, 0x0e, 0xb9, 0xa1, 0xe5, 0x0a, 0x2f, 0xc6, 0x9f, 0xe4, 0x57,
+	0x40, 0xce, 0x8c, 0x4b, 0x77, 0xea, 0x59, 0x01, 0x1d, 0x44, 0x43, 0xb3, 0x3c, 0x1b, 0x9a, 0xad,
+	0x46, 0x98, 0x9e, 0x40, 0xc8, 0xa5, 0x44, 0xe1, 0xce, 0xa5, 0x84, 0x6a, 0xc2, 0x8a, 0x4e, 0xcf,
+	0x2d, 0x3f, 0x88, 0x9b, 0xfa, 0x8b, 0x46, 0xbf, 0x86, 0xef, 0xbf, 0x77, 0xbd, 0xa8, 0xc3, 0x14,
+	0xae, 0xe7, 0x94, 0xcb, 0xcd, 0x29, 0xa7, 0x7e, 0x0b, 0xb5, 0xae, 0x7b, 0x6e, 0x39, 0x3f, 0xf3,
+	0x13, 0xea, 0x5f, 0x2b, 0xb0, 0x24, 0x18, 0xc4, 0xb7, 0x82, 0x65, 0x49, 0xb3, 0xb7, 0x02, 0x8f,
+	0x40, 0x24, 0x4d, 0x0d, 0x28, 0xf9, 0xd4, 0xf7, 0xc3, 0x69, 0x75, 0x45, 0x0f, 0x97, 0x68, 0x2a,
+	0x7a, 0x35, 0xb1, 0x3c, 0x31, 0x81, 0xba, 0xc5, 0x54, 0x82, 0x54, 0x8c, 0x93, 0xd8, 0x07, 0x16,
+	0xab, 0xa1, 0xfe, 0x14, 0xa6, 0x13, 0x27, 0x9e, 0x7b, 0x66, 0xd9, 0x54, 0x0a, 0x00, 0x09, 0x2b,
+	0x29, 0x0b, 0x5d, 0x20, 0x7b, 0x9b, 0x0b, 0xe4, 0x16, 0xb8, 0xc0, 0xa3, 0x67, 0x50, 0x0e, 0xe7,
+	0xe3, 0xd8, 0x8c, 0x39, 0x6c, 0xe9, 0xfb, 0xed, 0xe3, 0x37, 0x9a, 0xce, 0x27, 0x02, 0x27, 0xad,
+	0x13, 0x4d, 0xdf, 0x6b, 0xb5, 0x7f, 0xe0, 0x9d, 0x9b, 0x56, 0x7f, 0xbf, 0x73, 0xbc, 0x77, 0x7c,
+	0xfc, 0x43, 0x3d, 0xfb, 0xe8, 0x0c, 0x27, 0x1c, 0x89, 0x7f, 0x0b, 0x10, 0x80, 0xa2, 0x48, 0xb3,
+	0x33, 0xf8, 0x5b, 0xdb, 0xef, 0x9c, 0xb2, 0x94, 0x7b, 0x19, 0xe0, 0x54, 0x6f, 0x1d, 0xf5, 0xba,
+	0x2d, 0x5c, 0x67, 0x31, 0x27, 0xef, 0x74, 0xbb, 0xfd, 0xde, 0xa9, 0xce, 0x00, 0x39, 0xbc, 0x3d,
+	0x47, 0x2d, 0x9d, 0xaf, 0xf2, 0xb8, 0x3a, 0x38, 0xd6, 0xb5, 0xb7, 0xc7, 0xfa, 0x7e, 0xbd, 0xf0,
+	0xe8, 0x09, 0x40, 0x3c, 0x96, 0xc1, 0xfb, 0x73, 0xa2, 0x77, 0xde, 0xe0, 0x50, 0x22, 0x83, 0x84,
+	0xfd, 0xa3, 0x6e, 0xa7, 0xc7, 0xef, 0x19, 0x40, 0x91, 0xe5, 0xf4, 0xed, 0x7a, 0xf6, 0xd1, 0x57,
+	0x50, 0x95, 0xaa, 0x25, 0x44, 0x1d, 0x69, 0x6f, 0xb5, 0xde, 0x69, 0x3d, 0x43, 0xea, 0x50, 0x7b,
+	0x7d, 0xdc, 0x3b, 0x1d, 0x1c, 0x6a, 0xdd, 0x93, 0x83, 0x7e, 0xb7, 0xae, 0x3c, 0x7a, 0x01, 0x35,
+	0x39, 0xab, 0x40, 0x8a, 0xb7, 0xad, 0xa3, 0xd3, 0xc1, 0xe9, 0x31, 0xab, 0x23, 0xf8, 0x7d, 0xc6,
+	0x5f, 0x78, 0xb9, 0x15, 0x26, 0x5e, 0xe7, 0x08, 0x4b, 0x87, 0xfd, 0x7a, 0x76, 0xf7, 0x9f, 0xd7,
+	0xf8, 0x0b, 0xdd, 0xe3, 0xff, 0x22, 0x21, 0x4f, 0xa0, 0x24, 0x06, 0x88, 0x24, 0x8e, 0x3c, 0xc9,
+	0x91, 0x62, 0x33, 0x19, 0x49, 0xd4, 0x0c, 0x79, 0x01, 0x10, 0x8f, 0x13, 0x49, 0x33, 0x42, 0xcf,
+	0xcd, 0x18, 0xe7, 0xb6, 0x7e, 0xad, 0x90, 0xe7, 0xb0, 0xfc, 0xda, 0x78, 0x27, 0x4d, 0x1a, 0x48,
+	0x92, 0xa8, 0x99, 0x36, 0x33, 0x51, 0x33, 0x5b, 0x0a, 0xf9, 0x2d, 0x3f, 0xfb, 0xf6, 0x85, 0x11,
+	0x90, 0xf5, 0xc4, 0x1e, 0x91, 0x6f, 0x34, 0x3f, 0x4a, 0x40, 0xc3, 0x9b, 0x84, 0x9b, 0xbf, 0x56,
+	0x48, 0x37, 0x7c, 0x81, 0xb9, 0xd8, 0xf7, 0x67, 0x9e, 0xb8, 0x84, 0xdc, 0x0f, 0xd2, 0x91, 0x31,
+	0x3f, 0xe4, 0xa6, 0x5d, 0xa5, 0x71, 0xd3, 0xae, 0x6e, 0xe0, 0x96, 0xf2, 0xfa, 0x31, 0xa3, 0x7c,
+	0x0f, 0x10, 0x3f, 0x5d, 0x92, 0x45, 0xe7, 0xde, 0xb3, 0x26, 0x99, 0x7f, 0x1f, 0x18, 0x87, 0x6f,
+	0xa0, 0x12, 0xcd, 0x56, 0xc9, 0x2f, 0xe4, 0xa3, 0x4c, 0xcc, 0x5b, 0x9b, 0xb3, 0x03, 0x50, 0x35,
+	0x43, 0x5e, 0x41, 0x55, 0x9a, 0x9d, 0x4a, 0xaa, 0xcc, 0x0f, 0x62, 0x9b, 0x0f, 0xd2, 0x91, 0xa1,
+	0x2a, 0x64, 0x0f, 0x6a, 0xf2, 0x14, 0x95, 0x3c, 0x90, 0x25, 0x99, 0x1d, 0xae, 0x36, 0x53, 0xfe,
+	0x9b, 0xa2, 0x66, 0x88, 0x06, 0x4b, 0x89, 0xe1, 0x1d, 0xf9, 0x58, 0x66, 0x32, 0x37, 0xa2, 0x5a,
+	0xe0, 0x30, 0xe4, 0x0d, 0x6f, 0xa5, 0xc5, 0x30, 0x9f, 0x3c, 0x4c, 0x48, 0x3f, 0x3f, 0xf5, 0x6b,
+	0x6e, 0x2e, 0x26, 0x88, 0x54, 0xec, 0xc0, 0xca, 0xcc, 0xc4, 0x4e, 0xe2, 0x9b, 0x3e, 0xcb, 0x5b,
+	0x24, 0x62, 0x0f, 0xd6, 0xd3, 0x86, 0x77, 0xe4, 0x97, 0x52, 0x22, 0xbe, 0x70, 0xb6, 0xb7, 0x88,
+	0xe9, 0x0f, 0x50, 0x9f, 0x1d, 0xe6, 0x91, 0x58, 0xaf, 0x05, 0x73, 0xbe, 0x45, 0xcc, 0x5e, 0x02,
+	0xc4, 0xb3, 0x1d, 0xc9, 0x31, 0xe7, 0x26, 0x43, 0xcd, 0xfb, 0xa9, 0xb8, 0xc8, 0x6a, 0xaf, 0xa1,
+	0x26, 0x77, 0x47, 0x49, 0xd2, 0x91, 0x66, 0x7a, 0xa9, 0xcd, 0x8f, 0x17, 0x60, 0x23, 0x76, 0x4f,
+	0xa0, 0x24, 0xc6, 0x46, 0x52, 0xdc, 0x4a, 0x0e, 0x92, 0x9a, 0xc9, 0x89, 0x93, 0x9a, 0xc1, 0x7e,
+	0x6f, 0x38, 0x28, 0x22, 0xd2, 0x50, 0x3c, 0x39, 0x3b, 0x6a, 0x26, 0x67, 0x27, 0x6a, 0x86, 0xfc,
+	0x29, 0x14, 0xf9, 0xf0, 0x85, 0x6c, 0x48, 0xe7, 0x22, 0xcd, 0x84, 0xe6, 0xb7, 0xec, 0x40, 0x81,
+	0xcd, 0x82, 0x88, 0x5c, 0x9a, 0xc5, 0xb3, 0xa1, 0xf9, 0x0d, 0xfb, 0x50, 0x89, 0x86, 0x3a, 0xd2,
+	0xf5, 0x9d, 0x9d, 0x13, 0x35, 0x9b, 0x69, 0xa8, 0xc8, 0x2a, 0xcf, 0xa0, 0x12, 0x8d, 0x20, 0x24,
+	0x2e, 0xb3, 0x63, 0x89, 0x66, 0xb2, 0xef, 0xaf, 0x66, 0xb0, 0x24, 0x8e, 0x67, 0x12, 0xd2, 0x31,
+	0xcf, 0x0d, 0x2a, 0xe6, 0xb7, 0x0a, 0xc9, 0x71, 0x35, 0x2b, 0xb9, 0x3c, 0x3e, 0x68, 0x36, 0xd3,
+	0x50, 0x91, 0xe4, 0xdf, 0x41, 0x4d, 0x9e, 0x39, 0x48, 0xee, 0x91, 0x32, 0x8a, 0x98, 0x13, 0xe2,
+	0x6b, 0x85, 0xb4, 0xa0, 0x1c, 0x76, 0xc9, 0xa5, 0xb3, 0x9d, 0xe9, 0xa5, 0x37, 0x7f, 0x91, 0x82,
+	0x91, 0x65, 0x90, 0x1b, 0xc3, 0x92, 0x0c, 0x29, 0xfd, 0xe2, 0xe6, 0x6c, 0xb1, 0xcc, 0x19, 0xc8,
+	0x2d, 0x60, 0x89, 0x41, 0x4a, 0x67, 0x78, 0x01, 0x03, 0xb9, 0xc7, 0x2b, 0x31, 0x48, 0x69, 0xfd,
+	0xa6, 0x31, 0xe8, 0xc0, 0xea, 0x5c, 0xc3, 0x97, 0x7c, 0x12, 0xd1, 0x2d, 0x6a, 0x06, 0xa7, 0xb1,
+	0x12, 0xaf, 0x82, 0x2e, 0x3a, 0x00, 0xc9, 0xeb, 0x9d, 0xec, 0x0b, 0x37, 0x1f, 0xa4, 0x23, 0x23,
+	0xcb, 0xf6, 0xa4, 0xbe, 0x2b, 0x7f, 0xe2, 0x52, 0x9a, 0xa8, 0x89, 0x67, 0xee, 0xe1, 0x42, 0x7c,
+	0xc4, 0xf4, 0x25, 0x2c, 0x25, 0xba, 0x5a, 0xd2, 0x33, 0x91, 0xd6, 0xed, 0x6a, 0xa6, 0x77, 0x49,
+	0x38, 0xa3, 0x44, 0xef, 0x48, 0x62, 0x94, 0xd6, 0x53, 0x5a, 0xcc, 0x28, 0x32, 0x19, 0x67, 0x33,
+	0x6b, 0xb2, 0x04, 0x93, 0x07, 0xe9, 0xc8, 0x50, 0xbb, 0xdd, 0xff, 0x51, 0xa0, 0x8a, 0x89, 0x78,
+	0x98, 0xa8, 0x3d, 0x85, 0x72, 0x58, 0xc5, 0x48, 0xfe, 0x3d, 0x53, 0xd8, 0x34, 0x93, 0x55, 0x82,
+	0x9a, 0x21, 0xcf, 0xa1, 0xc0, 0xaa, 0x0a, 0x29, 0x10, 0xc9, 0x65, 0x4a, 0x73, 0x63, 0x16, 0x2c,
+	0x47, 0x58, 0x51, 0x0b, 0x24, 0x33, 0xc3, 0xbe, 0x7f, 0xc3, 0xe7, 0xbe, 0x83, 0xa5, 0x44, 0x69,
+	0x30, 0x77, 0x26, 0xc9, 0x92, 0x61, 0x8e, 0xc1, 0xde, 0xdf, 0x28, 0xff, 0xfb, 0xfd, 0x77, 0x37,
+	0xfc, 0x53, 0xf9, 0xdc, 0x9b, 0x8c, 0xde, 0xd3, 0xe1, 0xaf, 0xe8, 0x95, 0x31, 0x9e, 0xd8, 0x74,
+	0x67, 0x64, 0x5b, 0xd4, 0x11, 0x7f, 0x60, 0x0e, 0xff, 0x27, 0xfd, 0xe7, 0x1f, 0xc2, 0x00, 0xff,
+	0x4e, 0x4d, 0xbd, 0x24, 0x83, 0x61, 0x91, 0x2d, 0x9f, 0xfc, 0xff, 0x00, 0x04, 0x4f, 0x62, 0xa2,
+	0x80, 0x2d, 0x00, 0x00,
 }
@@ -0,0 +1,245 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// Reading lists are kept in a store of their own, as the records
+// "reading/<user>/<isbn>". Books removed from the reading lists
+// are kept as records with Removed set, so that devices syncing
+// their changes learn about them. Each change is numbered from
+// the sequence readingSeq, which devices sync after, as the
+// clocks of devices cannot be trusted to tell what changed since.
+
+// maxClockSkew is how far ahead of the server the clocks
+// of devices may be, when telling when they made a change.
+const maxClockSkew = 5 * time.Minute
+
+// readingSeq is the kind of the sequence numbering changes.
+const readingSeq = "reading"
+
+func readingKey(user string, isbn int64) string {
+	return readingPrefix(user) + strconv.FormatInt(isbn, 10)
+}
+
+func readingPrefix(user string) string { return "reading/" + user + "/" }
+
+// changeTime returns the time of a change made at the time
+// updatedAt on a device, or now if it is not set. It returns
+// an InvalidArgument error if updatedAt is too far ahead of now.
+func changeTime(updatedAt *timestamp.Timestamp, now time.Time) (*timestamp.Timestamp, error) {
+	if updatedAt == nil {
+		return ptypes.TimestampProto(now)
+	}
+	at, err := ptypes.Timestamp(updatedAt)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid updated_at: %v", err)
+	}
+	if at.After(now.Add(maxClockSkew)) {
+		return nil, status.Errorf(codes.InvalidArgument, "updated_at %v is in the future", at.Format(time.RFC3339))
+	}
+	return updatedAt, nil
+}
+
+// newer reports whether a is after b.
+func newer(a, b *timestamp.Timestamp) bool {
+	if a.Seconds != b.Seconds {
+		return a.Seconds > b.Seconds
+	}
+	return a.Nanos > b.Nanos
+}
+
+// checkProgress returns an InvalidArgument error if req sets
+// progress which the book bk cannot have on the shelf of req.
+func checkProgress(bk *library.Book, req *library.UpdateReadingRequest) error {
+	if req.Progress == nil {
+		return nil
+	}
+	if req.Shelf == library.ReadingShelf_WANT_TO_READ {
+		return status.Error(codes.InvalidArgument, "books the user wants to read have no progress")
+	}
+	audiobook := bk.BookType == library.BookType_AUDIOBOOK
+	var progress, end int32
+	switch p := req.Progress.(type) {
+	case *library.UpdateReadingRequest_Page:
+		if audiobook {
+			return status.Error(codes.InvalidArgument, "the progress of audiobooks is a playback position")
+		}
+		progress, end = p.Page, bk.GetPageCount()
+	case *library.UpdateReadingRequest_PositionSeconds:
+		if !audiobook {
+			return status.Error(codes.InvalidArgument, "the progress of books other than audiobooks is a page")
+		}
+		progress, end = p.PositionSeconds, bk.GetDurationSeconds()
+	}
+	if progress < 0 || (end != 0 && progress > end) {
+		return status.Errorf(codes.InvalidArgument, "progress %d is not from 0 to %d", progress, end)
+	}
+	return nil
+}
+
+// finish sets the progress of e to the end of the book
+// bk, if the length of the book is known.
+func finish(e *library.ReadingEntry, bk *library.Book) {
+	switch x := bk.Extent.(type) {
+	case *library.Book_PageCount:
+		if bk.BookType != library.BookType_AUDIOBOOK {
+			e.Progress = &library.ReadingEntry_Page{Page: x.PageCount}
+		}
+	case *library.Book_DurationSeconds:
+		if bk.BookType == library.BookType_AUDIOBOOK {
+			e.Progress = &library.ReadingEntry_PositionSeconds{PositionSeconds: x.DurationSeconds}
+		}
+	}
+}
+
+func (s *BookService) UpdateReading(ctx context.Context, req *library.UpdateReadingRequest) (*library.ReadingEntry, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := library.ReadingShelf_name[int32(req.Shelf)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown shelf %d", req.Shelf)
+	}
+	updatedAt, err := changeTime(req.UpdatedAt, s.now())
+	if err != nil {
+		return nil, err
+	}
+	bk, ok := s.c.Get(req.Isbn)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Book could not be found")
+	}
+	if err := checkProgress(bk, req); err != nil {
+		return nil, err
+	}
+
+	e := &library.ReadingEntry{}
+	err = s.rd.Update(func(t *tx) error {
+		old := &library.ReadingEntry{}
+		if t.Get(readingKey(user, req.Isbn), old) {
+			if newer(old.UpdatedAt, updatedAt) {
+				// The entry was changed later on another device
+				e = old
+				return nil
+			}
+			if old.Removed {
+				old = &library.ReadingEntry{}
+			}
+		}
+		e = &library.ReadingEntry{
+			Isbn:      req.Isbn,
+			User:      user,
+			Shelf:     req.Shelf,
+			Started:   old.Started,
+			UpdatedAt: updatedAt,
+			Sequence:  t.NextSeq(readingSeq),
+		}
+		switch p := req.Progress.(type) {
+		case *library.UpdateReadingRequest_Page:
+			e.Progress = &library.ReadingEntry_Page{Page: p.Page}
+		case *library.UpdateReadingRequest_PositionSeconds:
+			e.Progress = &library.ReadingEntry_PositionSeconds{PositionSeconds: p.PositionSeconds}
+		default:
+			switch e.Shelf {
+			case library.ReadingShelf_READING:
+				e.Progress = old.Progress
+			case library.ReadingShelf_FINISHED:
+				finish(e, bk)
+			}
+		}
+		if e.Shelf != library.ReadingShelf_WANT_TO_READ && e.Started == nil {
+			e.Started = updatedAt
+		}
+		if e.Shelf == library.ReadingShelf_FINISHED {
+			e.Finished = old.Finished
+			if old.Shelf != library.ReadingShelf_FINISHED || e.Finished == nil {
+				e.Finished = updatedAt
+			}
+		}
+		return t.Put(readingKey(user, req.Isbn), e)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *BookService) RemoveReading(ctx context.Context, req *library.RemoveReadingRequest) (*library.ReadingEntry, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := changeTime(req.UpdatedAt, s.now())
+	if err != nil {
+		return nil, err
+	}
+	e := &library.ReadingEntry{}
+	err = s.rd.Update(func(t *tx) error {
+		if !t.Get(readingKey(user, req.Isbn), e) || e.Removed {
+			return status.Errorf(codes.NotFound, "Reading entry could not be found")
+		}
+		if newer(e.UpdatedAt, updatedAt) {
+			return nil
+		}
+		e = &library.ReadingEntry{
+			Isbn:      req.Isbn,
+			User:      user,
+			UpdatedAt: updatedAt,
+			Removed:   true,
+			Sequence:  t.NextSeq(readingSeq),
+		}
+		return t.Put(readingKey(user, req.Isbn), e)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *BookService) ListReading(ctx context.Context, req *library.ListReadingRequest) (*library.ListReadingResponse, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shelves := map[library.ReadingShelf]bool{}
+	for _, sh := range req.Shelves {
+		shelves[sh] = true
+	}
+	resp := &library.ListReadingResponse{}
+	s.rd.View(func(t *tx) error {
+		for _, k := range t.Keys(readingPrefix(user)) {
+			e := &library.ReadingEntry{}
+			t.Get(k, e)
+			switch {
+			case e.Sequence <= req.AfterSequence:
+			case req.AfterSequence == 0 && e.Removed:
+			case len(shelves) != 0 && !e.Removed && !shelves[e.Shelf]:
+			default:
+				resp.Entries = append(resp.Entries, e)
+			}
+		}
+		return nil
+	})
+	sort.Slice(resp.Entries, func(i, j int) bool {
+		a, b := resp.Entries[i], resp.Entries[j]
+		if !proto.Equal(a.UpdatedAt, b.UpdatedAt) {
+			return newer(a.UpdatedAt, b.UpdatedAt)
+		}
+		return a.Isbn < b.Isbn
+	})
+	return resp, nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func updateReading(t *testing.T, client *testClient, user string, req *library.UpdateReadingRequest) *library.ReadingEntry {
+	t.Helper()
+	ctx, cancel := client.userContext(t, user)
+	defer cancel()
+	e, err := client.UpdateReading(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+// listReading describes the entries listed for user by
+// req, such as "60929871 READING page:12".
+func listReading(t *testing.T, client *testClient, user string, req *library.ListReadingRequest) []string {
+	t.Helper()
+	ctx, cancel := client.userContext(t, user)
+	defer cancel()
+	resp, err := client.ListReading(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var es []string
+	for _, e := range resp.Entries {
+		if e.Removed {
+			es = append(es, fmt.Sprintf("%d removed", e.Isbn))
+			continue
+		}
+		switch p := e.Progress.(type) {
+		case *library.ReadingEntry_Page:
+			es = append(es, fmt.Sprintf("%d %v page:%d", e.Isbn, e.Shelf, p.Page))
+		case *library.ReadingEntry_PositionSeconds:
+			es = append(es, fmt.Sprintf("%d %v position:%d", e.Isbn, e.Shelf, p.PositionSeconds))
+		default:
+			es = append(es, fmt.Sprintf("%d %v", e.Isbn, e.Shelf))
+		}
+	}
+	return es
+}
+
+// lastSequence returns the largest sequence of the entries
+// of user, as a device syncing their reading lists would.
+func lastSequence(t *testing.T, client *testClient, user string) int64 {
+	t.Helper()
+	ctx, cancel := client.userContext(t, user)
+	defer cancel()
+	resp, err := client.ListReading(ctx, &library.ListReadingRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seq int64
+	for _, e := range resp.Entries {
+		if e.Sequence > seq {
+			seq = e.Sequence
+		}
+	}
+	return seq
+}
+
+func at(t *testing.T, tm time.Time) *timestamp.Timestamp {
+	t.Helper()
+	ts, err := ptypes.TimestampProto(tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func TestReading(t *testing.T) {
+	clock := newFakeClock(testNow)
+	client, cleanup := newServiceClient(t, &BookService{Clock: clock})
+	defer cleanup()
+
+	updateReading(t, client, "alice", &library.UpdateReadingRequest{Isbn: books[3].Isbn})
+	clock.Advance(time.Minute)
+	e := updateReading(t, client, "alice", &library.UpdateReadingRequest{
+		Isbn:     books[0].Isbn,
+		Shelf:    library.ReadingShelf_READING,
+		Progress: &library.UpdateReadingRequest_Page{Page: 12},
+	})
+	if e.User != "alice" || e.Started.Seconds != clock.Now().Unix() || e.UpdatedAt.Seconds != clock.Now().Unix() || e.Finished != nil {
+		t.Errorf("got entry %v, want alice reading since now", e)
+	}
+	clock.Advance(time.Minute)
+	updateReading(t, client, "alice", &library.UpdateReadingRequest{
+		Isbn:     books[2].Isbn,
+		Shelf:    library.ReadingShelf_READING,
+		Progress: &library.UpdateReadingRequest_PositionSeconds{PositionSeconds: 600},
+	})
+	updateReading(t, client, "bob", &library.UpdateReadingRequest{Isbn: books[0].Isbn})
+
+	want := "[9780140301694 READING position:600 60929871 READING page:12 140008381 WANT_TO_READ]"
+	if got := listReading(t, client, "alice", &library.ListReadingRequest{}); fmt.Sprint(got) != want {
+		t.Errorf("got reading lists %v, want %s", got, want)
+	}
+	got := listReading(t, client, "alice", &library.ListReadingRequest{Shelves: []library.ReadingShelf{library.ReadingShelf_WANT_TO_READ}})
+	if fmt.Sprint(got) != "[140008381 WANT_TO_READ]" {
+		t.Errorf("got books alice wants to read %v, want Animal Farm", got)
+	}
+
+	t.Run("Finish", func(t *testing.T) {
+		clock.Advance(time.Hour)
+		e := updateReading(t, client, "alice", &library.UpdateReadingRequest{Isbn: books[0].Isbn, Shelf: library.ReadingShelf_FINISHED})
+		if e.GetPage() != 288 || e.Finished.Seconds != clock.Now().Unix() || e.Started.Seconds == clock.Now().Unix() {
+			t.Errorf("got entry %v, want finished now at page 288", e)
+		}
+		// Going back to the book keeps the progress
+		e = updateReading(t, client, "alice", &library.UpdateReadingRequest{Isbn: books[0].Isbn, Shelf: library.ReadingShelf_READING})
+		if e.GetPage() != 288 || e.Finished != nil {
+			t.Errorf("got entry %v, want reading at page 288", e)
+		}
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		clock.Advance(time.Hour)
+		synced := lastSequence(t, client, "alice")
+		// The phone of alice syncs a later position than the tablet
+		phone := at(t, clock.Now().Add(-time.Minute))
+		tablet := at(t, clock.Now().Add(-2*time.Minute))
+		e := updateReading(t, client, "alice", &library.UpdateReadingRequest{
+			Isbn:      books[2].Isbn,
+			Shelf:     library.ReadingShelf_READING,
+			Progress:  &library.UpdateReadingRequest_PositionSeconds{PositionSeconds: 1200},
+			UpdatedAt: phone,
+		})
+		if e.GetPositionSeconds() != 1200 {
+			t.Errorf("got entry %v, want position 1200", e)
+		}
+		e = updateReading(t, client, "alice", &library.UpdateReadingRequest{
+			Isbn:      books[2].Isbn,
+			Shelf:     library.ReadingShelf_READING,
+			Progress:  &library.UpdateReadingRequest_PositionSeconds{PositionSeconds: 900},
+			UpdatedAt: tablet,
+		})
+		if e.GetPositionSeconds() != 1200 || e.UpdatedAt.Seconds != phone.Seconds {
+			t.Errorf("got entry %v, want the change of the phone", e)
+		}
+
+		// Devices fetch the changes made since they last synced
+		got := listReading(t, client, "alice", &library.ListReadingRequest{AfterSequence: synced})
+		if fmt.Sprint(got) != "[9780140301694 READING position:1200]" {
+			t.Errorf("got changes %v, want the change of the phone", got)
+		}
+	})
+
+	t.Run("SlowClock", func(t *testing.T) {
+		// Changes from a device with a clock behind the
+		// others are synced by the devices synced since
+		synced := lastSequence(t, client, "alice")
+		updateReading(t, client, "alice", &library.UpdateReadingRequest{
+			Isbn:      books[1].Isbn,
+			UpdatedAt: at(t, clock.Now().Add(-24*time.Hour)),
+		})
+		got := listReading(t, client, "alice", &library.ListReadingRequest{AfterSequence: synced})
+		if fmt.Sprint(got) != "[140009728 WANT_TO_READ]" {
+			t.Errorf("got changes %v, want the change of the slow device", got)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		clock.Advance(time.Hour)
+		synced := lastSequence(t, client, "alice")
+		ctx, cancel := client.userContext(t, "alice")
+		defer cancel()
+		e, err := client.RemoveReading(ctx, &library.RemoveReadingRequest{Isbn: books[3].Isbn})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !e.Removed || e.UpdatedAt.Seconds != clock.Now().Unix() {
+			t.Errorf("got entry %v, want removed now", e)
+		}
+		_, err = client.RemoveReading(ctx, &library.RemoveReadingRequest{Isbn: books[3].Isbn})
+		checkCode(t, err, codes.NotFound)
+
+		if got := listReading(t, client, "alice", &library.ListReadingRequest{AfterSequence: synced}); fmt.Sprint(got) != "[140008381 removed]" {
+			t.Errorf("got changes %v, want Animal Farm removed", got)
+		}
+		want := "[9780140301694 READING position:1200 60929871 READING page:288 140009728 WANT_TO_READ]"
+		if got := listReading(t, client, "alice", &library.ListReadingRequest{}); fmt.Sprint(got) != want {
+			t.Errorf("got reading lists %v, want %s", got, want)
+		}
+
+		// Books can be added again after being removed
+		e = updateReading(t, client, "alice", &library.UpdateReadingRequest{Isbn: books[3].Isbn, Shelf: library.ReadingShelf_READING})
+		if e.Removed || e.Progress != nil || e.Started.Seconds != clock.Now().Unix() {
+			t.Errorf("got entry %v, want Animal Farm started again", e)
+		}
+	})
+
+	for _, test := range []struct {
+		name string
+		user string
+		req  *library.UpdateReadingRequest
+		code codes.Code
+	}{
+		{"NoUser", "", &library.UpdateReadingRequest{Isbn: books[0].Isbn}, codes.Unauthenticated},
+		{"UnknownBook", "alice", &library.UpdateReadingRequest{Isbn: 1}, codes.NotFound},
+		{"UnknownShelf", "alice", &library.UpdateReadingRequest{Isbn: books[0].Isbn, Shelf: 10}, codes.InvalidArgument},
+		{"PastLastPage", "alice", &library.UpdateReadingRequest{Isbn: books[0].Isbn, Shelf: library.ReadingShelf_READING, Progress: &library.UpdateReadingRequest_Page{Page: 289}}, codes.InvalidArgument},
+		{"AudiobookPage", "alice", &library.UpdateReadingRequest{Isbn: books[2].Isbn, Shelf: library.ReadingShelf_READING, Progress: &library.UpdateReadingRequest_Page{Page: 1}}, codes.InvalidArgument},
+		{"BookPosition", "alice", &library.UpdateReadingRequest{Isbn: books[0].Isbn, Shelf: library.ReadingShelf_READING, Progress: &library.UpdateReadingRequest_PositionSeconds{PositionSeconds: 1}}, codes.InvalidArgument},
+		{"WantToReadProgress", "alice", &library.UpdateReadingRequest{Isbn: books[0].Isbn, Progress: &library.UpdateReadingRequest_Page{Page: 1}}, codes.InvalidArgument},
+		{"Future", "alice", &library.UpdateReadingRequest{Isbn: books[0].Isbn, UpdatedAt: at(t, clock.Now().Add(time.Hour))}, codes.InvalidArgument},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := client.userContext(t, test.user)
+			defer cancel()
+			_, err := client.UpdateReading(ctx, test.req)
+			checkCode(t, err, test.code)
+		})
+	}
+
+	t.Run("UserMetadata", func(t *testing.T) {
+		// Naming the reader without their session is not enough
+		ctx, cancel := client.userContext(t, "", "user", "alice")
+		defer cancel()
+		_, err := client.ListReading(ctx, &library.ListReadingRequest{})
+		checkCode(t, err, codes.Unauthenticated)
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// defaultRecommendations and maxRecommendations are the number
+// of books recommended when not asked for, and at most.
+const (
+	defaultRecommendations = 5
+	maxRecommendations     = 20
+)
+
+// similarBook is a book liked by the readers
+// of another, with the similarity of the books.
+type similarBook struct {
+	isbn  int64
+	score float64
+}
+
+// recommender serves the similar books found by the last model built.
+// The zero value has not built a model, and recommends no similar books.
+type recommender struct {
+	mu    sync.RWMutex
+	built time.Time
+	// similar holds the books most similar to each book,
+	// up to maxRecommendations, most similar first.
+	similar map[int64][]similarBook
+}
+
+// Set replaces the model with similar, built at the time built.
+func (rc *recommender) Set(similar map[int64][]similarBook, built time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.similar = similar
+	rc.built = built
+}
+
+// Similar returns the books most similar to the book with the
+// ISBN isbn, and when the model was built. The books must not
+// be changed.
+func (rc *recommender) Similar(isbn int64) ([]similarBook, time.Time) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.similar[isbn], rc.built
+}
+
+// likes returns how much each reader liked the books they collected,
+// borrowed or rated, by reader and ISBN. Readers are users, or the
+// collections made without one. Collecting or borrowing a book counts
+// for 1, and ratings from 1 to 5 for -1 to 1. Books which were
+// disliked overall are left out.
+func (s *BookService) likes() map[string]map[int64]float64 {
+	likes := map[string]map[int64]float64{}
+	like := func(reader string, isbn int64, w float64) {
+		if likes[reader] == nil {
+			likes[reader] = map[int64]float64{}
+		}
+		likes[reader][isbn] += w
+	}
+	for _, c := range s.cs.All() {
+		reader := "user " + c.Owner
+		if c.Owner == "" {
+			reader = "collection " + c.Id
+		}
+		for _, bk := range c.Books {
+			like(reader, bk.Isbn, 1)
+		}
+	}
+	s.circ.store().View(func(t *tx) error {
+		for _, l := range loans(t, func(*library.Loan) bool { return true }) {
+			like("user "+l.Borrower, l.Isbn, 1)
+		}
+		return nil
+	})
+	s.rv.View(func(t *tx) error {
+		for _, k := range t.Keys(reviewKey("")) {
+			rv := &library.Review{}
+			t.Get(k, rv)
+			like("user "+rv.User, rv.Isbn, float64(rv.Rating-3)/2)
+		}
+		return nil
+	})
+	for _, books := range likes {
+		for isbn, w := range books {
+			if w <= 0 {
+				delete(books, isbn)
+			}
+		}
+	}
+	return likes
+}
+
+// similarBooks returns the books most similar to each book, by the
+// cosine similarity of how much each reader liked them, from likes.
+func similarBooks(likes map[string]map[int64]float64) map[int64][]similarBook {
+	norms := map[int64]float64{}
+	dots := map[int64]map[int64]float64{}
+	for _, books := range likes {
+		for a, wa := range books {
+			norms[a] += wa * wa
+			for b, wb := range books {
+				if a == b {
+					continue
+				}
+				if dots[a] == nil {
+					dots[a] = map[int64]float64{}
+				}
+				dots[a][b] += wa * wb
+			}
+		}
+	}
+	similar := map[int64][]similarBook{}
+	for a, ds := range dots {
+		var sbs []similarBook
+		for b, dot := range ds {
+			sbs = append(sbs, similarBook{isbn: b, score: dot / math.Sqrt(norms[a]*norms[b])})
+		}
+		sort.Slice(sbs, func(i, j int) bool {
+			if sbs[i].score != sbs[j].score {
+				return sbs[i].score > sbs[j].score
+			}
+			return sbs[i].isbn < sbs[j].isbn
+		})
+		if len(sbs) > maxRecommendations {
+			sbs = sbs[:maxRecommendations]
+		}
+		similar[a] = sbs
+	}
+	return similar
+}
+
+func (s *BookService) RecommendBooks(ctx context.Context, req *library.RecommendBooksRequest) (*library.RecommendBooksResponse, error) {
+	limit := int(req.Limit)
+	switch {
+	case limit < 0:
+		return nil, status.Error(codes.InvalidArgument, "negative limit")
+	case limit == 0:
+		limit = defaultRecommendations
+	case limit > maxRecommendations:
+		limit = maxRecommendations
+	}
+	bk, ok := s.c.Get(req.Isbn)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Book could not be found")
+	}
+
+	resp := &library.RecommendBooksResponse{}
+	seen := map[int64]bool{bk.Isbn: true}
+	add := func(other *library.Book, reason library.Recommendation_Reason, score float64) {
+		if len(resp.Recommendations) == limit || seen[other.Isbn] {
+			return
+		}
+		seen[other.Isbn] = true
+		resp.Recommendations = append(resp.Recommendations, &library.Recommendation{
+			Book:   other,
+			Reason: reason,
+			Score:  score,
+		})
+	}
+
+	similar, built := s.rec.Similar(bk.Isbn)
+	if !built.IsZero() {
+		var err error
+		resp.ModelBuilt, err = ptypes.TimestampProto(built)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, sb := range similar {
+		if other, ok := s.c.Get(sb.isbn); ok {
+			add(other, library.Recommendation_SIMILAR_READERS, sb.score)
+		}
+	}
+
+	// Books with too few readers get books by the
+	// same authors, and then of the same publisher.
+	for _, c := range bk.Contributors {
+		if c.Role != library.ContributorRole_AUTHOR || c.AuthorId == "" {
+			continue
+		}
+		for _, other := range s.c.Query(bookFilter{authorID: c.AuthorId}) {
+			add(other, library.Recommendation_SAME_AUTHOR, 0)
+		}
+	}
+	if p := bk.GetPublisher(); p.GetId() != "" {
+		for _, other := range s.c.Query(bookFilter{}) {
+			if other.GetPublisher().GetId() == p.Id {
+				add(other, library.Recommendation_SAME_PUBLISHER, 0)
+			}
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func recommend(t *testing.T, client *testClient, isbn int64, limit int32) *library.RecommendBooksResponse {
+	t.Helper()
+	ctx, cancel := testContext()
+	defer cancel()
+	resp, err := client.RecommendBooks(ctx, &library.RecommendBooksRequest{Isbn: isbn, Limit: limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// recommended describes the recommendations of resp,
+// such as "140008381 SAME_AUTHOR".
+func recommended(resp *library.RecommendBooksResponse) []string {
+	var rs []string
+	for _, r := range resp.Recommendations {
+		rs = append(rs, fmt.Sprintf("%d %v", r.Book.Isbn, r.Reason))
+	}
+	return rs
+}
+
+func TestRecommendBooks(t *testing.T) {
+	clock := newFakeClock(testNow)
+	srv := &BookService{Clock: clock}
+	client, cleanup := newServiceClient(t, srv)
+	defer cleanup()
+
+	// Before the model is built, only books by the
+	// same authors or publisher are recommended.
+	resp := recommend(t, client, books[1].Isbn, 0)
+	if resp.ModelBuilt != nil || fmt.Sprint(recommended(resp)) != "[140008381 SAME_AUTHOR]" {
+		t.Errorf("got recommendations %v built at %v, want Animal Farm", recommended(resp), resp.ModelBuilt)
+	}
+
+	// alice and bob read Nineteen Eighty-Four and Alice's Adventures
+	// in Wonderland, but only alice liked Brave New World.
+	for _, user := range []string{"alice", "bob"} {
+		checkOut(t, client, user, &library.CheckOutRequest{Isbn: books[1].Isbn})
+		submitReview(t, client, user, books[2].Isbn, 5)
+	}
+	submitReview(t, client, "alice", books[0].Isbn, 4)
+	submitReview(t, client, "bob", books[0].Isbn, 1)
+	if _, err := srv.computeRecommendations(clock.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	resp = recommend(t, client, books[1].Isbn, 0)
+	want := "[9780140301694 SIMILAR_READERS 60929871 SIMILAR_READERS 140008381 SAME_AUTHOR]"
+	if fmt.Sprint(recommended(resp)) != want {
+		t.Errorf("got recommendations %v, want %s", recommended(resp), want)
+	}
+	if built := time.Unix(resp.ModelBuilt.Seconds, 0); !built.Equal(clock.Now()) {
+		t.Errorf("got model built at %v, want %v", built, clock.Now())
+	}
+	// The books read by the same readers are the most similar, and
+	// Brave New World was only liked half as much by one of them.
+	if got := resp.Recommendations[0].Score; math.Abs(got-1) > 1e-9 {
+		t.Errorf("got score %v, want 1", got)
+	}
+	if got, want := resp.Recommendations[1].Score, 0.5/math.Sqrt(2*0.25); math.Abs(got-want) > 1e-9 {
+		t.Errorf("got score %v, want %v", got, want)
+	}
+
+	resp = recommend(t, client, books[1].Isbn, 1)
+	if fmt.Sprint(recommended(resp)) != "[9780140301694 SIMILAR_READERS]" {
+		t.Errorf("got recommendations %v, want only the most similar", recommended(resp))
+	}
+
+	ctx, cancel := testContext()
+	defer cancel()
+	_, err := client.RecommendBooks(ctx, &library.RecommendBooksRequest{Isbn: 1})
+	checkCode(t, err, codes.NotFound)
+	_, err = client.RecommendBooks(ctx, &library.RecommendBooksRequest{Isbn: books[1].Isbn, Limit: -1})
+	checkCode(t, err, codes.InvalidArgument)
+}
+
+func TestSimilarBooks(t *testing.T) {
+	similar := similarBooks(map[string]map[int64]float64{
+		"user alice":      {1: 1, 2: 1, 3: 1},
+		"user bob":        {1: 1, 2: 1},
+		"collection abcd": {3: 1, 4: 1},
+	})
+	if len(similar) != 4 {
+		t.Errorf("got similar books of %d books, want 4", len(similar))
+	}
+	got := similar[1]
+	if len(got) != 2 || got[0].isbn != 2 || got[1].isbn != 3 || got[0].score <= got[1].score {
+		t.Errorf("got books similar to 1 %v, want 2 and then 3", got)
+	}
+	if got := similar[4]; len(got) != 1 || got[0].isbn != 3 {
+		t.Errorf("got books similar to 4 %v, want 3", got)
+	}
+}
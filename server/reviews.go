@@ -0,0 +1,305 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// Reviews are kept in a store of their own, as the records
+// "review/<id>", with the Ids "<isbn>-<n>". The helpful votes
+// of users are the records "vote/<review id>/<user>", holding
+// the time of the vote.
+
+const (
+	// maxReviewLength is the largest number
+	// of characters in the text of a review.
+	maxReviewLength = 2000
+	// defaultReviewPageSize and maxReviewPageSize are the number
+	// of reviews listed when not asked for, and at most.
+	defaultReviewPageSize = 10
+	maxReviewPageSize     = 50
+)
+
+// pageTokenPrefix versions the format of page tokens.
+const pageTokenPrefix = "v1:"
+
+func reviewKey(id string) string { return "review/" + id }
+
+func voteKey(reviewID, user string) string { return "vote/" + reviewID + "/" + user }
+
+// reviewsOf returns the reviews of the book with the ISBN isbn.
+func reviewsOf(t *tx, isbn int64) []*library.Review {
+	var rvs []*library.Review
+	for _, k := range t.Keys(reviewKey(strconv.FormatInt(isbn, 10) + "-")) {
+		rv := &library.Review{}
+		t.Get(k, rv)
+		rvs = append(rvs, rv)
+	}
+	return rvs
+}
+
+// rating returns the number of reviews of the book
+// with the ISBN isbn, and their average rating.
+func rating(t *tx, isbn int64) (int32, float64) {
+	rvs := reviewsOf(t, isbn)
+	if len(rvs) == 0 {
+		return 0, 0
+	}
+	var sum int32
+	for _, rv := range rvs {
+		sum += rv.Rating
+	}
+	return int32(len(rvs)), float64(sum) / float64(len(rvs))
+}
+
+// sortReviews sorts rvs in the order o.
+func sortReviews(rvs []*library.Review, o library.ReviewOrder) {
+	newer := func(a, b *library.Review) bool {
+		if a.Created.Seconds != b.Created.Seconds {
+			return a.Created.Seconds > b.Created.Seconds
+		}
+		if a.Created.Nanos != b.Created.Nanos {
+			return a.Created.Nanos > b.Created.Nanos
+		}
+		return idLess(b.Id, a.Id)
+	}
+	sort.Slice(rvs, func(i, j int) bool {
+		if o == library.ReviewOrder_MOST_HELPFUL && rvs[i].HelpfulVotes != rvs[j].HelpfulVotes {
+			return rvs[i].HelpfulVotes > rvs[j].HelpfulVotes
+		}
+		return newer(rvs[i], rvs[j])
+	})
+}
+
+// checkReview returns an InvalidArgument error
+// if the rating or text of a review are not valid.
+func checkReview(rating int32, text string) error {
+	if rating < 1 || rating > 5 {
+		return status.Errorf(codes.InvalidArgument, "rating %d is not from 1 to 5", rating)
+	}
+	if utf8.RuneCountInString(text) > maxReviewLength {
+		return status.Errorf(codes.InvalidArgument, "text is longer than %d characters", maxReviewLength)
+	}
+	return nil
+}
+
+// ownReview returns the review with the Id id, or
+// an error if there is none or it is not by user.
+func ownReview(t *tx, id, user string) (*library.Review, error) {
+	rv := &library.Review{}
+	if !t.Get(reviewKey(id), rv) {
+		return nil, status.Errorf(codes.NotFound, "Review could not be found")
+	}
+	if rv.User != user {
+		return nil, status.Error(codes.PermissionDenied, "Review is by another user")
+	}
+	return rv, nil
+}
+
+func (s *BookService) SubmitReview(ctx context.Context, req *library.SubmitReviewRequest) (*library.Review, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReview(req.Rating, req.Text); err != nil {
+		return nil, err
+	}
+	if _, ok := s.c.Get(req.Isbn); !ok {
+		return nil, status.Errorf(codes.NotFound, "Book could not be found")
+	}
+	now, err := ptypes.TimestampProto(s.now())
+	if err != nil {
+		return nil, err
+	}
+	rv := &library.Review{
+		Isbn:    req.Isbn,
+		User:    user,
+		Rating:  req.Rating,
+		Text:    req.Text,
+		Created: now,
+		Updated: now,
+	}
+	err = s.rv.Update(func(t *tx) error {
+		for _, other := range reviewsOf(t, req.Isbn) {
+			if other.User == user {
+				return status.Errorf(codes.AlreadyExists, "book %d was already reviewed by the user, as review %s", req.Isbn, other.Id)
+			}
+		}
+		isbn := strconv.FormatInt(req.Isbn, 10)
+		rv.Id = isbn + "-" + t.NextID("review-"+isbn)
+		return t.Insert(reviewKey(rv.Id), rv)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (s *BookService) UpdateReview(ctx context.Context, req *library.UpdateReviewRequest) (*library.Review, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReview(req.Rating, req.Text); err != nil {
+		return nil, err
+	}
+	now, err := ptypes.TimestampProto(s.now())
+	if err != nil {
+		return nil, err
+	}
+	var rv *library.Review
+	err = s.rv.Update(func(t *tx) error {
+		var err error
+		rv, err = ownReview(t, req.ReviewId, user)
+		if err != nil {
+			return err
+		}
+		rv.Rating = req.Rating
+		rv.Text = req.Text
+		rv.Updated = now
+		return t.Put(reviewKey(rv.Id), rv)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (s *BookService) DeleteReview(ctx context.Context, req *library.DeleteReviewRequest) (*library.Review, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rv *library.Review
+	err = s.rv.Update(func(t *tx) error {
+		var err error
+		rv, err = ownReview(t, req.ReviewId, user)
+		if err != nil {
+			return err
+		}
+		for _, k := range t.Keys(voteKey(rv.Id, "")) {
+			if err := t.Delete(k); err != nil {
+				return err
+			}
+		}
+		return t.Delete(reviewKey(rv.Id))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (s *BookService) MarkReviewHelpful(ctx context.Context, req *library.MarkReviewHelpfulRequest) (*library.Review, error) {
+	user, err := s.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now, err := ptypes.TimestampProto(s.now())
+	if err != nil {
+		return nil, err
+	}
+	rv := &library.Review{}
+	err = s.rv.Update(func(t *tx) error {
+		if !t.Get(reviewKey(req.ReviewId), rv) {
+			return status.Errorf(codes.NotFound, "Review could not be found")
+		}
+		if rv.User == user {
+			return status.Error(codes.FailedPrecondition, "users cannot vote for their own reviews")
+		}
+		if err := t.Insert(voteKey(rv.Id, user), now); err != nil {
+			if err == errExists {
+				return status.Error(codes.AlreadyExists, "the user already found the review helpful")
+			}
+			return err
+		}
+		rv.HelpfulVotes++
+		return t.Put(reviewKey(rv.Id), rv)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (s *BookService) ListReviews(ctx context.Context, req *library.ListReviewsRequest) (*library.ListReviewsResponse, error) {
+	offset, err := decodePageToken(req.PageToken, req.Order)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+	size := int(req.PageSize)
+	switch {
+	case size < 0:
+		return nil, status.Error(codes.InvalidArgument, "negative page size")
+	case size == 0:
+		size = defaultReviewPageSize
+	case size > maxReviewPageSize:
+		size = maxReviewPageSize
+	}
+
+	var rvs []*library.Review
+	s.rv.View(func(t *tx) error {
+		rvs = reviewsOf(t, req.Isbn)
+		return nil
+	})
+	sortReviews(rvs, req.Order)
+	resp := &library.ListReviewsResponse{}
+	if offset < len(rvs) {
+		resp.Reviews = rvs[offset:]
+	}
+	if len(resp.Reviews) > size {
+		resp.Reviews = resp.Reviews[:size]
+		resp.NextPageToken = encodePageToken(offset+size, req.Order)
+	}
+	return resp, nil
+}
+
+// encodePageToken returns the page token listing
+// the reviews in the order o from offset on.
+func encodePageToken(offset int, o library.ReviewOrder) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s%d:%d", pageTokenPrefix, o, offset)))
+}
+
+// decodePageToken returns the offset of the first review of
+// the page listed by token, which must list in the order o.
+// An empty token lists the first page.
+func decodePageToken(token string, o library.ReviewOrder) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(b), pageTokenPrefix) {
+		return 0, errors.New("malformed token")
+	}
+	parts := strings.Split(strings.TrimPrefix(string(b), pageTokenPrefix), ":")
+	if len(parts) != 2 {
+		return 0, errors.New("malformed token")
+	}
+	order, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, errors.New("malformed token")
+	}
+	if library.ReviewOrder(order) != o {
+		return 0, errors.New("token is of another order")
+	}
+	offset, err := strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return 0, errors.New("malformed token")
+	}
+	return offset, nil
+}
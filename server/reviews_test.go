@@ -0,0 +1,206 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func submitReview(t *testing.T, client *testClient, user string, isbn int64, rating int32) *library.Review {
+	t.Helper()
+	ctx, cancel := client.userContext(t, user)
+	defer cancel()
+	rv, err := client.SubmitReview(ctx, &library.SubmitReviewRequest{Isbn: isbn, Rating: rating, Text: "Review by " + user})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rv
+}
+
+func markHelpful(t *testing.T, client *testClient, user, id string) {
+	t.Helper()
+	ctx, cancel := client.userContext(t, user)
+	defer cancel()
+	if _, err := client.MarkReviewHelpful(ctx, &library.MarkReviewHelpfulRequest{ReviewId: id}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// listReviews returns the users of all the reviews of the
+// book with the ISBN isbn, listing them in pages of size.
+func listReviews(t *testing.T, client *testClient, isbn int64, o library.ReviewOrder, size int32) []string {
+	t.Helper()
+	ctx, cancel := testContext()
+	defer cancel()
+	var users []string
+	req := &library.ListReviewsRequest{Isbn: isbn, Order: o, PageSize: size}
+	for {
+		resp, err := client.ListReviews(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Reviews) > int(size) {
+			t.Errorf("got %d reviews, want at most %d", len(resp.Reviews), size)
+		}
+		for _, rv := range resp.Reviews {
+			users = append(users, rv.User)
+		}
+		if resp.NextPageToken == "" {
+			return users
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+func TestReviews(t *testing.T) {
+	clock := newFakeClock(testNow)
+	client, cleanup := newServiceClient(t, &BookService{Clock: clock})
+	defer cleanup()
+
+	isbn := books[0].Isbn
+	alice := submitReview(t, client, "alice", isbn, 5)
+	if alice.Id != "60929871-1" || alice.User != "alice" || alice.Rating != 5 {
+		t.Errorf("got review %v, want review 60929871-1 by alice", alice)
+	}
+	clock.Advance(time.Minute)
+	bob := submitReview(t, client, "bob", isbn, 2)
+	clock.Advance(time.Minute)
+	submitReview(t, client, "carol", isbn, 3)
+	markHelpful(t, client, "carol", alice.Id)
+	markHelpful(t, client, "dave", alice.Id)
+	markHelpful(t, client, "dave", bob.Id)
+
+	for _, test := range []struct {
+		name string
+		user string
+		req  *library.SubmitReviewRequest
+		code codes.Code
+	}{
+		{"Twice", "alice", &library.SubmitReviewRequest{Isbn: isbn, Rating: 1}, codes.AlreadyExists},
+		{"NoUser", "", &library.SubmitReviewRequest{Isbn: isbn, Rating: 1}, codes.Unauthenticated},
+		{"UnknownBook", "dave", &library.SubmitReviewRequest{Isbn: 1, Rating: 1}, codes.NotFound},
+		{"NoRating", "dave", &library.SubmitReviewRequest{Isbn: isbn}, codes.InvalidArgument},
+		{"HighRating", "dave", &library.SubmitReviewRequest{Isbn: isbn, Rating: 6}, codes.InvalidArgument},
+		{"LongText", "dave", &library.SubmitReviewRequest{Isbn: isbn, Rating: 1, Text: strings.Repeat("a", maxReviewLength+1)}, codes.InvalidArgument},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := client.userContext(t, test.user)
+			defer cancel()
+			_, err := client.SubmitReview(ctx, test.req)
+			checkCode(t, err, test.code)
+		})
+	}
+
+	t.Run("UserMetadata", func(t *testing.T) {
+		// Reviews are only written with the session of the reviewer
+		ctx, cancel := client.userContext(t, "", "user", "dave")
+		defer cancel()
+		_, err := client.SubmitReview(ctx, &library.SubmitReviewRequest{Isbn: isbn, Rating: 1})
+		checkCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("Rating", func(t *testing.T) {
+		ctx, cancel := testContext()
+		defer cancel()
+		bk, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: isbn})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bk.RatingCount != 3 || bk.AverageRating != 10.0/3 {
+			t.Errorf("got %d ratings averaging %v, want 3 averaging %v", bk.RatingCount, bk.AverageRating, 10.0/3)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for _, size := range []int32{1, 2, 10} {
+			if got := strings.Join(listReviews(t, client, isbn, library.ReviewOrder_NEWEST, size), " "); got != "carol bob alice" {
+				t.Errorf("got newest reviews by %s in pages of %d, want carol bob alice", got, size)
+			}
+			if got := strings.Join(listReviews(t, client, isbn, library.ReviewOrder_MOST_HELPFUL, size), " "); got != "alice bob carol" {
+				t.Errorf("got most helpful reviews by %s in pages of %d, want alice bob carol", got, size)
+			}
+		}
+
+		ctx, cancel := testContext()
+		defer cancel()
+		resp, err := client.ListReviews(ctx, &library.ListReviewsRequest{Isbn: isbn, PageSize: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, req := range []*library.ListReviewsRequest{
+			{Isbn: isbn, PageToken: "invalid"},
+			{Isbn: isbn, PageToken: resp.NextPageToken, Order: library.ReviewOrder_MOST_HELPFUL},
+			{Isbn: isbn, PageSize: -1},
+		} {
+			_, err := client.ListReviews(ctx, req)
+			checkCode(t, err, codes.InvalidArgument)
+		}
+	})
+
+	t.Run("Helpful", func(t *testing.T) {
+		for _, test := range []struct {
+			name string
+			user string
+			id   string
+			code codes.Code
+		}{
+			{"Twice", "dave", alice.Id, codes.AlreadyExists},
+			{"Own", "alice", alice.Id, codes.FailedPrecondition},
+			{"UnknownReview", "dave", "1-1", codes.NotFound},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				ctx, cancel := client.userContext(t, test.user)
+				defer cancel()
+				_, err := client.MarkReviewHelpful(ctx, &library.MarkReviewHelpfulRequest{ReviewId: test.id})
+				checkCode(t, err, test.code)
+			})
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.Advance(time.Hour)
+		ctx, cancel := client.userContext(t, "bob")
+		defer cancel()
+		_, err := client.UpdateReview(ctx, &library.UpdateReviewRequest{ReviewId: alice.Id, Rating: 1})
+		checkCode(t, err, codes.PermissionDenied)
+		_, err = client.UpdateReview(ctx, &library.UpdateReviewRequest{ReviewId: bob.Id})
+		checkCode(t, err, codes.InvalidArgument)
+		rv, err := client.UpdateReview(ctx, &library.UpdateReviewRequest{ReviewId: bob.Id, Rating: 4, Text: "Better the second time"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rv.Rating != 4 || rv.Text != "Better the second time" || rv.HelpfulVotes != 1 ||
+			rv.Created.Seconds != bob.Created.Seconds || rv.Updated.Seconds != clock.Now().Unix() {
+			t.Errorf("got review %v, want the review of bob edited", rv)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx, cancel := client.userContext(t, "bob")
+		defer cancel()
+		_, err := client.DeleteReview(ctx, &library.DeleteReviewRequest{ReviewId: alice.Id})
+		checkCode(t, err, codes.PermissionDenied)
+		if _, err := client.DeleteReview(ctx, &library.DeleteReviewRequest{ReviewId: bob.Id}); err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.DeleteReview(ctx, &library.DeleteReviewRequest{ReviewId: bob.Id})
+		checkCode(t, err, codes.NotFound)
+		if got := strings.Join(listReviews(t, client, isbn, library.ReviewOrder_NEWEST, 10), " "); got != "carol alice" {
+			t.Errorf("got reviews by %s, want carol alice", got)
+		}
+
+		// Users can review a book again after deleting their review
+		rv := submitReview(t, client, "bob", isbn, 1)
+		if rv.Id != "60929871-4" || rv.HelpfulVotes != 0 {
+			t.Errorf("got review %v, want new review 60929871-4", rv)
+		}
+		markHelpful(t, client, "dave", rv.Id)
+	})
+}
@@ -5,13 +5,12 @@ package server
 
 import (
 	"io"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -19,7 +18,38 @@ import (
 )
 
 type BookService struct {
-	b broadcaster
+	// MaxCollectionSize is the largest number of books
+	// in a collection. If 0, DefaultMaxCollectionSize
+	// is used.
+	MaxCollectionSize int
+
+	// MaxImportSize is the largest number of books sent
+	// in an import. If 0, DefaultMaxImportSize is used.
+	MaxImportSize int
+
+	// Clock tells the time for loans, holds and the
+	// background jobs. If nil, the system clock is used.
+	Clock Clock
+
+	// Users are the registered users. If not nil, only the
+	// user a display name belongs to may chat under it, and
+	// must send the session of a login in the request metadata.
+	Users *UserService
+
+	// AdminToken is the token administrators send as the
+	// "admin-token" request metadata to call the methods
+	// meant for them, such as ListJobs, ImportBooks and
+	// AddCopy. If empty, no one can call them.
+	AdminToken string
+
+	b     broadcaster
+	c     catalogue
+	cs    collections
+	circ  circulation
+	sched scheduler
+	rv    store
+	rec   recommender
+	rd    store
 }
 
 var books = []*library.Book{
@@ -28,28 +58,46 @@ var books = []*library.Book{
 		Title:    "Brave New World",
 		Author:   "Aldous Huxley",
 		BookType: library.BookType_HARDCOVER,
+		Contributors: []*library.Contributor{
+			{Name: "Aldous Huxley", Role: library.ContributorRole_AUTHOR, AuthorId: "aldous-huxley"},
+		},
 		PublishingMethod: &library.Book_Publisher{
 			Publisher: &library.Publisher{
+				Id:   "chatto-windus",
 				Name: "Chatto & Windus",
 			},
 		},
 		PublicationDate: &timestamp.Timestamp{
 			Seconds: time.Date(1932, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
 		},
+		Language:      "en",
+		Extent:        &library.Book_PageCount{PageCount: 288},
+		Description:   "A dystopian novel set in a World State of genetically engineered citizens.",
+		Subjects:      []string{"Fiction", "Dystopias", "Science fiction"},
+		CoverImageUrl: "https://covers.openlibrary.org/b/isbn/0060929871-M.jpg",
 	},
 	&library.Book{
 		Isbn:     140009728,
 		Title:    "Nineteen Eighty-Four",
 		Author:   "George Orwell",
 		BookType: library.BookType_PAPERBACK,
+		Contributors: []*library.Contributor{
+			{Name: "George Orwell", Role: library.ContributorRole_AUTHOR, AuthorId: "george-orwell"},
+		},
 		PublishingMethod: &library.Book_Publisher{
 			Publisher: &library.Publisher{
+				Id:   "secker-warburg",
 				Name: "Secker & Warburg",
 			},
 		},
 		PublicationDate: &timestamp.Timestamp{
 			Seconds: time.Date(1949, time.June, 8, 0, 0, 0, 0, time.UTC).Unix(),
 		},
+		Language:      "en-GB",
+		Extent:        &library.Book_PageCount{PageCount: 326},
+		Description:   "A dystopian novel about life under the ever watchful eye of Big Brother.",
+		Subjects:      []string{"Fiction", "Dystopias", "Political fiction"},
+		CoverImageUrl: "https://covers.openlibrary.org/b/isbn/0140009728-M.jpg",
 	},
 	&library.Book{
 		Isbn:     9780140301694,
@@ -58,100 +106,165 @@ var books = []*library.Book{
 		BookType: library.BookType_AUDIOBOOK,
 		PublishingMethod: &library.Book_Publisher{
 			Publisher: &library.Publisher{
+				Id:   "macmillan",
 				Name: "Macmillan",
 			},
 		},
 		PublicationDate: &timestamp.Timestamp{
 			Seconds: time.Date(1865, time.November, 26, 0, 0, 0, 0, time.UTC).Unix(),
 		},
+		Contributors: []*library.Contributor{
+			{Name: "Lewis Carroll", Role: library.ContributorRole_AUTHOR, AuthorId: "lewis-carroll"},
+			{Name: "John Tenniel", Role: library.ContributorRole_ILLUSTRATOR, AuthorId: "john-tenniel"},
+		},
+		Series:        &library.Series{Name: "Alice", Position: 1},
+		Language:      "en-GB",
+		Extent:        &library.Book_DurationSeconds{DurationSeconds: 9840},
+		Description:   "Alice falls down a rabbit hole into a fantasy world of peculiar creatures.",
+		Subjects:      []string{"Fiction", "Fantasy", "Children's literature"},
+		CoverImageUrl: "https://covers.openlibrary.org/b/isbn/9780140301694-M.jpg",
 	},
 	&library.Book{
 		Isbn:     140008381,
 		Title:    "Animal Farm",
 		Author:   "George Orwell",
 		BookType: library.BookType_HARDCOVER,
+		Contributors: []*library.Contributor{
+			{Name: "George Orwell", Role: library.ContributorRole_AUTHOR, AuthorId: "george-orwell"},
+		},
 		PublishingMethod: &library.Book_Publisher{
 			Publisher: &library.Publisher{
+				Id:   "secker-warburg",
 				Name: "Secker & Warburg",
 			},
 		},
 		PublicationDate: &timestamp.Timestamp{
 			Seconds: time.Date(1945, time.August, 17, 0, 0, 0, 0, time.UTC).Unix(),
 		},
+		Language:      "en-GB",
+		Extent:        &library.Book_PageCount{PageCount: 120},
+		Description:   "An allegorical novella about the animals of a farm who overthrow their farmer.",
+		Subjects:      []string{"Fiction", "Political satire", "Fables"},
+		CoverImageUrl: "https://covers.openlibrary.org/b/isbn/0140008381-M.jpg",
 	},
 	&library.Book{
 		Isbn:     1501107739,
 		Title:    "Still Alice",
 		Author:   "Lisa Genova",
 		BookType: library.BookType_PAPERBACK,
+		Contributors: []*library.Contributor{
+			{Name: "Lisa Genova", Role: library.ContributorRole_AUTHOR, AuthorId: "lisa-genova"},
+		},
 		PublishingMethod: &library.Book_SelfPublished{
 			SelfPublished: true,
 		},
 		PublicationDate: &timestamp.Timestamp{
 			Seconds: time.Date(2007, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
 		},
+		Language:      "en",
+		Extent:        &library.Book_PageCount{PageCount: 352},
+		Description:   "A linguistics professor is diagnosed with early-onset Alzheimer's disease.",
+		Subjects:      []string{"Fiction", "Alzheimer's disease"},
+		CoverImageUrl: "https://covers.openlibrary.org/b/isbn/1501107739-M.jpg",
 	},
 }
 
 func (s *BookService) GetBook(ctx context.Context, bookQuery *library.GetBookRequest) (*library.Book, error) {
-	for _, bk := range books {
-		if bk.Isbn == bookQuery.Isbn {
-			return bk, nil
-		}
+	if bk, ok := s.c.Get(bookQuery.Isbn); ok {
+		return s.withSummaries(bk)[0], nil
 	}
 
-	return nil, grpc.Errorf(codes.NotFound, "Book could not be found")
+	return nil, status.Errorf(codes.NotFound, "Book could not be found")
+}
+
+// withSummaries returns copies of bks with the
+// summaries of their copies and reviews set.
+func (s *BookService) withSummaries(bks ...*library.Book) []*library.Book {
+	found := make([]*library.Book, len(bks))
+	for i, bk := range bks {
+		found[i] = proto.Clone(bk).(*library.Book)
+	}
+	s.circ.store().View(func(t *tx) error {
+		for _, bk := range found {
+			bk.Availability = availability(t, bk.Isbn)
+		}
+		return nil
+	})
+	s.rv.View(func(t *tx) error {
+		for _, bk := range found {
+			bk.RatingCount, bk.AverageRating = rating(t, bk.Isbn)
+		}
+		return nil
+	})
+	return found
 }
 
 func (s *BookService) QueryBooks(bookQuery *library.QueryBooksRequest, stream library.BookService_QueryBooksServer) error {
-	for _, book := range books {
+	filter := bookFilter{authorPrefix: bookQuery.AuthorPrefix, authorID: bookQuery.AuthorId}
+	branchID := bookQuery.AvailableAtBranch
+	if branchID != "" && !s.hasBranch(branchID) {
+		return status.Errorf(codes.NotFound, "Branch could not be found")
+	}
+	for _, book := range s.c.Query(filter) {
 		select {
 		case <-stream.Context().Done():
 			return nil
 		default:
 		}
-
-		if strings.HasPrefix(book.Author, bookQuery.AuthorPrefix) {
-			err := stream.Send(book)
-			if err != nil {
-				return err
-			}
+		book = s.withSummaries(book)[0]
+		if branchID != "" && availableAt(book.Availability, branchID) == 0 {
+			continue
 		}
-	}
-	return nil
-}
 
-func (s *BookService) MakeCollection(srv library.BookService_MakeCollectionServer) error {
-	collection := &library.Collection{}
-	for {
-		bk, err := srv.Recv()
-		if err == io.EOF {
-			return srv.SendAndClose(collection)
-		}
+		err := stream.Send(book)
 		if err != nil {
 			return err
 		}
-
-		collection.Books = append(collection.Books, bk)
 	}
+	return nil
 }
 
+// maxChatHistory is the number of messages
+// kept for the users joining the chat.
+const maxChatHistory = 100
+
 type broadcaster struct {
 	listenerMu sync.RWMutex
 	listeners  map[string]chan<- string
+
+	historyMu sync.Mutex
+	// history holds the last messages broadcast,
+	// up to maxChatHistory, oldest first.
+	history []chatMessage
 }
 
-func (b *broadcaster) Add(name string, listener chan<- string) error {
+// chatMessage is a message broadcast at a time.
+type chatMessage struct {
+	at  time.Time
+	msg string
+}
+
+// Add adds listener with the name provided, and returns
+// the messages of the history, which are not sent to it.
+func (b *broadcaster) Add(name string, listener chan<- string) ([]string, error) {
 	b.listenerMu.Lock()
 	defer b.listenerMu.Unlock()
 	if b.listeners == nil {
 		b.listeners = map[string]chan<- string{}
 	}
 	if _, ok := b.listeners[name]; ok {
-		return status.Errorf(codes.AlreadyExists, "The name %q is already in use by someone", name)
+		return nil, status.Errorf(codes.AlreadyExists, "The name %q is already in use by someone", name)
 	}
 	b.listeners[name] = listener
-	return nil
+	// Broadcasts hold listenerMu, so no message
+	// is both in the history and sent to listener.
+	b.historyMu.Lock()
+	defer b.historyMu.Unlock()
+	var history []string
+	for _, m := range b.history {
+		history = append(history, m.msg)
+	}
+	return history, nil
 }
 
 func (b *broadcaster) Remove(name string) {
@@ -163,10 +276,22 @@ func (b *broadcaster) Remove(name string) {
 	}
 }
 
-func (b *broadcaster) Broadcast(ctx context.Context, msg string) {
+// Broadcast adds msg, sent at the time at, to the history, and sends
+// it to all listeners except the one added with the name except, or
+// until ctx is cancelled.
+func (b *broadcaster) Broadcast(ctx context.Context, at time.Time, msg, except string) {
 	b.listenerMu.RLock()
 	defer b.listenerMu.RUnlock()
-	for _, listener := range b.listeners {
+	b.historyMu.Lock()
+	if len(b.history) == maxChatHistory {
+		b.history = append(b.history[:0], b.history[1:]...)
+	}
+	b.history = append(b.history, chatMessage{at: at, msg: msg})
+	b.historyMu.Unlock()
+	for name, listener := range b.listeners {
+		if name == except {
+			continue
+		}
 		select {
 		case listener <- msg:
 		case <-ctx.Done():
@@ -175,6 +300,19 @@ func (b *broadcaster) Broadcast(ctx context.Context, msg string) {
 	}
 }
 
+// Purge removes the messages sent before the time
+// before from the history, and returns how many.
+func (b *broadcaster) Purge(before time.Time) int {
+	b.historyMu.Lock()
+	defer b.historyMu.Unlock()
+	n := 0
+	for n < len(b.history) && b.history[n].at.Before(before) {
+		n++
+	}
+	b.history = append(b.history[:0], b.history[n:]...)
+	return n
+}
+
 func (s *BookService) BookChat(srv library.BookService_BookChatServer) error {
 	// Listen for initial message with name
 	msg, err := srv.Recv()
@@ -189,42 +327,56 @@ func (s *BookService) BookChat(srv library.BookService_BookChatServer) error {
 	if name == "" {
 		return status.Error(codes.FailedPrecondition, "first message should be the name of the user")
 	}
-
-	// Send join message before user joins
-	s.b.Broadcast(srv.Context(), name+" has joined the chat")
+	if err := s.checkChatName(srv.Context(), name); err != nil {
+		return err
+	}
 
 	listener := make(chan string)
-	err = s.b.Add(name, listener)
+	history, err := s.b.Add(name, listener)
 	if err != nil {
 		return err
 	}
+	// Streams must not be sent to after the handler has returned,
+	// so wait for the sending goroutine to finish, which it does
+	// once the listener is closed in broadcaster.Remove.
+	sendDone := make(chan struct{})
 	defer func() {
 		s.b.Remove(name)
-		s.b.Broadcast(context.Background(), name+" has left the chat")
+		<-sendDone
+		s.b.Broadcast(context.Background(), s.now(), name+" has left the chat", "")
 	}()
 
-	sendErrChan := make(chan error)
+	// The error channels are buffered so that the goroutines
+	// can exit even if this function has already returned.
+	sendErrChan := make(chan error, 1)
 	go func() {
-		for {
-			select {
-			case msg, ok := <-listener:
-				if !ok {
-					// Listener is closed in broadcaster.Remove,
-					// so this must mean the function has exited.
-					return
-				}
-				err = srv.Send(&library.BookResponse{Message: msg})
-				if err != nil {
-					sendErrChan <- err
-					return
-				}
-			case <-srv.Context().Done():
+		defer close(sendDone)
+		var sendErr error
+		send := func(msg string) {
+			if sendErr != nil {
 				return
 			}
+			sendErr = srv.Send(&library.BookResponse{Message: msg})
+			if sendErr != nil {
+				sendErrChan <- sendErr
+			}
+		}
+		// Users joining see the conversation so far
+		for _, msg := range history {
+			send(msg)
+		}
+		// Keep receiving from the listener until it is closed
+		// in broadcaster.Remove, even after sending fails, so that
+		// the broadcaster never blocks on a departing user.
+		for msg := range listener {
+			send(msg)
 		}
 	}()
 
-	recvErrChan := make(chan error)
+	// Let everyone else know about the new user
+	s.b.Broadcast(srv.Context(), s.now(), name+" has joined the chat", name)
+
+	recvErrChan := make(chan error, 1)
 	go func() {
 		for {
 			msg, err := srv.Recv()
@@ -237,7 +389,7 @@ func (s *BookService) BookChat(srv library.BookService_BookChatServer) error {
 				recvErrChan <- err
 				return
 			}
-			s.b.Broadcast(srv.Context(), name+": "+msg.GetMessage())
+			s.b.Broadcast(srv.Context(), s.now(), name+": "+msg.GetMessage(), "")
 		}
 	}()
 
@@ -0,0 +1,584 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// testClient is a client of a BookService served for a test,
+// and of the UserService of its users.
+type testClient struct {
+	library.BookServiceClient
+	users library.UserServiceClient
+
+	mu sync.Mutex
+	// registered holds the users registered by userContext.
+	registered map[string]bool
+}
+
+// newTestClient starts a BookService on an in-memory listener
+// and returns a client connected to it, and a function
+// which stops the server and closes the connection.
+func newTestClient(t *testing.T) (*testClient, func()) {
+	t.Helper()
+	return newServiceClient(t, &BookService{})
+}
+
+// testAdminToken is the admin token of the services
+// served by newServiceClient without one of their own.
+const testAdminToken = "admin-token-of-the-tests"
+
+// newServiceClient is like newTestClient, but serves srv,
+// with new users unless it has users of its own.
+func newServiceClient(t *testing.T, srv *BookService) (*testClient, func()) {
+	t.Helper()
+	if srv.Users == nil {
+		srv.Users = &UserService{PasswordCost: bcrypt.MinCost}
+	}
+	if srv.AdminToken == "" {
+		srv.AdminToken = testAdminToken
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	library.RegisterBookServiceServer(s, srv)
+	library.RegisterUserServiceServer(s, srv.Users)
+	go s.Serve(lis)
+
+	cc, err := grpc.Dial("bufconn",
+		grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		s.Stop()
+		t.Fatal(err)
+	}
+	c := &testClient{
+		BookServiceClient: library.NewBookServiceClient(cc),
+		users:             library.NewUserServiceClient(cc),
+		registered:        map[string]bool{},
+	}
+	return c, func() {
+		cc.Close()
+		s.Stop()
+	}
+}
+
+// userContext returns a test context for calls made by user, or
+// by no one if user is "", with the additional request metadata
+// key value pairs kv. Users are registered the first time, and
+// logged in each time, through the UserService.
+func (c *testClient) userContext(t *testing.T, user string, kv ...string) (context.Context, context.CancelFunc) {
+	t.Helper()
+	if user == "" {
+		ctx, cancel := testContext()
+		return metadata.NewOutgoingContext(ctx, metadata.Pairs(kv...)), cancel
+	}
+	c.mu.Lock()
+	if !c.registered[user] {
+		register(t, c.users, user, "")
+		c.registered[user] = true
+	}
+	c.mu.Unlock()
+	ctx, cancel := login(t, c.users, user)
+	return metadata.AppendToOutgoingContext(ctx, kv...), cancel
+}
+
+func testContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 5*time.Second)
+}
+
+func checkCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if status.Code(err) != want {
+		t.Fatalf("got error %v, want code %v", err, want)
+	}
+}
+
+func TestGetBook(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx, cancel := testContext()
+	defer cancel()
+
+	for _, want := range books {
+		bk, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: want.Isbn})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a := bk.Availability; a.GetCopies() != defaultCopies || a.Available != defaultCopies {
+			t.Errorf("got availability %v, want %d copies available", a, defaultCopies)
+		}
+		bk.Availability = nil
+		if !proto.Equal(bk, want) {
+			t.Errorf("got %v, want %v", bk, want)
+		}
+	}
+
+	_, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: 1})
+	checkCode(t, err, codes.NotFound)
+}
+
+func queryBooks(t *testing.T, client *testClient, prefix string) []*library.Book {
+	t.Helper()
+	return query(t, client, &library.QueryBooksRequest{AuthorPrefix: prefix})
+}
+
+// query returns the books matching req, without
+// their availability, which TestAvailability tests.
+func query(t *testing.T, client *testClient, req *library.QueryBooksRequest) []*library.Book {
+	t.Helper()
+	ctx, cancel := testContext()
+	defer cancel()
+	stream, err := client.QueryBooks(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bks []*library.Book
+	for {
+		bk, err := stream.Recv()
+		if err == io.EOF {
+			return bks
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		bk.Availability = nil
+		bks = append(bks, bk)
+	}
+}
+
+func TestQueryBooks(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	tests := []struct {
+		prefix string
+		isbns  []int64
+	}{
+		{prefix: "George", isbns: []int64{140009728, 140008381}},
+		{prefix: "L", isbns: []int64{9780140301694, 1501107739}},
+		{prefix: "", isbns: []int64{60929871, 140009728, 9780140301694, 140008381, 1501107739}},
+		{prefix: "Nobody"},
+	}
+	for _, tt := range tests {
+		bks := queryBooks(t, client, tt.prefix)
+		var isbns []int64
+		for _, bk := range bks {
+			isbns = append(isbns, bk.Isbn)
+		}
+		if fmt.Sprint(isbns) != fmt.Sprint(tt.isbns) {
+			t.Errorf("%q: got ISBNs %v, want %v", tt.prefix, isbns, tt.isbns)
+		}
+	}
+}
+
+func TestQueryBooksCancel(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.QueryBooks(ctx, &library.QueryBooksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	for {
+		_, err = stream.Recv()
+		if err != nil {
+			break
+		}
+	}
+	checkCode(t, err, codes.Canceled)
+}
+
+func TestMakeCollection(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+
+	for _, bks := range [][]*library.Book{nil, books[:1], books} {
+		ctx, cancel := testContext()
+		stream, err := client.MakeCollection(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, bk := range bks {
+			err = stream.Send(bk)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		collection, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+		if !proto.Equal(&library.Collection{Books: collection.Books}, &library.Collection{Books: bks}) {
+			t.Errorf("got %v, want %v", collection.Books, bks)
+		}
+		// Collections made without a user
+		// can only be shared by their Id.
+		if collection.Id == "" || collection.Owner != "" || collection.Visibility != library.Visibility_UNLISTED {
+			t.Errorf("got collection %v, want an unlisted collection without owner", collection)
+		}
+	}
+}
+
+// join joins the chat as name, returning once
+// the user is guaranteed to receive any messages.
+func join(ctx context.Context, t *testing.T, client *testClient, name string) library.BookService_BookChatClient {
+	t.Helper()
+	stream, err := client.BookChat(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&library.BookMessage{Content: &library.BookMessage_Name{Name: name}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Users receive their own messages,
+	// so this blocks until the user has joined.
+	// The messages of the history come first.
+	say(t, stream, "ping")
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Message == name+": ping" {
+			return stream
+		}
+	}
+}
+
+func say(t *testing.T, stream library.BookService_BookChatClient, msg string) {
+	t.Helper()
+	err := stream.Send(&library.BookMessage{Content: &library.BookMessage_Message{Message: msg}})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expect(t *testing.T, stream library.BookService_BookChatClient, want string) {
+	t.Helper()
+	msg, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Message != want {
+		t.Fatalf("got message %q, want %q", msg.Message, want)
+	}
+}
+
+func TestBookChat(t *testing.T) {
+	t.Run("Conversation", func(t *testing.T) {
+		client, cleanup := newTestClient(t)
+		defer cleanup()
+		ctx, cancel := testContext()
+		defer cancel()
+
+		alice := join(ctx, t, client, "alice")
+		bob := join(ctx, t, client, "bob")
+		expect(t, alice, "bob has joined the chat")
+		expect(t, alice, "bob: ping")
+
+		say(t, alice, "hello bob")
+		expect(t, alice, "alice: hello bob")
+		expect(t, bob, "alice: hello bob")
+
+		err := bob.CloseSend()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = bob.Recv()
+		if err != io.EOF {
+			t.Fatalf("got %v, want EOF", err)
+		}
+		expect(t, alice, "bob has left the chat")
+	})
+
+	t.Run("EOFBeforeName", func(t *testing.T) {
+		client, cleanup := newTestClient(t)
+		defer cleanup()
+		ctx, cancel := testContext()
+		defer cancel()
+
+		stream, err := client.BookChat(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = stream.CloseSend()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = stream.Recv()
+		if err != io.EOF {
+			t.Fatalf("got %v, want EOF", err)
+		}
+	})
+
+	t.Run("MissingName", func(t *testing.T) {
+		client, cleanup := newTestClient(t)
+		defer cleanup()
+		ctx, cancel := testContext()
+		defer cancel()
+
+		stream, err := client.BookChat(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		say(t, stream, "who am I?")
+		_, err = stream.Recv()
+		checkCode(t, err, codes.FailedPrecondition)
+	})
+
+	t.Run("DuplicateName", func(t *testing.T) {
+		client, cleanup := newTestClient(t)
+		defer cleanup()
+		ctx, cancel := testContext()
+		defer cancel()
+
+		alice := join(ctx, t, client, "alice")
+		impostor, err := client.BookChat(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = impostor.Send(&library.BookMessage{Content: &library.BookMessage_Name{Name: "alice"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = impostor.Recv()
+		checkCode(t, err, codes.AlreadyExists)
+
+		// The rejected user must not have been announced.
+		say(t, alice, "still here")
+		expect(t, alice, "alice: still here")
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		client, cleanup := newTestClient(t)
+		defer cleanup()
+		ctx, cancel := testContext()
+		defer cancel()
+
+		alice := join(ctx, t, client, "alice")
+		bobCtx, bobCancel := context.WithCancel(ctx)
+		join(bobCtx, t, client, "bob")
+		expect(t, alice, "bob has joined the chat")
+		expect(t, alice, "bob: ping")
+
+		bobCancel()
+		expect(t, alice, "bob has left the chat")
+
+		// The name is free to use again.
+		join(ctx, t, client, "bob")
+		expect(t, alice, "bob has joined the chat")
+	})
+}
+
+func TestBookChatManyUsers(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx, cancel := testContext()
+	defer cancel()
+
+	// Users join, talk and leave concurrently, some without
+	// reading any of their messages, which must not block the others.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			userCtx, userCancel := context.WithCancel(ctx)
+			defer userCancel()
+			stream, err := client.BookChat(userCtx)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			err = stream.Send(&library.BookMessage{Content: &library.BookMessage_Name{Name: fmt.Sprint("user", i)}})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for j := 0; j < 10; j++ {
+				err = stream.Send(&library.BookMessage{Content: &library.BookMessage_Message{Message: fmt.Sprint(j)}})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+			if i%2 == 0 {
+				return
+			}
+			err = stream.CloseSend()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for {
+				_, err = stream.Recv()
+				if err == io.EOF {
+					return
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Everyone has left, so the name is available.
+	alice := join(ctx, t, client, "alice")
+	err := alice.CloseSend()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBroadcaster(t *testing.T) {
+	var b broadcaster
+	ctx, cancel := testContext()
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprint("listener", i)
+			listener := make(chan string)
+			_, err := b.Add(name, listener)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for range listener {
+				}
+			}()
+			for j := 0; j < 10; j++ {
+				b.Broadcast(ctx, time.Now(), fmt.Sprint(name, j), name)
+			}
+			b.Remove(name)
+			<-done
+		}(i)
+	}
+	wg.Wait()
+
+	if len(b.listeners) != 0 {
+		t.Errorf("got %d listeners after all were removed", len(b.listeners))
+	}
+}
+
+func TestBroadcasterDuplicateName(t *testing.T) {
+	var b broadcaster
+	_, err := b.Add("alice", make(chan string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Add("alice", make(chan string))
+	checkCode(t, err, codes.AlreadyExists)
+
+	b.Remove("alice")
+	_, err = b.Add("alice", make(chan string))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBroadcasterCancel(t *testing.T) {
+	var b broadcaster
+	_, err := b.Add("alice", make(chan string))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nobody is listening, so Broadcast
+	// must return when ctx is cancelled.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	b.Broadcast(ctx, time.Now(), "hello?", "")
+	if ctx.Err() == nil {
+		t.Fatal("Broadcast returned before the message was received")
+	}
+}
+
+func TestBookChatHistory(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx, cancel := testContext()
+	defer cancel()
+
+	alice := join(ctx, t, client, "alice")
+	say(t, alice, "anyone reading Orwell?")
+	expect(t, alice, "alice: anyone reading Orwell?")
+
+	stream, err := client.BookChat(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&library.BookMessage{Content: &library.BookMessage_Name{Name: "bob"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, stream, "alice has joined the chat")
+	expect(t, stream, "alice: ping")
+	expect(t, stream, "alice: anyone reading Orwell?")
+	expect(t, alice, "bob has joined the chat")
+}
+
+func TestBroadcasterPurge(t *testing.T) {
+	var b broadcaster
+	ctx, cancel := testContext()
+	defer cancel()
+	for i := 0; i < maxChatHistory+2; i++ {
+		b.Broadcast(ctx, testNow.Add(time.Duration(i)*time.Minute), fmt.Sprint(i), "")
+	}
+
+	history, err := b.Add("alice", make(chan string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != maxChatHistory || history[0] != "2" {
+		t.Errorf("got history of %d messages from %q, want %d from %q", len(history), history[0], maxChatHistory, "2")
+	}
+	if n := b.Purge(testNow.Add(12 * time.Minute)); n != 10 {
+		t.Errorf("purged %d messages, want 10", n)
+	}
+	b.Remove("alice")
+	history, err = b.Add("alice", make(chan string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != maxChatHistory-10 || history[0] != "12" {
+		t.Errorf("got history of %d messages from %q, want %d from %q", len(history), history[0], maxChatHistory-10, "12")
+	}
+}
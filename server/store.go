@@ -0,0 +1,176 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// errExists is returned by Insert if the key is already set.
+var errExists = errors.New("key already exists")
+
+// store is a transactional key-value store of messages.
+// Transactions are serialized, so a transaction sees no
+// changes but its own while it runs, and its changes are
+// made all at once, or not at all if it fails.
+// The zero value is an empty store.
+type store struct {
+	mu      sync.RWMutex
+	records map[string]proto.Message
+	// ids holds the last number returned by NextSeq, by kind.
+	ids map[string]int64
+}
+
+// tx is a transaction of a store.
+type tx struct {
+	s        *store
+	writable bool
+	// writes holds the changes of the transaction,
+	// with nil values for deleted keys.
+	writes map[string]proto.Message
+	ids    map[string]int64
+}
+
+var errReadOnly = errors.New("transaction is read-only")
+
+// Update runs fn in a transaction which can change the store.
+// The changes are only made if fn returns nil.
+func (s *store) Update(fn func(*tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &tx{s: s, writable: true, writes: map[string]proto.Message{}, ids: map[string]int64{}}
+	if err := fn(t); err != nil {
+		return err
+	}
+	if s.records == nil {
+		s.records = map[string]proto.Message{}
+		s.ids = map[string]int64{}
+	}
+	for k, v := range t.writes {
+		if v == nil {
+			delete(s.records, k)
+			continue
+		}
+		s.records[k] = v
+	}
+	for kind, id := range t.ids {
+		s.ids[kind] = id
+	}
+	return nil
+}
+
+// View runs fn in a transaction which cannot change the store.
+func (s *store) View(fn func(*tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(&tx{s: s})
+}
+
+// get returns the value of key, if set.
+func (t *tx) get(key string) (proto.Message, bool) {
+	if v, ok := t.writes[key]; ok {
+		return v, v != nil
+	}
+	v, ok := t.s.records[key]
+	return v, ok
+}
+
+// Get sets msg to a copy of the value of key,
+// and returns false if key is not set.
+func (t *tx) Get(key string, msg proto.Message) bool {
+	v, ok := t.get(key)
+	if !ok {
+		return false
+	}
+	msg.Reset()
+	proto.Merge(msg, v)
+	return true
+}
+
+// Has returns whether key is set.
+func (t *tx) Has(key string) bool {
+	_, ok := t.get(key)
+	return ok
+}
+
+// Put sets key to a copy of msg.
+func (t *tx) Put(key string, msg proto.Message) error {
+	if !t.writable {
+		return errReadOnly
+	}
+	t.writes[key] = proto.Clone(msg)
+	return nil
+}
+
+// Insert sets key to a copy of msg,
+// or returns errExists if key is set.
+func (t *tx) Insert(key string, msg proto.Message) error {
+	if t.Has(key) {
+		return errExists
+	}
+	return t.Put(key, msg)
+}
+
+// Delete unsets key.
+func (t *tx) Delete(key string) error {
+	if !t.writable {
+		return errReadOnly
+	}
+	t.writes[key] = nil
+	return nil
+}
+
+// Keys returns the keys set starting with prefix, in order.
+func (t *tx) Keys(prefix string) []string {
+	var keys []string
+	for k := range t.s.records {
+		if _, ok := t.writes[k]; !ok && strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	for k, v := range t.writes {
+		if v != nil && strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NextID returns a new Id for a record of the kind
+// provided. Ids of a kind increase from 1.
+func (t *tx) NextID(kind string) string {
+	return strconv.FormatInt(t.NextSeq(kind), 10)
+}
+
+// NextSeq returns the next number of the sequence
+// of the kind provided, which increases from 1.
+func (t *tx) NextSeq(kind string) int64 {
+	id, ok := t.ids[kind]
+	if !ok {
+		id = t.s.ids[kind]
+	}
+	id++
+	t.ids[kind] = id
+	return id
+}
+
+// Changed returns the keys starting with prefix
+// set by the transaction, in order.
+func (t *tx) Changed(prefix string) []string {
+	var keys []string
+	for k, v := range t.writes {
+		if v != nil && strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,457 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/kvstore"
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// sessionKey is the request metadata key of
+// the session of the user making a call.
+const sessionKey = "session"
+
+// metadataValue returns the first value of the
+// request metadata key of ctx, or "" if not set.
+func metadataValue(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vs := md[key]; len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+const (
+	// maxFailedLogins is the number of failed logins
+	// in a row after which an account is locked.
+	maxFailedLogins = 5
+	// lockoutPeriod is how long accounts are locked for.
+	lockoutPeriod = 15 * time.Minute
+	// sessionLifetime is how long sessions last after a login.
+	sessionLifetime = 24 * time.Hour
+
+	minPasswordLength = 8
+	// maxPasswordLength is the longest password bcrypt hashes.
+	maxPasswordLength    = 72
+	maxDisplayNameLen    = 64
+	maxBioLength         = 500
+	maxFavouriteSubjects = 10
+)
+
+var userNamePattern = regexp.MustCompile(`^[a-z0-9_-]{3,32}$`)
+
+// UserService implements library.UserServiceServer. The accounts, display
+// names and sessions of users are stored in Store, under the keys
+// "users/account/<name>", "users/display-name/<lower case display name>"
+// and "users/session/<session>".
+type UserService struct {
+	// Store stores the accounts of users. If nil, they are
+	// kept in memory. Changes to accounts are only serialized
+	// within a process, so replicas sharing a Store may
+	// register the same display name at the same time.
+	Store kvstore.Store
+
+	// PasswordCost is the bcrypt cost of hashing
+	// passwords. If 0, bcrypt.DefaultCost is used.
+	PasswordCost int
+
+	// Clock tells the time for sessions and lock-outs.
+	// If nil, the system clock is used.
+	Clock Clock
+
+	once  sync.Once
+	store kvstore.Store
+	// unknown is the hash compared with the passwords
+	// of unknown users, made by unknownOnce.
+	unknownOnce sync.Once
+	unknown     []byte
+	// mu serializes changes to accounts, so
+	// that display names stay unique.
+	mu sync.Mutex
+}
+
+// account is a user account, as stored.
+type account struct {
+	Profile      *library.User `json:"profile"`
+	PasswordHash []byte        `json:"password_hash"`
+	// FailedLogins is the number of failed logins since
+	// the last successful login or lock-out.
+	FailedLogins int       `json:"failed_logins"`
+	LockedUntil  time.Time `json:"locked_until"`
+	// Sessions are the times the sessions of the user expire,
+	// by session, so that they are deleted once expired.
+	Sessions map[string]time.Time `json:"sessions,omitempty"`
+}
+
+// session is a session of a user, as stored.
+type session struct {
+	User    string    `json:"user"`
+	Expires time.Time `json:"expires"`
+}
+
+func accountKey(name string) string { return "users/account/" + name }
+
+func displayNameKey(displayName string) string {
+	return "users/display-name/" + strings.ToLower(displayName)
+}
+
+func sessionStoreKey(id string) string { return "users/session/" + id }
+
+func (u *UserService) st() kvstore.Store {
+	u.once.Do(func() {
+		u.store = u.Store
+		if u.store == nil {
+			u.store = kvstore.NewMemoryStore()
+		}
+	})
+	return u.store
+}
+
+func (u *UserService) passwordCost() int {
+	if u.PasswordCost == 0 {
+		return bcrypt.DefaultCost
+	}
+	return u.PasswordCost
+}
+
+// unknownHash returns a hash which Login compares the passwords
+// of unknown users with, so that they take as long to reject as
+// wrong passwords, and timing logins does not tell who registered.
+func (u *UserService) unknownHash() []byte {
+	u.unknownOnce.Do(func() {
+		var password [16]byte
+		rand.Read(password[:])
+		u.unknown, _ = bcrypt.GenerateFromPassword(password[:], u.passwordCost())
+	})
+	return u.unknown
+}
+
+func (u *UserService) now() time.Time {
+	if u.Clock == nil {
+		return systemClock{}.Now()
+	}
+	return u.Clock.Now()
+}
+
+// getJSON decodes the value of key into v. If key is not
+// set, it returns kvstore.ErrNotFound.
+func (u *UserService) getJSON(ctx context.Context, key string, v interface{}) error {
+	data, err := u.st().Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// setJSON sets key to the encoding of v.
+func (u *UserService) setJSON(ctx context.Context, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return u.st().Set(ctx, key, data)
+}
+
+// account returns the account of the user named name,
+// or a NotFound error if there is none.
+func (u *UserService) account(ctx context.Context, name string) (*account, error) {
+	a := &account{}
+	err := u.getJSON(ctx, accountKey(name), a)
+	if err == kvstore.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "User could not be found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// displayNameOwner returns the name of the user with
+// the display name displayName, or "" if there is none.
+func (u *UserService) displayNameOwner(ctx context.Context, displayName string) (string, error) {
+	data, err := u.st().Get(ctx, displayNameKey(displayName))
+	if err == kvstore.ErrNotFound {
+		return "", nil
+	}
+	return string(data), err
+}
+
+// reserveDisplayName reserves displayName for the user
+// named name, or returns an AlreadyExists error if another
+// user has it. u.mu must be held.
+func (u *UserService) reserveDisplayName(ctx context.Context, displayName, name string) error {
+	owner, err := u.displayNameOwner(ctx, displayName)
+	if err != nil {
+		return err
+	}
+	if owner != "" && owner != name {
+		return status.Errorf(codes.AlreadyExists, "the display name %q is taken", displayName)
+	}
+	return u.st().Set(ctx, displayNameKey(displayName), []byte(name))
+}
+
+// sessionUser returns the name of the user of the session in
+// the request metadata of ctx, or an Unauthenticated error if
+// there is no such session.
+func (u *UserService) sessionUser(ctx context.Context) (string, error) {
+	id := metadataValue(ctx, sessionKey)
+	if id == "" {
+		return "", status.Error(codes.Unauthenticated, "missing session, log in first")
+	}
+	sess := &session{}
+	err := u.getJSON(ctx, sessionStoreKey(id), sess)
+	if err == kvstore.ErrNotFound || (err == nil && !u.now().Before(sess.Expires)) {
+		return "", status.Error(codes.Unauthenticated, "session not found or expired, log in again")
+	}
+	if err != nil {
+		return "", err
+	}
+	return sess.User, nil
+}
+
+// checkDisplayName returns an InvalidArgument
+// error if displayName is not valid.
+func checkDisplayName(displayName string) error {
+	if displayName == "" || strings.TrimSpace(displayName) != displayName {
+		return status.Error(codes.InvalidArgument, "display names must not be empty, or start or end with spaces")
+	}
+	if utf8.RuneCountInString(displayName) > maxDisplayNameLen {
+		return status.Errorf(codes.InvalidArgument, "display names are at most %d characters", maxDisplayNameLen)
+	}
+	return nil
+}
+
+func (u *UserService) Register(ctx context.Context, req *library.RegisterRequest) (*library.User, error) {
+	if !userNamePattern.MatchString(req.Name) {
+		return nil, status.Error(codes.InvalidArgument, "user names are 3 to 32 lower case letters, digits, dashes or underscores")
+	}
+	if utf8.RuneCountInString(req.Password) < minPasswordLength || len(req.Password) > maxPasswordLength {
+		return nil, status.Errorf(codes.InvalidArgument, "passwords are at least %d characters and at most %d bytes", minPasswordLength, maxPasswordLength)
+	}
+	displayName := req.DisplayName
+	if displayName == "" {
+		displayName = req.Name
+	}
+	if err := checkDisplayName(displayName); err != nil {
+		return nil, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), u.passwordCost())
+	if err != nil {
+		return nil, err
+	}
+	created, err := ptypes.TimestampProto(u.now())
+	if err != nil {
+		return nil, err
+	}
+	a := &account{
+		Profile: &library.User{
+			Name:        req.Name,
+			DisplayName: displayName,
+			Created:     created,
+		},
+		PasswordHash: hash,
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, err := u.st().Get(ctx, accountKey(req.Name)); err != kvstore.ErrNotFound {
+		if err == nil {
+			return nil, status.Errorf(codes.AlreadyExists, "the user name %q is taken", req.Name)
+		}
+		return nil, err
+	}
+	if err := u.reserveDisplayName(ctx, displayName, req.Name); err != nil {
+		return nil, err
+	}
+	if err := u.setJSON(ctx, accountKey(req.Name), a); err != nil {
+		return nil, err
+	}
+	return a.Profile, nil
+}
+
+// newSession starts a session of the user of a, which lasts
+// from now for sessionLifetime, and deletes the expired sessions
+// of the user. u.mu must be held.
+func (u *UserService) newSession(ctx context.Context, a *account, now time.Time) (string, *session, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	id := base64.RawURLEncoding.EncodeToString(b)
+	sess := &session{User: a.Profile.Name, Expires: now.Add(sessionLifetime)}
+	if err := u.setJSON(ctx, sessionStoreKey(id), sess); err != nil {
+		return "", nil, err
+	}
+	for old, expires := range a.Sessions {
+		if now.Before(expires) {
+			continue
+		}
+		if err := u.st().Delete(ctx, sessionStoreKey(old)); err != nil {
+			return "", nil, err
+		}
+		delete(a.Sessions, old)
+	}
+	if a.Sessions == nil {
+		a.Sessions = map[string]time.Time{}
+	}
+	a.Sessions[id] = sess.Expires
+	if err := u.setJSON(ctx, accountKey(a.Profile.Name), a); err != nil {
+		return "", nil, err
+	}
+	return id, sess, nil
+}
+
+func (u *UserService) Login(ctx context.Context, req *library.LoginRequest) (*library.LoginResponse, error) {
+	// Locked accounts get the same error as wrong passwords,
+	// so that guessing passwords tells nothing about them.
+	wrong := status.Error(codes.Unauthenticated, "wrong user name or password")
+	a, err := u.account(ctx, req.Name)
+	if status.Code(err) == codes.NotFound {
+		bcrypt.CompareHashAndPassword(u.unknownHash(), []byte(req.Password))
+		return nil, wrong
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := u.now()
+	// Passwords are compared before taking the lock, as it is slow
+	ok := bcrypt.CompareHashAndPassword(a.PasswordHash, []byte(req.Password)) == nil
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	// The account may have changed while comparing passwords
+	if a, err = u.account(ctx, req.Name); err != nil {
+		return nil, err
+	}
+	if now.Before(a.LockedUntil) {
+		return nil, wrong
+	}
+	if !ok {
+		a.FailedLogins++
+		if a.FailedLogins >= maxFailedLogins {
+			a.FailedLogins = 0
+			a.LockedUntil = now.Add(lockoutPeriod)
+		}
+		if err := u.setJSON(ctx, accountKey(req.Name), a); err != nil {
+			return nil, err
+		}
+		return nil, wrong
+	}
+	a.FailedLogins = 0
+	id, sess, err := u.newSession(ctx, a, now)
+	if err != nil {
+		return nil, err
+	}
+	expires, err := ptypes.TimestampProto(sess.Expires)
+	if err != nil {
+		return nil, err
+	}
+	return &library.LoginResponse{User: a.Profile, Session: id, Expires: expires}, nil
+}
+
+func (u *UserService) GetUser(ctx context.Context, req *library.GetUserRequest) (*library.User, error) {
+	a, err := u.account(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return a.Profile, nil
+}
+
+func (u *UserService) UpdateProfile(ctx context.Context, req *library.UpdateProfileRequest) (*library.User, error) {
+	name, err := u.sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	displayName := req.DisplayName
+	if displayName == "" {
+		displayName = name
+	}
+	if err := checkDisplayName(displayName); err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(req.Bio) > maxBioLength {
+		return nil, status.Errorf(codes.InvalidArgument, "bios are at most %d characters", maxBioLength)
+	}
+	if len(req.FavouriteSubjects) > maxFavouriteSubjects {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d favourite subjects", maxFavouriteSubjects)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	a, err := u.account(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	old := a.Profile.DisplayName
+	if err := u.reserveDisplayName(ctx, displayName, name); err != nil {
+		return nil, err
+	}
+	a.Profile = proto.Clone(a.Profile).(*library.User)
+	a.Profile.DisplayName = displayName
+	a.Profile.Bio = req.Bio
+	a.Profile.FavouriteSubjects = req.FavouriteSubjects
+	if err := u.setJSON(ctx, accountKey(name), a); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(old, displayName) {
+		if err := u.st().Delete(ctx, displayNameKey(old)); err != nil {
+			return nil, err
+		}
+	}
+	return a.Profile, nil
+}
+
+// sessionUser returns the user logged in with the session in the
+// request metadata of ctx, or an Unauthenticated error if there is
+// no such session, or s has no users.
+func (s *BookService) sessionUser(ctx context.Context) (string, error) {
+	if s.Users == nil {
+		return "", status.Error(codes.Unauthenticated, "the service has no users to log in as")
+	}
+	return s.Users.sessionUser(ctx)
+}
+
+// caller returns the user logged in with the session in the request
+// metadata of ctx, or "" if there is no session. It returns an
+// Unauthenticated error if the session is not valid.
+func (s *BookService) caller(ctx context.Context) (string, error) {
+	if metadataValue(ctx, sessionKey) == "" {
+		return "", nil
+	}
+	return s.sessionUser(ctx)
+}
+
+// checkChatName returns a PermissionDenied error if name is the
+// display name of a registered user other than the one logged in
+// with the session in the request metadata of ctx.
+func (s *BookService) checkChatName(ctx context.Context, name string) error {
+	if s.Users == nil {
+		return nil
+	}
+	owner, err := s.Users.displayNameOwner(ctx, name)
+	if err != nil || owner == "" {
+		return err
+	}
+	if user, err := s.Users.sessionUser(ctx); err != nil || user != owner {
+		return status.Errorf(codes.PermissionDenied, "the name %q belongs to a registered user, log in as them to use it", name)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/johanbrandhorst/grpcweb-example/kvstore"
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+var testNow = time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+// newUsersClient returns clients of a UserService using clock, and of a
+// BookService with its users.
+func newUsersClient(t *testing.T, clock Clock) (library.UserServiceClient, *testClient, func()) {
+	t.Helper()
+	users := &UserService{PasswordCost: bcrypt.MinCost, Clock: clock}
+	client, cleanup := newServiceClient(t, &BookService{Users: users})
+	return client.users, client, cleanup
+}
+
+func register(t *testing.T, client library.UserServiceClient, name, displayName string) {
+	t.Helper()
+	ctx, cancel := testContext()
+	defer cancel()
+	_, err := client.Register(ctx, &library.RegisterRequest{Name: name, Password: "password-of-" + name, DisplayName: displayName})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// login logs in as name, and returns a context with the session.
+func login(t *testing.T, client library.UserServiceClient, name string) (context.Context, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := testContext()
+	resp, err := client.Login(ctx, &library.LoginRequest{Name: name, Password: "password-of-" + name})
+	if err != nil {
+		cancel()
+		t.Fatal(err)
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs(sessionKey, resp.Session)), cancel
+}
+
+func TestRegister(t *testing.T) {
+	clock := newFakeClock(testNow)
+	client, _, cleanup := newUsersClient(t, clock)
+	defer cleanup()
+	register(t, client, "alice", "Alice")
+	register(t, client, "bob", "")
+
+	ctx, cancel := testContext()
+	defer cancel()
+	u, err := client.GetUser(ctx, &library.GetUserRequest{Name: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Name != "bob" || u.DisplayName != "bob" || u.Created.Seconds != testNow.Unix() {
+		t.Errorf("got user %v, want bob created now", u)
+	}
+	_, err = client.GetUser(ctx, &library.GetUserRequest{Name: "carol"})
+	checkCode(t, err, codes.NotFound)
+
+	for _, test := range []struct {
+		name string
+		req  *library.RegisterRequest
+		code codes.Code
+	}{
+		{"TakenName", &library.RegisterRequest{Name: "alice", Password: "12345678", DisplayName: "Alice 2"}, codes.AlreadyExists},
+		{"TakenDisplayName", &library.RegisterRequest{Name: "carol", Password: "12345678", DisplayName: "ALICE"}, codes.AlreadyExists},
+		{"NameAsDisplayName", &library.RegisterRequest{Name: "carol", Password: "12345678", DisplayName: "bob"}, codes.AlreadyExists},
+		{"InvalidName", &library.RegisterRequest{Name: "Carol", Password: "12345678"}, codes.InvalidArgument},
+		{"ShortName", &library.RegisterRequest{Name: "cc", Password: "12345678"}, codes.InvalidArgument},
+		{"ShortPassword", &library.RegisterRequest{Name: "carol", Password: "1234567"}, codes.InvalidArgument},
+		{"LongPassword", &library.RegisterRequest{Name: "carol", Password: strings.Repeat("a", maxPasswordLength+1)}, codes.InvalidArgument},
+		{"SpacedDisplayName", &library.RegisterRequest{Name: "carol", Password: "12345678", DisplayName: " Carol"}, codes.InvalidArgument},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := testContext()
+			defer cancel()
+			_, err := client.Register(ctx, test.req)
+			checkCode(t, err, test.code)
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	clock := newFakeClock(testNow)
+	client, _, cleanup := newUsersClient(t, clock)
+	defer cleanup()
+	register(t, client, "alice", "Alice")
+
+	ctx, cancel := testContext()
+	defer cancel()
+	resp, err := client.Login(ctx, &library.LoginRequest{Name: "alice", Password: "password-of-alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.User.Name != "alice" || resp.Session == "" || resp.Expires.Seconds != testNow.Add(sessionLifetime).Unix() {
+		t.Errorf("got login %v, want a session of alice for a day", resp)
+	}
+	_, err = client.Login(ctx, &library.LoginRequest{Name: "bob", Password: "password-of-alice"})
+	checkCode(t, err, codes.Unauthenticated)
+
+	wrong := &library.LoginRequest{Name: "alice", Password: "wrong password"}
+	t.Run("Reset", func(t *testing.T) {
+		// Successful logins reset the count of failed logins
+		for i := 0; i < maxFailedLogins-1; i++ {
+			_, err := client.Login(ctx, wrong)
+			checkCode(t, err, codes.Unauthenticated)
+		}
+		login(t, client, "alice")
+		_, err := client.Login(ctx, wrong)
+		checkCode(t, err, codes.Unauthenticated)
+		login(t, client, "alice")
+	})
+
+	t.Run("LockOut", func(t *testing.T) {
+		for i := 0; i < maxFailedLogins; i++ {
+			_, err := client.Login(ctx, wrong)
+			checkCode(t, err, codes.Unauthenticated)
+		}
+		// Even the right password is refused while locked,
+		// with the same error as a wrong password
+		_, err := client.Login(ctx, &library.LoginRequest{Name: "alice", Password: "password-of-alice"})
+		checkCode(t, err, codes.Unauthenticated)
+		if _, werr := client.Login(ctx, wrong); err.Error() != werr.Error() {
+			t.Errorf("got error %q while locked, want %q as for a wrong password", err, werr)
+		}
+		clock.Advance(lockoutPeriod)
+		login(t, client, "alice")
+	})
+}
+
+func TestLoginPurgesSessions(t *testing.T) {
+	clock := newFakeClock(testNow)
+	store := kvstore.NewMemoryStore()
+	users := &UserService{Store: store, PasswordCost: bcrypt.MinCost, Clock: clock}
+	ctx, cancel := testContext()
+	defer cancel()
+	if _, err := users.Register(ctx, &library.RegisterRequest{Name: "alice", Password: "password-of-alice"}); err != nil {
+		t.Fatal(err)
+	}
+	req := &library.LoginRequest{Name: "alice", Password: "password-of-alice"}
+	first, err := users.Login(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(sessionLifetime / 2)
+	second, err := users.Login(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Logging in after the first session expired deletes it
+	clock.Advance(sessionLifetime / 2)
+	if _, err := users.Login(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, sessionStoreKey(first.Session)); err != kvstore.ErrNotFound {
+		t.Errorf("got error %v getting the expired session, want kvstore.ErrNotFound", err)
+	}
+	if _, err := store.Get(ctx, sessionStoreKey(second.Session)); err != nil {
+		t.Errorf("got error %v getting the session which has not expired", err)
+	}
+}
+
+func TestUpdateProfile(t *testing.T) {
+	clock := newFakeClock(testNow)
+	client, _, cleanup := newUsersClient(t, clock)
+	defer cleanup()
+	register(t, client, "alice", "Alice")
+	register(t, client, "bob", "Bob")
+
+	ctx, cancel := login(t, client, "alice")
+	defer cancel()
+	u, err := client.UpdateProfile(ctx, &library.UpdateProfileRequest{
+		DisplayName:       "Alice L.",
+		Bio:               "Reads anything with a rabbit in it",
+		FavouriteSubjects: []string{"Fantasy"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.DisplayName != "Alice L." || u.Bio == "" || len(u.FavouriteSubjects) != 1 || u.Created.Seconds != testNow.Unix() {
+		t.Errorf("got profile %v, want the profile of alice updated", u)
+	}
+	// The old display name is free again
+	register(t, client, "carol", "Alice")
+
+	for _, test := range []struct {
+		name string
+		req  *library.UpdateProfileRequest
+		code codes.Code
+	}{
+		{"TakenDisplayName", &library.UpdateProfileRequest{DisplayName: "bob"}, codes.AlreadyExists},
+		{"LongBio", &library.UpdateProfileRequest{Bio: strings.Repeat("a", maxBioLength+1)}, codes.InvalidArgument},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := client.UpdateProfile(ctx, test.req)
+			checkCode(t, err, test.code)
+		})
+	}
+
+	t.Run("NoSession", func(t *testing.T) {
+		ctx, cancel := testContext()
+		defer cancel()
+		_, err := client.UpdateProfile(ctx, &library.UpdateProfileRequest{})
+		checkCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("ExpiredSession", func(t *testing.T) {
+		clock.Advance(sessionLifetime)
+		_, err := client.UpdateProfile(ctx, &library.UpdateProfileRequest{})
+		checkCode(t, err, codes.Unauthenticated)
+	})
+}
+
+func TestBookChatDisplayNames(t *testing.T) {
+	client, books, cleanup := newUsersClient(t, newFakeClock(testNow))
+	defer cleanup()
+	register(t, client, "alice", "Alice")
+
+	// Unregistered names are free for anyone
+	ctx, cancel := testContext()
+	defer cancel()
+	join(ctx, t, books, "Bob")
+
+	for _, name := range []string{"Alice", "alice"} {
+		stream, err := books.BookChat(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := stream.Send(&library.BookMessage{Content: &library.BookMessage_Name{Name: name}}); err != nil {
+			t.Fatal(err)
+		}
+		_, err = stream.Recv()
+		checkCode(t, err, codes.PermissionDenied)
+	}
+
+	aliceCtx, aliceCancel := login(t, client, "alice")
+	defer aliceCancel()
+	join(aliceCtx, t, books, "Alice")
+}
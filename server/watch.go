@@ -0,0 +1,95 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+func (s *BookService) WatchBooks(req *library.WatchBooksRequest, srv library.BookService_WatchBooksServer) error {
+	w, err := s.c.Watch(req.AfterSequence, req.IncludeExisting)
+	switch err {
+	case nil:
+	case errFutureSequence:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errSequenceGone:
+		return status.Error(codes.OutOfRange, err.Error())
+	default:
+		return err
+	}
+	defer s.c.Unwatch(w)
+
+	filter := bookFilter{authorPrefix: req.AuthorPrefix, authorID: req.AuthorId}
+	var existing []*library.Book
+	for _, bk := range w.Existing {
+		if filter.match(bk) {
+			existing = append(existing, bk)
+		}
+	}
+	for _, bk := range s.withSummaries(existing...) {
+		err := srv.Send(&library.BookEvent{
+			Type:     library.BookEvent_EXISTING,
+			Sequence: w.Seq,
+			Book:     bk,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	last := w.Seq
+	send := func(ch change) error {
+		last = ch.seq
+		ev := changeEvent(ch, filter)
+		if ev == nil {
+			return nil
+		}
+		ev.Book = s.withSummaries(ev.Book)[0]
+		return srv.Send(ev)
+	}
+	for _, ch := range w.Backlog {
+		err := send(ch)
+		if err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case ch, ok := <-w.C:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "too many changes were not received in time, resume after sequence %d", last)
+			}
+			err := send(ch)
+			if err != nil {
+				return err
+			}
+		case <-srv.Context().Done():
+			return srv.Context().Err()
+		}
+	}
+}
+
+// changeEvent returns the event describing ch to a watch of the books
+// matched by filter, or nil if the books watched were not changed.
+// Books changed to match or not match the filter are created or
+// deleted, as far as the watch is concerned.
+func changeEvent(ch change, filter bookFilter) *library.BookEvent {
+	oldMatch := ch.old != nil && filter.match(ch.old)
+	newMatch := filter.match(ch.new)
+	ev := &library.BookEvent{Sequence: ch.seq}
+	switch {
+	case oldMatch && newMatch:
+		ev.Type, ev.Book = library.BookEvent_UPDATED, ch.new
+	case newMatch:
+		ev.Type, ev.Book = library.BookEvent_CREATED, ch.new
+	case oldMatch:
+		ev.Type, ev.Book = library.BookEvent_DELETED, ch.old
+	default:
+		return nil
+	}
+	return ev
+}
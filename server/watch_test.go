@@ -0,0 +1,168 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+
+	"github.com/johanbrandhorst/grpcweb-example/server/proto/library"
+)
+
+// watchBooks starts watching, and waits until the
+// existing books have been received, if requested.
+func watchBooks(ctx context.Context, t *testing.T, client *testClient, req *library.WatchBooksRequest) (library.BookService_WatchBooksClient, []*library.BookEvent) {
+	t.Helper()
+	stream, err := client.WatchBooks(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var existing []*library.BookEvent
+	if req.IncludeExisting {
+		for range queryBooks(t, client, req.AuthorPrefix) {
+			existing = append(existing, expectEvent(t, stream))
+		}
+	}
+	return stream, existing
+}
+
+func expectEvent(t *testing.T, stream library.BookService_WatchBooksClient) *library.BookEvent {
+	t.Helper()
+	ev, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ev
+}
+
+func checkEvent(t *testing.T, ev *library.BookEvent, typ library.BookEvent_Type, seq int64, title string) {
+	t.Helper()
+	if ev.Type != typ || ev.Sequence != seq || ev.Book.GetTitle() != title {
+		t.Errorf("got event %v, want %v %d %q", ev, typ, seq, title)
+	}
+}
+
+func TestWatchBooks(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx, cancel := testContext()
+	defer cancel()
+
+	all, existing := watchBooks(ctx, t, client, &library.WatchBooksRequest{IncludeExisting: true})
+	george, _ := watchBooks(ctx, t, client, &library.WatchBooksRequest{AuthorPrefix: "George", IncludeExisting: true})
+	if len(existing) != len(books) {
+		t.Fatalf("got %d existing books, want %d", len(existing), len(books))
+	}
+	for i, ev := range existing {
+		checkEvent(t, ev, library.BookEvent_EXISTING, 0, books[i].Title)
+	}
+
+	book := &library.Book{Isbn: 1, Title: "Homage to Catalonia", Author: "George Orwell"}
+	importBooks(t, client, nil, book)
+	book = &library.Book{Isbn: 1, Title: "Homage to Catalonia (Revised)", Author: "George Orwell"}
+	importBooks(t, client, nil, book)
+	book = &library.Book{Isbn: 1, Title: "Homage to Catalonia (Revised)", Author: "Eric Blair"}
+	importBooks(t, client, nil, book)
+
+	checkEvent(t, expectEvent(t, all), library.BookEvent_CREATED, 1, "Homage to Catalonia")
+	checkEvent(t, expectEvent(t, all), library.BookEvent_UPDATED, 2, "Homage to Catalonia (Revised)")
+	checkEvent(t, expectEvent(t, all), library.BookEvent_UPDATED, 3, "Homage to Catalonia (Revised)")
+
+	// Books no longer by a George are deleted,
+	// and books by others are not sent.
+	checkEvent(t, expectEvent(t, george), library.BookEvent_CREATED, 1, "Homage to Catalonia")
+	checkEvent(t, expectEvent(t, george), library.BookEvent_UPDATED, 2, "Homage to Catalonia (Revised)")
+	ev := expectEvent(t, george)
+	checkEvent(t, ev, library.BookEvent_DELETED, 3, "Homage to Catalonia (Revised)")
+	if ev.Book.Author != "George Orwell" {
+		t.Errorf("got deleted book %v, want the book before the change", ev.Book)
+	}
+
+	t.Run("Resume", func(t *testing.T) {
+		stream, _ := watchBooks(ctx, t, client, &library.WatchBooksRequest{AfterSequence: 1})
+		checkEvent(t, expectEvent(t, stream), library.BookEvent_UPDATED, 2, "Homage to Catalonia (Revised)")
+		checkEvent(t, expectEvent(t, stream), library.BookEvent_UPDATED, 3, "Homage to Catalonia (Revised)")
+	})
+
+	t.Run("ExistingSequence", func(t *testing.T) {
+		_, existing := watchBooks(ctx, t, client, &library.WatchBooksRequest{AuthorPrefix: "George", IncludeExisting: true})
+		if len(existing) != 2 {
+			t.Fatalf("got %d existing books, want 2", len(existing))
+		}
+		checkEvent(t, existing[0], library.BookEvent_EXISTING, 3, "Nineteen Eighty-Four")
+	})
+
+	t.Run("FutureSequence", func(t *testing.T) {
+		stream, _ := watchBooks(ctx, t, client, &library.WatchBooksRequest{AfterSequence: 4})
+		_, err := stream.Recv()
+		checkCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("SequenceGone", func(t *testing.T) {
+		var bks []*library.Book
+		for i := 0; i < maxChanges; i++ {
+			bks = append(bks, &library.Book{Isbn: int64(i + 10), Title: fmt.Sprint(i), Author: "Anonymous"})
+		}
+		importBooks(t, client, nil, bks...)
+		stream, _ := watchBooks(ctx, t, client, &library.WatchBooksRequest{AfterSequence: 2})
+		_, err := stream.Recv()
+		checkCode(t, err, codes.OutOfRange)
+
+		// The oldest change still kept can be resumed after
+		stream, _ = watchBooks(ctx, t, client, &library.WatchBooksRequest{AfterSequence: 3})
+		checkEvent(t, expectEvent(t, stream), library.BookEvent_CREATED, 4, "0")
+	})
+}
+
+func TestWatchBooksAvailability(t *testing.T) {
+	client, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx, cancel := testContext()
+	defer cancel()
+
+	stream, existing := watchBooks(ctx, t, client, &library.WatchBooksRequest{AuthorPrefix: "Aldous", IncludeExisting: true})
+	checkOut(t, client, "alice", &library.CheckOutRequest{Isbn: books[0].Isbn})
+	updated := proto.Clone(books[0]).(*library.Book)
+	updated.Title = "Brave New World (Revised)"
+	importBooks(t, client, nil, updated)
+
+	// Watched books have their availability, as
+	// the books returned by GetBook and QueryBooks.
+	if a := existing[0].Book.Availability; a.GetCopies() != 2 || a.GetAvailable() != 2 {
+		t.Errorf("got availability %v, want 2 copies available", a)
+	}
+	ev := expectEvent(t, stream)
+	bk, err := client.GetBook(ctx, &library.GetBookRequest{Isbn: books[0].Isbn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Book.Availability.GetAvailable() != 1 || !proto.Equal(ev.Book, bk) {
+		t.Errorf("got book %v, want %v", ev.Book, bk)
+	}
+}
+
+func TestCatalogueWatchFallsBehind(t *testing.T) {
+	var c catalogue
+	w, err := c.Watch(0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i <= watchBuffer; i++ {
+		c.Put(&library.Book{Isbn: int64(i + 1)})
+	}
+	for i := 0; i < watchBuffer; i++ {
+		if ch := <-w.C; ch.seq != int64(i+1) {
+			t.Fatalf("got change %d, want %d", ch.seq, i+1)
+		}
+	}
+	if _, ok := <-w.C; ok {
+		t.Error("got a change from a watch that fell behind")
+	}
+	// Unwatching a watch that fell behind does nothing
+	c.Unwatch(w)
+}
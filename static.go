@@ -0,0 +1,216 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/golang/gddo/httputil/header"
+)
+
+// hashedAsset matches the names of assets containing a content hash,
+// as generated by client/compiled/hash_generate.go.
+var hashedAsset = regexp.MustCompile(`^[^.]+\.[0-9a-f]{12}\.`)
+
+// assetETags returns the ETags of all files in fs, keyed by path.
+// Precompressed files are skipped, as they are served
+// in place of the file they were created from.
+func assetETags(fs http.FileSystem) (map[string]string, error) {
+	etags := map[string]string{}
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		d, err := fs.Open(dir)
+		if err != nil {
+			return err
+		}
+		defer d.Close()
+		infos, err := d.Readdir(-1)
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			name := path.Join(dir, info.Name())
+			if info.IsDir() {
+				err = walk(name)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if isPrecompressed(name) {
+				continue
+			}
+			content, err := readFile(fs, name)
+			if err != nil {
+				return err
+			}
+			sum := sha256.Sum256(content)
+			// The ETag is weak as the content may be served
+			// with different encodings.
+			etags[name] = `W/"` + hex.EncodeToString(sum[:8]) + `"`
+		}
+		return nil
+	}
+	return etags, walk("/")
+}
+
+// cacheHandler wraps an http.HandlerFunc serving static assets such that it
+// sets the ETag and Cache-Control headers. Assets with a content hash in
+// their name never change and can be cached forever, while everything else
+// must be revalidated, so that a new deploy is picked up immediately.
+func cacheHandler(etags map[string]string, fn http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag, ok := etags[r.URL.Path]
+		if ok {
+			// http.ServeContent handles conditional requests
+			// using the ETag set here.
+			w.Header().Set("ETag", etag)
+		}
+		if ok && hashedAsset.MatchString(path.Base(r.URL.Path)) {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			w.Header().Set("Cache-Control", "no-cache")
+		}
+		fn(w, r)
+	})
+}
+
+// encoding describes a content encoding the assets are precompressed with.
+type encoding struct {
+	// name is the name of the encoding, as used in HTTP headers.
+	name string
+	// ext is the extension of the precompressed files.
+	ext string
+}
+
+// encodings lists the available encodings, in order of preference.
+var encodings = []encoding{
+	{name: "br", ext: ".br"},
+	{name: "gzip", ext: ".gz"},
+}
+
+func isPrecompressed(name string) bool {
+	for _, enc := range encodings {
+		if strings.HasSuffix(name, enc.ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptedEncodings returns the encodings in encodings that the client
+// prefers over identity, ordered by the client's preference, as described
+// by the q-values in the Accept-Encoding header. Encodings the
+// client likes equally are ordered by our preference.
+func acceptedEncodings(r *http.Request) []encoding {
+	qs := map[string]float64{}
+	for _, spec := range header.ParseAccept(r.Header, "Accept-Encoding") {
+		qs[strings.ToLower(spec.Value)] = spec.Q
+	}
+
+	var accepted []encoding
+	q := func(name string) float64 {
+		if q, ok := qs[name]; ok {
+			return q
+		}
+		// Encodings not listed explicitly are
+		// given the quality of the wildcard, if any.
+		return qs["*"]
+	}
+	for _, enc := range encodings {
+		if q(enc.name) <= 0 {
+			continue
+		}
+		i := len(accepted)
+		for i > 0 && q(accepted[i-1].name) < q(enc.name) {
+			i--
+		}
+		accepted = append(accepted, encoding{})
+		copy(accepted[i+1:], accepted[i:])
+		accepted[i] = enc
+	}
+
+	// Identity is used as a fallback, unless the
+	// client explicitly prefers it over an encoding.
+	identity, ok := qs["identity"]
+	if !ok {
+		return accepted
+	}
+	for i, enc := range accepted {
+		if q(enc.name) < identity {
+			return accepted[:i]
+		}
+	}
+	return accepted
+}
+
+type assetServer struct {
+	root http.FileSystem
+}
+
+// newAssetServer returns an http.Handler serving the files in root.
+// If a precompressed version of a requested file exists in root
+// with an encoding the client accepts, the precompressed version is
+// served instead. Like gzipped.FileServer, and unlike http.FileServer,
+// it does not support directory listings.
+func newAssetServer(root http.FileSystem) http.Handler {
+	return &assetServer{root: root}
+}
+
+func (a *assetServer) openAndStat(name string) (http.File, os.FileInfo, error) {
+	file, err := a.root.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, nil, fmt.Errorf("%s is a directory", name)
+	}
+	return file, info, nil
+}
+
+func (a *assetServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := path.Clean("/" + r.URL.Path)
+	if isPrecompressed(name) {
+		// Precompressed files are only served in place
+		// of the file they were created from.
+		http.NotFound(w, r)
+		return
+	}
+
+	// The response depends on the Accept-Encoding header,
+	// whether or not a precompressed file was served.
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	for _, enc := range acceptedEncodings(r) {
+		file, info, err := a.openAndStat(name + enc.ext)
+		if err != nil {
+			continue
+		}
+		defer file.Close()
+		w.Header().Set("Content-Encoding", enc.name)
+		http.ServeContent(w, r, name, info.ModTime(), file)
+		return
+	}
+
+	file, info, err := a.openAndStat(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+	http.ServeContent(w, r, name, info.ModTime(), file)
+}
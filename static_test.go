@@ -0,0 +1,215 @@
+// Copyright 2017 Johan Brandhorst. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testAssets returns a directory of assets, as generated for
+// the client, with a hashed and an unhashed asset.
+func testAssets(t *testing.T) (http.FileSystem, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"index.html":                 "index",
+		"index.html.gz":              "index gzip",
+		"index.html.br":              "index br",
+		"client.0123456789ab.js":     "client",
+		"client.0123456789ab.js.gz":  "client gzip",
+		"source/book/bookchat.go":    "package book",
+		"source/book/bookchat.go.br": "package book br",
+	} {
+		name = filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return http.Dir(dir), func() { os.RemoveAll(dir) }
+}
+
+func TestAcceptedEncodings(t *testing.T) {
+	for _, test := range []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"BR", "br"},
+		{"gzip, br", "br gzip"},
+		{"gzip;q=1, br;q=0.5", "gzip br"},
+		{"br;q=0, gzip", "gzip"},
+		{"*", "br gzip"},
+		{"*;q=0.5, gzip", "gzip br"},
+		{"*, br;q=0", "gzip"},
+		{"gzip, identity;q=0", "gzip"},
+		{"gzip;q=0.5, identity", ""},
+		{"br, gzip;q=0.5, identity;q=0.8", "br"},
+	} {
+		r := httptest.NewRequest("GET", "/index.html", nil)
+		if test.acceptEncoding != "" {
+			r.Header.Set("Accept-Encoding", test.acceptEncoding)
+		}
+		var names []string
+		for _, enc := range acceptedEncodings(r) {
+			names = append(names, enc.name)
+		}
+		if got := strings.Join(names, " "); got != test.want {
+			t.Errorf("got encodings %q for Accept-Encoding %q, want %q", got, test.acceptEncoding, test.want)
+		}
+	}
+}
+
+func TestAssetServer(t *testing.T) {
+	assets, cleanup := testAssets(t)
+	defer cleanup()
+	etags, err := assetETags(assets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(etags) != 3 {
+		t.Errorf("got ETags of %d assets, want 3 without the precompressed files", len(etags))
+	}
+	handler := cacheHandler(etags, newAssetServer(assets).ServeHTTP)
+
+	const (
+		noCache   = "no-cache"
+		immutable = "public, max-age=31536000, immutable"
+	)
+	for _, test := range []struct {
+		name            string
+		path            string
+		acceptEncoding  string
+		code            int
+		body            string
+		contentEncoding string
+		cacheControl    string
+	}{
+		{"Identity", "/index.html", "", http.StatusOK, "index", "", noCache},
+		{"Brotli", "/index.html", "gzip, br", http.StatusOK, "index br", "br", noCache},
+		{"Gzip", "/index.html", "gzip", http.StatusOK, "index gzip", "gzip", noCache},
+		{"IdentityPreferred", "/index.html", "br;q=0.5, identity", http.StatusOK, "index", "", noCache},
+		{"Hashed", "/client.0123456789ab.js", "", http.StatusOK, "client", "", immutable},
+		{"HashedWithoutBrotli", "/client.0123456789ab.js", "br, gzip", http.StatusOK, "client gzip", "gzip", immutable},
+		{"Nested", "/source/book/bookchat.go", "br", http.StatusOK, "package book br", "br", noCache},
+		{"Precompressed", "/index.html.gz", "gzip", http.StatusNotFound, "", "", noCache},
+		{"Missing", "/missing.0123456789ab.js", "", http.StatusNotFound, "", "", noCache},
+		{"Directory", "/source/book", "", http.StatusNotFound, "", "", noCache},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", test.path, nil)
+			if test.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", test.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			if w.Code != test.code {
+				t.Fatalf("got status %d, want %d", w.Code, test.code)
+			}
+			if got := w.Header().Get("Cache-Control"); got != test.cacheControl {
+				t.Errorf("got Cache-Control %q, want %q", got, test.cacheControl)
+			}
+			if test.code != http.StatusOK {
+				return
+			}
+			if got := w.Body.String(); got != test.body {
+				t.Errorf("got body %q, want %q", got, test.body)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != test.contentEncoding {
+				t.Errorf("got Content-Encoding %q, want %q", got, test.contentEncoding)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("got Vary %q, want Accept-Encoding", got)
+			}
+			if got := w.Header().Get("ETag"); got != etags[test.path] {
+				t.Errorf("got ETag %q, want %q", got, etags[test.path])
+			}
+		})
+	}
+}
+
+func TestAssetServerNotModified(t *testing.T) {
+	assets, cleanup := testAssets(t)
+	defer cleanup()
+	etags, err := assetETags(assets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := cacheHandler(etags, newAssetServer(assets).ServeHTTP)
+
+	for _, test := range []struct {
+		name           string
+		ifNoneMatch    string
+		acceptEncoding string
+		code           int
+	}{
+		{"Match", etags["/index.html"], "", http.StatusNotModified},
+		// The ETag is weak, so it matches every encoding
+		{"MatchEncoded", etags["/index.html"], "br", http.StatusNotModified},
+		{"StrongMatch", strings.TrimPrefix(etags["/index.html"], "W/"), "", http.StatusNotModified},
+		{"Any", "*", "", http.StatusNotModified},
+		{"Stale", `W/"0000000000000000"`, "", http.StatusOK},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/index.html", nil)
+			r.Header.Set("If-None-Match", test.ifNoneMatch)
+			if test.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", test.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			handler(w, r)
+			if w.Code != test.code {
+				t.Errorf("got status %d, want %d", w.Code, test.code)
+			}
+			if test.code == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("got body %q, want none", w.Body)
+			}
+		})
+	}
+}
+
+func TestFolderReader(t *testing.T) {
+	assets, cleanup := testAssets(t)
+	defer cleanup()
+	handler := folderReader(newAssetServer(assets).ServeHTTP)
+
+	for _, test := range []struct {
+		path string
+		code int
+		body string
+	}{
+		{"/", http.StatusOK, "index"},
+		{"/books/60929871", http.StatusOK, "index"},
+		{"/collections/abc/", http.StatusOK, "index"},
+		{"/client.0123456789ab.js", http.StatusOK, "client"},
+		// Missing assets are not found, rather than served
+		// the index, which browsers would fail to run.
+		{"/client.js", http.StatusNotFound, ""},
+		{"/styles/missing.css", http.StatusNotFound, ""},
+	} {
+		r := httptest.NewRequest("GET", test.path, nil)
+		w := httptest.NewRecorder()
+		handler(w, r)
+		if w.Code != test.code {
+			t.Errorf("got status %d for %s, want %d", w.Code, test.path, test.code)
+			continue
+		}
+		if test.code == http.StatusOK && w.Body.String() != test.body {
+			t.Errorf("got body %q for %s, want %q", w.Body, test.path, test.body)
+		}
+	}
+}